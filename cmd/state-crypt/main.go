@@ -0,0 +1,150 @@
+// Command state-crypt inspects and maintains encrypted infrastructure state files.
+//
+// Usage:
+//
+//	state-crypt [-state path] [-region region] decrypt [-out file]
+//	state-crypt [-state path] [-region region] encrypt
+//	state-crypt [-state path] [-region region] rotate
+//
+// The key provider is configured with the same STATE_ENCRYPTION, STATE_ENCRYPTION_KEY_FILE,
+// STATE_ENCRYPTION_KEY and STATE_KMS_KEY_ID environment variables as the server.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/versus-control/ai-infrastructure-agent/internal/logging"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/aws"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/state"
+)
+
+func main() {
+	stateFile := flag.String("state", "./states/infrastructure-state.json", "path of the state file")
+	region := flag.String("region", os.Getenv("AWS_REGION"), "AWS region of the KMS key")
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() < 1 {
+		usage()
+		os.Exit(2)
+	}
+
+	logger := logging.NewLogger("state-crypt", "warn")
+	ctx := context.Background()
+
+	var err error
+	switch flag.Arg(0) {
+	case "decrypt":
+		err = decrypt(ctx, *stateFile, *region, flag.Args()[1:], logger)
+	case "encrypt":
+		err = encrypt(ctx, *stateFile, *region, logger)
+	case "rotate":
+		err = rotate(ctx, *stateFile, *region, logger)
+	default:
+		usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "state-crypt: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: state-crypt [-state path] [-region region] <decrypt [-out file] | encrypt | rotate>\n\n")
+	flag.PrintDefaults()
+}
+
+// decrypt writes the clear text state for inspection without changing the state file
+func decrypt(ctx context.Context, stateFile, region string, args []string, logger *logging.Logger) error {
+	flags := flag.NewFlagSet("decrypt", flag.ExitOnError)
+	out := flags.String("out", "", "write the clear text state to this file instead of stdout")
+	flags.Parse(args)
+
+	data, err := os.ReadFile(stateFile)
+	if err != nil {
+		return fmt.Errorf("failed to read state file: %w", err)
+	}
+
+	if state.IsEncrypted(data) {
+		provider, err := newProvider(stateFile, region, logger)
+		if err != nil {
+			return err
+		}
+		if data, _, err = state.DecryptState(ctx, provider, data); err != nil {
+			return err
+		}
+	} else {
+		fmt.Fprintln(os.Stderr, "state-crypt: state file is not encrypted")
+	}
+
+	if *out == "" {
+		_, err = os.Stdout.Write(append(data, '\n'))
+		return err
+	}
+	return os.WriteFile(*out, data, 0600)
+}
+
+// encrypt encrypts a clear text state file in place
+func encrypt(ctx context.Context, stateFile, region string, logger *logging.Logger) error {
+	manager, err := openManager(ctx, stateFile, region, logger)
+	if err != nil {
+		return err
+	}
+	// Loading a clear text file with encryption enabled writes it back encrypted
+	return manager.SaveState(ctx)
+}
+
+// rotate re-encrypts the state file with a new data key, and a new master key for local keys
+func rotate(ctx context.Context, stateFile, region string, logger *logging.Logger) error {
+	manager, err := openManager(ctx, stateFile, region, logger)
+	if err != nil {
+		return err
+	}
+	return manager.RotateEncryptionKey(ctx)
+}
+
+// openManager loads an existing state file with encryption enabled
+func openManager(ctx context.Context, stateFile, region string, logger *logging.Logger) (*state.Manager, error) {
+	if _, err := os.Stat(stateFile); err != nil {
+		return nil, fmt.Errorf("failed to read state file: %w", err)
+	}
+
+	provider, err := newProvider(stateFile, region, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	manager := state.NewManager(stateFile, region, logger)
+	manager.SetEncryption(provider)
+	if err := manager.LoadState(ctx); err != nil {
+		return nil, err
+	}
+	return manager, nil
+}
+
+// newProvider creates the configured key provider, which must not be disabled
+func newProvider(stateFile, region string, logger *logging.Logger) (state.KeyProvider, error) {
+	var awsClient *aws.Client
+	if strings.EqualFold(os.Getenv(state.EncryptionEnvVar), "kms") {
+		client, err := aws.NewClient(region, logger)
+		if err != nil {
+			return nil, err
+		}
+		awsClient = client
+	}
+
+	provider, err := state.NewKeyProviderFromEnv(stateFile, awsClient)
+	if err != nil {
+		return nil, err
+	}
+	if provider == nil {
+		return nil, fmt.Errorf("state encryption is not enabled, set %s to local or kms", state.EncryptionEnvVar)
+	}
+	return provider, nil
+}
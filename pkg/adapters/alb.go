@@ -26,13 +26,17 @@ func NewALBAdapter(client *aws.Client, logger *logging.Logger) interfaces.AWSRes
 	}
 }
 
-// Create creates a new Application Load Balancer
+// Create creates a new Application or Network Load Balancer
 func (a *ALBAdapter) Create(ctx context.Context, params interface{}) (*types.AWSResource, error) {
 	createParams, ok := params.(aws.CreateLoadBalancerParams)
 	if !ok {
 		return nil, fmt.Errorf("invalid parameters for ALB creation, expected aws.CreateLoadBalancerParams")
 	}
 
+	if createParams.Type == "network" {
+		return a.client.CreateNetworkLoadBalancer(ctx, createParams)
+	}
+
 	alb, err := a.client.CreateApplicationLoadBalancer(ctx, createParams)
 	if err != nil {
 		return nil, err
@@ -57,10 +61,13 @@ func (a *ALBAdapter) Update(ctx context.Context, id string, params interface{})
 	return nil, fmt.Errorf("ALB updates should use specialized operations")
 }
 
-// Delete deletes an Application Load Balancer
+// Delete deletes an Application Load Balancer together with its listeners and rules
 func (a *ALBAdapter) Delete(ctx context.Context, id string) error {
-	// Note: ALB deletion is not implemented in the AWS client yet
-	return fmt.Errorf("ALB deletion not yet implemented in AWS client")
+	if err := a.ValidateParams("delete", id); err != nil {
+		return err
+	}
+
+	return a.client.DeleteLoadBalancer(ctx, id)
 }
 
 // GetSupportedOperations returns the operations supported by this adapter
@@ -73,6 +80,12 @@ func (a *ALBAdapter) GetSupportedOperations() []string {
 		"create-target-group",
 		"attach-target-group",
 		"create-listener",
+		"set-cross-zone",
+		"delete-target-group",
+		"delete-listener",
+		"create-listener-rule",
+		"modify-listener-rule",
+		"delete-listener-rule",
 	}
 }
 
@@ -95,10 +108,37 @@ func (a *ALBAdapter) ValidateParams(operation string, params interface{}) error
 		}
 		return nil
 	case "get", "delete":
-		if params == nil {
+		if id, ok := params.(string); params == nil || (ok && id == "") {
 			return fmt.Errorf("load balancer ARN is required for %s operation", operation)
 		}
 		return nil
+	case "create-listener-rule":
+		ruleParams, ok := params.(aws.CreateListenerRuleParams)
+		if !ok {
+			return fmt.Errorf("invalid parameters for create-listener-rule operation")
+		}
+		if ruleParams.ListenerArn == "" {
+			return fmt.Errorf("listenerArn is required for listener rule creation")
+		}
+		if ruleParams.Priority < 1 || ruleParams.Priority > 50000 {
+			return fmt.Errorf("priority must be between 1 and 50000")
+		}
+		return nil
+	case "modify-listener-rule":
+		ruleParams, ok := params.(aws.ModifyListenerRuleParams)
+		if !ok {
+			return fmt.Errorf("invalid parameters for modify-listener-rule operation")
+		}
+		if ruleParams.RuleArn == "" {
+			return fmt.Errorf("ruleArn is required to modify a listener rule")
+		}
+		if ruleParams.Priority < 0 || ruleParams.Priority > 50000 {
+			return fmt.Errorf("priority must be between 1 and 50000")
+		}
+		if ruleParams.Priority == 0 && ruleParams.Conditions == nil && ruleParams.Action == nil {
+			return fmt.Errorf("at least one of priority, conditions or action must be changed")
+		}
+		return nil
 	default:
 		return fmt.Errorf("unsupported operation: %s", operation)
 	}
@@ -129,6 +169,11 @@ func (a *ALBSpecializedAdapter) ExecuteSpecialOperation(ctx context.Context, ope
 			return nil, fmt.Errorf("invalid parameters for create-load-balancer")
 		}
 
+		// Network Load Balancers allow a single subnet and static addresses
+		if util.GetStringFromMap(paramsMap, "type") == "network" {
+			return a.createNetworkLoadBalancer(ctx, paramsMap)
+		}
+
 		// Validate subnet requirements before proceeding
 		subnetIds := util.GetStringSlice(paramsMap, "subnetIds")
 		if len(subnetIds) == 0 {
@@ -232,6 +277,15 @@ func (a *ALBSpecializedAdapter) ExecuteSpecialOperation(ctx context.Context, ope
 			Port:                  util.GetInt32FromMap(paramsMap, "port", 80),
 			DefaultTargetGroupArn: getTargetGroupArn(paramsMap),
 			CertificateArn:        util.GetStringFromMap(paramsMap, "certificateArn"),
+			CertificateDomain:     util.GetStringFromMap(paramsMap, "certificateDomain"),
+			SslPolicy:             util.GetStringFromMap(paramsMap, "sslPolicy"),
+			AlpnPolicy:            util.GetStringFromMap(paramsMap, "alpnPolicy"),
+		}
+
+		// Non-forward default actions, e.g. the HTTP listener that redirects to HTTPS
+		if actionType := util.GetStringFromMap(paramsMap, "defaultActionType"); actionType != "" && actionType != "forward" {
+			action := listenerActionFromMap(paramsMap, actionType)
+			listenerParams.DefaultAction = &action
 		}
 
 		listener, err := a.client.CreateListener(ctx, listenerParams)
@@ -319,6 +373,169 @@ func (a *ALBSpecializedAdapter) ExecuteSpecialOperation(ctx context.Context, ope
 			},
 		}, nil
 
+	case "delete-target-group", "delete-listener", "delete-listener-rule":
+		arn, ok := params.(string)
+		if !ok || arn == "" {
+			return nil, fmt.Errorf("ARN required for %s operation", operation)
+		}
+
+		var err error
+		resourceType := ""
+		switch operation {
+		case "delete-target-group":
+			resourceType = "target_group"
+			err = a.client.DeleteTargetGroup(ctx, arn)
+		case "delete-listener":
+			resourceType = "listener"
+			err = a.client.DeleteListener(ctx, arn)
+		default:
+			resourceType = "listener_rule"
+			err = a.client.DeleteListenerRule(ctx, arn)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return &types.AWSResource{
+			ID:    arn,
+			Type:  resourceType,
+			State: "deleted",
+		}, nil
+
+	case "set-cross-zone":
+		paramsMap, ok := params.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid parameters for set-cross-zone")
+		}
+
+		loadBalancerArn := util.GetStringFromMap(paramsMap, "loadBalancerArn")
+		if loadBalancerArn == "" {
+			return nil, fmt.Errorf("loadBalancerArn required for set-cross-zone operation")
+		}
+		enabled := util.GetBoolFromMap(paramsMap, "enabled", true)
+
+		if err := a.client.SetLoadBalancerCrossZone(ctx, loadBalancerArn, enabled); err != nil {
+			return nil, err
+		}
+
+		loadBalancer, err := a.client.GetLoadBalancer(ctx, loadBalancerArn)
+		if err != nil {
+			return nil, err
+		}
+		loadBalancer.Details["crossZoneEnabled"] = enabled
+
+		return loadBalancer, nil
+
+	case "list-listeners":
+		loadBalancerArn, ok := params.(string)
+		if !ok || loadBalancerArn == "" {
+			return nil, fmt.Errorf("loadBalancerArn required for list-listeners operation")
+		}
+
+		listeners, err := a.client.DescribeListeners(ctx, loadBalancerArn)
+		if err != nil {
+			return nil, err
+		}
+
+		return &types.AWSResource{
+			ID:    loadBalancerArn,
+			Type:  "listener-list",
+			State: "available",
+			Details: map[string]interface{}{
+				"count":     len(listeners),
+				"listeners": listeners,
+			},
+		}, nil
+
+	case "create-listener-rule":
+		paramsMap, ok := params.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid parameters for create-listener-rule")
+		}
+
+		ruleParams := aws.CreateListenerRuleParams{
+			ListenerArn: util.GetStringFromMap(paramsMap, "listenerArn"),
+			Priority:    util.GetInt32FromMap(paramsMap, "priority", 0),
+			Conditions:  ruleConditionsFromMap(paramsMap),
+			Action:      listenerActionFromMap(paramsMap, util.GetStringFromMap(paramsMap, "actionType")),
+			Tags:        util.GetStringMap(paramsMap, "tags"),
+		}
+
+		if err := a.ValidateParams("create-listener-rule", ruleParams); err != nil {
+			return nil, err
+		}
+
+		return a.client.CreateListenerRule(ctx, ruleParams)
+
+	case "modify-listener-rule":
+		paramsMap, ok := params.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid parameters for modify-listener-rule")
+		}
+
+		ruleParams := aws.ModifyListenerRuleParams{
+			RuleArn:  util.GetStringFromMap(paramsMap, "ruleArn"),
+			Priority: util.GetInt32FromMap(paramsMap, "priority", 0),
+		}
+
+		// Only replace conditions or the action when the caller supplied them
+		conditions := ruleConditionsFromMap(paramsMap)
+		if len(conditions.PathPatterns)+len(conditions.HostHeaders)+len(conditions.HTTPMethods)+len(conditions.SourceIPs)+len(conditions.HeaderValues) > 0 {
+			ruleParams.Conditions = &conditions
+		}
+		if actionType := util.GetStringFromMap(paramsMap, "actionType"); actionType != "" || util.GetStringFromMap(paramsMap, "targetGroupArn") != "" {
+			action := listenerActionFromMap(paramsMap, actionType)
+			ruleParams.Action = &action
+		}
+
+		if err := a.ValidateParams("modify-listener-rule", ruleParams); err != nil {
+			return nil, err
+		}
+
+		return a.client.ModifyListenerRule(ctx, ruleParams)
+
+	case "list-listener-rules":
+		listenerArn, ok := params.(string)
+		if !ok || listenerArn == "" {
+			return nil, fmt.Errorf("listenerArn required for list-listener-rules operation")
+		}
+
+		rules, err := a.client.DescribeListenerRules(ctx, listenerArn)
+		if err != nil {
+			return nil, err
+		}
+
+		return &types.AWSResource{
+			ID:    listenerArn,
+			Type:  "listener-rule-list",
+			State: "available",
+			Details: map[string]interface{}{
+				"count": len(rules),
+				"rules": rules,
+			},
+		}, nil
+
+	case "find-certificate":
+		domain, ok := params.(string)
+		if !ok || domain == "" {
+			return nil, fmt.Errorf("domain required for find-certificate operation")
+		}
+
+		certificateArn, err := a.client.FindCertificateByDomain(ctx, domain)
+		if err != nil {
+			return nil, err
+		}
+
+		return &types.AWSResource{
+			ID:    certificateArn,
+			Type:  "acm_certificate",
+			State: "issued",
+			Details: map[string]interface{}{
+				"certificateArn": certificateArn,
+				"domainName":     domain,
+			},
+		}, nil
+
 	default:
 		return nil, fmt.Errorf("unsupported specialized operation: %s", operation)
 	}
@@ -334,7 +551,67 @@ func (a *ALBSpecializedAdapter) GetSpecialOperations() []string {
 		"list-target-groups",
 		"register-targets",
 		"deregister-targets",
+		"set-cross-zone",
+		"delete-target-group",
+		"delete-listener",
+		"list-listeners",
+		"create-listener-rule",
+		"modify-listener-rule",
+		"delete-listener-rule",
+		"list-listener-rules",
+		"find-certificate",
+	}
+}
+
+// createNetworkLoadBalancer builds Network Load Balancer parameters from tool arguments.
+// elasticIpAllocationIds and privateIpv4Addresses are matched to subnetIds by position.
+func (a *ALBSpecializedAdapter) createNetworkLoadBalancer(ctx context.Context, paramsMap map[string]interface{}) (*types.AWSResource, error) {
+	subnetIds := util.GetStringSlice(paramsMap, "subnetIds")
+	if len(subnetIds) == 0 {
+		return nil, fmt.Errorf("subnetIds are required for network load balancer creation")
+	}
+
+	allocationIds := util.GetStringSlice(paramsMap, "elasticIpAllocationIds")
+	if len(allocationIds) > 0 && len(allocationIds) != len(subnetIds) {
+		return nil, fmt.Errorf("elasticIpAllocationIds must have one entry per subnet (%d subnets, %d allocations)", len(subnetIds), len(allocationIds))
+	}
+	privateAddresses := util.GetStringSlice(paramsMap, "privateIpv4Addresses")
+	if len(privateAddresses) > 0 && len(privateAddresses) != len(subnetIds) {
+		return nil, fmt.Errorf("privateIpv4Addresses must have one entry per subnet (%d subnets, %d addresses)", len(subnetIds), len(privateAddresses))
+	}
+
+	mappings := make([]aws.SubnetMappingParams, len(subnetIds))
+	for i, subnetId := range subnetIds {
+		mappings[i].SubnetID = subnetId
+		if len(allocationIds) > 0 {
+			mappings[i].AllocationID = allocationIds[i]
+		}
+		if len(privateAddresses) > 0 {
+			mappings[i].PrivateIPv4Address = privateAddresses[i]
+		}
+	}
+
+	nlbParams := aws.CreateLoadBalancerParams{
+		Name:               util.GetStringFromMap(paramsMap, "name"),
+		Scheme:             util.GetStringFromMap(paramsMap, "scheme"),
+		Type:               "network",
+		IpAddressType:      util.GetStringFromMap(paramsMap, "ipAddressType"),
+		SecurityGroups:     util.GetStringSlice(paramsMap, "securityGroupIds"),
+		SubnetMappings:     mappings,
+		AllocateElasticIPs: util.GetBoolFromMap(paramsMap, "allocateElasticIps", false),
+		Tags:               util.GetStringMap(paramsMap, "tags"),
+	}
+	if crossZone, ok := paramsMap["crossZoneEnabled"].(bool); ok {
+		nlbParams.CrossZoneEnabled = &crossZone
+	}
+
+	loadBalancer, err := a.client.CreateNetworkLoadBalancer(ctx, nlbParams)
+	if err != nil {
+		// A load balancer that was created but not configured is returned with the error
+		return loadBalancer, fmt.Errorf("failed to create network load balancer: %w", err)
 	}
+
+	return loadBalancer, nil
 }
 
 // getTargetGroupArn handles both targetGroupArn and defaultTargetGroupArn parameter names
@@ -349,3 +626,46 @@ func getTargetGroupArn(params map[string]interface{}) string {
 	}
 	return ""
 }
+
+// listenerActionFromMap builds listener action parameters from tool arguments.
+// Redirects default to HTTPS on port 443, the common HTTP to HTTPS case.
+func listenerActionFromMap(params map[string]interface{}, actionType string) aws.ListenerActionParams {
+	action := aws.ListenerActionParams{
+		Type:                     actionType,
+		TargetGroupArn:           getTargetGroupArn(params),
+		RedirectProtocol:         util.GetStringFromMap(params, "redirectProtocol"),
+		RedirectPort:             util.GetStringFromMap(params, "redirectPort"),
+		RedirectHost:             util.GetStringFromMap(params, "redirectHost"),
+		RedirectPath:             util.GetStringFromMap(params, "redirectPath"),
+		RedirectStatusCode:       util.GetStringFromMap(params, "redirectStatusCode"),
+		FixedResponseStatusCode:  util.GetStringFromMap(params, "fixedResponseStatusCode"),
+		FixedResponseContentType: util.GetStringFromMap(params, "fixedResponseContentType"),
+		FixedResponseBody:        util.GetStringFromMap(params, "fixedResponseBody"),
+	}
+
+	// Numeric ports arrive as float64 from JSON arguments
+	if port, ok := params["redirectPort"].(float64); ok {
+		action.RedirectPort = fmt.Sprintf("%d", int(port))
+	}
+
+	if actionType == "redirect" && action.RedirectProtocol == "" && action.RedirectHost == "" && action.RedirectPath == "" {
+		action.RedirectProtocol = "HTTPS"
+		if action.RedirectPort == "" {
+			action.RedirectPort = "443"
+		}
+	}
+
+	return action
+}
+
+// ruleConditionsFromMap builds listener rule conditions from tool arguments
+func ruleConditionsFromMap(params map[string]interface{}) aws.ListenerRuleConditionParams {
+	return aws.ListenerRuleConditionParams{
+		PathPatterns: util.GetStringSlice(params, "pathPatterns"),
+		HostHeaders:  util.GetStringSlice(params, "hostHeaders"),
+		HTTPMethods:  util.GetStringSlice(params, "httpMethods"),
+		SourceIPs:    util.GetStringSlice(params, "sourceIps"),
+		HTTPHeader:   util.GetStringFromMap(params, "httpHeaderName"),
+		HeaderValues: util.GetStringSlice(params, "httpHeaderValues"),
+	}
+}
@@ -0,0 +1,329 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/versus-control/ai-infrastructure-agent/internal/logging"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/aws"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/interfaces"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/types"
+)
+
+// IAMAdapter implements the AWSResourceAdapter interface for IAM roles
+type IAMAdapter struct {
+	*BaseAWSAdapter
+	client *aws.Client
+}
+
+// NewIAMAdapter creates a new IAM role adapter
+func NewIAMAdapter(client *aws.Client, logger *logging.Logger) interfaces.AWSResourceAdapter {
+	base := NewBaseAWSAdapter(client, logger, "iam_role")
+	return &IAMAdapter{
+		BaseAWSAdapter: base,
+		client:         client,
+	}
+}
+
+// Create creates a new IAM role
+func (i *IAMAdapter) Create(ctx context.Context, params interface{}) (*types.AWSResource, error) {
+	createParams, ok := params.(aws.CreateRoleParams)
+	if !ok {
+		return nil, fmt.Errorf("invalid parameters for IAM role creation, expected aws.CreateRoleParams")
+	}
+
+	if err := i.ValidateParams("create", createParams); err != nil {
+		return nil, err
+	}
+
+	role, err := i.client.CreateRole(ctx, createParams)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create IAM role: %w", err)
+	}
+
+	i.logger.Infof("Created IAM role %s", role.ID)
+	return role, nil
+}
+
+// List returns all IAM roles that can be managed directly
+func (i *IAMAdapter) List(ctx context.Context) ([]*types.AWSResource, error) {
+	roles, err := i.client.ListRoles(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list IAM roles: %w", err)
+	}
+
+	return roles, nil
+}
+
+// Get retrieves a specific IAM role by name
+func (i *IAMAdapter) Get(ctx context.Context, id string) (*types.AWSResource, error) {
+	role, err := i.client.GetRole(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get IAM role %s: %w", id, err)
+	}
+
+	return role, nil
+}
+
+// Update attaches a managed policy or adds an inline policy to a role
+func (i *IAMAdapter) Update(ctx context.Context, id string, params interface{}) (*types.AWSResource, error) {
+	switch updateParams := params.(type) {
+	case aws.AttachRolePolicyParams:
+		updateParams.RoleName = id
+		if err := i.client.AttachRolePolicy(ctx, updateParams); err != nil {
+			return nil, err
+		}
+	case aws.PutRolePolicyParams:
+		updateParams.RoleName = id
+		if err := i.client.PutRolePolicy(ctx, updateParams); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("invalid parameters for IAM role update, expected aws.AttachRolePolicyParams or aws.PutRolePolicyParams")
+	}
+
+	return i.client.GetRole(ctx, id)
+}
+
+// Delete deletes an IAM role after detaching its policies and instance profiles
+func (i *IAMAdapter) Delete(ctx context.Context, id string) error {
+	if err := i.client.DeleteRole(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete IAM role %s: %w", id, err)
+	}
+
+	return nil
+}
+
+// GetSupportedOperations returns the operations supported by this adapter
+func (i *IAMAdapter) GetSupportedOperations() []string {
+	return []string{
+		"create",
+		"list",
+		"get",
+		"update",
+		"delete",
+	}
+}
+
+// ValidateParams validates IAM role-specific parameters
+func (i *IAMAdapter) ValidateParams(operation string, params interface{}) error {
+	switch operation {
+	case "create":
+		createParams, ok := params.(aws.CreateRoleParams)
+		if !ok {
+			return fmt.Errorf("invalid parameters for create operation")
+		}
+		if createParams.RoleName == "" {
+			return fmt.Errorf("roleName is required for IAM role creation")
+		}
+		if len(createParams.RoleName) > 64 {
+			return fmt.Errorf("roleName must be at most 64 characters")
+		}
+		for _, policyARN := range createParams.ManagedPolicyARNs {
+			if isAdministratorPolicy(policyARN) {
+				return fmt.Errorf("managed policy %s grants full access; use a scoped policy template instead", policyARN)
+			}
+		}
+		for policyName, document := range createParams.InlinePolicies {
+			if err := checkPolicyDocument(document); err != nil {
+				return fmt.Errorf("inline policy %s: %w", policyName, err)
+			}
+		}
+		return nil
+	case "attach-policy":
+		attachParams, ok := params.(aws.AttachRolePolicyParams)
+		if !ok {
+			return fmt.Errorf("invalid parameters for attach-policy operation")
+		}
+		if attachParams.RoleName == "" || attachParams.PolicyARN == "" {
+			return fmt.Errorf("roleName and policyArn are required to attach a policy")
+		}
+		if isAdministratorPolicy(attachParams.PolicyARN) {
+			return fmt.Errorf("managed policy %s grants full access; use a scoped policy template instead", attachParams.PolicyARN)
+		}
+		return nil
+	case "put-inline-policy":
+		putParams, ok := params.(aws.PutRolePolicyParams)
+		if !ok {
+			return fmt.Errorf("invalid parameters for put-inline-policy operation")
+		}
+		if putParams.RoleName == "" || putParams.PolicyName == "" || putParams.PolicyDocument == "" {
+			return fmt.Errorf("roleName, policyName and policyDocument are required for an inline policy")
+		}
+		if err := checkPolicyDocument(putParams.PolicyDocument); err != nil {
+			return fmt.Errorf("inline policy %s: %w", putParams.PolicyName, err)
+		}
+		return nil
+	case "create-instance-profile":
+		profileParams, ok := params.(aws.CreateInstanceProfileParams)
+		if !ok {
+			return fmt.Errorf("invalid parameters for create-instance-profile operation")
+		}
+		if profileParams.InstanceProfileName == "" {
+			return fmt.Errorf("instanceProfileName is required for instance profile creation")
+		}
+		return nil
+	case "get", "delete", "update":
+		if params == nil {
+			return fmt.Errorf("role name is required for %s operation", operation)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported operation: %s", operation)
+	}
+}
+
+// ListByTags overrides the base implementation with IAM role-specific logic
+func (i *IAMAdapter) ListByTags(ctx context.Context, tags map[string]string) ([]*types.AWSResource, error) {
+	allRoles, err := i.client.ListRoles(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var filtered []*types.AWSResource
+	for _, role := range allRoles {
+		if i.matchesTags(role.Tags, tags) {
+			filtered = append(filtered, role)
+		}
+	}
+
+	return filtered, nil
+}
+
+// IAMSpecializedAdapter adds policy and instance profile operations
+type IAMSpecializedAdapter struct {
+	interfaces.AWSResourceAdapter
+	client *aws.Client
+}
+
+// NewIAMSpecializedAdapter creates an adapter with specialized IAM operations
+func NewIAMSpecializedAdapter(client *aws.Client, logger *logging.Logger) interfaces.SpecializedOperations {
+	baseAdapter := NewIAMAdapter(client, logger)
+	return &IAMSpecializedAdapter{
+		AWSResourceAdapter: baseAdapter,
+		client:             client,
+	}
+}
+
+// ExecuteSpecialOperation handles IAM-specific operations
+func (i *IAMSpecializedAdapter) ExecuteSpecialOperation(ctx context.Context, operation string, params interface{}) (*types.AWSResource, error) {
+	if operation != "get-instance-profile" && operation != "delete-instance-profile" {
+		if err := i.ValidateParams(operation, params); err != nil {
+			return nil, fmt.Errorf("parameter validation failed for %s operation: %w", operation, err)
+		}
+	}
+
+	switch operation {
+	case "attach-policy":
+		attachParams := params.(aws.AttachRolePolicyParams)
+		return i.Update(ctx, attachParams.RoleName, attachParams)
+
+	case "put-inline-policy":
+		putParams := params.(aws.PutRolePolicyParams)
+		return i.Update(ctx, putParams.RoleName, putParams)
+
+	case "create-instance-profile":
+		return i.client.CreateInstanceProfile(ctx, params.(aws.CreateInstanceProfileParams))
+
+	case "get-instance-profile":
+		name, ok := params.(string)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("instance profile name required for get-instance-profile operation")
+		}
+		return i.client.GetInstanceProfile(ctx, name)
+
+	case "delete-instance-profile":
+		name, ok := params.(string)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("instance profile name required for delete-instance-profile operation")
+		}
+		if err := i.client.DeleteInstanceProfile(ctx, name); err != nil {
+			return nil, err
+		}
+		return &types.AWSResource{ID: name, Type: "iam_instance_profile", State: "deleted"}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported special operation: %s", operation)
+	}
+}
+
+// GetSpecialOperations returns the list of supported special operations
+func (i *IAMSpecializedAdapter) GetSpecialOperations() []string {
+	return []string{
+		"attach-policy",
+		"put-inline-policy",
+		"create-instance-profile",
+		"get-instance-profile",
+		"delete-instance-profile",
+	}
+}
+
+// isAdministratorPolicy reports whether a managed policy ARN grants unrestricted access
+func isAdministratorPolicy(policyARN string) bool {
+	return strings.HasSuffix(policyARN, ":policy/AdministratorAccess") ||
+		strings.HasSuffix(policyARN, ":policy/PowerUserAccess") ||
+		strings.HasSuffix(policyARN, ":policy/IAMFullAccess")
+}
+
+// checkPolicyDocument rejects policy documents with statements that allow every action or every
+// resource, which would grant the same access as the managed administrator policies
+func checkPolicyDocument(document string) error {
+	var policy struct {
+		Statement json.RawMessage `json:"Statement"`
+	}
+	if err := json.Unmarshal([]byte(document), &policy); err != nil {
+		return fmt.Errorf("policy document is not valid JSON: %w", err)
+	}
+
+	// Statement is a single statement or a list of them
+	var statements []map[string]interface{}
+	if err := json.Unmarshal(policy.Statement, &statements); err != nil {
+		var statement map[string]interface{}
+		if err := json.Unmarshal(policy.Statement, &statement); err != nil {
+			return fmt.Errorf("policy document has no valid Statement")
+		}
+		statements = []map[string]interface{}{statement}
+	}
+	if len(statements) == 0 {
+		return fmt.Errorf("policy document has no valid Statement")
+	}
+
+	for i, statement := range statements {
+		if effect, _ := statement["Effect"].(string); effect != "Allow" {
+			continue
+		}
+		if _, ok := statement["NotAction"]; ok {
+			return fmt.Errorf("statement %d allows every action except NotAction; list the allowed actions instead", i)
+		}
+		for _, action := range policyValues(statement["Action"]) {
+			if action == "*" || strings.HasPrefix(action, "*:") {
+				return fmt.Errorf("statement %d allows every action; list the allowed actions instead", i)
+			}
+		}
+		for _, resource := range policyValues(statement["Resource"]) {
+			if resource == "*" {
+				return fmt.Errorf("statement %d applies to every resource; list the resource ARNs instead", i)
+			}
+		}
+	}
+	return nil
+}
+
+// policyValues returns the values of a policy element, which is a string or a list of strings
+func policyValues(element interface{}) []string {
+	switch value := element.(type) {
+	case string:
+		return []string{value}
+	case []interface{}:
+		values := make([]string, 0, len(value))
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
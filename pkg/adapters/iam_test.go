@@ -0,0 +1,106 @@
+package adapters
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/versus-control/ai-infrastructure-agent/pkg/aws"
+)
+
+func TestCheckPolicyDocument(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		wantErr  string
+	}{
+		{
+			name:     "scoped statement",
+			document: `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject"],"Resource":["arn:aws:s3:::assets/*"]}]}`,
+		},
+		{
+			name:     "single statement object",
+			document: `{"Version":"2012-10-17","Statement":{"Effect":"Allow","Action":"logs:PutLogEvents","Resource":"arn:aws:logs:*:*:log-group:app:*"}}`,
+		},
+		{
+			name:     "service wildcard on a scoped resource",
+			document: `{"Statement":[{"Effect":"Allow","Action":"s3:*","Resource":"arn:aws:s3:::assets"}]}`,
+		},
+		{
+			name:     "deny of every action",
+			document: `{"Statement":[{"Effect":"Deny","Action":"*","Resource":"*"}]}`,
+		},
+		{
+			name:     "every action",
+			document: `{"Statement":[{"Effect":"Allow","Action":"*","Resource":"arn:aws:s3:::assets"}]}`,
+			wantErr:  "every action",
+		},
+		{
+			name:     "every action in a list",
+			document: `{"Statement":[{"Effect":"Allow","Action":["s3:GetObject","*"],"Resource":"arn:aws:s3:::assets"}]}`,
+			wantErr:  "every action",
+		},
+		{
+			name:     "every resource",
+			document: `{"Statement":[{"Effect":"Allow","Action":"iam:PassRole","Resource":"*"}]}`,
+			wantErr:  "every resource",
+		},
+		{
+			name:     "second statement",
+			document: `{"Statement":[{"Effect":"Allow","Action":"s3:GetObject","Resource":"arn:aws:s3:::assets/*"},{"Effect":"Allow","Action":"ec2:*","Resource":["*"]}]}`,
+			wantErr:  "statement 1",
+		},
+		{
+			name:     "not action",
+			document: `{"Statement":[{"Effect":"Allow","NotAction":"iam:*","Resource":"arn:aws:s3:::assets"}]}`,
+			wantErr:  "NotAction",
+		},
+		{
+			name:     "invalid JSON",
+			document: `{"Statement":`,
+			wantErr:  "not valid JSON",
+		},
+		{
+			name:     "no statement",
+			document: `{"Version":"2012-10-17"}`,
+			wantErr:  "no valid Statement",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkPolicyDocument(tt.document)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("checkPolicyDocument() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("checkPolicyDocument() error = %v, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIAMValidateParamsRejectsBroadPolicies(t *testing.T) {
+	adapter := &IAMAdapter{}
+	admin := `{"Statement":[{"Effect":"Allow","Action":"*","Resource":"*"}]}`
+
+	tests := []struct {
+		name      string
+		operation string
+		params    interface{}
+	}{
+		{"inline policy", "put-inline-policy", aws.PutRolePolicyParams{RoleName: "app", PolicyName: "admin", PolicyDocument: admin}},
+		{"inline policy of a new role", "create", aws.CreateRoleParams{RoleName: "app", InlinePolicies: map[string]string{"admin": admin}}},
+		{"administrator managed policy", "attach-policy", aws.AttachRolePolicyParams{RoleName: "app", PolicyARN: "arn:aws:iam::aws:policy/AdministratorAccess"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := adapter.ValidateParams(tt.operation, tt.params); err == nil {
+				t.Fatal("ValidateParams() accepted a policy that grants full access")
+			}
+		})
+	}
+}
@@ -68,9 +68,9 @@ func (k *KeyPairAdapter) Update(ctx context.Context, id string, params interface
 	return nil, fmt.Errorf("key pair updates are not supported, please delete and recreate the key pair")
 }
 
-// Not supported by AWS, implemented to satisfy interface
+// Delete deletes a key pair by name
 func (k *KeyPairAdapter) Delete(ctx context.Context, id string) error {
-	return fmt.Errorf("key pair deletion is not supported through this adapter")
+	return k.client.DeleteKeyPair(ctx, id)
 }
 
 // GetSupportedOperations returns the operations supported by this adapter
@@ -79,6 +79,7 @@ func (k *KeyPairAdapter) GetSupportedOperations() []string {
 		"create",
 		"list",
 		"get",
+		"delete",
 		"import",
 	}
 }
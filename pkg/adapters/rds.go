@@ -4,10 +4,13 @@ import (
 	"context"
 	"fmt"
 
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	rdstypes "github.com/aws/aws-sdk-go-v2/service/rds/types"
 	"github.com/versus-control/ai-infrastructure-agent/internal/logging"
 	"github.com/versus-control/ai-infrastructure-agent/pkg/aws"
 	"github.com/versus-control/ai-infrastructure-agent/pkg/interfaces"
 	"github.com/versus-control/ai-infrastructure-agent/pkg/types"
+	util "github.com/versus-control/ai-infrastructure-agent/pkg/utilities"
 )
 
 // RDSAdapter implements the AWSResourceAdapter interface for RDS instances
@@ -83,10 +86,24 @@ func (r *RDSAdapter) Get(ctx context.Context, id string) (*types.AWSResource, er
 	return dbInstance, nil
 }
 
-// Update updates an RDS instance (limited operations available)
+// Update modifies the class, storage, Multi-AZ, backup or parameter group settings of an RDS instance
 func (r *RDSAdapter) Update(ctx context.Context, id string, params interface{}) (*types.AWSResource, error) {
-	// RDS instances can be modified but it's complex
-	return nil, fmt.Errorf("RDS instance updates should use specialized operations")
+	var modifyParams aws.ModifyDBInstanceParams
+	switch p := params.(type) {
+	case aws.ModifyDBInstanceParams:
+		modifyParams = p
+	case map[string]interface{}:
+		modifyParams = modifyDBInstanceParamsFromMap(p)
+	default:
+		return nil, fmt.Errorf("invalid parameters for RDS update, expected aws.ModifyDBInstanceParams")
+	}
+	modifyParams.DBInstanceIdentifier = id
+
+	if err := r.ValidateParams("update", modifyParams); err != nil {
+		return nil, err
+	}
+
+	return r.client.ModifyDBInstance(ctx, modifyParams)
 }
 
 // Delete deletes an RDS instance
@@ -101,6 +118,7 @@ func (r *RDSAdapter) GetSupportedOperations() []string {
 		"create",
 		"list",
 		"get",
+		"update",
 		"delete",
 		"start",
 		"stop",
@@ -125,6 +143,81 @@ func (r *RDSAdapter) ValidateParams(operation string, params interface{}) error
 		if createParams.DBInstanceClass == "" {
 			return fmt.Errorf("dbInstanceClass is required for RDS creation")
 		}
+		if createParams.ManageMasterUserPassword && createParams.MasterUserPassword != "" {
+			return fmt.Errorf("masterUserPassword must be empty when RDS manages the master password")
+		}
+		if !createParams.ManageMasterUserPassword && createParams.MasterUserPassword == "" {
+			return fmt.Errorf("a master password or RDS-managed password is required for RDS creation")
+		}
+		return nil
+	case "update":
+		modifyParams, ok := params.(aws.ModifyDBInstanceParams)
+		if !ok {
+			return fmt.Errorf("invalid parameters for update operation")
+		}
+		if modifyParams.DBInstanceIdentifier == "" {
+			return fmt.Errorf("dbInstanceIdentifier is required for RDS modification")
+		}
+		if modifyParams.DBInstanceClass == "" && modifyParams.AllocatedStorage == 0 &&
+			modifyParams.MaxAllocatedStorage == 0 && modifyParams.StorageType == "" &&
+			modifyParams.Iops == 0 && modifyParams.MultiAZ == nil &&
+			modifyParams.BackupRetentionPeriod == nil && modifyParams.DBParameterGroupName == "" &&
+			len(modifyParams.VpcSecurityGroupIDs) == 0 {
+			return fmt.Errorf("at least one setting to change is required for RDS modification")
+		}
+		if modifyParams.MaxAllocatedStorage > 0 && modifyParams.AllocatedStorage > modifyParams.MaxAllocatedStorage {
+			return fmt.Errorf("maxAllocatedStorage must be greater than allocatedStorage")
+		}
+		return nil
+	case "create-read-replica":
+		replicaParams, ok := params.(aws.CreateDBReadReplicaParams)
+		if !ok {
+			return fmt.Errorf("invalid parameters for create-read-replica operation")
+		}
+		if replicaParams.DBInstanceIdentifier == "" || replicaParams.SourceDBInstanceIdentifier == "" {
+			return fmt.Errorf("dbInstanceIdentifier and sourceDbInstanceIdentifier are required for read replica creation")
+		}
+		if replicaParams.DBInstanceIdentifier == replicaParams.SourceDBInstanceIdentifier {
+			return fmt.Errorf("read replica identifier must differ from the source instance identifier")
+		}
+		return nil
+	case "restore-from-snapshot":
+		restoreParams, ok := params.(aws.RestoreDBInstanceFromSnapshotParams)
+		if !ok {
+			return fmt.Errorf("invalid parameters for restore-from-snapshot operation")
+		}
+		if restoreParams.DBInstanceIdentifier == "" || restoreParams.DBSnapshotIdentifier == "" {
+			return fmt.Errorf("dbInstanceIdentifier and dbSnapshotIdentifier are required to restore from a snapshot")
+		}
+		return nil
+	case "create-parameter-group":
+		groupParams, ok := params.(aws.CreateDBParameterGroupParams)
+		if !ok {
+			return fmt.Errorf("invalid parameters for create-parameter-group operation")
+		}
+		if groupParams.DBParameterGroupName == "" || groupParams.DBParameterGroupFamily == "" {
+			return fmt.Errorf("dbParameterGroupName and family are required for parameter group creation")
+		}
+		return nil
+	case "modify-parameter-group":
+		groupParams, ok := params.(aws.ModifyDBParameterGroupParams)
+		if !ok {
+			return fmt.Errorf("invalid parameters for modify-parameter-group operation")
+		}
+		if groupParams.DBParameterGroupName == "" {
+			return fmt.Errorf("dbParameterGroupName is required to modify a parameter group")
+		}
+		if len(groupParams.Parameters) == 0 {
+			return fmt.Errorf("at least one parameter is required to modify a parameter group")
+		}
+		for _, parameter := range groupParams.Parameters {
+			if parameter.Name == "" {
+				return fmt.Errorf("every parameter requires a name")
+			}
+			if parameter.ApplyMethod != "" && parameter.ApplyMethod != "immediate" && parameter.ApplyMethod != "pending-reboot" {
+				return fmt.Errorf("applyMethod for %s must be immediate or pending-reboot", parameter.Name)
+			}
+		}
 		return nil
 	case "get", "delete", "start", "stop":
 		if params == nil {
@@ -221,7 +314,7 @@ func (r *RDSSpecializedAdapter) ExecuteSpecialOperation(ctx context.Context, ope
 		}
 
 		// Extract parameters with type checking
-		var dbIdentifier, dbClass, engine, username, password, subnetGroup string
+		var dbIdentifier, dbClass, engine, username, subnetGroup string
 		var allocatedStorage int32 = 20
 		var securityGroupIds []string
 
@@ -237,9 +330,6 @@ func (r *RDSSpecializedAdapter) ExecuteSpecialOperation(ctx context.Context, ope
 		if val, ok := createParams["masterUsername"].(string); ok {
 			username = val
 		}
-		if val, ok := createParams["masterUserPassword"].(string); ok {
-			password = val
-		}
 		if val, ok := createParams["allocatedStorage"].(float64); ok {
 			allocatedStorage = int32(val)
 		}
@@ -259,27 +349,49 @@ func (r *RDSSpecializedAdapter) ExecuteSpecialOperation(ctx context.Context, ope
 			DBInstanceClass:      dbClass,
 			Engine:               engine,
 			MasterUsername:       username,
-			MasterUserPassword:   password,
 			AllocatedStorage:     allocatedStorage,
 			DBSubnetGroupName:    subnetGroup,
 			VpcSecurityGroupIDs:  securityGroupIds,
+			MultiAZ:              util.GetBoolFromMap(createParams, "multiAz", false),
+			StorageEncrypted:     util.GetBoolFromMap(createParams, "storageEncrypted", false),
+			PubliclyAccessible:   util.GetBoolFromMap(createParams, "publiclyAccessible", false),
+		}
+
+		credentials, err := r.resolveMasterCredentials(ctx, createParams, &dbParams)
+		if err != nil {
+			return nil, err
 		}
 
 		result, err := r.client.CreateDBInstance(ctx, dbParams)
 		if err != nil {
+			// Generated secrets are useless without the instance they were created for
+			if credentials.mode == passwordModeGenerate {
+				if deleteErr := r.client.DeleteSecret(ctx, credentials.secretArn); deleteErr != nil {
+					err = fmt.Errorf("%w (cleanup of secret %s also failed: %v)", err, credentials.secretArn, deleteErr)
+				}
+			}
 			return nil, err
 		}
 
+		details := map[string]interface{}{
+			"engine":           awssdk.ToString(result.Engine),
+			"instanceClass":    awssdk.ToString(result.DBInstanceClass),
+			"allocatedStorage": result.AllocatedStorage,
+			"passwordMode":     credentials.mode,
+		}
+
+		// The secret ARN is left out when it is unknown, so that plan references to it fail
+		// instead of resolving to an empty string
+		if secretArn := r.masterUserSecretArn(ctx, result, credentials); secretArn != "" {
+			details["masterUserSecretArn"] = secretArn
+		}
+
 		// Convert to AWSResource
 		return &types.AWSResource{
-			ID:    *result.DBInstanceIdentifier,
-			Type:  "rds-instance",
-			State: *result.DBInstanceStatus,
-			Details: map[string]interface{}{
-				"engine":           *result.Engine,
-				"instanceClass":    *result.DBInstanceClass,
-				"allocatedStorage": result.AllocatedStorage,
-			},
+			ID:      awssdk.ToString(result.DBInstanceIdentifier),
+			Type:    "rds-instance",
+			State:   awssdk.ToString(result.DBInstanceStatus),
+			Details: details,
 		}, nil
 
 	case "create-db-subnet-group":
@@ -389,11 +501,150 @@ func (r *RDSSpecializedAdapter) ExecuteSpecialOperation(ctx context.Context, ope
 		}
 		return resource, nil
 
+	case "modify-db-instance":
+		modifyMap, ok := params.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("modify parameters required")
+		}
+		return r.Update(ctx, util.GetStringFromMap(modifyMap, "dbInstanceIdentifier"), modifyMap)
+
+	case "create-read-replica":
+		replicaMap, ok := params.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("read replica parameters required")
+		}
+		replicaParams := aws.CreateDBReadReplicaParams{
+			DBInstanceIdentifier:       util.GetStringFromMap(replicaMap, "dbInstanceIdentifier"),
+			SourceDBInstanceIdentifier: util.GetStringFromMap(replicaMap, "sourceDbInstanceIdentifier"),
+			DBInstanceClass:            util.GetStringFromMap(replicaMap, "dbInstanceClass"),
+			AvailabilityZone:           util.GetStringFromMap(replicaMap, "availabilityZone"),
+			DBSubnetGroupName:          util.GetStringFromMap(replicaMap, "dbSubnetGroupName"),
+			VpcSecurityGroupIDs:        util.GetStringSlice(replicaMap, "vpcSecurityGroupIds"),
+			DBParameterGroupName:       util.GetStringFromMap(replicaMap, "dbParameterGroupName"),
+			MultiAZ:                    util.GetBoolFromMap(replicaMap, "multiAz", false),
+			PubliclyAccessible:         util.GetBoolFromMap(replicaMap, "publiclyAccessible", false),
+			Tags:                       util.GetStringMap(replicaMap, "tags"),
+		}
+		if err := r.ValidateParams(operation, replicaParams); err != nil {
+			return nil, err
+		}
+		return r.client.CreateDBInstanceReadReplica(ctx, replicaParams)
+
+	case "restore-from-snapshot":
+		restoreMap, ok := params.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("restore parameters required")
+		}
+		restoreParams := aws.RestoreDBInstanceFromSnapshotParams{
+			DBInstanceIdentifier: util.GetStringFromMap(restoreMap, "dbInstanceIdentifier"),
+			DBSnapshotIdentifier: util.GetStringFromMap(restoreMap, "dbSnapshotIdentifier"),
+			DBInstanceClass:      util.GetStringFromMap(restoreMap, "dbInstanceClass"),
+			DBSubnetGroupName:    util.GetStringFromMap(restoreMap, "dbSubnetGroupName"),
+			VpcSecurityGroupIDs:  util.GetStringSlice(restoreMap, "vpcSecurityGroupIds"),
+			DBParameterGroupName: util.GetStringFromMap(restoreMap, "dbParameterGroupName"),
+			MultiAZ:              util.GetBoolFromMap(restoreMap, "multiAz", false),
+			PubliclyAccessible:   util.GetBoolFromMap(restoreMap, "publiclyAccessible", false),
+			Tags:                 util.GetStringMap(restoreMap, "tags"),
+		}
+		if err := r.ValidateParams(operation, restoreParams); err != nil {
+			return nil, err
+		}
+		return r.client.RestoreDBInstanceFromSnapshot(ctx, restoreParams)
+
+	case "create-parameter-group":
+		groupMap, ok := params.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("parameter group parameters required")
+		}
+		groupParams := aws.CreateDBParameterGroupParams{
+			DBParameterGroupName:   util.GetStringFromMap(groupMap, "dbParameterGroupName"),
+			DBParameterGroupFamily: util.GetStringFromMap(groupMap, "family"),
+			Description:            util.GetStringFromMap(groupMap, "description"),
+			Tags:                   util.GetStringMap(groupMap, "tags"),
+		}
+		if err := r.ValidateParams(operation, groupParams); err != nil {
+			return nil, err
+		}
+		return r.client.CreateDBParameterGroup(ctx, groupParams)
+
+	case "modify-parameter-group":
+		groupMap, ok := params.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("parameter group parameters required")
+		}
+		groupParams := aws.ModifyDBParameterGroupParams{
+			DBParameterGroupName: util.GetStringFromMap(groupMap, "dbParameterGroupName"),
+			Parameters:           dbParametersFromMap(groupMap),
+		}
+		if err := r.ValidateParams(operation, groupParams); err != nil {
+			return nil, err
+		}
+		if err := r.client.ModifyDBParameterGroup(ctx, groupParams); err != nil {
+			return nil, err
+		}
+		return r.describeParameterGroup(ctx, groupParams.DBParameterGroupName)
+
+	case "describe-parameter-group":
+		groupName, ok := params.(string)
+		if !ok || groupName == "" {
+			return nil, fmt.Errorf("parameter group name required for describe-parameter-group operation")
+		}
+		return r.describeParameterGroup(ctx, groupName)
+
+	case "list-parameter-groups":
+		groups, err := r.client.ListDBParameterGroups(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return &types.AWSResource{
+			ID:    "db-parameter-group-list",
+			Type:  "db-parameter-group-list",
+			State: "available",
+			Details: map[string]interface{}{
+				"count":             len(groups),
+				"dbParameterGroups": groups,
+			},
+		}, nil
+
+	case "delete-parameter-group":
+		groupName, ok := params.(string)
+		if !ok || groupName == "" {
+			return nil, fmt.Errorf("parameter group name required for delete-parameter-group operation")
+		}
+		if err := r.client.DeleteDBParameterGroup(ctx, groupName); err != nil {
+			return nil, err
+		}
+		return &types.AWSResource{ID: groupName, Type: "db-parameter-group", State: "deleted"}, nil
+
 	default:
 		return nil, fmt.Errorf("unsupported specialized operation: %s", operation)
 	}
 }
 
+// describeParameterGroup returns a parameter group along with its user-modified parameters
+func (r *RDSSpecializedAdapter) describeParameterGroup(ctx context.Context, groupName string) (*types.AWSResource, error) {
+	groups, err := r.client.ListDBParameterGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, group := range groups {
+		if group.ID != groupName {
+			continue
+		}
+
+		parameters, err := r.client.DescribeDBParameters(ctx, groupName)
+		if err != nil {
+			return nil, err
+		}
+		group.Details["parameters"] = parameters
+		return group, nil
+	}
+
+	return nil, fmt.Errorf("DB parameter group %s not found", groupName)
+}
+
 // GetSpecialOperations returns the specialized operations available
 func (r *RDSSpecializedAdapter) GetSpecialOperations() []string {
 	return []string{
@@ -404,5 +655,169 @@ func (r *RDSSpecializedAdapter) GetSpecialOperations() []string {
 		"create-db-subnet-group",
 		"list-db-snapshots",
 		"create-snapshot",
+		"modify-db-instance",
+		"create-read-replica",
+		"restore-from-snapshot",
+		"create-parameter-group",
+		"modify-parameter-group",
+		"describe-parameter-group",
+		"list-parameter-groups",
+		"delete-parameter-group",
 	}
 }
+
+// Master password modes for create-db-instance
+const (
+	passwordModeRDSManaged = "rds-managed" // RDS generates the password and owns the secret
+	passwordModeGenerate   = "generate"    // a generated password is stored in a new Secrets Manager secret
+	passwordModeSecret     = "secret"      // the password is read from an existing secret
+	passwordModeLiteral    = "literal"     // the caller passed masterUserPassword directly
+)
+
+// masterCredentials records how the master password of a new instance was obtained
+type masterCredentials struct {
+	mode      string
+	secretArn string
+}
+
+// resolveMasterCredentials fills in the master password settings of a create request. Passwords
+// read or generated here only travel to RDS and are never returned or logged.
+func (r *RDSSpecializedAdapter) resolveMasterCredentials(ctx context.Context, params map[string]interface{}, dbParams *aws.CreateDBInstanceParams) (masterCredentials, error) {
+	literalPassword := util.GetStringFromMap(params, "masterUserPassword")
+	secretID := util.GetStringFromMap(params, "masterUserSecretId")
+
+	mode := util.GetStringFromMap(params, "passwordMode")
+	if mode == "" {
+		switch {
+		case literalPassword != "":
+			mode = passwordModeLiteral
+		case secretID != "":
+			mode = passwordModeSecret
+		default:
+			mode = passwordModeRDSManaged
+		}
+	}
+
+	if mode != passwordModeLiteral && literalPassword != "" {
+		return masterCredentials{}, fmt.Errorf("masterUserPassword cannot be combined with passwordMode %s", mode)
+	}
+
+	switch mode {
+	case passwordModeRDSManaged:
+		dbParams.ManageMasterUserPassword = true
+		dbParams.MasterUserSecretKmsKeyID = util.GetStringFromMap(params, "masterUserSecretKmsKeyId")
+		return masterCredentials{mode: mode}, nil
+
+	case passwordModeGenerate:
+		secretName := util.GetStringFromMap(params, "masterUserSecretName")
+		if secretName == "" {
+			secretName = fmt.Sprintf("rds/%s/master", dbParams.DBInstanceIdentifier)
+		}
+		secretArn, password, err := r.client.CreateDBCredentialsSecret(ctx, aws.CreateDBCredentialsSecretParams{
+			Name:                 secretName,
+			Username:             dbParams.MasterUsername,
+			Engine:               dbParams.Engine,
+			DBInstanceIdentifier: dbParams.DBInstanceIdentifier,
+			KmsKeyID:             util.GetStringFromMap(params, "masterUserSecretKmsKeyId"),
+		})
+		if err != nil {
+			return masterCredentials{}, err
+		}
+		dbParams.MasterUserPassword = password
+		return masterCredentials{mode: mode, secretArn: secretArn}, nil
+
+	case passwordModeSecret:
+		if secretID == "" {
+			return masterCredentials{}, fmt.Errorf("masterUserSecretId is required when passwordMode is secret")
+		}
+		password, err := r.client.GetSecretPassword(ctx, secretID)
+		if err != nil {
+			return masterCredentials{}, err
+		}
+		dbParams.MasterUserPassword = password
+		return masterCredentials{mode: mode, secretArn: secretID}, nil
+
+	case passwordModeLiteral:
+		if literalPassword == "" {
+			return masterCredentials{}, fmt.Errorf("masterUserPassword is required when passwordMode is literal")
+		}
+		dbParams.MasterUserPassword = literalPassword
+		return masterCredentials{mode: mode}, nil
+
+	default:
+		return masterCredentials{}, fmt.Errorf("unsupported passwordMode %s, expected rds-managed, generate, secret or literal", mode)
+	}
+}
+
+// masterUserSecretArn returns the ARN of the secret holding the master password of a new
+// instance. RDS-managed secrets are reported by the create call, or looked up when it does not
+// include them; it returns an empty string when the ARN is unknown.
+func (r *RDSSpecializedAdapter) masterUserSecretArn(ctx context.Context, instance *rdstypes.DBInstance, credentials masterCredentials) string {
+	if instance.MasterUserSecret != nil {
+		if secretArn := awssdk.ToString(instance.MasterUserSecret.SecretArn); secretArn != "" {
+			return secretArn
+		}
+	}
+	if credentials.mode != passwordModeRDSManaged {
+		return credentials.secretArn
+	}
+
+	described, err := r.client.GetDBInstance(ctx, awssdk.ToString(instance.DBInstanceIdentifier))
+	if err != nil {
+		return ""
+	}
+	secretArn, _ := described.Details["master_user_secret_arn"].(string)
+	return secretArn
+}
+
+// modifyDBInstanceParamsFromMap builds instance modification parameters from tool arguments.
+// multiAz and backupRetentionPeriod are only changed when present in the arguments.
+func modifyDBInstanceParamsFromMap(params map[string]interface{}) aws.ModifyDBInstanceParams {
+	modifyParams := aws.ModifyDBInstanceParams{
+		DBInstanceIdentifier: util.GetStringFromMap(params, "dbInstanceIdentifier"),
+		DBInstanceClass:      util.GetStringFromMap(params, "dbInstanceClass"),
+		AllocatedStorage:     util.GetInt32FromMap(params, "allocatedStorage", 0),
+		MaxAllocatedStorage:  util.GetInt32FromMap(params, "maxAllocatedStorage", 0),
+		StorageType:          util.GetStringFromMap(params, "storageType"),
+		Iops:                 util.GetInt32FromMap(params, "iops", 0),
+		DBParameterGroupName: util.GetStringFromMap(params, "dbParameterGroupName"),
+		VpcSecurityGroupIDs:  util.GetStringSlice(params, "vpcSecurityGroupIds"),
+		ApplyImmediately:     util.GetBoolFromMap(params, "applyImmediately", false),
+	}
+
+	if _, ok := params["multiAz"]; ok {
+		multiAZ := util.GetBoolFromMap(params, "multiAz", false)
+		modifyParams.MultiAZ = &multiAZ
+	}
+	if _, ok := params["backupRetentionPeriod"]; ok {
+		retention := util.GetInt32FromMap(params, "backupRetentionPeriod", 0)
+		modifyParams.BackupRetentionPeriod = &retention
+	}
+
+	return modifyParams
+}
+
+// dbParametersFromMap reads the parameters list of a modify-parameter-group request
+func dbParametersFromMap(params map[string]interface{}) []aws.DBParameterParams {
+	var parameters []aws.DBParameterParams
+
+	entries, _ := params["parameters"].([]interface{})
+	for _, entry := range entries {
+		parameterMap, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		// Numeric and boolean values are accepted and sent in their string form
+		value := util.GetStringFromMap(parameterMap, "value")
+		if raw, ok := parameterMap["value"]; ok && value == "" && raw != nil {
+			value = fmt.Sprint(raw)
+		}
+		parameters = append(parameters, aws.DBParameterParams{
+			Name:        util.GetStringFromMap(parameterMap, "name"),
+			Value:       value,
+			ApplyMethod: util.GetStringFromMap(parameterMap, "applyMethod"),
+		})
+	}
+
+	return parameters
+}
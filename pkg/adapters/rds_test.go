@@ -0,0 +1,104 @@
+package adapters
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/versus-control/ai-infrastructure-agent/pkg/aws"
+)
+
+// Modes that create or read a Secrets Manager secret need AWS and are not covered here
+func TestResolveMasterCredentials(t *testing.T) {
+	adapter := &RDSSpecializedAdapter{}
+
+	tests := []struct {
+		name             string
+		params           map[string]interface{}
+		wantMode         string
+		wantManaged      bool
+		wantPassword     string
+		wantSecretKmsKey string
+		wantErr          string
+	}{
+		{
+			name:        "rds-managed by default",
+			params:      map[string]interface{}{},
+			wantMode:    passwordModeRDSManaged,
+			wantManaged: true,
+		},
+		{
+			name:             "rds-managed with a KMS key",
+			params:           map[string]interface{}{"passwordMode": "rds-managed", "masterUserSecretKmsKeyId": "alias/db"},
+			wantMode:         passwordModeRDSManaged,
+			wantManaged:      true,
+			wantSecretKmsKey: "alias/db",
+		},
+		{
+			name:         "literal inferred from the password",
+			params:       map[string]interface{}{"masterUserPassword": "s3cret-Passw0rd"},
+			wantMode:     passwordModeLiteral,
+			wantPassword: "s3cret-Passw0rd",
+		},
+		{
+			name:         "explicit literal",
+			params:       map[string]interface{}{"passwordMode": "literal", "masterUserPassword": "s3cret-Passw0rd"},
+			wantMode:     passwordModeLiteral,
+			wantPassword: "s3cret-Passw0rd",
+		},
+		{
+			name:    "literal without a password",
+			params:  map[string]interface{}{"passwordMode": "literal"},
+			wantErr: "masterUserPassword is required",
+		},
+		{
+			name:    "password combined with another mode",
+			params:  map[string]interface{}{"passwordMode": "rds-managed", "masterUserPassword": "s3cret-Passw0rd"},
+			wantErr: "cannot be combined with passwordMode rds-managed",
+		},
+		{
+			name:    "password combined with a secret",
+			params:  map[string]interface{}{"passwordMode": "secret", "masterUserSecretId": "db/orders", "masterUserPassword": "s3cret-Passw0rd"},
+			wantErr: "cannot be combined with passwordMode secret",
+		},
+		{
+			name:    "secret without a secret ID",
+			params:  map[string]interface{}{"passwordMode": "secret"},
+			wantErr: "masterUserSecretId is required",
+		},
+		{
+			name:    "unsupported mode",
+			params:  map[string]interface{}{"passwordMode": "plaintext"},
+			wantErr: "expected rds-managed, generate, secret or literal",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbParams := aws.CreateDBInstanceParams{DBInstanceIdentifier: "orders", MasterUsername: "admin"}
+			credentials, err := adapter.resolveMasterCredentials(context.Background(), tt.params, &dbParams)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("resolveMasterCredentials() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveMasterCredentials() error = %v", err)
+			}
+
+			if credentials.mode != tt.wantMode {
+				t.Errorf("mode = %q, want %q", credentials.mode, tt.wantMode)
+			}
+			if dbParams.ManageMasterUserPassword != tt.wantManaged {
+				t.Errorf("ManageMasterUserPassword = %v, want %v", dbParams.ManageMasterUserPassword, tt.wantManaged)
+			}
+			if dbParams.MasterUserPassword != tt.wantPassword {
+				t.Errorf("MasterUserPassword = %q, want %q", dbParams.MasterUserPassword, tt.wantPassword)
+			}
+			if dbParams.MasterUserSecretKmsKeyID != tt.wantSecretKmsKey {
+				t.Errorf("MasterUserSecretKmsKeyID = %q, want %q", dbParams.MasterUserSecretKmsKeyID, tt.wantSecretKmsKey)
+			}
+		})
+	}
+}
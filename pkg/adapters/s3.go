@@ -0,0 +1,175 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/versus-control/ai-infrastructure-agent/internal/logging"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/aws"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/interfaces"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/types"
+)
+
+// S3Adapter implements the AWSResourceAdapter interface for S3 buckets
+type S3Adapter struct {
+	*BaseAWSAdapter
+	client *aws.Client
+}
+
+// NewS3Adapter creates a new S3 bucket adapter
+func NewS3Adapter(client *aws.Client, logger *logging.Logger) interfaces.AWSResourceAdapter {
+	base := NewBaseAWSAdapter(client, logger, "s3_bucket")
+	return &S3Adapter{
+		BaseAWSAdapter: base,
+		client:         client,
+	}
+}
+
+// Create creates a new S3 bucket with its initial settings
+func (s *S3Adapter) Create(ctx context.Context, params interface{}) (*types.AWSResource, error) {
+	createParams, ok := params.(aws.CreateBucketParams)
+	if !ok {
+		return nil, fmt.Errorf("invalid parameters for S3 bucket creation, expected aws.CreateBucketParams")
+	}
+
+	if err := s.ValidateParams("create", createParams); err != nil {
+		return nil, err
+	}
+
+	bucket, err := s.client.CreateBucket(ctx, createParams)
+	if err != nil {
+		// A bucket that was created but not configured is returned with the error
+		return bucket, err
+	}
+
+	s.logger.Infof("Created S3 bucket %s", bucket.ID)
+	return bucket, nil
+}
+
+// List returns all S3 buckets in the client's region
+func (s *S3Adapter) List(ctx context.Context) ([]*types.AWSResource, error) {
+	buckets, err := s.client.ListBuckets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list S3 buckets: %w", err)
+	}
+
+	return buckets, nil
+}
+
+// Get retrieves a specific S3 bucket by name
+func (s *S3Adapter) Get(ctx context.Context, id string) (*types.AWSResource, error) {
+	bucket, err := s.client.GetBucket(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get S3 bucket %s: %w", id, err)
+	}
+
+	return bucket, nil
+}
+
+// Update applies bucket setting changes (versioning, encryption, public access block,
+// lifecycle, policy and tags); settings left empty in the params are not touched
+func (s *S3Adapter) Update(ctx context.Context, id string, params interface{}) (*types.AWSResource, error) {
+	updateParams, ok := params.(aws.UpdateBucketParams)
+	if !ok {
+		return nil, fmt.Errorf("invalid parameters for S3 bucket update, expected aws.UpdateBucketParams")
+	}
+
+	if updateParams.BlockPublicAccess != nil {
+		if err := s.client.PutPublicAccessBlock(ctx, id, *updateParams.BlockPublicAccess); err != nil {
+			return nil, err
+		}
+	}
+	if updateParams.Versioning != nil {
+		if err := s.client.PutBucketVersioning(ctx, id, *updateParams.Versioning); err != nil {
+			return nil, err
+		}
+	}
+	if updateParams.Encryption != "" {
+		if err := s.client.PutBucketEncryption(ctx, id, updateParams.Encryption, updateParams.KMSKeyID); err != nil {
+			return nil, err
+		}
+	}
+	if len(updateParams.LifecycleRules) > 0 {
+		if err := s.client.PutBucketLifecycle(ctx, id, updateParams.LifecycleRules); err != nil {
+			return nil, err
+		}
+	}
+	if updateParams.Policy != "" {
+		if err := s.client.PutBucketPolicy(ctx, id, updateParams.Policy); err != nil {
+			return nil, err
+		}
+	}
+	if len(updateParams.Tags) > 0 {
+		if err := s.client.PutBucketTags(ctx, id, updateParams.Tags); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.Get(ctx, id)
+}
+
+// Delete deletes an empty S3 bucket
+func (s *S3Adapter) Delete(ctx context.Context, id string) error {
+	return s.client.DeleteBucket(ctx, id)
+}
+
+// GetSupportedOperations returns the operations supported by this adapter
+func (s *S3Adapter) GetSupportedOperations() []string {
+	return []string{
+		"create",
+		"list",
+		"get",
+		"update",
+		"delete",
+	}
+}
+
+// ValidateParams validates S3-specific parameters
+func (s *S3Adapter) ValidateParams(operation string, params interface{}) error {
+	switch operation {
+	case "create":
+		createParams, ok := params.(aws.CreateBucketParams)
+		if !ok {
+			return fmt.Errorf("invalid parameters for create operation")
+		}
+		if createParams.BucketName == "" {
+			return fmt.Errorf("bucketName is required for S3 bucket creation")
+		}
+		if len(createParams.BucketName) < 3 || len(createParams.BucketName) > 63 {
+			return fmt.Errorf("bucketName must be between 3 and 63 characters")
+		}
+		if createParams.KMSKeyID != "" && createParams.Encryption != "aws:kms" {
+			return fmt.Errorf("kmsKeyId can only be used with aws:kms encryption")
+		}
+		return nil
+	case "update":
+		if _, ok := params.(aws.UpdateBucketParams); !ok {
+			return fmt.Errorf("invalid parameters for update operation")
+		}
+		return nil
+	case "get", "delete":
+		if params == nil {
+			return fmt.Errorf("bucket name is required for %s operation", operation)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported operation: %s", operation)
+	}
+}
+
+// ListByTags overrides the base implementation with S3-specific logic
+func (s *S3Adapter) ListByTags(ctx context.Context, tags map[string]string) ([]*types.AWSResource, error) {
+	allBuckets, err := s.client.ListBuckets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var filtered []*types.AWSResource
+	for _, bucket := range allBuckets {
+		if s.matchesTags(bucket.Tags, tags) {
+			filtered = append(filtered, bucket)
+		}
+	}
+
+	return filtered, nil
+}
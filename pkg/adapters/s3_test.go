@@ -0,0 +1,99 @@
+package adapters
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/versus-control/ai-infrastructure-agent/pkg/aws"
+)
+
+func TestS3ValidateParams(t *testing.T) {
+	adapter := &S3Adapter{}
+	enabled := true
+
+	tests := []struct {
+		name      string
+		operation string
+		params    interface{}
+		wantErr   string
+	}{
+		{
+			name:      "private bucket",
+			operation: "create",
+			params:    aws.CreateBucketParams{BucketName: "app-assets", BlockPublicAccess: true, Encryption: "AES256"},
+		},
+		{
+			name:      "kms encrypted bucket",
+			operation: "create",
+			params:    aws.CreateBucketParams{BucketName: "app-assets", Encryption: "aws:kms", KMSKeyID: "alias/app"},
+		},
+		{
+			name:      "missing bucket name",
+			operation: "create",
+			params:    aws.CreateBucketParams{},
+			wantErr:   "bucketName is required",
+		},
+		{
+			name:      "short bucket name",
+			operation: "create",
+			params:    aws.CreateBucketParams{BucketName: "ab"},
+			wantErr:   "between 3 and 63",
+		},
+		{
+			name:      "long bucket name",
+			operation: "create",
+			params:    aws.CreateBucketParams{BucketName: strings.Repeat("a", 64)},
+			wantErr:   "between 3 and 63",
+		},
+		{
+			name:      "kms key without kms encryption",
+			operation: "create",
+			params:    aws.CreateBucketParams{BucketName: "app-assets", Encryption: "AES256", KMSKeyID: "alias/app"},
+			wantErr:   "kmsKeyId can only be used",
+		},
+		{
+			name:      "wrong create parameters",
+			operation: "create",
+			params:    aws.UpdateBucketParams{},
+			wantErr:   "invalid parameters for create",
+		},
+		{
+			name:      "update",
+			operation: "update",
+			params:    aws.UpdateBucketParams{Versioning: &enabled},
+		},
+		{
+			name:      "wrong update parameters",
+			operation: "update",
+			params:    aws.CreateBucketParams{BucketName: "app-assets"},
+			wantErr:   "invalid parameters for update",
+		},
+		{
+			name:      "delete without bucket name",
+			operation: "delete",
+			params:    nil,
+			wantErr:   "bucket name is required",
+		},
+		{
+			name:      "unsupported operation",
+			operation: "copy",
+			params:    aws.CreateBucketParams{BucketName: "app-assets"},
+			wantErr:   "unsupported operation",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := adapter.ValidateParams(tt.operation, tt.params)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("ValidateParams() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ValidateParams() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
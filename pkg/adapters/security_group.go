@@ -3,12 +3,14 @@ package adapters
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/versus-control/ai-infrastructure-agent/internal/logging"
 	"github.com/versus-control/ai-infrastructure-agent/pkg/aws"
 	"github.com/versus-control/ai-infrastructure-agent/pkg/interfaces"
 	"github.com/versus-control/ai-infrastructure-agent/pkg/types"
+	util "github.com/versus-control/ai-infrastructure-agent/pkg/utilities"
 )
 
 // SecurityGroupAdapter implements the AWSResourceAdapter interface for Security Group resources
@@ -121,10 +123,39 @@ func (s *SecurityGroupAdapter) Get(ctx context.Context, id string) (*types.AWSRe
 	return resource, nil
 }
 
-// Update updates a security group (limited operations available)
+// Update reconciles the rules of a security group against the desired rule set.
+// It accepts aws.SetSecurityGroupRulesParams or a map with ingressRules/egressRules.
 func (s *SecurityGroupAdapter) Update(ctx context.Context, id string, params interface{}) (*types.AWSResource, error) {
-	// Security groups have limited update operations (mainly rule management)
-	return nil, fmt.Errorf("security group updates not supported via standard interface, use specialized operations for rule management")
+	var setParams aws.SetSecurityGroupRulesParams
+	switch p := params.(type) {
+	case aws.SetSecurityGroupRulesParams:
+		setParams = p
+	case map[string]interface{}:
+		setParams = setRulesParamsFromMap(p)
+	default:
+		return nil, fmt.Errorf("invalid parameters for security group update, expected aws.SetSecurityGroupRulesParams")
+	}
+	setParams.GroupID = id
+
+	if err := s.ValidateParams("update", setParams); err != nil {
+		return nil, err
+	}
+
+	changes, err := s.client.SetSecurityGroupRules(ctx, setParams)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update rules of security group %s: %w", id, err)
+	}
+
+	resource, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	resource.Details["added"] = changes.Added
+	resource.Details["removed"] = changes.Removed
+	resource.Details["unchanged"] = changes.Unchanged
+	resource.Details["dryRun"] = setParams.DryRun
+
+	return resource, nil
 }
 
 // Delete deletes a security group
@@ -144,6 +175,7 @@ func (s *SecurityGroupAdapter) GetSupportedOperations() []string {
 		"create",
 		"list",
 		"get",
+		"update",
 		"delete",
 	}
 }
@@ -163,6 +195,29 @@ func (s *SecurityGroupAdapter) ValidateParams(operation string, params interface
 			return fmt.Errorf("description is required for security group creation")
 		}
 		return nil
+	case "update":
+		setParams, ok := params.(aws.SetSecurityGroupRulesParams)
+		if !ok {
+			return fmt.Errorf("invalid parameters for update operation")
+		}
+		if setParams.GroupID == "" {
+			return fmt.Errorf("security group ID is required for update operation")
+		}
+		for _, rule := range append(setParams.Ingress, setParams.Egress...) {
+			if err := validateRuleParams(rule); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "add-rule", "revoke-rule":
+		ruleParams, ok := params.(aws.SecurityGroupRuleParams)
+		if !ok {
+			return fmt.Errorf("invalid parameters for %s operation", operation)
+		}
+		if ruleParams.GroupID == "" {
+			return fmt.Errorf("groupId is required for %s operation", operation)
+		}
+		return validateRuleParams(ruleParams)
 	case "get", "delete":
 		if params == nil {
 			return fmt.Errorf("security group ID is required for %s operation", operation)
@@ -176,93 +231,89 @@ func (s *SecurityGroupAdapter) ValidateParams(operation string, params interface
 // ExecuteSpecialOperation executes security group-specific operations
 func (s *SecurityGroupAdapter) ExecuteSpecialOperation(ctx context.Context, operation string, params interface{}) (*types.AWSResource, error) {
 	switch operation {
-	case "add-ingress-rule":
-		ruleParams, ok := params.(map[string]interface{})
+	case "add-ingress-rule", "add-egress-rule", "revoke-ingress-rule", "revoke-egress-rule":
+		ruleMap, ok := params.(map[string]interface{})
 		if !ok {
-			return nil, fmt.Errorf("add ingress rule parameters required")
+			return nil, fmt.Errorf("%s parameters required", operation)
 		}
 
-		groupID, _ := ruleParams["groupId"].(string)
-		protocol, _ := ruleParams["protocol"].(string)
-		fromPort, _ := ruleParams["fromPort"].(int)
-		toPort, _ := ruleParams["toPort"].(int)
-		cidrBlock, _ := ruleParams["cidrBlock"].(string)
-
-		if groupID == "" || protocol == "" || cidrBlock == "" {
-			return nil, fmt.Errorf("groupId, protocol, and cidrBlock are required for add ingress rule operation")
+		direction := "ingress"
+		if strings.Contains(operation, "egress") {
+			direction = "egress"
 		}
+		revoke := strings.HasPrefix(operation, "revoke-")
 
-		// Create rule parameters
-		sgRuleParams := aws.SecurityGroupRuleParams{
-			GroupID:    groupID,
-			Type:       "ingress",
-			Protocol:   protocol,
-			FromPort:   int32(fromPort),
-			ToPort:     int32(toPort),
-			CidrBlocks: []string{cidrBlock},
+		sgRuleParams := ruleParamsFromMap(util.GetStringFromMap(ruleMap, "groupId"), direction, ruleMap)
+		validateOperation := "add-rule"
+		if revoke {
+			validateOperation = "revoke-rule"
+		}
+		if err := s.ValidateParams(validateOperation, sgRuleParams); err != nil {
+			return nil, err
 		}
 
-		err := s.client.AddSecurityGroupRule(ctx, sgRuleParams)
+		var err error
+		if revoke {
+			err = s.client.RevokeSecurityGroupRule(ctx, sgRuleParams)
+		} else {
+			err = s.client.AddSecurityGroupRule(ctx, sgRuleParams)
+		}
 		if err != nil {
-			return nil, fmt.Errorf("failed to add ingress rule: %w", err)
+			return nil, err
+		}
+
+		state := "available"
+		if revoke {
+			state = "revoked"
 		}
 
 		// Return result resource
 		return &types.AWSResource{
-			ID:    groupID,
+			ID:    sgRuleParams.GroupID,
 			Type:  "security-group-rule",
-			State: "available",
+			State: state,
 			Details: map[string]interface{}{
-				"direction": "ingress",
-				"protocol":  protocol,
-				"fromPort":  fromPort,
-				"toPort":    toPort,
-				"cidrBlock": cidrBlock,
+				"direction":             direction,
+				"protocol":              sgRuleParams.Protocol,
+				"fromPort":              sgRuleParams.FromPort,
+				"toPort":                sgRuleParams.ToPort,
+				"cidrBlocks":            sgRuleParams.CidrBlocks,
+				"sourceSecurityGroupId": sgRuleParams.SourceSG,
+				"prefixListIds":         sgRuleParams.PrefixListIDs,
 			},
 		}, nil
 
-	case "add-egress-rule":
-		ruleParams, ok := params.(map[string]interface{})
+	case "set-rules":
+		setMap, ok := params.(map[string]interface{})
 		if !ok {
-			return nil, fmt.Errorf("add egress rule parameters required")
+			return nil, fmt.Errorf("set-rules parameters required")
 		}
 
-		groupID, _ := ruleParams["groupId"].(string)
-		protocol, _ := ruleParams["protocol"].(string)
-		fromPort, _ := ruleParams["fromPort"].(int)
-		toPort, _ := ruleParams["toPort"].(int)
-		cidrBlock, _ := ruleParams["cidrBlock"].(string)
-
-		if groupID == "" || protocol == "" || cidrBlock == "" {
-			return nil, fmt.Errorf("groupId, protocol, and cidrBlock are required for add egress rule operation")
+		groupID := util.GetStringFromMap(setMap, "groupId")
+		if groupID == "" {
+			return nil, fmt.Errorf("groupId is required for set-rules operation")
 		}
 
-		// Create rule parameters
-		sgRuleParams := aws.SecurityGroupRuleParams{
-			GroupID:    groupID,
-			Type:       "egress",
-			Protocol:   protocol,
-			FromPort:   int32(fromPort),
-			ToPort:     int32(toPort),
-			CidrBlocks: []string{cidrBlock},
+		return s.Update(ctx, groupID, setMap)
+
+	case "list-rules":
+		groupID, ok := params.(string)
+		if !ok || groupID == "" {
+			return nil, fmt.Errorf("security group ID required for list-rules operation")
 		}
 
-		err := s.client.AddSecurityGroupRule(ctx, sgRuleParams)
+		rules, err := s.client.ListSecurityGroupRules(ctx, groupID)
 		if err != nil {
-			return nil, fmt.Errorf("failed to add egress rule: %w", err)
+			return nil, err
 		}
 
-		// Return result resource
 		return &types.AWSResource{
 			ID:    groupID,
-			Type:  "security-group-rule",
+			Type:  "security-group-rule-list",
 			State: "available",
 			Details: map[string]interface{}{
-				"direction": "egress",
-				"protocol":  protocol,
-				"fromPort":  fromPort,
-				"toPort":    toPort,
-				"cidrBlock": cidrBlock,
+				"rules": rules,
+				"count": len(rules),
 			},
 		}, nil
 
@@ -297,6 +348,10 @@ func (s *SecurityGroupAdapter) GetSpecialOperations() []string {
 	return []string{
 		"add-ingress-rule",
 		"add-egress-rule",
+		"revoke-ingress-rule",
+		"revoke-egress-rule",
+		"set-rules",
+		"list-rules",
 		"delete-security-group",
 	}
 }
@@ -308,3 +363,83 @@ func NewSecurityGroupSpecializedAdapter(client *aws.Client, logger *logging.Logg
 		client:         client,
 	}
 }
+
+// ruleParamsFromMap builds rule parameters from tool arguments. A rule names its source
+// (or, for egress, destination) with cidrBlock(s), sourceSecurityGroupId or prefixListId(s).
+// For ICMP, fromPort and toPort are the type and code, each -1 (all) when omitted.
+func ruleParamsFromMap(groupID, direction string, ruleMap map[string]interface{}) aws.SecurityGroupRuleParams {
+	params := aws.SecurityGroupRuleParams{
+		GroupID:       groupID,
+		Type:          direction,
+		Protocol:      util.GetStringFromMap(ruleMap, "protocol"),
+		FromPort:      util.GetInt32FromMap(ruleMap, "fromPort", 0),
+		ToPort:        util.GetInt32FromMap(ruleMap, "toPort", 0),
+		CidrBlocks:    util.GetStringSlice(ruleMap, "cidrBlocks"),
+		SourceSG:      util.GetStringFromMap(ruleMap, "sourceSecurityGroupId"),
+		PrefixListIDs: util.GetStringSlice(ruleMap, "prefixListIds"),
+		Description:   util.GetStringFromMap(ruleMap, "description"),
+	}
+	_, hasFrom := ruleMap["fromPort"]
+	_, hasTo := ruleMap["toPort"]
+	switch {
+	case aws.IsICMPProtocol(params.Protocol):
+		if !hasFrom {
+			params.FromPort = -1
+		}
+		if !hasTo {
+			params.ToPort = -1
+		}
+	case !hasTo:
+		params.ToPort = params.FromPort
+	}
+	if cidrBlock := util.GetStringFromMap(ruleMap, "cidrBlock"); cidrBlock != "" {
+		params.CidrBlocks = append(params.CidrBlocks, cidrBlock)
+	}
+	if prefixListID := util.GetStringFromMap(ruleMap, "prefixListId"); prefixListID != "" {
+		params.PrefixListIDs = append(params.PrefixListIDs, prefixListID)
+	}
+
+	return params
+}
+
+// setRulesParamsFromMap builds a desired rule set from ingressRules and egressRules arrays.
+// Egress is only reconciled when egressRules is present.
+func setRulesParamsFromMap(setMap map[string]interface{}) aws.SetSecurityGroupRulesParams {
+	params := aws.SetSecurityGroupRulesParams{
+		GroupID: util.GetStringFromMap(setMap, "groupId"),
+		DryRun:  util.GetBoolFromMap(setMap, "dryRun", false),
+	}
+
+	rulesFromList := func(key, direction string) []aws.SecurityGroupRuleParams {
+		var rules []aws.SecurityGroupRuleParams
+		list, _ := setMap[key].([]interface{})
+		for _, item := range list {
+			if ruleMap, ok := item.(map[string]interface{}); ok {
+				rules = append(rules, ruleParamsFromMap(params.GroupID, direction, ruleMap))
+			}
+		}
+		return rules
+	}
+
+	params.Ingress = rulesFromList("ingressRules", "ingress")
+	if _, ok := setMap["egressRules"]; ok {
+		params.ManageEgress = true
+		params.Egress = rulesFromList("egressRules", "egress")
+	}
+
+	return params
+}
+
+// validateRuleParams checks that a rule has a protocol and exactly one kind of source
+func validateRuleParams(rule aws.SecurityGroupRuleParams) error {
+	if rule.Protocol == "" {
+		return fmt.Errorf("protocol is required for security group rules")
+	}
+	if len(rule.CidrBlocks) == 0 && rule.SourceSG == "" && len(rule.PrefixListIDs) == 0 {
+		return fmt.Errorf("each %s rule needs a cidrBlock, sourceSecurityGroupId or prefixListId", rule.Type)
+	}
+	if (rule.Protocol == "tcp" || rule.Protocol == "udp") && rule.FromPort > rule.ToPort {
+		return fmt.Errorf("fromPort %d is greater than toPort %d", rule.FromPort, rule.ToPort)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/versus-control/ai-infrastructure-agent/pkg/types"
+)
+
+// ========== Interface defines ==========
+
+// CostEstimatorInterface defines plan cost estimation and cost reporting functionality
+//
+// Available Functions:
+//   - EstimateCost()          : Estimate the monthly cost of a decision's plan and store it on the decision
+//   - GenerateCostReport()    : Report the monthly spend of existing infrastructure and its idle resources
+//   - BuildCleanupRequest()   : Describe the cleanup of a report's findings as an agent request
+//   - PlanCostCleanup()       : Generate a plan that removes the idle resources of a report
+//
+// Prices come from the offline pricing catalog in settings/pricing.yaml (or PRICING_FILE). The
+// estimate is attached to the decision before guardrail policies are evaluated, so decision
+// policies can set budget thresholds on "monthlyCost" and "stepCosts".
+//
+// Usage Example:
+//   1. estimate := agent.EstimateCost(decision)
+//   2. fmt.Printf("%.2f %s per month", estimate.MonthlyTotal, estimate.Currency)
+//   3. report, _ := agent.GenerateCostReport(ctx, true)
+//   4. decision, _ := agent.PlanCostCleanup(ctx, report, nil)
+
+// ========== Cost Estimation Functions ==========
+
+// EstimateCost estimates the monthly cost of the resources a decision's plan creates and stores
+// the estimate on the decision. It returns nil when no pricing catalog is loaded.
+func (a *StateAwareAgent) EstimateCost(decision *types.AgentDecision) *types.CostEstimate {
+	if a.costEstimator == nil {
+		return nil
+	}
+
+	estimate := a.costEstimator.EstimateDecision(decision, a.awsConfig.Region)
+	decision.CostEstimate = estimate
+
+	a.Logger.WithFields(map[string]interface{}{
+		"decision_id":    decision.ID,
+		"monthly_total":  estimate.MonthlyTotal,
+		"currency":       estimate.Currency,
+		"priced_steps":   len(estimate.Steps) - len(estimate.UnpricedSteps),
+		"unpriced_steps": estimate.UnpricedSteps,
+	}).Info("Estimated plan cost")
+
+	return estimate
+}
+
+// ========== Cost Report Functions ==========
+
+// GenerateCostReport asks the MCP server for a cost and rightsizing report over the managed
+// state, merged with a live discovery scan when scanLive is set
+func (a *StateAwareAgent) GenerateCostReport(ctx context.Context, scanLive bool) (*types.CostReport, error) {
+	result, err := a.callMCPTool("generate-cost-report", map[string]interface{}{
+		"scan_live": scanLive,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate cost report: %w", err)
+	}
+
+	reportData, exists := result["report"]
+	if !exists {
+		return nil, fmt.Errorf("cost report missing from MCP response")
+	}
+
+	// The report arrives as generic JSON; round-trip it into the typed report
+	reportJSON, err := json.Marshal(reportData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cost report: %w", err)
+	}
+	var report types.CostReport
+	if err := json.Unmarshal(reportJSON, &report); err != nil {
+		return nil, fmt.Errorf("failed to parse cost report: %w", err)
+	}
+
+	return &report, nil
+}
+
+// BuildCleanupRequest describes the removal of a report's idle resources as a request for the
+// agent. Only the findings of resourceIDs are included, or every finding when it is empty. It
+// returns an empty string when no finding is selected.
+func (a *StateAwareAgent) BuildCleanupRequest(report *types.CostReport, resourceIDs []string) string {
+	selected := make(map[string]bool, len(resourceIDs))
+	for _, id := range resourceIDs {
+		selected[id] = true
+	}
+
+	var lines []string
+	var savings float64
+	for _, finding := range report.Findings {
+		if len(selected) > 0 && !selected[finding.ResourceID] {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("- %s in %s: %s. %s (saves %.2f %s per month)",
+			finding.ResourceID, finding.Region, finding.Description, finding.Recommendation, finding.MonthlySavings, report.Currency))
+		savings += finding.MonthlySavings
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("Clean up the following idle resources found by the cost report, saving %.2f %s per month. "+
+		"Only remove the resources listed here, do not modify anything else:\n%s",
+		savings, report.Currency, strings.Join(lines, "\n"))
+}
+
+// PlanCostCleanup generates a plan that removes the idle resources of a report. Like any other
+// request, the plan still has to be confirmed before it is executed.
+func (a *StateAwareAgent) PlanCostCleanup(ctx context.Context, report *types.CostReport, resourceIDs []string) (*types.AgentDecision, error) {
+	request := a.BuildCleanupRequest(report, resourceIDs)
+	if request == "" {
+		return nil, fmt.Errorf("the cost report has no cleanup findings for the selected resources")
+	}
+
+	a.Logger.WithFields(map[string]interface{}{
+		"finding_count":   len(report.Findings),
+		"selected":        resourceIDs,
+		"monthly_savings": report.MonthlySavings,
+	}).Info("Planning cleanup of idle resources")
+
+	return a.ProcessRequest(ctx, request)
+}
@@ -17,6 +17,7 @@ import (
 //   - resolveDefaultValue()                 : Provide intelligent default values
 //   - addMissingRequiredParameters()    : Add defaults for missing required parameters
 //   - validateNativeMCPArguments()      : Validate arguments against tool schema
+//   - rejectLiteralSecrets()            : Refuse plaintext passwords in plan parameters
 //
 // This file handles dependency resolution between plan steps, parameter
 // validation, and intelligent default value provisioning for infrastructure operations.
@@ -425,5 +426,24 @@ func (a *StateAwareAgent) validateNativeMCPArguments(toolName string, arguments
 		}
 	}
 
+	return rejectLiteralSecrets(toolName, arguments)
+}
+
+// literalSecretParameters are the parameters of the RDS tools that would carry a plaintext master
+// password. Plans must use secret references (passwordMode, masterUserSecretId) instead so
+// credentials never pass through prompts, plans, logs or state. Other tools, including federated
+// ones, keep their own password parameters.
+var literalSecretParameters = map[string][]string{
+	"create-db-instance": {"masterUserPassword"},
+	"modify-db-instance": {"masterUserPassword"},
+}
+
+// rejectLiteralSecrets refuses plan parameters of the RDS tools that contain plaintext passwords
+func rejectLiteralSecrets(toolName string, arguments map[string]interface{}) error {
+	for _, paramName := range literalSecretParameters[toolName] {
+		if val, exists := arguments[paramName]; exists && val != nil && val != "" {
+			return fmt.Errorf("parameter %s of tool %s must not contain a literal password; use passwordMode \"rds-managed\", \"generate\" or \"secret\" with masterUserSecretId instead", paramName, toolName)
+		}
+	}
 	return nil
 }
@@ -0,0 +1,27 @@
+package agent
+
+import "testing"
+
+func TestRejectLiteralSecrets(t *testing.T) {
+	tests := []struct {
+		name      string
+		tool      string
+		arguments map[string]interface{}
+		wantErr   bool
+	}{
+		{"literal master password", "create-db-instance", map[string]interface{}{"masterUserPassword": "hunter22"}, true},
+		{"literal password on modify", "modify-db-instance", map[string]interface{}{"masterUserPassword": "hunter22"}, true},
+		{"secret reference", "create-db-instance", map[string]interface{}{"passwordMode": "secret", "masterUserSecretId": "db/orders"}, false},
+		{"empty password", "create-db-instance", map[string]interface{}{"masterUserPassword": ""}, false},
+		{"password of a federated tool", "tickets.create-user", map[string]interface{}{"password": "hunter22"}, false},
+		{"password of another AWS tool", "create-ec2-instance", map[string]interface{}{"password": "hunter22"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := rejectLiteralSecrets(tt.tool, tt.arguments); (err != nil) != tt.wantErr {
+				t.Fatalf("rejectLiteralSecrets() error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
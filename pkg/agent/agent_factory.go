@@ -15,6 +15,10 @@ import (
 	"github.com/versus-control/ai-infrastructure-agent/pkg/agent/resources"
 	"github.com/versus-control/ai-infrastructure-agent/pkg/agent/retrieval"
 	"github.com/versus-control/ai-infrastructure-agent/pkg/aws"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/cost"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/policy"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/redact"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/tagging"
 )
 
 // ========== Interface defines ==========
@@ -41,6 +45,9 @@ import (
 
 // NewStateAwareAgent creates a new state-aware AI agent
 func NewStateAwareAgent(agentConfig *config.AgentConfig, awsClient *aws.Client, stateFilePath, region string, logger *logging.Logger, awsConfig *config.AWSConfig) (*StateAwareAgent, error) {
+	// Mask secrets in everything the agent logs
+	redact.Install(logger)
+
 	// Initialize LLM based on provider
 	llm, err := initializeLLM(agentConfig, logger)
 	if err != nil {
@@ -88,6 +95,35 @@ func NewStateAwareAgent(agentConfig *config.AgentConfig, awsClient *aws.Client,
 	fieldResolver := resources.NewFieldResolver(fieldMappingConfig)
 	fieldResolver.SetPatternMatcher(patternMatcher)
 
+	// Load guardrail policies
+	policyEngine, err := policy.LoadConfigured()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load guardrail policies: %w", err)
+	}
+	logger.WithField("policies", len(policyEngine.Policies())).Info("Loaded guardrail policies")
+
+	// Load the tag policy so plans whose tags it rejects are denied before they execute
+	tagPolicy, err := tagging.LoadConfigured()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tag policy: %w", err)
+	}
+
+	// Load the pricing catalog used to estimate plan costs
+	var costEstimator *cost.Estimator
+	catalog, err := cost.LoadConfiguredCatalog()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load pricing catalog: %w", err)
+	}
+	if catalog != nil {
+		costEstimator = cost.NewEstimator(catalog)
+		logger.WithFields(map[string]interface{}{
+			"version": catalog.Version,
+			"regions": len(catalog.Regions),
+		}).Info("Loaded pricing catalog")
+	} else {
+		logger.Warn("No pricing catalog found, plans will not include cost estimates")
+	}
+
 	// Get global retrieval registry
 	registry := retrieval.GetGlobalRegistry()
 
@@ -104,6 +140,7 @@ func NewStateAwareAgent(agentConfig *config.AgentConfig, awsClient *aws.Client,
 		resourceMappings: make(map[string]string),
 		mcpTools:         make(map[string]MCPToolInfo),
 		mcpResources:     make(map[string]MCPResourceInfo),
+		progressHandlers: make(map[string]MCPProgressFunc),
 
 		// Lock properties
 		capabilityMutex: sync.RWMutex{},
@@ -118,7 +155,10 @@ func NewStateAwareAgent(agentConfig *config.AgentConfig, awsClient *aws.Client,
 		extractionConfig: extractionConfig,
 		idExtractor:      idExtractor,
 
-		registry: registry,
+		registry:      registry,
+		policyEngine:  policyEngine,
+		tagPolicy:     tagPolicy,
+		costEstimator: costEstimator,
 	}
 
 	return agent, nil
@@ -150,6 +190,7 @@ func (a *StateAwareAgent) Initialize(ctx context.Context) error {
 // Cleanup ensures proper cleanup of resources
 func (a *StateAwareAgent) Cleanup() {
 	a.stopMCPProcess()
+	a.stopFederatedServers()
 }
 
 // initializeLLM initializes the appropriate LLM based on the provider configuration
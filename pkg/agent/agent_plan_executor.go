@@ -3,11 +3,13 @@ package agent
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/auth"
 	"github.com/versus-control/ai-infrastructure-agent/pkg/types"
 )
 
@@ -18,7 +20,7 @@ import (
 // Available Functions:
 //   - ExecuteConfirmedPlanWithDryRun() : Execute confirmed plans with dry-run support
 //   - simulatePlanExecution()          : Simulate plan execution for dry-run mode
-//   - executeExecutionStep()           : Execute individual plan steps
+//   - executeExecutionStep()           : Execute individual plan steps, enforcing guardrail policies
 //   - executeCreateAction()            : Execute create actions via MCP tools
 //   - executeAPIValueRetrieval()       : Execute AWS API retrieval operations
 //   - executeNativeMCPTool()          : Execute native MCP tool calls
@@ -26,14 +28,18 @@ import (
 //   - executeDeleteAction()            : Execute delete actions on resources
 //   - executeValidateAction()          : Execute validation actions
 //   - updateStateFromMCPResult()       : Update state from MCP operation results
+//   - recordFailedCreation()           : Record the resource a failed tool call created anyway
 //   - extractResourceTypeFromStep()    : Extract resource type from execution step
 //   - getAvailableToolsContext()       : Get available tools context for AI prompts
 //   - persistCurrentState()            : Persist current state to storage
+//   - recordExecution()                : Record a finished execution in the state's history
 //   - extractResourceIDFromResponse()  : Extract AWS resource IDs from responses
 //   - waitForResourceReady()           : Wait for AWS resources to be ready before continuing
 //   - checkResourceState()             : Check if a specific AWS resource is ready
 //   - checkNATGatewayState()           : Check if NAT gateway is available
 //   - checkRDSInstanceState()          : Check if RDS instance is available
+//   - withRegion()                     : Add a target region to MCP tool arguments
+//   - stampDecisionID()                : Pass the decision ID to creation tools for the DecisionID tag
 //   - storeResourceMapping()           : Store step-to-resource ID mappings
 //
 // This file manages the execution of infrastructure plans, including dry-run
@@ -50,6 +56,11 @@ func (a *StateAwareAgent) ExecuteConfirmedPlanWithRecovery(ctx context.Context,
 		return a.ExecuteConfirmedPlanWithDryRun(ctx, decision, progressChan, dryRun)
 	}
 
+	if err := a.enforcePlanPolicies(decision); err != nil {
+		return nil, err
+	}
+	a.stampDecisionID(decision)
+
 	a.Logger.WithFields(map[string]interface{}{
 		"decision_id": decision.ID,
 		"action":      decision.Action,
@@ -65,6 +76,7 @@ func (a *StateAwareAgent) ExecuteConfirmedPlanWithRecovery(ctx context.Context,
 		Steps:     []*types.ExecutionStep{},
 		Changes:   []*types.ChangeDetection{},
 		Errors:    []string{},
+		Principal: auth.PrincipalFromContext(ctx),
 	}
 
 	// Send initial progress update
@@ -145,6 +157,7 @@ func (a *StateAwareAgent) ExecuteConfirmedPlanWithRecovery(ctx context.Context,
 
 	now := time.Now()
 	execution.CompletedAt = &now
+	a.recordExecution(execution)
 
 	// Send final progress update
 	if progressChan != nil {
@@ -186,10 +199,16 @@ func (a *StateAwareAgent) ExecuteConfirmedPlanWithDryRun(ctx context.Context, de
 		a.Logger.Info("Dry run mode - simulating execution")
 		a.Logger.Debug("About to call simulatePlanExecution")
 		result := a.simulatePlanExecution(decision, progressChan)
+		result.Principal = auth.PrincipalFromContext(ctx)
 		a.Logger.WithField("simulation_result", result.Status).Debug("Simulation completed")
 		return result, nil
 	}
 
+	if err := a.enforcePlanPolicies(decision); err != nil {
+		return nil, err
+	}
+	a.stampDecisionID(decision)
+
 	// Create execution plan
 	execution := &types.PlanExecution{
 		ID:        uuid.New().String(),
@@ -199,6 +218,7 @@ func (a *StateAwareAgent) ExecuteConfirmedPlanWithDryRun(ctx context.Context, de
 		Steps:     []*types.ExecutionStep{},
 		Changes:   []*types.ChangeDetection{},
 		Errors:    []string{},
+		Principal: auth.PrincipalFromContext(ctx),
 	}
 
 	// Send initial progress update
@@ -300,6 +320,7 @@ func (a *StateAwareAgent) ExecuteConfirmedPlanWithDryRun(ctx context.Context, de
 	} else {
 		decision.Result = "success"
 	}
+	a.recordExecution(execution)
 
 	// Send final progress update
 	if progressChan != nil {
@@ -475,13 +496,23 @@ func (a *StateAwareAgent) executeExecutionStep(ctx context.Context, planStep *ty
 		}
 	}
 
+	// Re-check guardrail policies right before anything reaches AWS
+	if err := a.enforceStepPolicies(planStep, progressChan, execution.ID); err != nil {
+		endTime := time.Now()
+		step.Status = "failed"
+		step.Error = err.Error()
+		step.CompletedAt = &endTime
+		step.Duration = endTime.Sub(startTime)
+		return step, err
+	}
+
 	// Execute based on action type
 	var result map[string]interface{}
 	var err error
 
 	switch planStep.Action {
 	case "create":
-		result, err = a.executeCreateAction(planStep, progressChan, execution.ID)
+		result, err = a.executeCreateAction(ctx, planStep, progressChan, execution.ID)
 	// case "update":
 	// 	result, err = a.executeUpdateAction(ctx, planStep, progressChan, execution.ID)
 	// case "delete":
@@ -511,7 +542,7 @@ func (a *StateAwareAgent) executeExecutionStep(ctx context.Context, planStep *ty
 }
 
 // executeCreateAction handles resource creation using native MCP tool calls
-func (a *StateAwareAgent) executeCreateAction(planStep *types.ExecutionPlanStep, progressChan chan<- *types.ExecutionUpdate, executionID string) (map[string]interface{}, error) {
+func (a *StateAwareAgent) executeCreateAction(ctx context.Context, planStep *types.ExecutionPlanStep, progressChan chan<- *types.ExecutionUpdate, executionID string) (map[string]interface{}, error) {
 	// Send progress update
 	if progressChan != nil {
 		progressChan <- &types.ExecutionUpdate{
@@ -524,7 +555,7 @@ func (a *StateAwareAgent) executeCreateAction(planStep *types.ExecutionPlanStep,
 	}
 
 	// Use native MCP tool call approach
-	return a.executeNativeMCPTool(planStep, progressChan, executionID)
+	return a.executeNativeMCPTool(ctx, planStep, progressChan, executionID)
 }
 
 // executeAPIValueRetrieval handles API calls to retrieve real values instead of AI-generated placeholders
@@ -590,7 +621,7 @@ func (a *StateAwareAgent) executeAPIValueRetrieval(ctx context.Context, planStep
 }
 
 // executeNativeMCPTool executes MCP tools directly with AI-provided parameters
-func (a *StateAwareAgent) executeNativeMCPTool(planStep *types.ExecutionPlanStep, _ chan<- *types.ExecutionUpdate, _ string) (map[string]interface{}, error) {
+func (a *StateAwareAgent) executeNativeMCPTool(ctx context.Context, planStep *types.ExecutionPlanStep, progressChan chan<- *types.ExecutionUpdate, executionID string) (map[string]interface{}, error) {
 	toolName := planStep.MCPTool
 
 	if a.config.EnableDebug {
@@ -676,14 +707,61 @@ func (a *StateAwareAgent) executeNativeMCPTool(planStep *types.ExecutionPlanStep
 		return nil, fmt.Errorf("failed to add required parameters for tool %s: %w", toolName, err)
 	}
 
+	// Route the call to the step's region; an explicit region parameter from the AI takes precedence
+	if planStep.Region != "" {
+		if _, exists := arguments["region"]; !exists {
+			arguments["region"] = planStep.Region
+		}
+	}
+
 	// Validate arguments before MCP call
 	if err := a.validateNativeMCPArguments(toolName, arguments, toolInfo); err != nil {
 		return nil, fmt.Errorf("invalid arguments for MCP tool %s: %w", toolName, err)
 	}
 
-	// Call the actual MCP tool
-	result, err := a.callMCPTool(toolName, arguments)
+	// Tools that can wait for their resource report progress while they do, so let them wait
+	// instead of polling from here
+	toolWaits := false
+	if properties, ok := toolInfo.InputSchema["properties"].(map[string]interface{}); ok {
+		if _, declaresWait := properties["wait"]; declaresWait {
+			if _, exists := arguments["wait"]; !exists {
+				arguments["wait"] = true
+			}
+			toolWaits = arguments["wait"] == true
+		}
+	}
+
+	// Call the actual MCP tool, forwarding its progress as step updates
+	var onProgress MCPProgressFunc
+	if progressChan != nil {
+		onProgress = func(progress, total float64, message string) {
+			update := &types.ExecutionUpdate{
+				Type:        "step_progress",
+				ExecutionID: executionID,
+				StepID:      planStep.ID,
+				Message:     message,
+				Timestamp:   time.Now(),
+			}
+			if total > 0 {
+				update.Progress = progress / total
+			}
+
+			// Progress is informational; the notification reader must never wait for a slow
+			// consumer, so an update that does not fit is dropped
+			select {
+			case progressChan <- update:
+			default:
+				a.Logger.WithField("step_id", planStep.ID).Debug("Dropping step progress update, the progress channel is full")
+			}
+		}
+	}
+	result, err := a.callMCPToolWithProgress(ctx, toolName, arguments, onProgress)
 	if err != nil {
+		// A tool whose wait failed still created its resource, which must not be orphaned
+		var toolErr *MCPToolError
+		if errors.As(err, &toolErr) {
+			a.recordFailedCreation(planStep, toolName, toolErr.Result)
+		}
 		return nil, fmt.Errorf("MCP tool call failed: %w", err)
 	}
 
@@ -699,13 +777,27 @@ func (a *StateAwareAgent) executeNativeMCPTool(planStep *types.ExecutionPlanStep
 	// Store the mapping of plan step ID to actual resource ID
 	a.storeResourceMapping(planStep.ID, resourceID)
 
-	// Wait for resource to be ready if it has dependencies
-	if err := a.waitForResourceReady(toolName, resourceID); err != nil {
+	// Wait for resource to be ready if it has dependencies. Deferred RDS modifications are only
+	// applied in the next maintenance window, so there is nothing to wait for.
+	waitToolName := toolName
+	if toolName == "modify-db-instance" && arguments["applyImmediately"] != true {
+		waitToolName = ""
+	}
+	if toolWaits {
+		// The tool only returned once the resource was available
+		waitToolName = ""
+	}
+	if err := a.waitForResourceReady(waitToolName, resourceID, planStep.Region); err != nil {
 		a.Logger.WithError(err).WithFields(map[string]interface{}{
 			"step_id":     planStep.ID,
 			"tool_name":   toolName,
 			"resource_id": resourceID,
 		}).Error("Failed to wait for resource to be ready")
+
+		// The resource exists, so record it before failing the step
+		if stateErr := a.updateStateFromMCPResult(planStep, result); stateErr != nil {
+			a.Logger.WithError(stateErr).WithField("resource_id", resourceID).Error("Failed to record resource that did not become ready")
+		}
 		return nil, fmt.Errorf("resource %s not ready: %w", resourceID, err)
 	}
 
@@ -807,6 +899,30 @@ func (a *StateAwareAgent) executeNativeMCPTool(planStep *types.ExecutionPlanStep
 // 	}, nil
 // }
 
+// recordFailedCreation records the resource named by the response of a tool that failed after
+// creating it, such as a creation tool whose wait for the resource timed out, so the resource is
+// tracked in state instead of orphaned
+func (a *StateAwareAgent) recordFailedCreation(planStep *types.ExecutionPlanStep, toolName string, result map[string]interface{}) {
+	resourceID, _ := result["resourceId"].(string)
+	if resourceID == "" {
+		return
+	}
+
+	planStep.ResourceID = resourceID
+	a.storeResourceMapping(planStep.ID, resourceID)
+
+	logger := a.Logger.WithFields(map[string]interface{}{
+		"step_id":     planStep.ID,
+		"tool_name":   toolName,
+		"resource_id": resourceID,
+	})
+	if err := a.updateStateFromMCPResult(planStep, result); err != nil {
+		logger.WithError(err).Error("CRITICAL: Failed to record resource created by a failed tool call - this may cause state inconsistency")
+		return
+	}
+	logger.Warn("Recorded resource created by a tool call that then failed")
+}
+
 // updateStateFromMCPResult updates the state manager with results from MCP operations
 func (a *StateAwareAgent) updateStateFromMCPResult(planStep *types.ExecutionPlanStep, result map[string]interface{}) error {
 	a.Logger.WithFields(map[string]interface{}{
@@ -831,6 +947,7 @@ func (a *StateAwareAgent) updateStateFromMCPResult(planStep *types.ExecutionPlan
 		Name:         planStep.Name,
 		Description:  planStep.Description,
 		Type:         resourceType,
+		Region:       planStep.Region,
 		Status:       "created",
 		Properties:   resultData,
 		Dependencies: planStep.DependsOn,
@@ -870,6 +987,7 @@ func (a *StateAwareAgent) updateStateFromMCPResult(planStep *types.ExecutionPlan
 			Name:         planStep.Name,
 			Description:  planStep.Description + " (Step Reference)",
 			Type:         "step_reference",
+			Region:       planStep.Region,
 			Status:       "created",
 			Properties:   resultData,
 			Dependencies: planStep.DependsOn,
@@ -1013,6 +1131,11 @@ func (a *StateAwareAgent) generateMCPToolsSchema() string {
 			category = "Other"
 		}
 
+		// Tools of an external MCP server are grouped under the server
+		if toolInfo.Server != "" {
+			category = fmt.Sprintf("External MCP Server: %s", toolInfo.Server)
+		}
+
 		// Build detailed tool schema
 		var toolDetail strings.Builder
 		toolDetail.WriteString(fmt.Sprintf("  TOOL: %s\n", toolName))
@@ -1091,6 +1214,24 @@ func (a *StateAwareAgent) persistCurrentState() error {
 	return nil
 }
 
+// recordExecution adds a finished execution to the execution history of the state, so MCP
+// clients can read it back. An in-process MCP server's state manager records it directly; a
+// server subprocess owns its state, so the internal record-execution tool is called there.
+// Failing to record it does not fail the execution.
+func (a *StateAwareAgent) recordExecution(execution *types.PlanExecution) {
+	var err error
+	if a.inProcessServer != nil {
+		err = a.inProcessServer.StateManager.RecordExecution(context.Background(), execution)
+	} else {
+		_, err = a.callMCPTool("record-execution", map[string]interface{}{
+			"execution": execution,
+		})
+	}
+	if err != nil {
+		a.Logger.WithError(err).WithField("execution_id", execution.ID).Warn("Failed to record execution in state")
+	}
+}
+
 // extractResourceIDFromResponse extracts the actual AWS resource ID from MCP response
 func (a *StateAwareAgent) extractResourceIDFromResponse(result map[string]interface{}, toolName string) (string, error) {
 	// Use configuration-driven extraction
@@ -1125,7 +1266,7 @@ func (a *StateAwareAgent) extractResourceIDFromResponse(result map[string]interf
 }
 
 // waitForResourceReady waits for AWS resources to be in a ready state before continuing
-func (a *StateAwareAgent) waitForResourceReady(toolName, resourceID string) error {
+func (a *StateAwareAgent) waitForResourceReady(toolName, resourceID, region string) error {
 	if a.testMode {
 		return nil
 	}
@@ -1139,9 +1280,13 @@ func (a *StateAwareAgent) waitForResourceReady(toolName, resourceID string) erro
 	case "create-nat-gateway":
 		needsWaiting = true
 		maxWaitTime = 5 * time.Minute // NAT gateways typically take 2-3 minutes
-	case "create-rds-db-instance", "create-database":
+	case "create-rds-db-instance", "create-database", "create-db-instance",
+		"create-db-read-replica", "restore-db-instance-from-snapshot":
 		needsWaiting = true
 		maxWaitTime = 15 * time.Minute // RDS instances can take longer
+	case "modify-db-instance":
+		needsWaiting = true
+		maxWaitTime = 30 * time.Minute // Class changes and Multi-AZ conversions can take a while
 	case "create-internet-gateway", "create-vpc", "create-subnet":
 		// These are typically available immediately
 		needsWaiting = false
@@ -1177,7 +1322,10 @@ func (a *StateAwareAgent) waitForResourceReady(toolName, resourceID string) erro
 			return fmt.Errorf("timeout waiting for %s %s to be ready after %v", toolName, resourceID, elapsed)
 
 		case <-ticker.C:
-			ready, err := a.checkResourceState(toolName, resourceID)
+			ready, err := a.checkResourceState(toolName, resourceID, region)
+			if errors.Is(err, errResourceFailed) {
+				return fmt.Errorf("%s %s will not become ready: %w", toolName, resourceID, err)
+			}
 			if err != nil {
 				a.Logger.WithError(err).WithFields(map[string]interface{}{
 					"tool_name":   toolName,
@@ -1207,12 +1355,13 @@ func (a *StateAwareAgent) waitForResourceReady(toolName, resourceID string) erro
 }
 
 // checkResourceState checks if a specific AWS resource is in a ready state
-func (a *StateAwareAgent) checkResourceState(toolName, resourceID string) (bool, error) {
+func (a *StateAwareAgent) checkResourceState(toolName, resourceID, region string) (bool, error) {
 	switch toolName {
 	case "create-nat-gateway":
-		return a.checkNATGatewayState(resourceID)
-	case "create-rds-db-instance", "create-database":
-		return a.checkRDSInstanceState(resourceID)
+		return a.checkNATGatewayState(resourceID, region)
+	case "create-rds-db-instance", "create-database", "create-db-instance",
+		"create-db-read-replica", "restore-db-instance-from-snapshot", "modify-db-instance":
+		return a.checkRDSInstanceState(resourceID, region)
 	default:
 		// For unknown resource types, assume they're ready
 		return true, nil
@@ -1220,11 +1369,11 @@ func (a *StateAwareAgent) checkResourceState(toolName, resourceID string) (bool,
 }
 
 // checkNATGatewayState checks if a NAT gateway is available
-func (a *StateAwareAgent) checkNATGatewayState(natGatewayID string) (bool, error) {
+func (a *StateAwareAgent) checkNATGatewayState(natGatewayID, region string) (bool, error) {
 	// Try to use MCP tool to describe the NAT gateway if available
-	result, err := a.callMCPTool("describe-nat-gateways", map[string]interface{}{
+	result, err := a.callMCPTool("describe-nat-gateways", withRegion(map[string]interface{}{
 		"natGatewayIds": []string{natGatewayID},
-	})
+	}, region))
 	if err != nil {
 		// If describe tool is not available, use a simple time-based approach
 		a.Logger.WithFields(map[string]interface{}{
@@ -1255,12 +1404,16 @@ func (a *StateAwareAgent) checkNATGatewayState(natGatewayID string) (bool, error
 	return false, fmt.Errorf("could not determine NAT gateway state from response")
 }
 
-// checkRDSInstanceState checks if an RDS instance is available
-func (a *StateAwareAgent) checkRDSInstanceState(dbInstanceID string) (bool, error) {
+// errResourceFailed marks resource states that will never become ready, so waiting stops early
+var errResourceFailed = errors.New("resource entered a failed state")
+
+// checkRDSInstanceState checks if an RDS instance is available. Instances stay not-ready while
+// creating, modifying, backing up or rebooting, and while applied modifications are still pending.
+func (a *StateAwareAgent) checkRDSInstanceState(dbInstanceID, region string) (bool, error) {
 	// Try to use MCP tool to describe the RDS instance if available
-	result, err := a.callMCPTool("describe-db-instances", map[string]interface{}{
+	result, err := a.callMCPTool("describe-db-instances", withRegion(map[string]interface{}{
 		"dbInstanceIdentifier": dbInstanceID,
-	})
+	}, region))
 	if err != nil {
 		// If describe tool is not available, use a simple time-based approach
 		a.Logger.WithFields(map[string]interface{}{
@@ -1278,12 +1431,14 @@ func (a *StateAwareAgent) checkRDSInstanceState(dbInstanceID string) (bool, erro
 	if dbInstances, ok := result["dbInstances"].([]interface{}); ok && len(dbInstances) > 0 {
 		if dbInstance, ok := dbInstances[0].(map[string]interface{}); ok {
 			if status, ok := dbInstance["dbInstanceStatus"].(string); ok {
+				pending, _ := dbInstance["pendingModifications"].(map[string]interface{})
 				a.Logger.WithFields(map[string]interface{}{
-					"db_instance_id": dbInstanceID,
-					"status":         status,
+					"db_instance_id":        dbInstanceID,
+					"status":                status,
+					"pending_modifications": len(pending),
 				}).Debug("RDS instance state check")
 
-				return status == "available", nil
+				return rdsInstanceReady(status, len(pending) > 0)
 			}
 		}
 	}
@@ -1291,6 +1446,46 @@ func (a *StateAwareAgent) checkRDSInstanceState(dbInstanceID string) (bool, erro
 	return false, fmt.Errorf("could not determine RDS instance state from response")
 }
 
+// rdsInstanceReady maps an RDS instance status to readiness. Transitional states such as
+// modifying, backing-up and configuring-* are not ready; failure states return errResourceFailed.
+func rdsInstanceReady(status string, hasPendingModifications bool) (bool, error) {
+	switch status {
+	case "available", "storage-optimization":
+		// storage-optimization runs in the background with the instance fully usable
+		return !hasPendingModifications, nil
+	case "failed", "storage-full", "inaccessible-encryption-credentials",
+		"incompatible-network", "incompatible-option-group", "incompatible-parameters",
+		"incompatible-restore", "restore-error":
+		return false, fmt.Errorf("RDS instance status %s: %w", status, errResourceFailed)
+	default:
+		// creating, modifying, backing-up, rebooting, upgrading, renaming,
+		// resetting-master-credentials, configuring-* and similar transitional states
+		return false, nil
+	}
+}
+
+// withRegion adds the region argument to MCP tool arguments when a non-default region is targeted
+func withRegion(arguments map[string]interface{}, region string) map[string]interface{} {
+	if region != "" {
+		arguments["region"] = region
+	}
+	return arguments
+}
+
+// stampDecisionID passes the decision ID to every creation step so the MCP server can write it
+// to the DecisionID tag of the resources the step creates
+func (a *StateAwareAgent) stampDecisionID(decision *types.AgentDecision) {
+	for _, planStep := range decision.ExecutionPlan {
+		if planStep.MCPTool == "" || a.idExtractor.ClassifyTool(planStep.MCPTool) != "creation" {
+			continue
+		}
+		if planStep.ToolParameters == nil {
+			planStep.ToolParameters = make(map[string]interface{})
+		}
+		planStep.ToolParameters["decisionId"] = decision.ID
+	}
+}
+
 // storeResourceMapping stores the mapping between plan step ID and actual AWS resource ID
 func (a *StateAwareAgent) storeResourceMapping(stepID, resourceID string) {
 	a.mappingsMutex.Lock()
@@ -0,0 +1,51 @@
+package agent
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRDSInstanceReady(t *testing.T) {
+	tests := []struct {
+		status              string
+		pendingModification bool
+		wantReady           bool
+		wantFailed          bool
+	}{
+		{status: "available", wantReady: true},
+		{status: "available", pendingModification: true},
+		{status: "storage-optimization", wantReady: true},
+		{status: "storage-optimization", pendingModification: true},
+		{status: "creating"},
+		{status: "modifying"},
+		{status: "backing-up"},
+		{status: "configuring-enhanced-monitoring"},
+		{status: "failed", wantFailed: true},
+		{status: "storage-full", wantFailed: true},
+		{status: "inaccessible-encryption-credentials", wantFailed: true},
+		{status: "incompatible-parameters", wantFailed: true},
+		{status: "restore-error", wantFailed: true},
+	}
+
+	for _, tt := range tests {
+		name := tt.status
+		if tt.pendingModification {
+			name += " with pending modifications"
+		}
+		t.Run(name, func(t *testing.T) {
+			ready, err := rdsInstanceReady(tt.status, tt.pendingModification)
+			if tt.wantFailed {
+				if !errors.Is(err, errResourceFailed) {
+					t.Fatalf("rdsInstanceReady() error = %v, want errResourceFailed", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("rdsInstanceReady() error = %v", err)
+			}
+			if ready != tt.wantReady {
+				t.Fatalf("rdsInstanceReady() = %v, want %v", ready, tt.wantReady)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package agent
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/versus-control/ai-infrastructure-agent/pkg/policy"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/tagging"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/types"
+)
+
+// ========== Interface defines ==========
+
+// PolicyEnforcerInterface defines guardrail policy evaluation functionality
+//
+// Available Functions:
+//   - EvaluatePolicies()       : Evaluate guardrail policies against a decision and record violations
+//   - enforcePlanPolicies()    : Refuse to start executing a plan that policies deny
+//   - enforceStepPolicies()    : Re-evaluate policies for a step right before it executes
+//   - tagPolicyViolations()    : Check the tags each creation step will write against the tag policy
+//
+// Policies are loaded from settings/policies.yaml (or POLICY_FILE). A decision is evaluated
+// when the plan is generated so deny and warn outcomes are part of the plan response, and
+// every step is evaluated again before execution so a denied step never reaches AWS.
+// Creation steps whose merged tags break the tag policy (settings/tag-policy.yaml) are
+// reported as deny violations, so a plan that would fail halfway on tagging never starts.
+//
+// Usage Example:
+//   1. violations := agent.EvaluatePolicies(decision)
+//   2. if policy.Denied(violations) { // refuse to execute the plan }
+
+// ========== Policy Enforcement Functions ==========
+
+// tagPolicyID identifies tag policy violations among the guardrail policy violations
+const tagPolicyID = "tag-policy"
+
+// EvaluatePolicies evaluates the guardrail policies against a decision and its steps and stores
+// the violations on the decision
+func (a *StateAwareAgent) EvaluatePolicies(decision *types.AgentDecision) []*types.PolicyViolation {
+	var violations []*types.PolicyViolation
+	if a.policyEngine != nil {
+		violations = a.policyEngine.EvaluateDecision(decision, a.awsConfig.Region)
+	}
+	violations = append(violations, a.tagPolicyViolations(decision)...)
+	decision.PolicyViolations = violations
+
+	for _, violation := range violations {
+		a.Logger.WithFields(map[string]interface{}{
+			"decision_id": decision.ID,
+			"policy_id":   violation.PolicyID,
+			"effect":      violation.Effect,
+			"step_id":     violation.StepID,
+			"tool":        violation.Tool,
+		}).Warn("Plan matched guardrail policy: " + violation.Message)
+	}
+
+	return violations
+}
+
+// enforcePlanPolicies re-evaluates a decision before execution starts so a plan denied at
+// generation time cannot be executed anyway
+func (a *StateAwareAgent) enforcePlanPolicies(decision *types.AgentDecision) error {
+	violations := a.EvaluatePolicies(decision)
+	if policy.Denied(violations) {
+		return fmt.Errorf("plan denied by guardrail policy: %s", policy.Summary(violations))
+	}
+	return nil
+}
+
+// tagPolicyViolations merges the tag policy into the tags of every creation step, the same way
+// the MCP server does when the step runs, and returns a deny violation for each step whose
+// tags the policy rejects
+func (a *StateAwareAgent) tagPolicyViolations(decision *types.AgentDecision) []*types.PolicyViolation {
+	if a.tagPolicy == nil {
+		return nil
+	}
+
+	var violations []*types.PolicyViolation
+	for _, planStep := range decision.ExecutionPlan {
+		if planStep.MCPTool == "" || a.idExtractor == nil || a.idExtractor.ClassifyTool(planStep.MCPTool) != "creation" {
+			continue
+		}
+
+		explicit := make(map[string]string)
+		if tagsParam, ok := planStep.ToolParameters["tags"].(map[string]interface{}); ok {
+			for key, value := range tagsParam {
+				explicit[key] = fmt.Sprintf("%v", value)
+			}
+		}
+
+		region := planStep.Region
+		if region == "" {
+			region = a.awsConfig.Region
+		}
+
+		tags := a.tagPolicy.Apply(explicit, tagging.Variables{
+			Workspace:  tagging.WorkspaceFromEnv(),
+			DecisionID: decision.ID,
+			Tool:       planStep.MCPTool,
+			Region:     region,
+		})
+		if err := a.tagPolicy.Validate(tags); err != nil {
+			violations = append(violations, &types.PolicyViolation{
+				PolicyID: tagPolicyID,
+				Effect:   policy.EffectDeny,
+				Message:  err.Error(),
+				StepID:   planStep.ID,
+				Tool:     planStep.MCPTool,
+			})
+		}
+	}
+	return violations
+}
+
+// enforceStepPolicies evaluates the policies for a step about to execute. Warnings are reported
+// through the progress channel; a deny returns an error so the step is not executed.
+func (a *StateAwareAgent) enforceStepPolicies(planStep *types.ExecutionPlanStep, progressChan chan<- *types.ExecutionUpdate, executionID string) error {
+	if a.policyEngine == nil {
+		return nil
+	}
+
+	violations := a.policyEngine.EvaluateStep(planStep, a.awsConfig.Region)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	if policy.Denied(violations) {
+		a.Logger.WithFields(map[string]interface{}{
+			"step_id": planStep.ID,
+			"tool":    planStep.MCPTool,
+		}).Error("Step denied by guardrail policy: " + policy.Summary(violations))
+		return fmt.Errorf("step %s denied by guardrail policy: %s", planStep.ID, policy.Summary(violations))
+	}
+
+	a.Logger.WithFields(map[string]interface{}{
+		"step_id": planStep.ID,
+		"tool":    planStep.MCPTool,
+	}).Warn("Step matched guardrail policy: " + policy.Summary(violations))
+
+	if progressChan != nil {
+		progressChan <- &types.ExecutionUpdate{
+			Type:        "step_progress",
+			ExecutionID: executionID,
+			StepID:      planStep.ID,
+			Message:     fmt.Sprintf("Policy warning: %s", policy.Summary(violations)),
+			Timestamp:   time.Now(),
+		}
+	}
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/tmc/langchaingo/llms"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/redact"
 	"github.com/versus-control/ai-infrastructure-agent/pkg/types"
 )
 
@@ -23,6 +24,7 @@ import (
 //   - generateDecisionWithPlan()      : Generate AI decision with detailed execution plan
 //   - validateDecision()              : Validate agent decisions for safety and consistency
 //   - buildDecisionWithPlanPrompt()   : Build comprehensive prompts for AI decision making
+//   - formatRegionSuffix()            : Format resource regions for prompt listings
 //   - parseAIResponseWithPlan()       : Parse AI responses into structured execution plans
 //
 // This file handles the core request processing pipeline from natural language
@@ -56,6 +58,12 @@ func (a *StateAwareAgent) ProcessRequest(ctx context.Context, request string) (*
 		return nil, fmt.Errorf("decision validation failed: %w", err)
 	}
 
+	// Estimate the monthly cost of the plan so budget policies can see it
+	a.EstimateCost(decision)
+
+	// Evaluate guardrail policies; denials are returned with the plan and block its execution
+	a.EvaluatePolicies(decision)
+
 	a.Logger.WithFields(map[string]interface{}{
 		"decision_id": decision.ID,
 		"action":      decision.Action,
@@ -266,6 +274,7 @@ func (a *StateAwareAgent) validateDecision(decision *types.AgentDecision, contex
 // buildDecisionWithPlanPrompt builds a prompt for AI decision-making with execution plan
 func (a *StateAwareAgent) buildDecisionWithPlanPrompt(request string, context *DecisionContext) (string, error) {
 	var prompt strings.Builder
+	redactor := redact.Default()
 
 	prompt.WriteString("You are an expert AWS infrastructure automation agent with comprehensive state management capabilities.\n\n")
 
@@ -281,13 +290,17 @@ func (a *StateAwareAgent) buildDecisionWithPlanPrompt(request string, context *D
 
 	// === INFRASTRUCTURE STATE OVERVIEW ===
 	prompt.WriteString("📊 INFRASTRUCTURE STATE OVERVIEW:\n")
-	prompt.WriteString("Analyze ALL available resources from the state file to make informed decisions.\n\n")
+	prompt.WriteString("Analyze ALL available resources from the state file to make informed decisions.\n")
+	if context.CurrentState != nil && context.CurrentState.Region != "" {
+		prompt.WriteString(fmt.Sprintf("Default region: %s (steps without a \"region\" field run here)\n", context.CurrentState.Region))
+	}
+	prompt.WriteString("\n")
 
 	// Show current managed resources from state file
 	if len(context.CurrentState.Resources) > 0 {
 		prompt.WriteString("🏗️ MANAGED RESOURCES (from state file):\n")
 		for resourceID, resource := range context.CurrentState.Resources {
-			prompt.WriteString(fmt.Sprintf("- %s (%s): %s", resourceID, resource.Type, resource.Status))
+			prompt.WriteString(fmt.Sprintf("- %s (%s%s): %s", resourceID, resource.Type, formatRegionSuffix(resource.Region), resource.Status))
 
 			// Extract and show key properties from state file
 			if resource.Properties != nil {
@@ -300,12 +313,12 @@ func (a *StateAwareAgent) buildDecisionWithPlanPrompt(request string, context *D
 						if mcpMap, ok := value.(map[string]interface{}); ok {
 							for mcpKey, mcpValue := range mcpMap {
 								if mcpKey != "success" && mcpKey != "timestamp" && mcpKey != "message" {
-									properties = append(properties, fmt.Sprintf("%s:%v", mcpKey, mcpValue))
+									properties = append(properties, fmt.Sprintf("%s:%v", mcpKey, redactor.Field(mcpKey, mcpValue)))
 								}
 							}
 						}
 					} else if key != "status" {
-						properties = append(properties, fmt.Sprintf("%s:%v", key, value))
+						properties = append(properties, fmt.Sprintf("%s:%v", key, redactor.Field(key, value)))
 					}
 				}
 
@@ -322,7 +335,7 @@ func (a *StateAwareAgent) buildDecisionWithPlanPrompt(request string, context *D
 	if len(context.DiscoveredState) > 0 {
 		prompt.WriteString("🔍 DISCOVERED AWS RESOURCES (not managed in state file):\n")
 		for _, resource := range context.DiscoveredState {
-			prompt.WriteString(fmt.Sprintf("- %s (%s): %s", resource.ID, resource.Type, resource.Status))
+			prompt.WriteString(fmt.Sprintf("- %s (%s%s): %s", resource.ID, resource.Type, formatRegionSuffix(resource.Region), resource.Status))
 
 			if resource.Properties != nil {
 				var properties []string
@@ -331,7 +344,7 @@ func (a *StateAwareAgent) buildDecisionWithPlanPrompt(request string, context *D
 				relevantKeys := []string{"vpcId", "groupName", "instanceType", "cidrBlock", "name", "state", "availabilityZone"}
 				for _, key := range relevantKeys {
 					if value, exists := resource.Properties[key]; exists {
-						properties = append(properties, fmt.Sprintf("%s:%v", key, value))
+						properties = append(properties, fmt.Sprintf("%s:%v", key, redactor.Field(key, value)))
 					}
 				}
 
@@ -371,7 +384,16 @@ func (a *StateAwareAgent) buildDecisionWithPlanPrompt(request string, context *D
 	}
 	prompt.WriteString(decisionTemplate)
 
-	return prompt.String(), nil
+	// Catch secrets embedded in the request text, conflict details or property values
+	return redactor.String(prompt.String()), nil
+}
+
+// formatRegionSuffix formats a resource region for prompt listings
+func formatRegionSuffix(region string) string {
+	if region == "" {
+		return ""
+	}
+	return ", " + region
 }
 
 // parseAIResponseWithPlan parses the AI response into an AgentDecision with execution plan
@@ -433,6 +455,7 @@ func (a *StateAwareAgent) parseAIResponseWithPlan(decisionID, request, response
 			Description       string                 `json:"description"`
 			Action            string                 `json:"action"`
 			ResourceID        string                 `json:"resourceId"`
+			Region            string                 `json:"region"`         // Optional target region
 			MCPTool           string                 `json:"mcpTool"`        // New: Direct MCP tool name
 			ToolParameters    map[string]interface{} `json:"toolParameters"` // New: Direct tool parameters
 			Parameters        map[string]interface{} `json:"parameters"`     // Legacy fallback
@@ -457,6 +480,7 @@ func (a *StateAwareAgent) parseAIResponseWithPlan(decisionID, request, response
 			Description:       step.Description,
 			Action:            step.Action,
 			ResourceID:        step.ResourceID,
+			Region:            step.Region,
 			MCPTool:           step.MCPTool,
 			ToolParameters:    step.ToolParameters,
 			Parameters:        step.Parameters,
@@ -1351,7 +1351,7 @@ func testExecuteFullPlanWithMocks(t *testing.T, agent *StateAwareAgent, mockSuit
 
 		switch planStep.Action {
 		case "create":
-			result, err = agent.executeCreateAction(planStep, nil, execution.ID)
+			result, err = agent.executeCreateAction(context.Background(), planStep, nil, execution.ID)
 		case "update":
 			result, err = testExecuteUpdateActionWithMocks(t, mockSuite, planStep)
 		case "delete":
@@ -1691,7 +1691,7 @@ func testErrorScenarios(t *testing.T, agent *StateAwareAgent, mockSuite *mocks.M
 		var err error
 
 		if firstStep.Action == "create" {
-			result, err = agent.executeCreateAction(firstStep, nil, execution.ID)
+			result, err = agent.executeCreateAction(context.Background(), firstStep, nil, execution.ID)
 		}
 
 		if err != nil {
@@ -11,6 +11,8 @@ import (
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/versus-control/ai-infrastructure-agent/internal/logging"
+	mcpserver "github.com/versus-control/ai-infrastructure-agent/pkg/mcp"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/redact"
 	"github.com/versus-control/ai-infrastructure-agent/pkg/tools"
 	"github.com/versus-control/ai-infrastructure-agent/pkg/types"
 	util "github.com/versus-control/ai-infrastructure-agent/pkg/utilities"
@@ -21,17 +23,20 @@ import (
 // MCPCommunicationInterface defines MCP (Model Context Protocol) communication functionality
 //
 // Available Functions:
-//   - startMCPProcess()                 : Start the MCP server subprocess for tool execution
+//   - startMCPProcess()                 : Start the MCP server subprocess, or connect to the in-process server
 //   - stopMCPProcess()                  : Stop the MCP server process
 //   - initializeMCP()                   : Initialize MCP connection and handshake
 //   - ensureMCPCapabilities()           : Ensure MCP capabilities are discovered and available
 //   - sendMCPRequest()                  : Send JSON-RPC request to MCP server
+//   - readMCPResponses()                : Route MCP server responses to their requests and handle notifications
 //   - sendMCPNotification()             : Send notification to MCP server
-//   - discoverMCPCapabilities()         : Discover available tools and resources from MCP server
+//   - discoverMCPCapabilities()         : Discover available tools and resources from MCP server and external MCP servers
 //   - logDiscoveredCapabilities()       : Log all discovered tools and resources for debugging
 //   - discoverMCPTools()                : Discover available tools from the MCP server
 //   - discoverMCPResources()            : Discover available resources from the MCP server
 //   - callMCPTool()                     : Call a tool via the MCP server
+//   - callMCPToolWithProgress()         : Call a tool via the MCP server, receiving its progress notifications
+//   - parseMCPToolResult()              : Extract the tool response from a CallToolResult
 //   - getStringFromMap()                : Helper function to safely extract string from map
 //
 //   - AnalyzeInfrastructureState()      : Call MCP server to analyze infrastructure state
@@ -55,6 +60,10 @@ func (a *StateAwareAgent) startMCPProcess() error {
 		return nil // Already started
 	}
 
+	if a.inProcessServer != nil {
+		return a.startInProcessMCP()
+	}
+
 	a.Logger.Info("Starting MCP server process for tool execution")
 
 	// Start the MCP server as a subprocess
@@ -68,9 +77,11 @@ func (a *StateAwareAgent) startMCPProcess() error {
 		cmd.Dir = "." // Current directory should be the project root
 	}
 
-	// Set environment variables from config
+	// Set environment variables from config. The agent talks to the subprocess over its pipes,
+	// whatever transport a shared MCP server is configured with.
 	envVars := append(os.Environ(),
 		fmt.Sprintf("AWS_REGION=%s", a.awsConfig.Region),
+		fmt.Sprintf("%s=%s", mcpserver.TransportEnvVar, mcpserver.TransportStdio),
 	)
 
 	cmd.Env = envVars
@@ -114,12 +125,15 @@ func (a *StateAwareAgent) startMCPProcess() error {
 	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
 
 	a.mcpProcess = &MCPProcess{
-		cmd:    cmd,
-		stdin:  bufio.NewWriter(stdin),
-		stdout: scanner,
-		mutex:  sync.Mutex{},
-		reqID:  0,
+		cmd:     cmd,
+		stdin:   bufio.NewWriter(stdin),
+		stdout:  scanner,
+		mutex:   sync.Mutex{},
+		reqID:   0,
+		pending: make(map[string]chan map[string]interface{}),
+		done:    make(chan struct{}),
 	}
+	go a.readMCPResponses(a.mcpProcess)
 
 	// Initialize MCP connection
 	if err := a.initializeMCP(); err != nil {
@@ -139,6 +153,12 @@ func (a *StateAwareAgent) stopMCPProcess() {
 
 	a.Logger.Info("Stopping MCP server process")
 
+	if a.mcpProcess.client != nil {
+		if err := a.mcpProcess.client.Close(); err != nil {
+			a.Logger.WithError(err).Warn("Failed to close in-process MCP client")
+		}
+	}
+
 	if a.mcpProcess.cmd != nil && a.mcpProcess.cmd.Process != nil {
 		a.mcpProcess.cmd.Process.Kill()
 		a.mcpProcess.cmd.Wait()
@@ -164,7 +184,7 @@ func (a *StateAwareAgent) initializeMCP() error {
 		},
 	}
 
-	_, err := a.sendMCPRequest(initRequest)
+	_, err := a.sendMCPRequest(context.Background(), initRequest)
 	if err != nil {
 		return fmt.Errorf("initialize request failed: %w", err)
 	}
@@ -264,10 +284,14 @@ func (a *StateAwareAgent) setupMockMCPCapabilities() error {
 
 // ========== MCP Communication Layer ==========
 
-// sendMCPRequest sends a request to the MCP server and waits for response
-func (a *StateAwareAgent) sendMCPRequest(request map[string]interface{}) (map[string]interface{}, error) {
-	a.mcpProcess.mutex.Lock()
-	defer a.mcpProcess.mutex.Unlock()
+// sendMCPRequest sends a request to the MCP server and waits for response. When ctx ends first
+// the server is told to cancel the request.
+func (a *StateAwareAgent) sendMCPRequest(ctx context.Context, request map[string]interface{}) (map[string]interface{}, error) {
+	if a.mcpProcess.client != nil {
+		return a.sendInProcessRequest(ctx, request)
+	}
+
+	process := a.mcpProcess
 
 	// Marshal and send request
 	reqBytes, err := json.Marshal(request)
@@ -275,25 +299,90 @@ func (a *StateAwareAgent) sendMCPRequest(request map[string]interface{}) (map[st
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	a.mcpProcess.stdin.Write(reqBytes)
-	a.mcpProcess.stdin.WriteString("\n")
-	a.mcpProcess.stdin.Flush()
+	// Register for the response before sending, so it cannot arrive unclaimed
+	id := fmt.Sprint(request["id"])
+	responses := make(chan map[string]interface{}, 1)
+	process.pendingMutex.Lock()
+	process.pending[id] = responses
+	process.pendingMutex.Unlock()
+	defer func() {
+		process.pendingMutex.Lock()
+		delete(process.pending, id)
+		process.pendingMutex.Unlock()
+	}()
 
-	// Read response
-	if !a.mcpProcess.stdout.Scan() {
-		return nil, fmt.Errorf("failed to read response")
+	// Only the write is serialized; other requests go out while this one runs
+	process.mutex.Lock()
+	process.stdin.Write(reqBytes)
+	process.stdin.WriteString("\n")
+	err = process.stdin.Flush()
+	process.mutex.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+
+	select {
+	case response := <-responses:
+		return response, nil
+	case <-process.done:
+		return nil, fmt.Errorf("failed to read response: %v", process.readErr)
+	case <-ctx.Done():
+		a.sendMCPNotification(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"method":  "notifications/cancelled",
+			"params": map[string]interface{}{
+				"requestId": request["id"],
+				"reason":    ctx.Err().Error(),
+			},
+		})
+		return nil, ctx.Err()
 	}
+}
+
+// readMCPResponses reads the MCP server's stdout until it ends, delivering each response to
+// the request waiting for its ID and handling the notifications sent while requests run
+func (a *StateAwareAgent) readMCPResponses(process *MCPProcess) {
+	for process.stdout.Scan() {
+		var message map[string]interface{}
+		if err := json.Unmarshal(process.stdout.Bytes(), &message); err != nil {
+			a.Logger.WithError(err).Warn("Ignoring MCP server output that is not a JSON-RPC message")
+			continue
+		}
+
+		if method, isMessage := message["method"].(string); isMessage {
+			params, _ := message["params"].(map[string]interface{})
+			a.handleMCPNotification(method, params)
+			continue
+		}
 
-	var response map[string]interface{}
-	if err := json.Unmarshal(a.mcpProcess.stdout.Bytes(), &response); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		id := fmt.Sprint(message["id"])
+		process.pendingMutex.Lock()
+		responses, exists := process.pending[id]
+		process.pendingMutex.Unlock()
+		if !exists {
+			a.Logger.WithField("request_id", id).Warn("Ignoring MCP server response to an unknown request")
+			continue
+		}
+		select {
+		case responses <- message:
+		default:
+			a.Logger.WithField("request_id", id).Warn("Ignoring duplicate MCP server response")
+		}
 	}
 
-	return response, nil
+	process.readErr = process.stdout.Err()
+	if process.readErr == nil {
+		process.readErr = fmt.Errorf("MCP server closed its output")
+	}
+	close(process.done)
 }
 
 // sendMCPNotification sends a notification to the MCP server (no response expected)
 func (a *StateAwareAgent) sendMCPNotification(notification map[string]interface{}) error {
+	if a.mcpProcess.client != nil {
+		return a.sendInProcessNotification(notification)
+	}
+
 	a.mcpProcess.mutex.Lock()
 	defer a.mcpProcess.mutex.Unlock()
 
@@ -320,7 +409,7 @@ func (a *StateAwareAgent) discoverMCPCapabilities() error {
 	}
 
 	// Check if MCP process is actually running
-	if a.mcpProcess == nil || a.mcpProcess.cmd == nil || a.mcpProcess.cmd.Process == nil {
+	if a.mcpProcess == nil || !a.mcpProcess.isRunning() {
 		return fmt.Errorf("MCP process is not running")
 	}
 
@@ -336,6 +425,9 @@ func (a *StateAwareAgent) discoverMCPCapabilities() error {
 		return fmt.Errorf("failed to discover MCP resources: %w", err)
 	}
 
+	// Merge the tools of external MCP servers, which never fails discovery
+	a.discoverFederatedTools()
+
 	a.Logger.WithFields(map[string]interface{}{
 		"tools_count":     len(a.mcpTools),
 		"resources_count": len(a.mcpResources),
@@ -389,7 +481,7 @@ func (a *StateAwareAgent) discoverMCPTools() error {
 		"params":  map[string]interface{}{},
 	}
 
-	response, err := a.sendMCPRequest(request)
+	response, err := a.sendMCPRequest(context.Background(), request)
 	if err != nil {
 		return fmt.Errorf("failed to list MCP tools: %w", err)
 	}
@@ -447,6 +539,9 @@ func (a *StateAwareAgent) discoverMCPTools() error {
 			// Register the tool's action type with the IDExtractor
 			actionType := factory.GetToolActionType(name)
 			a.idExtractor.RegisterToolActionType(name, actionType)
+
+			// Arguments the server flags sensitive are masked in logs, state and prompts
+			redact.Default().RegisterSchema(inputSchema)
 		}
 	}
 
@@ -467,7 +562,7 @@ func (a *StateAwareAgent) discoverMCPResources() error {
 		"params":  map[string]interface{}{},
 	}
 
-	response, err := a.sendMCPRequest(request)
+	response, err := a.sendMCPRequest(context.Background(), request)
 	if err != nil {
 		return fmt.Errorf("failed to list MCP resources: %w", err)
 	}
@@ -528,9 +623,14 @@ func (a *StateAwareAgent) discoverMCPResources() error {
 
 // callMCPTool calls a tool via the MCP server
 func (a *StateAwareAgent) callMCPTool(name string, arguments map[string]interface{}) (map[string]interface{}, error) {
+	return a.callMCPToolWithProgress(context.Background(), name, arguments, nil)
+}
+
+// callMCPToolWithProgress calls a tool via the MCP server, passing the progress notifications the
+// tool sends while it runs to onProgress. The call ends with ctx.
+func (a *StateAwareAgent) callMCPToolWithProgress(ctx context.Context, name string, arguments map[string]interface{}, onProgress MCPProgressFunc) (map[string]interface{}, error) {
 	// In test mode, use the mock MCP server
 	if a.testMode && a.mockMCPServer != nil {
-		ctx := context.Background()
 		result, err := a.mockMCPServer.CallTool(ctx, name, arguments)
 		if err != nil {
 			return nil, fmt.Errorf("mock MCP tool call failed: %w", err)
@@ -584,6 +684,14 @@ func (a *StateAwareAgent) callMCPTool(name string, arguments map[string]interfac
 		}, nil
 	}
 
+	// Tools of external MCP servers go to the server that provides them
+	a.capabilityMutex.RLock()
+	toolInfo, exists := a.mcpTools[name]
+	a.capabilityMutex.RUnlock()
+	if exists && toolInfo.Server != "" {
+		return a.callFederatedTool(ctx, toolInfo.Server, name, arguments, onProgress)
+	}
+
 	if a.mcpProcess == nil {
 		if err := a.startMCPProcess(); err != nil {
 			return nil, fmt.Errorf("failed to start MCP process: %w", err)
@@ -595,17 +703,26 @@ func (a *StateAwareAgent) callMCPTool(name string, arguments map[string]interfac
 	reqID := a.mcpProcess.reqID
 	a.mcpProcess.mutex.Unlock()
 
+	params := map[string]interface{}{
+		"name":      name,
+		"arguments": arguments,
+	}
+	if onProgress != nil {
+		token := a.registerProgressHandler(reqID, onProgress)
+		defer a.unregisterProgressHandler(token)
+		params["_meta"] = map[string]interface{}{
+			"progressToken": token,
+		}
+	}
+
 	request := map[string]interface{}{
 		"jsonrpc": "2.0",
 		"id":      reqID,
 		"method":  "tools/call",
-		"params": map[string]interface{}{
-			"name":      name,
-			"arguments": arguments,
-		},
+		"params":  params,
 	}
 
-	response, err := a.sendMCPRequest(request)
+	response, err := a.sendMCPRequest(ctx, request)
 	if err != nil {
 		return nil, fmt.Errorf("MCP tool call failed: %w", err)
 	}
@@ -624,6 +741,31 @@ func (a *StateAwareAgent) callMCPTool(name string, arguments map[string]interfac
 		return nil, fmt.Errorf("invalid result format from MCP server")
 	}
 
+	return parseMCPToolResult(resultMap)
+}
+
+// MCPToolError is a failure reported by a tool. Result holds the tool's response, which names
+// the resource created when the tool failed after creating it.
+type MCPToolError struct {
+	Message string
+	Result  map[string]interface{}
+}
+
+func (e *MCPToolError) Error() string {
+	return fmt.Sprintf("tool execution failed: %s", e.Message)
+}
+
+// parseMCPToolResult extracts the tool response from a CallToolResult, failing when the tool
+// reports an error
+func parseMCPToolResult(resultMap map[string]interface{}) (map[string]interface{}, error) {
+	// Tools with an output schema return their result as structured content, which needs no parsing
+	if toolResult, ok := resultMap["structuredContent"].(map[string]interface{}); ok {
+		if success, ok := toolResult["success"].(bool); ok && !success {
+			return nil, &MCPToolError{Message: fmt.Sprint(toolResult["error"]), Result: toolResult}
+		}
+		return toolResult, nil
+	}
+
 	// The MCP server returns a CallToolResult structure with Content array
 	// Extract the actual tool response from the content
 	if content, exists := resultMap["content"]; exists {
@@ -638,11 +780,11 @@ func (a *StateAwareAgent) callMCPTool(name string, arguments map[string]interfac
 							if errorMsg, hasError := toolResult["error"]; hasError {
 								if success, hasSuccess := toolResult["success"]; hasSuccess {
 									if successBool, ok := success.(bool); ok && !successBool {
-										return nil, fmt.Errorf("tool execution failed: %v", errorMsg)
+										return nil, &MCPToolError{Message: fmt.Sprint(errorMsg), Result: toolResult}
 									}
 								} else {
 									// If no success field but error exists, treat as error
-									return nil, fmt.Errorf("tool execution failed: %v", errorMsg)
+									return nil, &MCPToolError{Message: fmt.Sprint(errorMsg), Result: toolResult}
 								}
 							}
 							// Successfully parsed as JSON and no error
@@ -691,7 +833,7 @@ func (a *StateAwareAgent) AnalyzeInfrastructureState(ctx context.Context, scanLi
 		},
 	}
 
-	response, err := a.sendMCPRequest(request)
+	response, err := a.sendMCPRequest(ctx, request)
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("MCP tool call failed: %w", err)
 	}
@@ -919,7 +1061,7 @@ func (a *StateAwareAgent) ExportInfrastructureStateWithOptions(ctx context.Conte
 		},
 	}
 
-	response, err := a.sendMCPRequest(request)
+	response, err := a.sendMCPRequest(ctx, request)
 	if err != nil {
 		return "", fmt.Errorf("MCP tool call failed: %w", err)
 	}
@@ -960,7 +1102,7 @@ func (a *StateAwareAgent) AddResourceToState(resourceState *types.ResourceState)
 	a.Logger.WithField("resource_id", resourceState.ID).Info("Adding resource to state via MCP server")
 
 	// Call the MCP tool to add the resource to state
-	result, err := a.callMCPTool("add-resource-to-state", map[string]interface{}{
+	arguments := map[string]interface{}{
 		"resource_id":   resourceState.ID,
 		"resource_name": resourceState.Name,
 		"description":   resourceState.Description,
@@ -968,7 +1110,12 @@ func (a *StateAwareAgent) AddResourceToState(resourceState *types.ResourceState)
 		"status":        resourceState.Status,
 		"properties":    resourceState.Properties,
 		"dependencies":  resourceState.Dependencies,
-	})
+	}
+	if resourceState.Region != "" {
+		arguments["region"] = resourceState.Region
+	}
+
+	result, err := a.callMCPTool("add-resource-to-state", arguments)
 	if err != nil {
 		return fmt.Errorf("failed to add resource to state via MCP: %w", err)
 	}
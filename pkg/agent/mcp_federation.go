@@ -0,0 +1,484 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/redact"
+	"gopkg.in/yaml.v3"
+)
+
+// ========== Interface defines ==========
+
+// MCPFederationInterface defines how the agent uses external MCP servers as additional tool sources
+//
+// Available Functions:
+//   - LoadFederatedServers()            : Load the external MCP servers listed in a settings file
+//   - LoadConfiguredFederatedServers()  : Load the file named by MCP_SERVERS_FILE, or the default file
+//   - discoverFederatedTools()          : Connect to the external MCP servers and merge their tools
+//   - addFederatedTools()               : Merge the tools of one external MCP server
+//   - federatedClient()                 : Return the client of an external MCP server, connecting it when needed
+//   - dropFederatedClient()             : Close a failed client so the next request reconnects
+//   - connectFederatedServer()          : Start and initialize the client of an external MCP server
+//   - callFederatedTool()               : Call a tool of an external MCP server
+//   - stopFederatedServers()            : Close the clients of the external MCP servers
+//
+// Tools of an external server are offered to the planner as "<server>.<tool>", e.g.
+// "tickets.create-ticket", so they never clash with the agent's own tools or each other.
+// An external server that cannot be reached is skipped; the agent keeps its own tools. Every
+// request to a server is bounded by its timeout, and a server whose connection failed is
+// connected again on the next request.
+//
+// Usage Example:
+//   1. MCP_SERVERS_FILE=settings/mcp-servers.yaml
+//   2. result, err := agent.callMCPTool("tickets.create-ticket", arguments)
+
+// FederatedServersFileEnvVar points at the external MCP servers file, DefaultFederatedServersFile
+// when unset
+const FederatedServersFileEnvVar = "MCP_SERVERS_FILE"
+
+// DefaultFederatedServersFile lists the external MCP servers shipped with the agent
+const DefaultFederatedServersFile = "settings/mcp-servers.yaml"
+
+// defaultFederatedTimeout bounds a request to an external MCP server without a timeout setting
+const defaultFederatedTimeout = 60 * time.Second
+
+// federatedDiscoveryTimeout bounds connecting to an external MCP server and listing its tools
+// during discovery, which holds up agent startup. A server that misses it is skipped until the
+// next discovery.
+const federatedDiscoveryTimeout = 10 * time.Second
+
+// federatedToolSeparator joins the server name and tool name of a federated tool
+const federatedToolSeparator = "."
+
+// Transports of external MCP servers
+const (
+	FederatedTransportStdio = "stdio"
+	FederatedTransportHTTP  = "http"
+	FederatedTransportSSE   = "sse"
+)
+
+// federatedServerNamePattern keeps server names usable as a tool name prefix
+var federatedServerNamePattern = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// FederatedServersConfig lists the external MCP servers whose tools the agent can use
+type FederatedServersConfig struct {
+	Servers []FederatedServerConfig `yaml:"servers"`
+}
+
+// FederatedServerConfig describes an external MCP server. Values of env and headers may
+// reference environment variables as ${NAME}, so secrets stay out of the file.
+type FederatedServerConfig struct {
+	// Name prefixes the tools of the server, e.g. "tickets" gives "tickets.create-ticket"
+	Name string `yaml:"name"`
+
+	// Disabled servers are listed but not connected
+	Disabled bool `yaml:"disabled"`
+
+	// Transport is stdio, http (streamable HTTP) or sse
+	Transport string `yaml:"transport"`
+
+	// Command, Args and Env launch a stdio server
+	Command string            `yaml:"command"`
+	Args    []string          `yaml:"args"`
+	Env     map[string]string `yaml:"env"`
+
+	// URL and Headers reach an http or sse server
+	URL     string            `yaml:"url"`
+	Headers map[string]string `yaml:"headers"`
+
+	// Tools restricts the tools offered to the planner, all tools when empty
+	Tools []string `yaml:"tools"`
+
+	// Timeout bounds connecting and every request to the server, e.g. "2m";
+	// defaultFederatedTimeout when unset. Discovery never waits longer than
+	// federatedDiscoveryTimeout.
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// federatedServer is a configured external MCP server
+type federatedServer struct {
+	config FederatedServerConfig
+	reqID  int64
+
+	// client is nil until the server is reached, and again after its connection failed
+	client      *client.Client
+	clientMutex sync.Mutex
+}
+
+// LoadFederatedServers reads an external MCP servers file
+func LoadFederatedServers(path string) (*FederatedServersConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var servers FederatedServersConfig
+	if err := yaml.Unmarshal(data, &servers); err != nil {
+		return nil, fmt.Errorf("failed to parse MCP servers file %s: %w", path, err)
+	}
+	if err := servers.validate(); err != nil {
+		return nil, fmt.Errorf("invalid MCP servers file %s: %w", path, err)
+	}
+	return &servers, nil
+}
+
+// LoadConfiguredFederatedServers loads the file named by MCP_SERVERS_FILE, or the default
+// external MCP servers file. Without either no external server is used.
+func LoadConfiguredFederatedServers() (*FederatedServersConfig, error) {
+	if path := os.Getenv(FederatedServersFileEnvVar); path != "" {
+		return LoadFederatedServers(path)
+	}
+
+	servers, err := LoadFederatedServers(DefaultFederatedServersFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return &FederatedServersConfig{}, nil
+	}
+	return servers, err
+}
+
+// validate checks the servers can be connected and their tools named unambiguously
+func (c *FederatedServersConfig) validate() error {
+	names := make(map[string]bool)
+	for i, server := range c.Servers {
+		if !federatedServerNamePattern.MatchString(server.Name) {
+			return fmt.Errorf("server %d: name %q must be lowercase letters, digits and dashes", i, server.Name)
+		}
+		if names[server.Name] {
+			return fmt.Errorf("server %s is listed twice", server.Name)
+		}
+		names[server.Name] = true
+
+		switch server.Transport {
+		case FederatedTransportStdio:
+			if server.Command == "" {
+				return fmt.Errorf("server %s: stdio transport requires a command", server.Name)
+			}
+		case FederatedTransportHTTP, FederatedTransportSSE:
+			if server.URL == "" {
+				return fmt.Errorf("server %s: %s transport requires a url", server.Name, server.Transport)
+			}
+		default:
+			return fmt.Errorf("server %s: unknown transport %q, expected stdio, http or sse", server.Name, server.Transport)
+		}
+
+		if server.Timeout < 0 {
+			return fmt.Errorf("server %s: timeout must not be negative", server.Name)
+		}
+	}
+	return nil
+}
+
+// requestTimeout returns how long connecting or a request to the server may take
+func (c FederatedServerConfig) requestTimeout() time.Duration {
+	if c.Timeout > 0 {
+		return c.Timeout
+	}
+	return defaultFederatedTimeout
+}
+
+// allowsTool reports whether a tool of the server is offered to the planner
+func (c FederatedServerConfig) allowsTool(name string) bool {
+	if len(c.Tools) == 0 {
+		return true
+	}
+	for _, tool := range c.Tools {
+		if tool == name {
+			return true
+		}
+	}
+	return false
+}
+
+// discoverFederatedTools connects to the configured external MCP servers and merges their
+// namespaced tools into the discovered tools. It runs after discoverMCPTools, which resets them.
+// Servers are connected in parallel; those that cannot be reached in time are tried again on
+// the next discovery.
+func (a *StateAwareAgent) discoverFederatedTools() {
+	a.federationMutex.Lock()
+	if a.federatedServers == nil {
+		servers, err := LoadConfiguredFederatedServers()
+		if err != nil {
+			a.Logger.WithError(err).Error("Failed to load external MCP servers, using the agent's own tools only")
+			servers = &FederatedServersConfig{}
+		}
+
+		a.federatedServers = make(map[string]*federatedServer)
+		for _, config := range servers.Servers {
+			if !config.Disabled {
+				a.federatedServers[config.Name] = &federatedServer{config: config}
+			}
+		}
+	}
+	servers := make(map[string]*federatedServer, len(a.federatedServers))
+	for name, server := range a.federatedServers {
+		servers[name] = server
+	}
+	a.federationMutex.Unlock()
+
+	// A slow server holds up discovery only until its deadline, not the other servers
+	var wg sync.WaitGroup
+	for name, server := range servers {
+		wg.Add(1)
+		go func(name string, server *federatedServer) {
+			defer wg.Done()
+			a.addFederatedTools(name, server)
+		}(name, server)
+	}
+	wg.Wait()
+}
+
+// addFederatedTools lists the tools of one external MCP server and merges the allowed ones
+// into the discovered tools
+func (a *StateAwareAgent) addFederatedTools(name string, server *federatedServer) {
+	result, err := a.listFederatedTools(server)
+	if err != nil {
+		a.Logger.WithError(err).WithField("server", name).Warn("Failed to list tools of external MCP server, skipping its tools")
+		return
+	}
+
+	a.capabilityMutex.Lock()
+	count := 0
+	for _, tool := range result.Tools {
+		if !server.config.allowsTool(tool.Name) {
+			continue
+		}
+
+		// Marshalling the whole tool keeps raw input schemas as the server sent them
+		var toolMap map[string]interface{}
+		if toolBytes, err := json.Marshal(tool); err == nil {
+			json.Unmarshal(toolBytes, &toolMap)
+		}
+		inputSchema, _ := toolMap["inputSchema"].(map[string]interface{})
+
+		toolName := name + federatedToolSeparator + tool.Name
+		a.mcpTools[toolName] = MCPToolInfo{
+			Name:        toolName,
+			Description: tool.Description,
+			InputSchema: inputSchema,
+			Server:      name,
+		}
+
+		// Read-only tools are queries; the action type of other tools is unknown to the agent
+		if tool.Annotations.ReadOnlyHint != nil && *tool.Annotations.ReadOnlyHint {
+			a.idExtractor.RegisterToolActionType(toolName, "query")
+		}
+
+		redact.Default().RegisterSchema(inputSchema)
+		count++
+	}
+	a.capabilityMutex.Unlock()
+
+	a.Logger.WithFields(map[string]interface{}{
+		"server":      name,
+		"tools_count": count,
+	}).Info("Discovered tools of external MCP server")
+}
+
+// listFederatedTools lists the tools of an external MCP server within the discovery timeout, or
+// the server's own timeout when that is shorter
+func (a *StateAwareAgent) listFederatedTools(server *federatedServer) (*mcp.ListToolsResult, error) {
+	timeout := server.config.requestTimeout()
+	if timeout > federatedDiscoveryTimeout {
+		timeout = federatedDiscoveryTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	mcpClient, err := a.federatedClient(ctx, server)
+	if err != nil {
+		return nil, err
+	}
+	result, err := mcpClient.ListTools(ctx, mcp.ListToolsRequest{})
+	if err != nil {
+		a.dropFederatedClient(server, mcpClient, err)
+		return nil, err
+	}
+	return result, nil
+}
+
+// federatedClient returns the client of an external MCP server, connecting it first when the
+// server has not been reached yet or its last connection failed
+func (a *StateAwareAgent) federatedClient(ctx context.Context, server *federatedServer) (*client.Client, error) {
+	server.clientMutex.Lock()
+	defer server.clientMutex.Unlock()
+
+	if server.client == nil {
+		mcpClient, err := a.connectFederatedServer(ctx, server.config)
+		if err != nil {
+			return nil, err
+		}
+		server.client = mcpClient
+	}
+	return server.client, nil
+}
+
+// dropFederatedClient closes the client of an external MCP server when a request failed in the
+// transport, e.g. the server exited or timed out, so the next request connects again. Errors
+// the server answered with leave the connection alone.
+func (a *StateAwareAgent) dropFederatedClient(server *federatedServer, failed *client.Client, err error) {
+	var transportErr *transport.Error
+	if !errors.As(err, &transportErr) {
+		return
+	}
+
+	server.clientMutex.Lock()
+	defer server.clientMutex.Unlock()
+	if server.client != failed {
+		return
+	}
+
+	a.Logger.WithError(err).WithField("server", server.config.Name).Warn("Lost connection to external MCP server, reconnecting on the next request")
+	failed.Close()
+	server.client = nil
+}
+
+// connectFederatedServer starts and initializes the client of an external MCP server. ctx
+// bounds the initialization; a stdio server keeps running after it ends.
+func (a *StateAwareAgent) connectFederatedServer(ctx context.Context, config FederatedServerConfig) (*client.Client, error) {
+	headers := make(map[string]string, len(config.Headers))
+	for key, value := range config.Headers {
+		headers[key] = os.ExpandEnv(value)
+	}
+
+	var mcpTransport transport.Interface
+	var err error
+	switch config.Transport {
+	case FederatedTransportStdio:
+		env := make([]string, 0, len(config.Env))
+		for key, value := range config.Env {
+			env = append(env, key+"="+os.ExpandEnv(value))
+		}
+		mcpTransport = transport.NewStdio(config.Command, env, config.Args...)
+	case FederatedTransportHTTP:
+		mcpTransport, err = transport.NewStreamableHTTP(config.URL, transport.WithHTTPHeaders(headers))
+	case FederatedTransportSSE:
+		mcpTransport, err = transport.NewSSE(config.URL, transport.WithHeaders(headers))
+	default:
+		err = fmt.Errorf("unknown transport %q", config.Transport)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s transport: %w", config.Transport, err)
+	}
+
+	// Start launches a stdio server, which must outlive ctx
+	mcpClient := client.NewClient(mcpTransport)
+	if err := mcpClient.Start(context.Background()); err != nil {
+		return nil, fmt.Errorf("failed to start MCP client: %w", err)
+	}
+	mcpClient.OnNotification(func(notification mcp.JSONRPCNotification) {
+		a.handleMCPNotification(notification.Method, notification.Params.AdditionalFields)
+	})
+
+	initRequest := mcp.InitializeRequest{}
+	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	initRequest.Params.ClientInfo = mcp.Implementation{
+		Name:    "aws-mcp-ai-agent",
+		Version: "1.0.0",
+	}
+	initResult, err := mcpClient.Initialize(ctx, initRequest)
+	if err != nil {
+		mcpClient.Close()
+		return nil, fmt.Errorf("failed to initialize MCP connection: %w", err)
+	}
+
+	a.Logger.WithFields(map[string]interface{}{
+		"server":         config.Name,
+		"transport":      config.Transport,
+		"server_name":    initResult.ServerInfo.Name,
+		"server_version": initResult.ServerInfo.Version,
+	}).Info("Connected to external MCP server")
+
+	return mcpClient, nil
+}
+
+// callFederatedTool calls a namespaced tool on the external MCP server that provides it. The
+// call ends with ctx or the server's timeout, whichever comes first.
+func (a *StateAwareAgent) callFederatedTool(ctx context.Context, serverName, name string, arguments map[string]interface{}, onProgress MCPProgressFunc) (map[string]interface{}, error) {
+	a.federationMutex.RLock()
+	server, exists := a.federatedServers[serverName]
+	a.federationMutex.RUnlock()
+	if !exists {
+		return nil, fmt.Errorf("external MCP server %s is not configured", serverName)
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, server.config.requestTimeout())
+	defer cancel()
+
+	mcpClient, err := a.federatedClient(callCtx, server)
+	if err != nil {
+		return nil, fmt.Errorf("external MCP server %s is unavailable: %w", serverName, err)
+	}
+
+	callRequest := mcp.CallToolRequest{}
+	callRequest.Params.Name = strings.TrimPrefix(name, serverName+federatedToolSeparator)
+	callRequest.Params.Arguments = arguments
+	if onProgress != nil {
+		token := fmt.Sprintf("progress-%s-%d", serverName, atomic.AddInt64(&server.reqID, 1))
+		a.setProgressHandler(token, onProgress)
+		defer a.unregisterProgressHandler(token)
+		callRequest.Params.Meta = &mcp.Meta{
+			ProgressToken: token,
+		}
+	}
+
+	result, err := mcpClient.CallTool(callCtx, callRequest)
+	if err != nil {
+		// A cancelled step says nothing about the connection
+		if ctx.Err() == nil {
+			a.dropFederatedClient(server, mcpClient, err)
+		}
+		return nil, fmt.Errorf("external MCP tool call failed: %w", err)
+	}
+
+	resultBytes, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal external tool result: %w", err)
+	}
+	var resultMap map[string]interface{}
+	if err := json.Unmarshal(resultBytes, &resultMap); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal external tool result: %w", err)
+	}
+
+	// External tools rarely answer with the success/error shape of the agent's own tools
+	if result.IsError {
+		var messages []string
+		for _, content := range result.Content {
+			if textContent, ok := content.(mcp.TextContent); ok {
+				messages = append(messages, textContent.Text)
+			}
+		}
+		return nil, fmt.Errorf("tool execution failed: %s", strings.Join(messages, "; "))
+	}
+
+	return parseMCPToolResult(resultMap)
+}
+
+// stopFederatedServers closes the clients of the external MCP servers
+func (a *StateAwareAgent) stopFederatedServers() {
+	a.federationMutex.Lock()
+	defer a.federationMutex.Unlock()
+
+	for name, server := range a.federatedServers {
+		server.clientMutex.Lock()
+		if server.client != nil {
+			if err := server.client.Close(); err != nil {
+				a.Logger.WithError(err).WithField("server", name).Warn("Failed to close external MCP client")
+			}
+			server.client = nil
+		}
+		server.clientMutex.Unlock()
+	}
+	a.federatedServers = nil
+}
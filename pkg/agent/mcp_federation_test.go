@@ -0,0 +1,262 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/versus-control/ai-infrastructure-agent/internal/logging"
+)
+
+func TestFederatedServersConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		servers []FederatedServerConfig
+		wantErr string
+	}{
+		{
+			name: "stdio and http servers",
+			servers: []FederatedServerConfig{
+				{Name: "tickets", Transport: FederatedTransportStdio, Command: "tickets-mcp"},
+				{Name: "docs-search", Transport: FederatedTransportHTTP, URL: "https://docs.example.com/mcp"},
+			},
+		},
+		{
+			name:    "uppercase name",
+			servers: []FederatedServerConfig{{Name: "Tickets", Transport: FederatedTransportStdio, Command: "tickets-mcp"}},
+			wantErr: "must be lowercase",
+		},
+		{
+			name:    "name with the tool separator",
+			servers: []FederatedServerConfig{{Name: "tickets.v2", Transport: FederatedTransportStdio, Command: "tickets-mcp"}},
+			wantErr: "must be lowercase",
+		},
+		{
+			name: "duplicate name",
+			servers: []FederatedServerConfig{
+				{Name: "tickets", Transport: FederatedTransportStdio, Command: "tickets-mcp"},
+				{Name: "tickets", Transport: FederatedTransportSSE, URL: "https://tickets.example.com/sse"},
+			},
+			wantErr: "listed twice",
+		},
+		{
+			name:    "stdio without command",
+			servers: []FederatedServerConfig{{Name: "tickets", Transport: FederatedTransportStdio}},
+			wantErr: "requires a command",
+		},
+		{
+			name:    "sse without url",
+			servers: []FederatedServerConfig{{Name: "tickets", Transport: FederatedTransportSSE}},
+			wantErr: "requires a url",
+		},
+		{
+			name:    "unknown transport",
+			servers: []FederatedServerConfig{{Name: "tickets", Transport: "grpc", URL: "tickets:443"}},
+			wantErr: "unknown transport",
+		},
+		{
+			name:    "negative timeout",
+			servers: []FederatedServerConfig{{Name: "tickets", Transport: FederatedTransportStdio, Command: "tickets-mcp", Timeout: -1}},
+			wantErr: "must not be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &FederatedServersConfig{Servers: tt.servers}
+			err := config.validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("validate() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("validate() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFederatedServerAllowsTool(t *testing.T) {
+	tests := []struct {
+		name  string
+		tools []string
+		tool  string
+		want  bool
+	}{
+		{"no restriction", nil, "create-ticket", true},
+		{"listed tool", []string{"create-ticket", "get-ticket"}, "get-ticket", true},
+		{"unlisted tool", []string{"create-ticket"}, "delete-ticket", false},
+		{"prefix of a listed tool", []string{"create-ticket"}, "create", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := FederatedServerConfig{Name: "tickets", Tools: tt.tools}
+			if got := config.allowsTool(tt.tool); got != tt.want {
+				t.Fatalf("allowsTool(%q) = %v, want %v", tt.tool, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseMCPToolResult(t *testing.T) {
+	textResult := func(text string) map[string]interface{} {
+		return map[string]interface{}{
+			"content": []interface{}{
+				map[string]interface{}{"type": "text", "text": text},
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		result    map[string]interface{}
+		want      map[string]interface{}
+		wantError string
+	}{
+		{
+			name:   "structured content",
+			result: map[string]interface{}{"structuredContent": map[string]interface{}{"success": true, "ticketId": "T-1"}},
+			want:   map[string]interface{}{"success": true, "ticketId": "T-1"},
+		},
+		{
+			name:      "structured content reporting failure",
+			result:    map[string]interface{}{"structuredContent": map[string]interface{}{"success": false, "error": "queue is full"}},
+			wantError: "queue is full",
+		},
+		{
+			name:   "json text",
+			result: textResult(`{"success": true, "ticketId": "T-1"}`),
+			want:   map[string]interface{}{"success": true, "ticketId": "T-1"},
+		},
+		{
+			name:      "json text reporting failure",
+			result:    textResult(`{"success": false, "error": "queue is full"}`),
+			wantError: "queue is full",
+		},
+		{
+			name:      "json text with an error only",
+			result:    textResult(`{"error": "queue is full"}`),
+			wantError: "queue is full",
+		},
+		{
+			name:   "plain text",
+			result: textResult("ticket T-1 created"),
+			want:   map[string]interface{}{"text": "ticket T-1 created"},
+		},
+		{
+			name:   "no content",
+			result: map[string]interface{}{"isError": false},
+			want:   map[string]interface{}{"isError": false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseMCPToolResult(tt.result)
+			if tt.wantError != "" {
+				var toolErr *MCPToolError
+				if !errors.As(err, &toolErr) || toolErr.Message != tt.wantError {
+					t.Fatalf("parseMCPToolResult() error = %v, want tool error %q", err, tt.wantError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseMCPToolResult() error = %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("parseMCPToolResult() = %v, want %v", got, tt.want)
+			}
+			for key, value := range tt.want {
+				if got[key] != value {
+					t.Fatalf("parseMCPToolResult()[%q] = %v, want %v", key, got[key], value)
+				}
+			}
+		})
+	}
+}
+
+// newInProcessFederatedServer serves a tickets MCP server in process and returns it connected
+// as an external server of the agent
+func newInProcessFederatedServer(t *testing.T) *federatedServer {
+	t.Helper()
+
+	mcpServer := server.NewMCPServer("tickets", "1.0.0")
+	mcpServer.AddTool(mcp.NewTool("create-ticket", mcp.WithString("title", mcp.Required())),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			title, err := request.RequireString("title")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			return mcp.NewToolResultText(`{"success": true, "ticketId": "T-1", "title": "` + title + `"}`), nil
+		})
+	mcpServer.AddTool(mcp.NewTool("close-ticket"),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultError("ticket is locked"), nil
+		})
+
+	mcpClient, err := client.NewInProcessClient(mcpServer)
+	if err != nil {
+		t.Fatalf("failed to create in-process client: %v", err)
+	}
+	t.Cleanup(func() { mcpClient.Close() })
+
+	ctx := context.Background()
+	if err := mcpClient.Start(ctx); err != nil {
+		t.Fatalf("failed to start in-process client: %v", err)
+	}
+	initRequest := mcp.InitializeRequest{}
+	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	if _, err := mcpClient.Initialize(ctx, initRequest); err != nil {
+		t.Fatalf("failed to initialize in-process client: %v", err)
+	}
+
+	return &federatedServer{
+		config: FederatedServerConfig{Name: "tickets", Transport: FederatedTransportStdio, Command: "tickets-mcp"},
+		client: mcpClient,
+	}
+}
+
+func TestCallMCPToolRoutesFederatedTools(t *testing.T) {
+	agent := &StateAwareAgent{
+		Logger: logging.NewLogger("test", "error"),
+		mcpTools: map[string]MCPToolInfo{
+			"tickets.create-ticket": {Name: "tickets.create-ticket", Server: "tickets"},
+			"tickets.close-ticket":  {Name: "tickets.close-ticket", Server: "tickets"},
+		},
+		federatedServers: map[string]*federatedServer{
+			"tickets": newInProcessFederatedServer(t),
+		},
+	}
+
+	result, err := agent.callMCPToolWithProgress(context.Background(), "tickets.create-ticket", map[string]interface{}{"title": "Rotate keys"}, nil)
+	if err != nil {
+		t.Fatalf("callMCPToolWithProgress() error = %v", err)
+	}
+	if result["ticketId"] != "T-1" || result["title"] != "Rotate keys" {
+		t.Fatalf("callMCPToolWithProgress() = %v, want the ticket created by the external server", result)
+	}
+
+	_, err = agent.callMCPToolWithProgress(context.Background(), "tickets.close-ticket", nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "ticket is locked") {
+		t.Fatalf("callMCPToolWithProgress() error = %v, want the error reported by the external tool", err)
+	}
+}
+
+func TestCallFederatedToolUnknownServer(t *testing.T) {
+	agent := &StateAwareAgent{
+		Logger:           logging.NewLogger("test", "error"),
+		federatedServers: map[string]*federatedServer{},
+	}
+
+	_, err := agent.callFederatedTool(context.Background(), "tickets", "tickets.create-ticket", nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "not configured") {
+		t.Fatalf("callFederatedTool() error = %v, want an error for an unconfigured server", err)
+	}
+}
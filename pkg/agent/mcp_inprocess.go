@@ -0,0 +1,173 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/versus-control/ai-infrastructure-agent/pkg/mcp"
+)
+
+// ========== Interface defines ==========
+
+// MCPInProcessInterface defines the in-process MCP transport
+//
+// Available Functions:
+//   - MCPModeFromEnv()                  : Return the MCP mode configured with AGENT_MCP_MODE
+//   - UseInProcessMCP()                 : Talk to an MCP server in this process instead of a subprocess
+//   - startInProcessMCP()               : Connect an in-memory client to the in-process MCP server
+//   - sendInProcessRequest()            : Send a JSON-RPC request through the in-memory client
+//   - sendInProcessNotification()       : Send a notification through the in-memory client
+//
+// The in-process transport skips building and launching the server binary, which makes the
+// agent start fast in development. The subprocess mode stays the default, since it isolates a
+// crashing tool from the agent.
+//
+// Usage Example:
+//   1. agent.UseInProcessMCP(mcpserver.NewServer(cfg, awsClient, logger))
+//   2. agent.Initialize(ctx)
+
+// MCPModeEnvVar selects how the agent reaches its MCP server, MCPModeSubprocess when unset
+const MCPModeEnvVar = "AGENT_MCP_MODE"
+
+// MCP modes of the agent
+const (
+	// MCPModeSubprocess launches the MCP server binary and talks JSON-RPC over its pipes
+	MCPModeSubprocess = "subprocess"
+
+	// MCPModeInProcess runs the MCP server in the agent process behind an in-memory client
+	MCPModeInProcess = "inprocess"
+)
+
+// MCPModeFromEnv returns the MCP mode configured with AGENT_MCP_MODE
+func MCPModeFromEnv() string {
+	mode := strings.ToLower(strings.TrimSpace(os.Getenv(MCPModeEnvVar)))
+	switch mode {
+	case "":
+		return MCPModeSubprocess
+	case "in-process", "in_process":
+		return MCPModeInProcess
+	}
+	return mode
+}
+
+// UseInProcessMCP makes the agent call tools on server directly instead of launching the MCP
+// server as a subprocess. It must be called before Initialize.
+func (a *StateAwareAgent) UseInProcessMCP(server *mcpserver.Server) {
+	a.inProcessServer = server
+}
+
+// startInProcessMCP connects an in-memory client to the in-process MCP server
+func (a *StateAwareAgent) startInProcessMCP() error {
+	a.Logger.Info("Connecting to in-process MCP server for tool execution")
+
+	mcpClient, err := client.NewInProcessClient(a.inProcessServer.MCPServer())
+	if err != nil {
+		return fmt.Errorf("failed to create in-process MCP client: %w", err)
+	}
+	if err := mcpClient.Start(context.Background()); err != nil {
+		return fmt.Errorf("failed to start in-process MCP client: %w", err)
+	}
+	mcpClient.OnNotification(func(notification mcp.JSONRPCNotification) {
+		a.handleMCPNotification(notification.Method, notification.Params.AdditionalFields)
+	})
+
+	a.mcpProcess = &MCPProcess{
+		client: mcpClient,
+	}
+
+	// Initialize MCP connection
+	if err := a.initializeMCP(); err != nil {
+		a.stopMCPProcess()
+		return fmt.Errorf("failed to initialize MCP connection: %w", err)
+	}
+
+	a.Logger.Info("In-process MCP server connected successfully")
+	return nil
+}
+
+// sendInProcessRequest sends a JSON-RPC request through the in-memory client and returns the
+// response in the shape the subprocess transport reads from stdout. Cancelling ctx cancels the
+// tool call, which runs on it.
+func (a *StateAwareAgent) sendInProcessRequest(ctx context.Context, request map[string]interface{}) (map[string]interface{}, error) {
+	mcpClient := a.mcpProcess.client
+	method, _ := request["method"].(string)
+	params, _ := request["params"].(map[string]interface{})
+
+	var result interface{}
+	var err error
+	switch method {
+	case "initialize":
+		initRequest := mcp.InitializeRequest{}
+		initRequest.Params.ProtocolVersion, _ = params["protocolVersion"].(string)
+		if clientInfo, ok := params["clientInfo"].(map[string]interface{}); ok {
+			initRequest.Params.ClientInfo.Name, _ = clientInfo["name"].(string)
+			initRequest.Params.ClientInfo.Version, _ = clientInfo["version"].(string)
+		}
+		result, err = mcpClient.Initialize(ctx, initRequest)
+	case "tools/list":
+		result, err = mcpClient.ListTools(ctx, mcp.ListToolsRequest{})
+	case "resources/list":
+		result, err = mcpClient.ListResources(ctx, mcp.ListResourcesRequest{})
+	case "tools/call":
+		callRequest := mcp.CallToolRequest{}
+		callRequest.Params.Name, _ = params["name"].(string)
+		callRequest.Params.Arguments = params["arguments"]
+		if meta, ok := params["_meta"].(map[string]interface{}); ok {
+			callRequest.Params.Meta = &mcp.Meta{
+				ProgressToken: meta["progressToken"],
+			}
+		}
+		result, err = mcpClient.CallTool(ctx, callRequest)
+	default:
+		return nil, fmt.Errorf("MCP method %s is not supported by the in-process transport", method)
+	}
+
+	response := map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      request["id"],
+	}
+	if err != nil {
+		response["error"] = map[string]interface{}{
+			"message": err.Error(),
+		}
+		return response, nil
+	}
+
+	resultBytes, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal response: %w", err)
+	}
+	var resultMap map[string]interface{}
+	if err := json.Unmarshal(resultBytes, &resultMap); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	response["result"] = resultMap
+
+	return response, nil
+}
+
+// sendInProcessNotification sends a notification through the in-memory client
+func (a *StateAwareAgent) sendInProcessNotification(notification map[string]interface{}) error {
+	method, _ := notification["method"].(string)
+
+	// The client already announced itself when Initialize returned
+	if method == "notifications/initialized" {
+		return nil
+	}
+
+	params, _ := notification["params"].(map[string]interface{})
+	return a.mcpProcess.client.GetTransport().SendNotification(context.Background(), mcp.JSONRPCNotification{
+		JSONRPC: mcp.JSONRPC_VERSION,
+		Notification: mcp.Notification{
+			Method: method,
+			Params: mcp.NotificationParams{
+				AdditionalFields: params,
+			},
+		},
+	})
+}
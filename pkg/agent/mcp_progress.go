@@ -0,0 +1,74 @@
+package agent
+
+import (
+	"fmt"
+)
+
+// ========== Interface defines ==========
+
+// MCPProgressInterface defines how the agent receives progress of long-running MCP tool calls
+//
+// Available Functions:
+//   - registerProgressHandler()         : Register the progress handler of a tool call and return its token
+//   - setProgressHandler()              : Register the progress handler of a tool call under a given token
+//   - unregisterProgressHandler()       : Remove the progress handler once the tool call returned
+//   - handleMCPNotification()           : Dispatch a notification sent by the MCP server
+//
+// Tools that wait for a resource to become available send notifications/progress with the
+// progress token of the call. The plan executor forwards them as step_progress updates.
+//
+// Usage Example:
+//   1. result, err := agent.callMCPToolWithProgress(ctx, "create-nat-gateway", arguments, onProgress)
+
+// MCPProgressFunc receives the progress of a running MCP tool call. total is 0 when the tool
+// does not know how much work is left.
+type MCPProgressFunc func(progress, total float64, message string)
+
+// registerProgressHandler registers the progress handler of a tool call and returns the progress
+// token to send with it
+func (a *StateAwareAgent) registerProgressHandler(reqID int64, onProgress MCPProgressFunc) string {
+	token := fmt.Sprintf("progress-%d", reqID)
+	a.setProgressHandler(token, onProgress)
+	return token
+}
+
+// setProgressHandler registers the progress handler of a tool call under a token the caller
+// made unique
+func (a *StateAwareAgent) setProgressHandler(token string, onProgress MCPProgressFunc) {
+	a.progressMutex.Lock()
+	defer a.progressMutex.Unlock()
+	if a.progressHandlers == nil {
+		a.progressHandlers = make(map[string]MCPProgressFunc)
+	}
+	a.progressHandlers[token] = onProgress
+}
+
+// unregisterProgressHandler removes the progress handler of a tool call that returned
+func (a *StateAwareAgent) unregisterProgressHandler(token string) {
+	a.progressMutex.Lock()
+	defer a.progressMutex.Unlock()
+	delete(a.progressHandlers, token)
+}
+
+// handleMCPNotification dispatches a notification sent by the MCP server
+func (a *StateAwareAgent) handleMCPNotification(method string, params map[string]interface{}) {
+	switch method {
+	case "notifications/progress":
+		token := fmt.Sprint(params["progressToken"])
+		progress, _ := params["progress"].(float64)
+		total, _ := params["total"].(float64)
+		message, _ := params["message"].(string)
+
+		a.progressMutex.Lock()
+		onProgress, exists := a.progressHandlers[token]
+		a.progressMutex.Unlock()
+
+		if !exists {
+			a.Logger.WithField("progress_token", token).Debug("Ignoring progress notification of a finished tool call")
+			return
+		}
+		onProgress(progress, total, message)
+	default:
+		a.Logger.WithField("method", method).Debug("Ignoring MCP server notification")
+	}
+}
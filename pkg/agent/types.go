@@ -6,12 +6,17 @@ import (
 	"os/exec"
 	"sync"
 
+	"github.com/mark3labs/mcp-go/client"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/versus-control/ai-infrastructure-agent/internal/config"
 	"github.com/versus-control/ai-infrastructure-agent/internal/logging"
 	"github.com/versus-control/ai-infrastructure-agent/pkg/agent/resources"
 	"github.com/versus-control/ai-infrastructure-agent/pkg/agent/retrieval"
 	"github.com/versus-control/ai-infrastructure-agent/pkg/aws"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/cost"
+	mcpserver "github.com/versus-control/ai-infrastructure-agent/pkg/mcp"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/policy"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/tagging"
 	"github.com/versus-control/ai-infrastructure-agent/pkg/types"
 
 	"github.com/tmc/langchaingo/llms"
@@ -44,13 +49,29 @@ import (
 
 // ========== Agent Type Definitions ==========
 
-// MCPProcess represents a running MCP server process
+// MCPProcess represents a running MCP server process, or the in-memory client of an
+// in-process MCP server
 type MCPProcess struct {
 	cmd    *exec.Cmd
 	stdin  *bufio.Writer
 	stdout *bufio.Scanner
-	mutex  sync.Mutex
+	mutex  sync.Mutex // Serializes writes to stdin and request IDs
 	reqID  int64
+
+	// Requests waiting for their response, by JSON-RPC ID. Responses are read from stdout by
+	// readMCPResponses, so a long tool call does not block other requests.
+	pending      map[string]chan map[string]interface{}
+	pendingMutex sync.Mutex
+	done         chan struct{} // Closed when stdout ends
+	readErr      error         // Why stdout ended, set before done is closed
+
+	// In-memory client of the in-process MCP server, nil for a subprocess
+	client *client.Client
+}
+
+// isRunning reports whether the MCP server can take requests
+func (p *MCPProcess) isRunning() bool {
+	return p.client != nil || (p.cmd != nil && p.cmd.Process != nil)
 }
 
 // StateAwareAgent represents an AI agent with state management capabilities
@@ -63,12 +84,21 @@ type StateAwareAgent struct {
 
 	// MCP properties
 	mcpProcess       *MCPProcess
+	inProcessServer  *mcpserver.Server // Served through an in-memory client instead of a subprocess when set
 	resourceMappings map[string]string
 	mappingsMutex    sync.RWMutex
 	mcpTools         map[string]MCPToolInfo
 	mcpResources     map[string]MCPResourceInfo
 	capabilityMutex  sync.RWMutex
 
+	// External MCP servers providing additional tools, by name
+	federatedServers map[string]*federatedServer
+	federationMutex  sync.RWMutex
+
+	// Progress handlers of running tool calls, by progress token
+	progressHandlers map[string]MCPProgressFunc
+	progressMutex    sync.Mutex
+
 	// Configuration-driven components
 	fieldResolver     *resources.FieldResolver
 	patternMatcher    *resources.PatternMatcher
@@ -81,6 +111,15 @@ type StateAwareAgent struct {
 	// Retrieval functions registry
 	registry retrieval.RetrievalRegistryInterface
 
+	// Guardrail policies evaluated on plans and before each step executes
+	policyEngine *policy.Engine
+
+	// Tag policy the tags of creation steps are checked against before a plan executes
+	tagPolicy *tagging.Policy
+
+	// Monthly cost estimation of plans, nil when no pricing catalog is available
+	costEstimator *cost.Estimator
+
 	// Test mode flag to bypass real MCP server startup
 	testMode bool
 
@@ -96,6 +135,7 @@ type MCPToolInfo struct {
 	Description string                 `json:"description"`
 	InputSchema map[string]interface{} `json:"inputSchema"`
 	Type        string                 `json:"type"`
+	Server      string                 `json:"server,omitempty"` // External MCP server providing the tool, empty for the agent's own tools
 }
 
 // MCPResourceInfo represents information about an available MCP resource
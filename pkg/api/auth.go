@@ -0,0 +1,87 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/versus-control/ai-infrastructure-agent/internal/logging"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/auth"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/types"
+)
+
+// authRealm names the web API in WWW-Authenticate challenges
+const authRealm = "ai-infrastructure-agent"
+
+// newAuthenticator creates the authenticator configured in settings/api-auth.yaml (or
+// API_AUTH_FILE). Settings that cannot be loaded reject every request rather than leave the
+// API open.
+func newAuthenticator(logger *logging.Logger) *auth.Authenticator {
+	config, err := auth.LoadConfigured()
+	if err == nil {
+		var authenticator *auth.Authenticator
+		if authenticator, err = auth.New(config); err == nil {
+			return authenticator
+		}
+	}
+
+	logger.WithError(err).Error("Failed to load web API authentication settings - rejecting all API requests")
+	authenticator, _ := auth.New(&auth.Config{Enabled: true})
+	return authenticator
+}
+
+// warnAuthenticationDisabled makes an unauthenticated API hard to miss in the startup logs. The
+// server listens on every interface, so anyone who can reach it may plan and execute changes.
+func (ws *WebServer) warnAuthenticationDisabled(addr string) {
+	ws.logger.WithFields(map[string]interface{}{
+		"addr":        addr,
+		"settings":    auth.DefaultConfigFile,
+		"settingsEnv": auth.ConfigFileEnvVar,
+	}).Error("WEB API AUTHENTICATION IS DISABLED - anyone who can reach this server can plan, execute and recover " +
+		"infrastructure changes as anonymous. Set enabled: true with an API key or OIDC issuer before exposing it")
+}
+
+// requireAuthentication rejects API requests without a valid API key or bearer token and
+// passes the principal of the others to the handlers in the request context
+func (ws *WebServer) requireAuthentication(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Preflight requests and health checks carry no credentials
+		if r.Method == http.MethodOptions || r.URL.Path == "/api/health" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		principal, ok := ws.authenticate(w, r)
+		if !ok {
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(auth.WithPrincipal(r.Context(), principal)))
+	})
+}
+
+// authenticate returns the principal of a request, answering 401 when it has none
+func (ws *WebServer) authenticate(w http.ResponseWriter, r *http.Request) (*types.Principal, bool) {
+	principal, err := ws.authenticator.Authenticate(r)
+	if err != nil {
+		ws.logger.WithError(err).WithFields(map[string]interface{}{
+			"remote_addr": r.RemoteAddr,
+			"path":        r.URL.Path,
+		}).Warn("Rejected unauthenticated web API request")
+		w.Header().Set("WWW-Authenticate", `Bearer realm="`+authRealm+`"`)
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return nil, false
+	}
+	return principal, true
+}
+
+// checkOrigin only lets the origin serving the UI and the allowed origins open websockets, so
+// other sites cannot drive the agent from a user's browser
+func (ws *WebServer) checkOrigin(r *http.Request) bool {
+	if ws.authenticator.OriginAllowed(r) {
+		return true
+	}
+
+	ws.logger.WithFields(map[string]interface{}{
+		"origin":      r.Header.Get("Origin"),
+		"remote_addr": r.RemoteAddr,
+	}).Warn("Rejected WebSocket connection from a disallowed origin")
+	return false
+}
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/versus-control/ai-infrastructure-agent/pkg/auth"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/keystore"
+
+	"github.com/gorilla/mux"
+)
+
+// downloadTokenHeader carries the KEYSTORE_DOWNLOAD_TOKEN when the Authorization header holds
+// the credential of an authenticated web API
+const downloadTokenHeader = "X-Download-Token"
+
+// downloadPrivateKeyHandler returns the private key of a key pair created by the agent. The
+// request must carry the KEYSTORE_DOWNLOAD_TOKEN in the X-Download-Token header or as a bearer
+// token. ?region names the region of the key pair, the configured region by default; with
+// ?delete=true the key is removed from the keystore once it has been sent.
+func (ws *WebServer) downloadPrivateKeyHandler(w http.ResponseWriter, r *http.Request) {
+	keyName := mux.Vars(r)["keyName"]
+
+	if ws.keystore == nil {
+		http.Error(w, "Keystore not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	expectedToken := os.Getenv(keystore.DownloadTokenEnvVar)
+	if expectedToken == "" {
+		http.Error(w, fmt.Sprintf("Private key downloads are disabled; set %s to enable them", keystore.DownloadTokenEnvVar), http.StatusForbidden)
+		return
+	}
+
+	token := r.Header.Get(downloadTokenHeader)
+	if token == "" {
+		token = auth.BearerToken(r)
+	}
+	if subtle.ConstantTimeCompare([]byte(token), []byte(expectedToken)) != 1 {
+		ws.logger.WithField("remoteAddr", r.RemoteAddr).Warn("Rejected private key download with invalid token")
+		w.Header().Set("WWW-Authenticate", `Bearer realm="keystore"`)
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	region := r.URL.Query().Get("region")
+	if region == "" {
+		region = ws.region
+	}
+	if err := keystore.ValidateRegion(region); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := keystore.ValidateName(keyName); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	material, err := ws.keystore.Get(r.Context(), region, keyName)
+	if errors.Is(err, keystore.ErrNotFound) {
+		http.Error(w, "Private key not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		ws.logger.WithError(err).WithFields(map[string]interface{}{
+			"keyName": keyName,
+			"region":  region,
+		}).Error("Failed to read private key from keystore")
+		http.Error(w, "Failed to read private key", http.StatusInternalServerError)
+		return
+	}
+
+	extension := "pem"
+	if strings.HasPrefix(string(material), "PuTTY-User-Key-File") {
+		extension = "ppk"
+	}
+
+	w.Header().Set("Content-Type", "application/x-pem-file")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s.%s", keyName, extension))
+	w.Header().Set("Cache-Control", "no-store")
+	if _, err := w.Write(material); err != nil {
+		ws.logger.WithError(err).WithField("keyName", keyName).Error("Failed to send private key")
+		return
+	}
+
+	ws.logger.WithFields(map[string]interface{}{
+		"keyName":    keyName,
+		"region":     region,
+		"remoteAddr": r.RemoteAddr,
+	}).Info("Private key downloaded")
+
+	if r.URL.Query().Get("delete") == "true" {
+		if err := ws.keystore.Delete(r.Context(), region, keyName); err != nil {
+			ws.logger.WithError(err).WithField("keyName", keyName).Error("Failed to delete private key after download")
+		}
+	}
+}
@@ -14,7 +14,12 @@ import (
 	"github.com/versus-control/ai-infrastructure-agent/internal/config"
 	"github.com/versus-control/ai-infrastructure-agent/internal/logging"
 	"github.com/versus-control/ai-infrastructure-agent/pkg/agent"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/auth"
 	"github.com/versus-control/ai-infrastructure-agent/pkg/aws"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/keystore"
+	mcpserver "github.com/versus-control/ai-infrastructure-agent/pkg/mcp"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/policy"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/redact"
 	"github.com/versus-control/ai-infrastructure-agent/pkg/types"
 
 	"github.com/gorilla/mux"
@@ -24,8 +29,9 @@ import (
 
 // WebSocket connection wrapper
 type wsConnection struct {
-	conn     *websocket.Conn
-	lastPong time.Time
+	conn      *websocket.Conn
+	lastPong  time.Time
+	principal *types.Principal
 }
 
 // WebServer handles HTTP requests for the AI agent UI
@@ -46,6 +52,15 @@ type WebServer struct {
 	// Recovery coordination
 	recoveryRequests map[string]*RecoveryRequest
 	recoveryMutex    sync.RWMutex
+
+	// Keystore holding generated key pair private keys for download, and the region of key
+	// pairs whose download names none
+	keystore keystore.Store
+	region   string
+
+	// Authentication of API and WebSocket requests
+	authenticator *auth.Authenticator
+	logger        *logging.Logger
 }
 
 // RecoveryRequest represents a pending recovery decision
@@ -71,23 +86,32 @@ type StoredDecision struct {
 
 // NewWebServer creates a new web server instance
 func NewWebServer(cfg *config.Config, awsClient *aws.Client, logger *logging.Logger) *WebServer {
+	redact.Install(logger)
+
 	ws := &WebServer{
 		router:           mux.NewRouter(),
 		connections:      make(map[string]*wsConnection),
 		decisions:        make(map[string]*StoredDecision),
 		recoveryRequests: make(map[string]*RecoveryRequest),
+		logger:           logger,
+		authenticator:    newAuthenticator(logger),
 		upgrader: websocket.Upgrader{
-			CheckOrigin: func(r *http.Request) bool {
-				return true // Allow all origins in development
-			},
 			ReadBufferSize:  1024,
 			WriteBufferSize: 1024,
 		},
 	}
+	ws.upgrader.CheckOrigin = ws.checkOrigin
 
 	// Initialize AI agent with all infrastructure components
 	ws.initializeAIAgent(cfg, awsClient, logger)
 
+	store, err := keystore.NewFromEnv(cfg.State.FilePath, awsClient, logger)
+	if err != nil {
+		logger.WithError(err).Warn("Keystore unavailable - private key downloads are disabled")
+	}
+	ws.keystore = store
+	ws.region = cfg.AWS.Region
+
 	ws.setupRoutes()
 
 	return ws
@@ -133,6 +157,16 @@ func (ws *WebServer) initializeAIAgent(cfg *config.Config, awsClient *aws.Client
 		return
 	}
 
+	// Serve the agent's tools from this process instead of a server subprocess when configured
+	switch mode := agent.MCPModeFromEnv(); mode {
+	case agent.MCPModeInProcess:
+		logger.Info("Using in-process MCP server for the AI agent")
+		aiAgent.UseInProcessMCP(mcpserver.NewServer(cfg, awsClient, logger))
+	case agent.MCPModeSubprocess:
+	default:
+		logger.WithField("mode", mode).Warn("Unknown MCP mode, using an MCP server subprocess")
+	}
+
 	// Initialize the agent
 	if err := aiAgent.Initialize(context.Background()); err != nil {
 		logger.WithError(err).Error("Failed to initialize AI agent - running in demo mode")
@@ -149,7 +183,7 @@ func (ws *WebServer) corsMiddleware(next http.Handler) http.Handler {
 		// Set CORS headers
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, Authorization")
+		w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, Authorization, "+auth.APIKeyHeader+", "+downloadTokenHeader)
 
 		// Handle preflight requests
 		if r.Method == "OPTIONS" {
@@ -178,9 +212,10 @@ func (ws *WebServer) setupRoutes() {
 	// Health check endpoint
 	ws.router.HandleFunc("/health", ws.healthHandler).Methods("GET")
 
-	// API routes with CORS middleware
+	// API routes with CORS and authentication middleware
 	api := ws.router.PathPrefix("/api").Subrouter()
 	api.Use(ws.corsMiddleware) // Apply CORS middleware to all API routes
+	api.Use(ws.requireAuthentication)
 	api.HandleFunc("/health", ws.healthHandler).Methods("GET")
 	api.HandleFunc("/state", ws.getStateHandler).Methods("GET")
 	api.HandleFunc("/discover", ws.discoverInfrastructureHandler).Methods("POST")
@@ -190,6 +225,9 @@ func (ws *WebServer) setupRoutes() {
 	api.HandleFunc("/agent/process", ws.processRequestHandler).Methods("POST")
 	api.HandleFunc("/agent/execute", ws.executeConfirmedPlanHandler).Methods("POST")
 	api.HandleFunc("/export", ws.exportStateHandler).Methods("GET")
+	api.HandleFunc("/cost/report", ws.costReportHandler).Methods("GET")
+	api.HandleFunc("/cost/cleanup", ws.costCleanupHandler).Methods("POST")
+	api.HandleFunc("/keypairs/{keyName}/private-key", ws.downloadPrivateKeyHandler).Methods("GET")
 
 	// Handle OPTIONS requests for all API routes
 	api.HandleFunc("/{path:.*}", func(w http.ResponseWriter, r *http.Request) {
@@ -197,7 +235,7 @@ func (ws *WebServer) setupRoutes() {
 		w.WriteHeader(http.StatusOK)
 	}).Methods("OPTIONS")
 
-	// WebSocket for real-time updates
+	// WebSocket for real-time updates, authenticated by websocketHandler
 	ws.router.HandleFunc("/ws", ws.websocketHandler)
 
 	// Handler for React Router
@@ -224,6 +262,9 @@ func (ws *WebServer) reactHandler(w http.ResponseWriter, r *http.Request) {
 func (ws *WebServer) Start(port int) error {
 	addr := fmt.Sprintf(":%d", port)
 	ws.aiAgent.Logger.WithField("port", port).Info("Starting web server")
+	if !ws.authenticator.Enabled() {
+		ws.warnAuthenticationDisabled(addr)
+	}
 
 	return http.ListenAndServe(addr, ws.router)
 }
@@ -451,8 +492,9 @@ func (ws *WebServer) processRequestHandler(w http.ResponseWriter, r *http.Reques
 	}
 
 	ws.aiAgent.Logger.WithFields(map[string]interface{}{
-		"request": request,
-		"dry_run": dryRun,
+		"request":   request,
+		"dry_run":   dryRun,
+		"principal": auth.PrincipalFromContext(ctx).Subject,
 	}).Info("Processing request with AI agent")
 
 	// Notify WebSocket clients that processing has started
@@ -471,21 +513,39 @@ func (ws *WebServer) processRequestHandler(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	ws.respondWithDecision(w, r, request, dryRun, decision)
+}
+
+// respondWithDecision stores a decision for confirmation, writes it as the response of a
+// request and notifies WebSocket clients that processing completed
+func (ws *WebServer) respondWithDecision(w http.ResponseWriter, r *http.Request, request string, dryRun bool, decision *types.AgentDecision) {
+	// Record who asked for the decision
+	decision.Principal = auth.PrincipalFromContext(r.Context())
+
 	// Store the decision for later execution
 	ws.storeDecisionWithDryRun(decision, dryRun)
 
+	// Plans denied by a guardrail policy are shown but cannot be confirmed, and plans matching
+	// a "confirm" policy (such as a budget threshold) need an extra confirmation
+	policyDenied := policy.Denied(decision.PolicyViolations)
+	requiresExtraConfirmation := !policyDenied && policy.RequiresConfirmation(decision.PolicyViolations)
+
 	// Build response with execution plan (without executing yet)
 	response := map[string]interface{}{
-		"request":              request,
-		"dry_run":              dryRun,
-		"mode":                 "live",
-		"decision":             decision,
-		"executionPlan":        decision.ExecutionPlan,
-		"confidence":           decision.Confidence,
-		"action":               decision.Action,
-		"reasoning":            decision.Reasoning,
-		"requiresConfirmation": true,
-		"timestamp":            time.Now(),
+		"request":                   request,
+		"dry_run":                   dryRun,
+		"mode":                      "live",
+		"decision":                  decision,
+		"executionPlan":             decision.ExecutionPlan,
+		"confidence":                decision.Confidence,
+		"action":                    decision.Action,
+		"reasoning":                 decision.Reasoning,
+		"policyViolations":          decision.PolicyViolations,
+		"policyDenied":              policyDenied,
+		"requiresConfirmation":      !policyDenied,
+		"requiresExtraConfirmation": requiresExtraConfirmation,
+		"costEstimate":              decision.CostEstimate,
+		"timestamp":                 time.Now(),
 	}
 
 	if err := json.NewEncoder(w).Encode(response); err != nil {
@@ -496,18 +556,108 @@ func (ws *WebServer) processRequestHandler(w http.ResponseWriter, r *http.Reques
 
 	// Notify WebSocket clients that processing has completed
 	ws.broadcastUpdate(map[string]interface{}{
-		"type":                 "processing_completed",
-		"request":              request,
-		"decisionId":           decision.ID,
-		"success":              true,
-		"requiresConfirmation": true,
-		"timestamp":            time.Now(),
+		"type":                      "processing_completed",
+		"request":                   request,
+		"decisionId":                decision.ID,
+		"success":                   true,
+		"policyDenied":              policyDenied,
+		"requiresConfirmation":      !policyDenied,
+		"requiresExtraConfirmation": requiresExtraConfirmation,
+		"timestamp":                 time.Now(),
+	})
+}
+
+func (ws *WebServer) costReportHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if ws.aiAgent == nil {
+		http.Error(w, "AI agent not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	// Live discovery finds the volumes, Elastic IPs and route tables idle checks need
+	scanLive := r.URL.Query().Get("cache_only") != "true"
+
+	report, err := ws.aiAgent.GenerateCostReport(r.Context(), scanLive)
+	if err != nil {
+		ws.aiAgent.Logger.WithError(err).Error("Failed to generate cost report")
+		http.Error(w, fmt.Sprintf("Cost report failed: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{
+		"report":    report,
+		"scan_live": scanLive,
+		"timestamp": time.Now(),
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		ws.aiAgent.Logger.WithError(err).Error("Failed to encode cost report response")
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+func (ws *WebServer) costCleanupHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	var cleanupRequest struct {
+		// ResourceIDs selects the findings to clean up, all of them when empty
+		ResourceIDs []string `json:"resourceIds"`
+		DryRun      *bool    `json:"dry_run"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&cleanupRequest); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if ws.aiAgent == nil {
+		http.Error(w, "AI agent not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	dryRun := true
+	if cleanupRequest.DryRun != nil {
+		dryRun = *cleanupRequest.DryRun
+	}
+
+	ctx := r.Context()
+	report, err := ws.aiAgent.GenerateCostReport(ctx, true)
+	if err != nil {
+		ws.aiAgent.Logger.WithError(err).Error("Failed to generate cost report for cleanup")
+		http.Error(w, fmt.Sprintf("Cost report failed: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	request := ws.aiAgent.BuildCleanupRequest(report, cleanupRequest.ResourceIDs)
+	if request == "" {
+		http.Error(w, "No cleanup findings for the selected resources", http.StatusNotFound)
+		return
+	}
+
+	ws.broadcastUpdate(map[string]interface{}{
+		"type":      "processing_started",
+		"request":   request,
+		"dry_run":   dryRun,
+		"timestamp": time.Now(),
 	})
+
+	decision, err := ws.aiAgent.PlanCostCleanup(ctx, report, cleanupRequest.ResourceIDs)
+	if err != nil {
+		ws.aiAgent.Logger.WithError(err).Error("Cleanup planning failed")
+		http.Error(w, fmt.Sprintf("AI processing failed: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	ws.respondWithDecision(w, r, request, dryRun, decision)
 }
 
 func (ws *WebServer) executeConfirmedPlanHandler(w http.ResponseWriter, r *http.Request) {
 	var executeRequest struct {
 		DecisionID string `json:"decisionId"`
+
+		// ExtraConfirmation confirms a plan that matched a "confirm" policy, e.g. a budget threshold
+		ExtraConfirmation bool `json:"extraConfirmation"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&executeRequest); err != nil {
@@ -521,7 +671,11 @@ func (ws *WebServer) executeConfirmedPlanHandler(w http.ResponseWriter, r *http.
 		return
 	}
 
-	ws.aiAgent.Logger.WithField("decision_id", executeRequest.DecisionID).Info("Executing confirmed plan")
+	principal := auth.PrincipalFromContext(r.Context())
+	ws.aiAgent.Logger.WithFields(map[string]interface{}{
+		"decision_id": executeRequest.DecisionID,
+		"principal":   principal.Subject,
+	}).Info("Executing confirmed plan")
 
 	// Retrieve the stored decision with dry run flag
 	decision, dryRun, exists := ws.getStoredDecisionWithDryRun(executeRequest.DecisionID)
@@ -531,6 +685,18 @@ func (ws *WebServer) executeConfirmedPlanHandler(w http.ResponseWriter, r *http.
 		return
 	}
 
+	if policy.Denied(decision.PolicyViolations) {
+		ws.aiAgent.Logger.WithField("decision_id", executeRequest.DecisionID).Warn("Refusing to execute plan denied by guardrail policy")
+		http.Error(w, fmt.Sprintf("Plan denied by guardrail policy: %s", policy.Summary(decision.PolicyViolations)), http.StatusForbidden)
+		return
+	}
+
+	if policy.RequiresConfirmation(decision.PolicyViolations) && !executeRequest.ExtraConfirmation {
+		ws.aiAgent.Logger.WithField("decision_id", executeRequest.DecisionID).Warn("Refusing to execute plan that needs an extra confirmation")
+		http.Error(w, fmt.Sprintf("Plan requires extra confirmation (set extraConfirmation): %s", policy.Summary(decision.PolicyViolations)), http.StatusPreconditionRequired)
+		return
+	}
+
 	// Create a buffered progress channel to avoid blocking
 	progressChan := make(chan *types.ExecutionUpdate, 100)
 
@@ -542,6 +708,9 @@ func (ws *WebServer) executeConfirmedPlanHandler(w http.ResponseWriter, r *http.
 		ctx, cancel := context.WithTimeout(context.Background(), time.Minute*10)
 		defer cancel()
 
+		// The execution records who confirmed it
+		ctx = auth.WithPrincipal(ctx, principal)
+
 		ws.aiAgent.Logger.WithFields(map[string]interface{}{
 			"decision_id": executeRequest.DecisionID,
 			"dry_run":     dryRun,
@@ -627,6 +796,11 @@ func (ws *WebServer) exportStateHandler(w http.ResponseWriter, r *http.Request)
 
 // WebSocket handler for real-time updates
 func (ws *WebServer) websocketHandler(w http.ResponseWriter, r *http.Request) {
+	principal, ok := ws.authenticate(w, r)
+	if !ok {
+		return
+	}
+
 	conn, err := ws.upgrader.Upgrade(w, r, nil)
 	if err != nil {
 		ws.aiAgent.Logger.WithError(err).Error("Failed to upgrade WebSocket")
@@ -639,12 +813,16 @@ func (ws *WebServer) websocketHandler(w http.ResponseWriter, r *http.Request) {
 	// Store connection
 	ws.connMutex.Lock()
 	ws.connections[connID] = &wsConnection{
-		conn:     conn,
-		lastPong: time.Now(),
+		conn:      conn,
+		lastPong:  time.Now(),
+		principal: principal,
 	}
 	ws.connMutex.Unlock()
 
-	ws.aiAgent.Logger.WithField("conn_id", connID).Info("WebSocket connection established")
+	ws.aiAgent.Logger.WithFields(map[string]interface{}{
+		"conn_id":   connID,
+		"principal": principal.Subject,
+	}).Info("WebSocket connection established")
 
 	// Setup connection cleanup
 	defer func() {
@@ -761,7 +939,7 @@ func (ws *WebServer) sendStateUpdate(connID string) {
 	}
 
 	wsConn.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
-	if err := wsConn.conn.WriteJSON(update); err != nil {
+	if err := wsConn.conn.WriteJSON(redactUpdate(update)); err != nil {
 		ws.aiAgent.Logger.WithError(err).WithField("conn_id", connID).Debug("Failed to send state update, connection likely closed")
 		// Connection is broken, it will be cleaned up by the main handler
 	}
@@ -769,6 +947,8 @@ func (ws *WebServer) sendStateUpdate(connID string) {
 
 // broadcastUpdate sends an update to all active WebSocket connections
 func (ws *WebServer) broadcastUpdate(update map[string]interface{}) {
+	payload := redactUpdate(update)
+
 	ws.connMutex.RLock()
 	connections := make(map[string]*wsConnection)
 	for id, conn := range ws.connections {
@@ -778,7 +958,7 @@ func (ws *WebServer) broadcastUpdate(update map[string]interface{}) {
 
 	for connID, wsConn := range connections {
 		wsConn.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
-		if err := wsConn.conn.WriteJSON(update); err != nil {
+		if err := wsConn.conn.WriteJSON(payload); err != nil {
 			ws.aiAgent.Logger.WithError(err).WithField("conn_id", connID).Debug("Failed to broadcast update, removing connection")
 			// Remove broken connection
 			ws.connMutex.Lock()
@@ -789,6 +969,20 @@ func (ws *WebServer) broadcastUpdate(update map[string]interface{}) {
 	}
 }
 
+// redactUpdate masks secrets in a websocket update, including those inside decisions, plans
+// and step results. Updates that cannot be serialized are replaced rather than sent unredacted.
+func redactUpdate(update map[string]interface{}) interface{} {
+	redacted, err := redact.Default().Structured(update)
+	if err != nil {
+		return map[string]interface{}{
+			"type":      update["type"],
+			"error":     "update could not be redacted",
+			"timestamp": time.Now(),
+		}
+	}
+	return redacted
+}
+
 // storeDecisionWithDryRun stores a decision with dry run flag for later execution
 func (ws *WebServer) storeDecisionWithDryRun(decision *types.AgentDecision, dryRun bool) {
 	ws.decisionsMutex.Lock()
@@ -838,17 +1032,27 @@ func (ws *WebServer) handleIncomingMessage(connID string, msgData []byte) {
 		return
 	}
 
+	ws.connMutex.RLock()
+	conn, exists := ws.connections[connID]
+	ws.connMutex.RUnlock()
+	if !exists {
+		ws.aiAgent.Logger.WithField("conn_id", connID).Warn("Ignoring WebSocket message of a closed connection")
+		return
+	}
+	principal := conn.principal
+
 	ws.aiAgent.Logger.WithFields(logrus.Fields{
-		"conn_id": connID,
-		"type":    message.Type,
-		"step_id": message.StepID,
+		"conn_id":   connID,
+		"type":      message.Type,
+		"step_id":   message.StepID,
+		"principal": principal.Subject,
 	}).Debug("Received WebSocket message")
 
 	switch message.Type {
 	case "recovery_decision":
-		ws.handleRecoveryDecision(message)
+		ws.handleRecoveryDecision(message, principal)
 	case "recovery_abort":
-		ws.handleRecoveryAbort(message)
+		ws.handleRecoveryAbort(message, principal)
 	default:
 		ws.aiAgent.Logger.WithFields(logrus.Fields{
 			"conn_id": connID,
@@ -858,10 +1062,11 @@ func (ws *WebServer) handleIncomingMessage(connID string, msgData []byte) {
 }
 
 // handleRecoveryDecision processes user's recovery decision
-func (ws *WebServer) handleRecoveryDecision(message RecoveryMessage) {
+func (ws *WebServer) handleRecoveryDecision(message RecoveryMessage, principal *types.Principal) {
 	ws.aiAgent.Logger.WithFields(logrus.Fields{
 		"step_id":               message.StepID,
 		"selected_option_index": message.SelectedOptionIndex,
+		"principal":             principal.Subject,
 	}).Info("Processing recovery decision")
 
 	// Find the pending recovery request
@@ -889,8 +1094,11 @@ func (ws *WebServer) handleRecoveryDecision(message RecoveryMessage) {
 }
 
 // handleRecoveryAbort processes user's recovery abort decision
-func (ws *WebServer) handleRecoveryAbort(message RecoveryMessage) {
-	ws.aiAgent.Logger.WithField("step_id", message.StepID).Info("Processing recovery abort")
+func (ws *WebServer) handleRecoveryAbort(message RecoveryMessage, principal *types.Principal) {
+	ws.aiAgent.Logger.WithFields(logrus.Fields{
+		"step_id":   message.StepID,
+		"principal": principal.Subject,
+	}).Info("Processing recovery abort")
 
 	// Find the pending recovery request
 	ws.recoveryMutex.Lock()
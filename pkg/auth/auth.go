@@ -0,0 +1,302 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/versus-control/ai-infrastructure-agent/pkg/types"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFileEnvVar points at the web API authentication file, DefaultConfigFile when unset
+const ConfigFileEnvVar = "API_AUTH_FILE"
+
+// DefaultConfigFile holds the web API authentication settings shipped with the agent
+const DefaultConfigFile = "settings/api-auth.yaml"
+
+// Authentication methods recorded on principals
+const (
+	MethodAPIKey    = "api-key"
+	MethodJWT       = "jwt"
+	MethodAnonymous = "anonymous"
+)
+
+// APIKeyHeader carries an API key for clients that do not send it as a bearer token
+const APIKeyHeader = "X-API-Key"
+
+// accessTokenParam carries the credential of websocket clients, which cannot set headers from
+// a browser
+const accessTokenParam = "access_token"
+
+// Errors returned by Authenticate
+var (
+	// ErrNoCredentials means the request carries no API key or bearer token
+	ErrNoCredentials = errors.New("no credentials")
+
+	// ErrInvalidCredentials means the credential matches no API key and is not a valid token
+	ErrInvalidCredentials = errors.New("invalid credentials")
+)
+
+// Config holds the authentication settings of the web API
+type Config struct {
+	// Enabled requires a valid API key or bearer token on every API and websocket request.
+	// Disabled requests are served as an anonymous principal.
+	Enabled bool `yaml:"enabled"`
+
+	// APIKeys are static keys, typically for automation
+	APIKeys []APIKey `yaml:"apiKeys"`
+
+	// JWT accepts bearer tokens of an OIDC issuer when its issuer is set
+	JWT JWTConfig `yaml:"jwt"`
+
+	// AllowedOrigins may open websockets besides the origin serving the UI, e.g.
+	// "http://localhost:3000" for the UI development server
+	AllowedOrigins []string `yaml:"allowedOrigins"`
+}
+
+// APIKey is a named static API key. The key is either given as ${NAME} of an environment
+// variable or as the hex SHA-256 of the key, so the file never holds it in clear text.
+type APIKey struct {
+	Name   string `yaml:"name"`
+	Key    string `yaml:"key"`
+	SHA256 string `yaml:"sha256"`
+}
+
+// Authenticator checks the credentials of web API requests
+type Authenticator struct {
+	config  *Config
+	apiKeys map[string][sha256.Size]byte
+	jwt     *JWTVerifier
+}
+
+type principalKey struct{}
+
+// DefaultConfig returns the settings used when no authentication file is configured:
+// authentication disabled and websockets limited to the origin serving the UI
+func DefaultConfig() *Config {
+	return &Config{}
+}
+
+// LoadFile reads an authentication file
+func LoadFile(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	config := DefaultConfig()
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("failed to parse authentication file %s: %w", path, err)
+	}
+	if err := config.validate(); err != nil {
+		return nil, fmt.Errorf("invalid authentication file %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// LoadConfigured loads the file named by API_AUTH_FILE, or the default authentication file.
+// Without either the default settings are used.
+func LoadConfigured() (*Config, error) {
+	if path := os.Getenv(ConfigFileEnvVar); path != "" {
+		return LoadFile(path)
+	}
+
+	config, err := LoadFile(DefaultConfigFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return DefaultConfig(), nil
+	}
+	return config, err
+}
+
+// validate checks the API keys can be matched and at least one method is configured when
+// authentication is enabled
+func (c *Config) validate() error {
+	names := make(map[string]bool)
+	for i, key := range c.APIKeys {
+		if key.Name == "" {
+			return fmt.Errorf("API key %d has no name", i)
+		}
+		if names[key.Name] {
+			return fmt.Errorf("API key %s is listed twice", key.Name)
+		}
+		names[key.Name] = true
+
+		if (key.Key == "") == (key.SHA256 == "") {
+			return fmt.Errorf("API key %s needs exactly one of key or sha256", key.Name)
+		}
+		if key.SHA256 != "" {
+			if digest, err := hex.DecodeString(key.SHA256); err != nil || len(digest) != sha256.Size {
+				return fmt.Errorf("API key %s: sha256 must be 64 hex characters", key.Name)
+			}
+		}
+	}
+
+	if c.JWT.Issuer != "" {
+		if err := checkIssuerURL(c.JWT.Issuer); err != nil {
+			return fmt.Errorf("jwt issuer: %w", err)
+		}
+		if len(c.JWT.Audiences) == 0 {
+			return fmt.Errorf("jwt: audiences are required, so tokens issued to other applications are rejected")
+		}
+	}
+	if c.Enabled && len(c.APIKeys) == 0 && c.JWT.Issuer == "" {
+		return fmt.Errorf("authentication is enabled but no API key or JWT issuer is configured")
+	}
+
+	for _, origin := range c.AllowedOrigins {
+		if parsed, err := url.Parse(origin); err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			return fmt.Errorf("allowed origin %q must be a scheme and host, e.g. http://localhost:3000", origin)
+		}
+	}
+	return nil
+}
+
+// New creates the authenticator of a configuration. API keys given as ${NAME} are read from
+// the environment and must be set.
+func New(config *Config) (*Authenticator, error) {
+	authenticator := &Authenticator{
+		config:  config,
+		apiKeys: make(map[string][sha256.Size]byte),
+	}
+
+	for _, key := range config.APIKeys {
+		var digest [sha256.Size]byte
+		if key.SHA256 != "" {
+			decoded, _ := hex.DecodeString(key.SHA256)
+			copy(digest[:], decoded)
+		} else {
+			value := os.ExpandEnv(key.Key)
+			if value == "" {
+				return nil, fmt.Errorf("API key %s expands to an empty value", key.Name)
+			}
+			digest = sha256.Sum256([]byte(value))
+		}
+		authenticator.apiKeys[key.Name] = digest
+	}
+
+	if config.JWT.Issuer != "" {
+		verifier, err := NewJWTVerifier(config.JWT)
+		if err != nil {
+			return nil, err
+		}
+		authenticator.jwt = verifier
+	}
+
+	return authenticator, nil
+}
+
+// Enabled reports whether requests must authenticate
+func (a *Authenticator) Enabled() bool {
+	return a.config.Enabled
+}
+
+// Authenticate returns the principal of a request. With authentication disabled every
+// request is anonymous.
+func (a *Authenticator) Authenticate(r *http.Request) (*types.Principal, error) {
+	if !a.config.Enabled {
+		return &types.Principal{Subject: MethodAnonymous, Method: MethodAnonymous}, nil
+	}
+
+	credential := credentialFromRequest(r)
+	if credential == "" {
+		return nil, ErrNoCredentials
+	}
+
+	if name, ok := a.matchAPIKey(credential); ok {
+		return &types.Principal{Subject: name, Name: name, Method: MethodAPIKey}, nil
+	}
+
+	// API keys are opaque, so only credentials shaped like a JWT are verified as one
+	if a.jwt != nil && strings.Count(credential, ".") == 2 {
+		principal, err := a.jwt.Verify(r.Context(), credential)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidCredentials, err)
+		}
+		return principal, nil
+	}
+
+	return nil, ErrInvalidCredentials
+}
+
+// matchAPIKey returns the name of the API key matching a credential. Every key is compared,
+// in constant time, so the time taken does not tell which key was close.
+func (a *Authenticator) matchAPIKey(credential string) (string, bool) {
+	digest := sha256.Sum256([]byte(credential))
+
+	matched := ""
+	for name, keyDigest := range a.apiKeys {
+		if subtle.ConstantTimeCompare(digest[:], keyDigest[:]) == 1 {
+			matched = name
+		}
+	}
+	return matched, matched != ""
+}
+
+// OriginAllowed reports whether a websocket may be opened from the origin of a request: the
+// origin serving the UI, or one of the allowed origins. Requests without an Origin header do
+// not come from a browser and are allowed.
+func (a *Authenticator) OriginAllowed(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	parsed, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	if strings.EqualFold(parsed.Host, r.Host) {
+		return true
+	}
+
+	for _, allowed := range a.config.AllowedOrigins {
+		if strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// BearerToken returns the token of a request's Authorization header, empty unless the header
+// uses the Bearer scheme
+func BearerToken(r *http.Request) string {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+// credentialFromRequest returns the bearer token, API key header or, for websockets, the
+// access_token query parameter of a request
+func credentialFromRequest(r *http.Request) string {
+	if r.Header.Get("Authorization") != "" {
+		return BearerToken(r)
+	}
+	if key := r.Header.Get(APIKeyHeader); key != "" {
+		return strings.TrimSpace(key)
+	}
+	if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+		return r.URL.Query().Get(accessTokenParam)
+	}
+	return ""
+}
+
+// WithPrincipal returns a context carrying the authenticated principal
+func WithPrincipal(ctx context.Context, principal *types.Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns the authenticated principal of a context, nil when there is none
+func PrincipalFromContext(ctx context.Context) *types.Principal {
+	principal, _ := ctx.Value(principalKey{}).(*types.Principal)
+	return principal
+}
@@ -0,0 +1,369 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testIssuer is a local OIDC issuer publishing one RSA signing key
+type testIssuer struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+	keyID  string
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate signing key: %v", err)
+	}
+	issuer := &testIssuer{key: key, keyID: "test-key"}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":   issuer.server.URL,
+			"jwks_uri": issuer.server.URL + "/keys",
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": issuer.keyID,
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	issuer.server = httptest.NewServer(mux)
+	t.Cleanup(issuer.server.Close)
+	return issuer
+}
+
+// sign issues an RS256 token with the given claims
+func (i *testIssuer) sign(t *testing.T, claims map[string]interface{}) string {
+	t.Helper()
+
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": i.keyID})
+	payload, _ := json.Marshal(claims)
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, i.key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func (i *testIssuer) claims() map[string]interface{} {
+	return map[string]interface{}{
+		"iss":   i.server.URL,
+		"aud":   "ai-infrastructure-agent",
+		"sub":   "user-1234",
+		"email": "alex@example.com",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func newTestAuthenticator(t *testing.T, issuer *testIssuer) *Authenticator {
+	t.Helper()
+	t.Setenv("TEST_CI_API_KEY", "ci-secret-key")
+
+	config := &Config{
+		Enabled: true,
+		APIKeys: []APIKey{{Name: "ci-pipeline", Key: "${TEST_CI_API_KEY}"}},
+		JWT: JWTConfig{
+			Issuer:    issuer.server.URL,
+			Audiences: []string{"ai-infrastructure-agent"},
+		},
+	}
+	if err := config.validate(); err != nil {
+		t.Fatalf("valid configuration rejected: %v", err)
+	}
+	authenticator, err := New(config)
+	if err != nil {
+		t.Fatalf("failed to create authenticator: %v", err)
+	}
+	return authenticator
+}
+
+func requestWithToken(token string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/api/agent/execute", nil)
+	if token != "" {
+		r.Header.Set("Authorization", "Bearer "+token)
+	}
+	return r
+}
+
+func TestAuthenticateJWTFromLocalIssuer(t *testing.T) {
+	issuer := newTestIssuer(t)
+	authenticator := newTestAuthenticator(t, issuer)
+
+	principal, err := authenticator.Authenticate(requestWithToken(issuer.sign(t, issuer.claims())))
+	if err != nil {
+		t.Fatalf("valid token rejected: %v", err)
+	}
+	if principal.Subject != "user-1234" || principal.Name != "alex@example.com" || principal.Method != MethodJWT {
+		t.Fatalf("unexpected principal %+v", principal)
+	}
+}
+
+func TestAuthenticateRejectsInvalidTokens(t *testing.T) {
+	issuer := newTestIssuer(t)
+	authenticator := newTestAuthenticator(t, issuer)
+
+	expired := issuer.claims()
+	expired["exp"] = time.Now().Add(-time.Hour).Unix()
+
+	otherAudience := issuer.claims()
+	otherAudience["aud"] = "another-application"
+
+	otherIssuer := issuer.claims()
+	otherIssuer["iss"] = "https://issuer.example.com"
+
+	valid := issuer.sign(t, issuer.claims())
+	tampered := valid[:strings.LastIndex(valid, ".")] + "." + base64.RawURLEncoding.EncodeToString([]byte("not-a-signature"))
+	unsigned := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`)) + "." + strings.Split(valid, ".")[1] + "."
+
+	tokens := map[string]string{
+		"expired":        issuer.sign(t, expired),
+		"other audience": issuer.sign(t, otherAudience),
+		"other issuer":   issuer.sign(t, otherIssuer),
+		"bad signature":  tampered,
+		"alg none":       unsigned,
+		"unknown key":    "wrong-key",
+	}
+	for name, token := range tokens {
+		if principal, err := authenticator.Authenticate(requestWithToken(token)); err == nil {
+			t.Errorf("%s: token accepted as %+v", name, principal)
+		}
+	}
+
+	if _, err := authenticator.Authenticate(requestWithToken("")); err != ErrNoCredentials {
+		t.Errorf("request without credentials: got %v, want ErrNoCredentials", err)
+	}
+}
+
+func TestAuthenticateAPIKey(t *testing.T) {
+	issuer := newTestIssuer(t)
+	authenticator := newTestAuthenticator(t, issuer)
+
+	principal, err := authenticator.Authenticate(requestWithToken("ci-secret-key"))
+	if err != nil {
+		t.Fatalf("valid API key rejected: %v", err)
+	}
+	if principal.Subject != "ci-pipeline" || principal.Method != MethodAPIKey {
+		t.Fatalf("unexpected principal %+v", principal)
+	}
+
+	r := requestWithToken("")
+	r.Header.Set(APIKeyHeader, "ci-secret-key")
+	if _, err := authenticator.Authenticate(r); err != nil {
+		t.Fatalf("API key header rejected: %v", err)
+	}
+}
+
+func TestOriginAllowed(t *testing.T) {
+	authenticator, err := New(&Config{AllowedOrigins: []string{"http://localhost:3000"}})
+	if err != nil {
+		t.Fatalf("failed to create authenticator: %v", err)
+	}
+
+	origins := map[string]bool{
+		"":                      true,
+		"http://agent.internal": true,
+		"http://localhost:3000": true,
+		"https://evil.example":  false,
+		"http://localhost:3001": false,
+	}
+	for origin, allowed := range origins {
+		r := httptest.NewRequest(http.MethodGet, "http://agent.internal/ws", nil)
+		if origin != "" {
+			r.Header.Set("Origin", origin)
+		}
+		if got := authenticator.OriginAllowed(r); got != allowed {
+			t.Errorf("origin %q: allowed = %v, want %v", origin, got, allowed)
+		}
+	}
+}
+
+func TestBearerToken(t *testing.T) {
+	headers := map[string]string{
+		"":                   "",
+		"Bearer abc123":      "abc123",
+		"bearer abc123":      "abc123",
+		"Bearer  abc123 ":    "abc123",
+		"Basic YWxpY2U6cHc=": "",
+		"Bearerabc123":       "",
+		"abc123":             "",
+	}
+	for header, want := range headers {
+		r := httptest.NewRequest(http.MethodGet, "http://agent.internal/api/keypairs", nil)
+		if header != "" {
+			r.Header.Set("Authorization", header)
+		}
+		if got := BearerToken(r); got != want {
+			t.Errorf("Authorization %q: token = %q, want %q", header, got, want)
+		}
+	}
+}
+
+func TestSigningKeyFetchDoesNotBlockCachedKeys(t *testing.T) {
+	issuer := newTestIssuer(t)
+
+	// The key set of a slow issuer is only served once released
+	release := make(chan struct{})
+	var fetches int32
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&fetches, 1)
+		<-release
+		w.Write([]byte(`{"keys":[]}`))
+	}))
+	t.Cleanup(slow.Close)
+	defer close(release)
+
+	verifier, err := NewJWTVerifier(JWTConfig{Issuer: issuer.server.URL, JWKSURL: slow.URL, Audiences: []string{"ai-infrastructure-agent"}})
+	if err != nil {
+		t.Fatalf("failed to create verifier: %v", err)
+	}
+	verifier.keys = map[string]crypto.PublicKey{issuer.keyID: &issuer.key.PublicKey}
+
+	// Two requests for a rotated key share one fetch
+	results := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, err := verifier.signingKey(context.Background(), "rotated-key")
+			results <- err
+		}()
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for atomic.LoadInt32(&fetches) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// The cached key is served while the fetch is in progress
+	done := make(chan error, 1)
+	go func() {
+		_, err := verifier.Verify(context.Background(), issuer.sign(t, issuer.claims()))
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("token signed with a cached key rejected: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("verification waited for the key set fetch")
+	}
+
+	release <- struct{}{}
+	for i := 0; i < 2; i++ {
+		select {
+		case err := <-results:
+			if err == nil {
+				t.Fatal("rotated key found in an empty key set")
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("request for the rotated key did not return")
+		}
+	}
+	if n := atomic.LoadInt32(&fetches); n != 1 {
+		t.Fatalf("key set fetched %d times, want once", n)
+	}
+}
+
+func TestSigningKeyFetchOutlivesCancelledRequest(t *testing.T) {
+	issuer := newTestIssuer(t)
+
+	release := make(chan struct{})
+	var fetches int32
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&fetches, 1)
+		<-release
+		issuer.server.Config.Handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/keys", nil))
+	}))
+	t.Cleanup(slow.Close)
+
+	verifier, err := NewJWTVerifier(JWTConfig{Issuer: issuer.server.URL, JWKSURL: slow.URL, Audiences: []string{"ai-infrastructure-agent"}})
+	if err != nil {
+		t.Fatalf("failed to create verifier: %v", err)
+	}
+
+	// The request that starts the fetch goes away while another one waits for it
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() {
+		_, err := verifier.signingKey(ctx, issuer.keyID)
+		first <- err
+	}()
+	deadline := time.Now().Add(5 * time.Second)
+	for atomic.LoadInt32(&fetches) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	second := make(chan error, 1)
+	go func() {
+		_, err := verifier.signingKey(context.Background(), issuer.keyID)
+		second <- err
+	}()
+
+	cancel()
+	if err := <-first; err != context.Canceled {
+		t.Fatalf("cancelled request returned %v", err)
+	}
+	close(release)
+	select {
+	case err := <-second:
+		if err != nil {
+			t.Fatalf("waiting request failed with the cancelled one: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("waiting request did not return")
+	}
+	if n := atomic.LoadInt32(&fetches); n != 1 {
+		t.Fatalf("key set fetched %d times, want once", n)
+	}
+}
+
+func TestSigningKeyFetchRetriesUnreachableIssuer(t *testing.T) {
+	issuer := newTestIssuer(t)
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	unreachable.Close()
+
+	verifier, err := NewJWTVerifier(JWTConfig{Issuer: issuer.server.URL, JWKSURL: unreachable.URL, Audiences: []string{"ai-infrastructure-agent"}})
+	if err != nil {
+		t.Fatalf("failed to create verifier: %v", err)
+	}
+	if _, err := verifier.signingKey(context.Background(), issuer.keyID); err == nil {
+		t.Fatal("key found at an unreachable issuer")
+	}
+
+	// A failed connection does not hold off the next fetch
+	verifier.jwksURL = issuer.server.URL + "/keys"
+	if _, err := verifier.signingKey(context.Background(), issuer.keyID); err != nil {
+		t.Fatalf("key set was not fetched again: %v", err)
+	}
+
+	// An answer does
+	verifier.keys = nil
+	verifier.jwksURL = unreachable.URL
+	if _, err := verifier.signingKey(context.Background(), issuer.keyID); err == nil || !strings.Contains(err.Error(), "unknown signing key") {
+		t.Fatalf("key set fetched again within the refresh interval: %v", err)
+	}
+}
@@ -0,0 +1,499 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	_ "crypto/sha256" // Registers SHA-256 for RS256, PS256 and ES256
+	_ "crypto/sha512" // Registers SHA-384 and SHA-512 for the 384 and 512 algorithms
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/versus-control/ai-infrastructure-agent/pkg/types"
+)
+
+// Defaults of the JWT settings
+const (
+	defaultSubjectClaim = "sub"
+	defaultNameClaim    = "email"
+	defaultClockSkew    = time.Minute
+)
+
+// jwksRefreshInterval limits how often the signing keys are fetched again for an unknown key ID
+const jwksRefreshInterval = time.Minute
+
+// jwksFetchTimeout bounds the discovery and key set requests to the issuer
+const jwksFetchTimeout = 10 * time.Second
+
+// maxJWKSSize is the largest discovery document or key set read from the issuer
+const maxJWKSSize = 1 << 20
+
+// JWTConfig accepts bearer tokens signed by an OIDC issuer
+type JWTConfig struct {
+	// Issuer must match the iss claim. Its signing keys are discovered from
+	// <issuer>/.well-known/openid-configuration unless JWKSURL is set. It must use https,
+	// except on loopback addresses so a local issuer can be used for testing.
+	Issuer string `yaml:"issuer"`
+
+	// Audiences accepted in the aud claim, e.g. the client ID of the agent
+	Audiences []string `yaml:"audiences"`
+
+	// JWKSURL overrides the discovered signing key set
+	JWKSURL string `yaml:"jwksUrl"`
+
+	// SubjectClaim and NameClaim identify the principal, "sub" and "email" by default
+	SubjectClaim string `yaml:"subjectClaim"`
+	NameClaim    string `yaml:"nameClaim"`
+
+	// ClockSkew tolerated when checking exp and nbf, one minute by default
+	ClockSkew time.Duration `yaml:"clockSkew"`
+}
+
+// JWTVerifier verifies bearer tokens of an OIDC issuer against its published signing keys
+type JWTVerifier struct {
+	config JWTConfig
+	client *http.Client
+
+	// The mutex guards the cached key set, never a fetch: requests keep being verified against
+	// the cached keys while a slow issuer is asked for new ones
+	mutex       sync.Mutex
+	jwksURL     string
+	keys        map[string]crypto.PublicKey
+	lastFetched time.Time
+	fetchErr    error         // Error of the last fetch, nil when it succeeded
+	fetching    chan struct{} // Closed when the fetch in progress ends, nil when none is
+}
+
+// jwtHeader holds the fields of the JOSE header the verifier uses
+type jwtHeader struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+}
+
+// jsonWebKey holds the fields of RSA and EC signing keys
+type jsonWebKey struct {
+	KeyType string `json:"kty"`
+	KeyID   string `json:"kid"`
+	Use     string `json:"use"`
+	N       string `json:"n"`
+	E       string `json:"e"`
+	Curve   string `json:"crv"`
+	X       string `json:"x"`
+	Y       string `json:"y"`
+}
+
+// signingAlgorithm describes how a JWS algorithm verifies a signature
+type signingAlgorithm struct {
+	hash  crypto.Hash
+	keyEC bool
+	pss   bool
+}
+
+// signingAlgorithms are the asymmetric algorithms accepted. "none" and the HMAC algorithms
+// are rejected, since the issuer's public keys must not double as shared secrets.
+var signingAlgorithms = map[string]signingAlgorithm{
+	"RS256": {hash: crypto.SHA256},
+	"RS384": {hash: crypto.SHA384},
+	"RS512": {hash: crypto.SHA512},
+	"PS256": {hash: crypto.SHA256, pss: true},
+	"PS384": {hash: crypto.SHA384, pss: true},
+	"PS512": {hash: crypto.SHA512, pss: true},
+	"ES256": {hash: crypto.SHA256, keyEC: true},
+	"ES384": {hash: crypto.SHA384, keyEC: true},
+	"ES512": {hash: crypto.SHA512, keyEC: true},
+}
+
+// NewJWTVerifier creates the verifier of an issuer. Signing keys are fetched on first use.
+func NewJWTVerifier(config JWTConfig) (*JWTVerifier, error) {
+	if err := checkIssuerURL(config.Issuer); err != nil {
+		return nil, fmt.Errorf("jwt issuer: %w", err)
+	}
+	if config.JWKSURL != "" {
+		if err := checkIssuerURL(config.JWKSURL); err != nil {
+			return nil, fmt.Errorf("jwt jwksUrl: %w", err)
+		}
+	}
+	if config.SubjectClaim == "" {
+		config.SubjectClaim = defaultSubjectClaim
+	}
+	if config.NameClaim == "" {
+		config.NameClaim = defaultNameClaim
+	}
+	if config.ClockSkew == 0 {
+		config.ClockSkew = defaultClockSkew
+	}
+
+	return &JWTVerifier{
+		config:  config,
+		client:  &http.Client{Timeout: jwksFetchTimeout},
+		jwksURL: config.JWKSURL,
+		keys:    make(map[string]crypto.PublicKey),
+	}, nil
+}
+
+// Verify checks the signature and claims of a token and returns its principal
+func (v *JWTVerifier) Verify(ctx context.Context, token string) (*types.Principal, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("malformed token")
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("malformed token header: %w", err)
+	}
+	algorithm, ok := signingAlgorithms[header.Algorithm]
+	if !ok {
+		return nil, fmt.Errorf("unsupported signing algorithm %q", header.Algorithm)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("malformed token signature: %w", err)
+	}
+	key, err := v.signingKey(ctx, header.KeyID)
+	if err != nil {
+		return nil, err
+	}
+	if err := algorithm.verify(key, []byte(parts[0]+"."+parts[1]), signature); err != nil {
+		return nil, err
+	}
+
+	var claims map[string]interface{}
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("malformed token claims: %w", err)
+	}
+	if err := v.checkClaims(claims, time.Now()); err != nil {
+		return nil, err
+	}
+
+	subject, _ := claims[v.config.SubjectClaim].(string)
+	if subject == "" {
+		return nil, fmt.Errorf("token has no %s claim", v.config.SubjectClaim)
+	}
+	name, _ := claims[v.config.NameClaim].(string)
+
+	return &types.Principal{
+		Subject: subject,
+		Name:    name,
+		Method:  MethodJWT,
+		Issuer:  v.config.Issuer,
+	}, nil
+}
+
+// checkClaims checks the issuer, audience and validity period of a token
+func (v *JWTVerifier) checkClaims(claims map[string]interface{}, now time.Time) error {
+	if issuer, _ := claims["iss"].(string); issuer != v.config.Issuer {
+		return fmt.Errorf("token issued by %q, expected %q", issuer, v.config.Issuer)
+	}
+
+	var audiences []string
+	switch aud := claims["aud"].(type) {
+	case string:
+		audiences = []string{aud}
+	case []interface{}:
+		for _, value := range aud {
+			if audience, ok := value.(string); ok {
+				audiences = append(audiences, audience)
+			}
+		}
+	}
+	if !containsAny(audiences, v.config.Audiences) {
+		return fmt.Errorf("token audience %v is not accepted", audiences)
+	}
+
+	expiresAt, ok := claims["exp"].(float64)
+	if !ok {
+		return fmt.Errorf("token has no exp claim")
+	}
+	if now.Add(-v.config.ClockSkew).After(time.Unix(int64(expiresAt), 0)) {
+		return fmt.Errorf("token expired")
+	}
+	if notBefore, ok := claims["nbf"].(float64); ok && now.Add(v.config.ClockSkew).Before(time.Unix(int64(notBefore), 0)) {
+		return fmt.Errorf("token is not valid yet")
+	}
+	return nil
+}
+
+// signingKey returns the signing key with a key ID, fetching the key set again when the key is
+// unknown, e.g. after the issuer rotated its keys. Concurrent requests for unknown keys share
+// one fetch.
+func (v *JWTVerifier) signingKey(ctx context.Context, keyID string) (crypto.PublicKey, error) {
+	v.mutex.Lock()
+	if key, ok := v.lookupKey(keyID); ok {
+		v.mutex.Unlock()
+		return key, nil
+	}
+
+	fetching := v.fetching
+	if fetching == nil {
+		if time.Since(v.lastFetched) < jwksRefreshInterval {
+			v.mutex.Unlock()
+			return nil, fmt.Errorf("unknown signing key %q", keyID)
+		}
+		fetching = make(chan struct{})
+		v.fetching = fetching
+		go v.refreshKeys(context.WithoutCancel(ctx), v.jwksURL, fetching)
+	}
+	v.mutex.Unlock()
+
+	select {
+	case <-fetching:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	if key, ok := v.lookupKey(keyID); ok {
+		return key, nil
+	}
+	if v.fetchErr != nil {
+		return nil, fmt.Errorf("failed to fetch signing keys of %s: %w", v.config.Issuer, v.fetchErr)
+	}
+	return nil, fmt.Errorf("unknown signing key %q", keyID)
+}
+
+// refreshKeys fetches the key set without the lock, swaps the cached key set and closes
+// fetching. The fetch is shared, so it runs on its own deadline rather than on the context of
+// the request that started it.
+func (v *JWTVerifier) refreshKeys(ctx context.Context, jwksURL string, fetching chan struct{}) {
+	ctx, cancel := context.WithTimeout(ctx, jwksFetchTimeout)
+	defer cancel()
+	jwksURL, keys, err := v.fetchKeys(ctx, jwksURL)
+
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	v.fetchErr = err
+	if err == nil {
+		v.jwksURL = jwksURL
+		v.keys = keys
+	}
+	// Back off once the issuer answered; an unreachable issuer is asked again by the next request
+	var transportErr *url.Error
+	if err == nil || !errors.As(err, &transportErr) {
+		v.lastFetched = time.Now()
+	}
+	v.fetching = nil
+	close(fetching)
+}
+
+// lookupKey finds a cached key. Tokens without a key ID are accepted when the issuer
+// publishes a single key. The caller holds the mutex.
+func (v *JWTVerifier) lookupKey(keyID string) (crypto.PublicKey, bool) {
+	if keyID == "" && len(v.keys) == 1 {
+		for _, key := range v.keys {
+			return key, true
+		}
+	}
+	key, ok := v.keys[keyID]
+	return key, ok
+}
+
+// fetchKeys loads the signing keys of the issuer, discovering the key set URL first when
+// jwksURL is empty. It returns the key set URL with the keys.
+func (v *JWTVerifier) fetchKeys(ctx context.Context, jwksURL string) (string, map[string]crypto.PublicKey, error) {
+	if jwksURL == "" {
+		var discovery struct {
+			Issuer  string `json:"issuer"`
+			JWKSURI string `json:"jwks_uri"`
+		}
+		discoveryURL := strings.TrimSuffix(v.config.Issuer, "/") + "/.well-known/openid-configuration"
+		if err := v.getJSON(ctx, discoveryURL, &discovery); err != nil {
+			return "", nil, err
+		}
+		if discovery.Issuer != v.config.Issuer {
+			return "", nil, fmt.Errorf("discovery document names issuer %q", discovery.Issuer)
+		}
+		if err := checkIssuerURL(discovery.JWKSURI); err != nil {
+			return "", nil, fmt.Errorf("jwks_uri: %w", err)
+		}
+		jwksURL = discovery.JWKSURI
+	}
+
+	var keySet struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := v.getJSON(ctx, jwksURL, &keySet); err != nil {
+		return "", nil, err
+	}
+
+	keys := make(map[string]crypto.PublicKey)
+	for _, jwk := range keySet.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			continue
+		}
+		keys[jwk.KeyID] = key
+	}
+	if len(keys) == 0 {
+		return "", nil, fmt.Errorf("key set has no usable signing key")
+	}
+	return jwksURL, keys, nil
+}
+
+// getJSON fetches and decodes a JSON document of the issuer
+func (v *JWTVerifier) getJSON(ctx context.Context, documentURL string, target interface{}) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, documentURL, nil)
+	if err != nil {
+		return err
+	}
+	response, err := v.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s returned %s", documentURL, response.Status)
+	}
+	if err := json.NewDecoder(http.MaxBytesReader(nil, response.Body, maxJWKSSize)).Decode(target); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", documentURL, err)
+	}
+	return nil
+}
+
+// publicKey converts an RSA or EC JSON web key
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.KeyType {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("RSA exponent out of range")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Curve {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Curve)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, fmt.Errorf("EC point is not on curve %s", k.Curve)
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	}
+	return nil, fmt.Errorf("unsupported key type %q", k.KeyType)
+}
+
+// verify checks a signature made with the algorithm
+func (a signingAlgorithm) verify(key crypto.PublicKey, signingInput, signature []byte) error {
+	hasher := a.hash.New()
+	hasher.Write(signingInput)
+	digest := hasher.Sum(nil)
+
+	switch publicKey := key.(type) {
+	case *rsa.PublicKey:
+		if a.keyEC {
+			return fmt.Errorf("signing algorithm does not match the RSA key")
+		}
+		if a.pss {
+			if err := rsa.VerifyPSS(publicKey, a.hash, digest, signature, nil); err != nil {
+				return fmt.Errorf("invalid token signature")
+			}
+			return nil
+		}
+		if err := rsa.VerifyPKCS1v15(publicKey, a.hash, digest, signature); err != nil {
+			return fmt.Errorf("invalid token signature")
+		}
+		return nil
+	case *ecdsa.PublicKey:
+		if !a.keyEC {
+			return fmt.Errorf("signing algorithm does not match the EC key")
+		}
+		size := (publicKey.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return fmt.Errorf("invalid token signature")
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(publicKey, digest, r, s) {
+			return fmt.Errorf("invalid token signature")
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported signing key")
+}
+
+// checkIssuerURL requires https, except for loopback hosts such as a local test issuer
+func checkIssuerURL(rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" {
+		return fmt.Errorf("%q is not an absolute URL", rawURL)
+	}
+	switch parsed.Scheme {
+	case "https":
+		return nil
+	case "http":
+		host := parsed.Hostname()
+		if ip := net.ParseIP(host); host == "localhost" || (ip != nil && ip.IsLoopback()) {
+			return nil
+		}
+	}
+	return errors.New("must use https unless the host is a loopback address")
+}
+
+// decodeSegment decodes a base64url JSON segment of a token
+func decodeSegment(segment string, target interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, target)
+}
+
+// decodeBigInt decodes a base64url unsigned integer of a JSON web key
+func decodeBigInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil || len(data) == 0 {
+		return nil, fmt.Errorf("malformed key parameter")
+	}
+	return new(big.Int).SetBytes(data), nil
+}
+
+// containsAny reports whether values and accepted share a value
+func containsAny(values, accepted []string) bool {
+	for _, value := range values {
+		for _, candidate := range accepted {
+			if value == candidate {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,153 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/acm"
+	acmtypes "github.com/aws/aws-sdk-go-v2/service/acm/types"
+
+	"github.com/versus-control/ai-infrastructure-agent/pkg/types"
+)
+
+// ========== ACM Certificate Methods ==========
+
+// ListCertificates lists issued ACM certificates in the region
+func (c *Client) ListCertificates(ctx context.Context) ([]*types.AWSResource, error) {
+	var certificates []*types.AWSResource
+
+	// Without a key type filter ACM only lists RSA_1024 and RSA_2048 certificates
+	paginator := acm.NewListCertificatesPaginator(c.acm, &acm.ListCertificatesInput{
+		CertificateStatuses: []acmtypes.CertificateStatus{acmtypes.CertificateStatusIssued},
+		Includes: &acmtypes.Filters{
+			KeyTypes: acmtypes.KeyAlgorithm("").Values(),
+		},
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list certificates: %w", err)
+		}
+
+		for _, summary := range page.CertificateSummaryList {
+			certificate := c.convertCertificateSummary(summary)
+
+			// Summaries carry at most 100 alternative names; the rest are only described
+			if aws.ToBool(summary.HasAdditionalSubjectAlternativeNames) {
+				names, err := c.certificateDomainNames(ctx, certificate.ID)
+				if err != nil {
+					return nil, err
+				}
+				certificate.Details["domainNames"] = names
+			}
+
+			certificates = append(certificates, certificate)
+		}
+	}
+
+	return certificates, nil
+}
+
+// FindCertificateByDomain returns the ARN of an issued certificate covering the domain.
+// Exact domain or SAN matches are preferred over wildcard matches.
+func (c *Client) FindCertificateByDomain(ctx context.Context, domain string) (string, error) {
+	domain = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(domain), "."))
+	if domain == "" {
+		return "", fmt.Errorf("domain is required to look up a certificate")
+	}
+
+	certificates, err := c.ListCertificates(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	wildcardMatch := ""
+	for _, certificate := range certificates {
+		names, _ := certificate.Details["domainNames"].([]string)
+		for _, name := range names {
+			name = strings.ToLower(name)
+			if name == domain {
+				return certificate.ID, nil
+			}
+			if wildcardMatch == "" && certificateWildcardMatches(name, domain) {
+				wildcardMatch = certificate.ID
+			}
+		}
+	}
+
+	if wildcardMatch != "" {
+		return wildcardMatch, nil
+	}
+
+	return "", fmt.Errorf("no issued ACM certificate found for %s in %s", domain, c.cfg.Region)
+}
+
+// certificateDomainNames returns the domain name and every subject alternative name of a certificate
+func (c *Client) certificateDomainNames(ctx context.Context, certificateArn string) ([]string, error) {
+	result, err := c.acm.DescribeCertificate(ctx, &acm.DescribeCertificateInput{
+		CertificateArn: aws.String(certificateArn),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe certificate %s: %w", certificateArn, err)
+	}
+
+	domainName := aws.ToString(result.Certificate.DomainName)
+	domainNames := []string{domainName}
+	for _, name := range result.Certificate.SubjectAlternativeNames {
+		if name != domainName {
+			domainNames = append(domainNames, name)
+		}
+	}
+	return domainNames, nil
+}
+
+// certificateWildcardMatches reports whether a wildcard name such as *.example.com covers
+// the domain. Wildcards only cover a single label, so a.b.example.com does not match.
+func certificateWildcardMatches(certificateName, domain string) bool {
+	if !strings.HasPrefix(certificateName, "*.") {
+		return false
+	}
+
+	suffix := certificateName[1:]
+	if !strings.HasSuffix(domain, suffix) {
+		return false
+	}
+
+	label := strings.TrimSuffix(domain, suffix)
+	return label != "" && !strings.Contains(label, ".")
+}
+
+// convertCertificateSummary converts an ACM certificate summary to our internal resource representation
+func (c *Client) convertCertificateSummary(summary acmtypes.CertificateSummary) *types.AWSResource {
+	domainNames := []string{aws.ToString(summary.DomainName)}
+	for _, name := range summary.SubjectAlternativeNameSummaries {
+		if name != aws.ToString(summary.DomainName) {
+			domainNames = append(domainNames, name)
+		}
+	}
+
+	details := map[string]interface{}{
+		"certificateArn": aws.ToString(summary.CertificateArn),
+		"domainName":     aws.ToString(summary.DomainName),
+		"domainNames":    domainNames,
+		"status":         string(summary.Status),
+		"type":           string(summary.Type),
+		"inUse":          aws.ToBool(summary.InUse),
+	}
+	if summary.NotAfter != nil {
+		details["notAfter"] = *summary.NotAfter
+	}
+
+	return &types.AWSResource{
+		ID:       aws.ToString(summary.CertificateArn),
+		Type:     "acm_certificate",
+		Region:   c.cfg.Region,
+		State:    strings.ToLower(string(summary.Status)),
+		Tags:     make(map[string]string),
+		Details:  details,
+		LastSeen: time.Now(),
+	}
+}
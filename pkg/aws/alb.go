@@ -3,6 +3,7 @@ package aws
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -33,16 +34,7 @@ func (c *Client) CreateApplicationLoadBalancer(ctx context.Context, params Creat
 	}
 
 	// Add tags
-	if len(params.Tags) > 0 {
-		var tags []elbv2types.Tag
-		for k, v := range params.Tags {
-			tags = append(tags, elbv2types.Tag{
-				Key:   aws.String(k),
-				Value: aws.String(v),
-			})
-		}
-		input.Tags = tags
-	}
+	input.Tags = toELBTags(resourceTags(ctx, params.Tags))
 
 	result, err := c.elbv2.CreateLoadBalancer(ctx, input)
 	if err != nil {
@@ -79,17 +71,16 @@ func (c *Client) CreateApplicationLoadBalancer(ctx context.Context, params Creat
 // CreateTargetGroup creates a target group for the load balancer
 func (c *Client) CreateTargetGroup(ctx context.Context, params CreateTargetGroupParams) (*types.AWSResource, error) {
 	// Validate and set defaults for critical parameters
-	if params.HealthCheckPath == "" {
-		params.HealthCheckPath = "/"
-	}
 	if params.HealthCheckProtocol == "" {
 		params.HealthCheckProtocol = params.Protocol
 		if params.HealthCheckProtocol == "" {
 			params.HealthCheckProtocol = "HTTP"
 		}
-	}
-	if params.Matcher == "" {
-		params.Matcher = "200"
+		// Network target groups check TCP connectivity unless told otherwise;
+		// UDP and TLS are not valid health check protocols
+		if isNetworkProtocol(params.HealthCheckProtocol) {
+			params.HealthCheckProtocol = "TCP"
+		}
 	}
 	if params.TargetType == "" {
 		params.TargetType = "instance"
@@ -102,29 +93,30 @@ func (c *Client) CreateTargetGroup(ctx context.Context, params CreateTargetGroup
 		VpcId:                      aws.String(params.VpcID),
 		TargetType:                 elbv2types.TargetTypeEnum(params.TargetType),
 		HealthCheckEnabled:         aws.Bool(params.HealthCheckEnabled),
-		HealthCheckPath:            aws.String(params.HealthCheckPath),
 		HealthCheckProtocol:        elbv2types.ProtocolEnum(params.HealthCheckProtocol),
 		HealthCheckIntervalSeconds: aws.Int32(params.HealthCheckIntervalSeconds),
 		HealthCheckTimeoutSeconds:  aws.Int32(params.HealthCheckTimeoutSeconds),
 		HealthyThresholdCount:      aws.Int32(params.HealthyThresholdCount),
 		UnhealthyThresholdCount:    aws.Int32(params.UnhealthyThresholdCount),
-		Matcher: &elbv2types.Matcher{
-			HttpCode: aws.String(params.Matcher),
-		},
 	}
 
-	// Add tags
-	if len(params.Tags) > 0 {
-		var tags []elbv2types.Tag
-		for k, v := range params.Tags {
-			tags = append(tags, elbv2types.Tag{
-				Key:   aws.String(k),
-				Value: aws.String(v),
-			})
+	// Paths and response codes only apply to HTTP(S) health checks
+	if !isNetworkProtocol(params.HealthCheckProtocol) {
+		if params.HealthCheckPath == "" {
+			params.HealthCheckPath = "/"
+		}
+		if params.Matcher == "" {
+			params.Matcher = "200"
+		}
+		input.HealthCheckPath = aws.String(params.HealthCheckPath)
+		input.Matcher = &elbv2types.Matcher{
+			HttpCode: aws.String(params.Matcher),
 		}
-		input.Tags = tags
 	}
 
+	// Add tags
+	input.Tags = toELBTags(resourceTags(ctx, params.Tags))
+
 	result, err := c.elbv2.CreateTargetGroup(ctx, input)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create target group: %w", err)
@@ -156,29 +148,57 @@ func (c *Client) CreateTargetGroup(ctx context.Context, params CreateTargetGroup
 	return resource, nil
 }
 
-// CreateListener creates a listener for the load balancer
+// CreateListener creates a listener for the load balancer. HTTPS listeners take their
+// certificate from CertificateArn or, failing that, an ACM lookup of CertificateDomain.
 func (c *Client) CreateListener(ctx context.Context, params CreateListenerParams) (*types.AWSResource, error) {
+	defaultAction := ListenerActionParams{
+		Type:           "forward",
+		TargetGroupArn: params.DefaultTargetGroupArn,
+	}
+	if params.DefaultAction != nil {
+		defaultAction = *params.DefaultAction
+	}
+
+	action, err := buildListenerAction(defaultAction)
+	if err != nil {
+		return nil, err
+	}
+
 	input := &elasticloadbalancingv2.CreateListenerInput{
 		LoadBalancerArn: aws.String(params.LoadBalancerArn),
 		Protocol:        elbv2types.ProtocolEnum(params.Protocol),
 		Port:            aws.Int32(params.Port),
-		DefaultActions: []elbv2types.Action{
-			{
-				Type:           elbv2types.ActionTypeEnumForward,
-				TargetGroupArn: aws.String(params.DefaultTargetGroupArn),
-			},
-		},
+		DefaultActions:  []elbv2types.Action{action},
 	}
 
-	// Add SSL certificate for HTTPS
-	if params.Protocol == "HTTPS" && params.CertificateArn != "" {
+	// Add SSL certificate for HTTPS and TLS
+	certificateArn := params.CertificateArn
+	if params.Protocol == "HTTPS" || params.Protocol == "TLS" {
+		if certificateArn == "" && params.CertificateDomain != "" {
+			certificateArn, err = c.FindCertificateByDomain(ctx, params.CertificateDomain)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if certificateArn == "" {
+			return nil, fmt.Errorf("%s listeners require a certificateArn or a certificateDomain with an issued ACM certificate", params.Protocol)
+		}
+
 		input.Certificates = []elbv2types.Certificate{
 			{
-				CertificateArn: aws.String(params.CertificateArn),
+				CertificateArn: aws.String(certificateArn),
 			},
 		}
+		if params.SslPolicy != "" {
+			input.SslPolicy = aws.String(params.SslPolicy)
+		}
+		if params.Protocol == "TLS" && params.AlpnPolicy != "" {
+			input.AlpnPolicy = []string{params.AlpnPolicy}
+		}
 	}
 
+	input.Tags = toELBTags(resourceTags(ctx, nil))
+
 	result, err := c.elbv2.CreateListener(ctx, input)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create listener: %w", err)
@@ -193,18 +213,12 @@ func (c *Client) CreateListener(ctx context.Context, params CreateListenerParams
 		"listenerArn": *listener.ListenerArn,
 		"protocol":    string(listener.Protocol),
 		"port":        *listener.Port,
+		"action":      defaultAction.Type,
 	}).Info("Listener created successfully")
 
-	resource := &types.AWSResource{
-		ID:    *listener.ListenerArn,
-		Type:  "listener",
-		State: "active",
-		Details: map[string]interface{}{
-			"protocol":        string(listener.Protocol),
-			"port":            *listener.Port,
-			"loadBalancerArn": params.LoadBalancerArn,
-		},
-		LastSeen: time.Now(),
+	resource := c.convertListener(listener)
+	if certificateArn != "" {
+		resource.Details["certificateArn"] = certificateArn
 	}
 
 	return resource, nil
@@ -370,6 +384,21 @@ func (c *Client) convertLoadBalancer(lb elbv2types.LoadBalancer) *types.AWSResou
 		"customerOwnedIpv4Pool": aws.ToString(lb.CustomerOwnedIpv4Pool),
 	}
 
+	// Subnets and static Elastic IPs (Network Load Balancers) per Availability Zone
+	var subnets, allocationIDs []string
+	for _, zone := range lb.AvailabilityZones {
+		subnets = append(subnets, aws.ToString(zone.SubnetId))
+		for _, address := range zone.LoadBalancerAddresses {
+			if address.AllocationId != nil {
+				allocationIDs = append(allocationIDs, aws.ToString(address.AllocationId))
+			}
+		}
+	}
+	details["subnets"] = subnets
+	if len(allocationIDs) > 0 {
+		details["elasticIpAllocationIds"] = allocationIDs
+	}
+
 	return &types.AWSResource{
 		ID:       aws.ToString(lb.LoadBalancerArn),
 		Type:     "load-balancer",
@@ -413,3 +442,429 @@ func (c *Client) convertTargetGroup(tg elbv2types.TargetGroup) *types.AWSResourc
 		LastSeen: time.Now(),
 	}
 }
+
+// ========== Load Balancer Deletion Methods ==========
+
+// DeleteLoadBalancer deletes a load balancer and waits until it is gone.
+// Its listeners and listener rules are deleted with it, and the Elastic IPs the agent
+// allocated for a Network Load Balancer are released.
+func (c *Client) DeleteLoadBalancer(ctx context.Context, loadBalancerArn string) error {
+	// The addresses are found by load balancer name, which is gone after the deletion
+	var networkLBName string
+	described, err := c.elbv2.DescribeLoadBalancers(ctx, &elasticloadbalancingv2.DescribeLoadBalancersInput{
+		LoadBalancerArns: []string{loadBalancerArn},
+	})
+	if err == nil && len(described.LoadBalancers) > 0 && described.LoadBalancers[0].Type == elbv2types.LoadBalancerTypeEnumNetwork {
+		networkLBName = aws.ToString(described.LoadBalancers[0].LoadBalancerName)
+	}
+
+	_, err = c.elbv2.DeleteLoadBalancer(ctx, &elasticloadbalancingv2.DeleteLoadBalancerInput{
+		LoadBalancerArn: aws.String(loadBalancerArn),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete load balancer %s: %w", loadBalancerArn, err)
+	}
+
+	// Target groups stay "in use" until the load balancer is fully deleted
+	waiter := elasticloadbalancingv2.NewLoadBalancersDeletedWaiter(c.elbv2)
+	if err := waiter.Wait(ctx, &elasticloadbalancingv2.DescribeLoadBalancersInput{
+		LoadBalancerArns: []string{loadBalancerArn},
+	}, 10*time.Minute); err != nil {
+		c.logger.WithError(err).Warn("Timed out waiting for load balancer deletion to complete")
+	} else if networkLBName != "" {
+		if err := c.releaseLoadBalancerAddresses(ctx, networkLBName); err != nil {
+			c.logger.WithError(err).WithField("loadBalancerName", networkLBName).Warn("Failed to release the Elastic IPs of the network load balancer")
+		}
+	}
+
+	c.logger.WithField("loadBalancerArn", loadBalancerArn).Info("Load balancer deleted successfully")
+	return nil
+}
+
+// DeleteTargetGroup deletes a target group that is no longer referenced by any listener or rule
+func (c *Client) DeleteTargetGroup(ctx context.Context, targetGroupArn string) error {
+	_, err := c.elbv2.DeleteTargetGroup(ctx, &elasticloadbalancingv2.DeleteTargetGroupInput{
+		TargetGroupArn: aws.String(targetGroupArn),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete target group %s: %w", targetGroupArn, err)
+	}
+
+	c.logger.WithField("targetGroupArn", targetGroupArn).Info("Target group deleted successfully")
+	return nil
+}
+
+// DeleteListener deletes a listener together with its rules
+func (c *Client) DeleteListener(ctx context.Context, listenerArn string) error {
+	_, err := c.elbv2.DeleteListener(ctx, &elasticloadbalancingv2.DeleteListenerInput{
+		ListenerArn: aws.String(listenerArn),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete listener %s: %w", listenerArn, err)
+	}
+
+	c.logger.WithField("listenerArn", listenerArn).Info("Listener deleted successfully")
+	return nil
+}
+
+// ========== Listener and Listener Rule Methods ==========
+
+// DescribeListeners lists the listeners of a load balancer
+func (c *Client) DescribeListeners(ctx context.Context, loadBalancerArn string) ([]*types.AWSResource, error) {
+	var resources []*types.AWSResource
+
+	paginator := elasticloadbalancingv2.NewDescribeListenersPaginator(c.elbv2, &elasticloadbalancingv2.DescribeListenersInput{
+		LoadBalancerArn: aws.String(loadBalancerArn),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe listeners for %s: %w", loadBalancerArn, err)
+		}
+		for _, listener := range page.Listeners {
+			resources = append(resources, c.convertListener(listener))
+		}
+	}
+
+	return resources, nil
+}
+
+// CreateListenerRule adds a routing rule to a listener
+func (c *Client) CreateListenerRule(ctx context.Context, params CreateListenerRuleParams) (*types.AWSResource, error) {
+	conditions := buildRuleConditions(params.Conditions)
+	if len(conditions) == 0 {
+		return nil, fmt.Errorf("listener rules require at least one condition")
+	}
+
+	action, err := buildListenerAction(params.Action)
+	if err != nil {
+		return nil, err
+	}
+
+	input := &elasticloadbalancingv2.CreateRuleInput{
+		ListenerArn: aws.String(params.ListenerArn),
+		Priority:    aws.Int32(params.Priority),
+		Conditions:  conditions,
+		Actions:     []elbv2types.Action{action},
+	}
+
+	input.Tags = toELBTags(resourceTags(ctx, params.Tags))
+
+	result, err := c.elbv2.CreateRule(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create listener rule: %w", err)
+	}
+
+	if len(result.Rules) == 0 {
+		return nil, fmt.Errorf("no listener rule created")
+	}
+
+	rule := result.Rules[0]
+	c.logger.WithFields(logrus.Fields{
+		"ruleArn":     aws.ToString(rule.RuleArn),
+		"listenerArn": params.ListenerArn,
+		"priority":    params.Priority,
+	}).Info("Listener rule created successfully")
+
+	return c.convertListenerRule(rule, params.ListenerArn), nil
+}
+
+// ModifyListenerRule changes the conditions, action or priority of a listener rule
+func (c *Client) ModifyListenerRule(ctx context.Context, params ModifyListenerRuleParams) (*types.AWSResource, error) {
+	if params.Conditions != nil || params.Action != nil {
+		input := &elasticloadbalancingv2.ModifyRuleInput{
+			RuleArn: aws.String(params.RuleArn),
+		}
+
+		if params.Conditions != nil {
+			input.Conditions = buildRuleConditions(*params.Conditions)
+			if len(input.Conditions) == 0 {
+				return nil, fmt.Errorf("listener rules require at least one condition")
+			}
+		}
+
+		if params.Action != nil {
+			action, err := buildListenerAction(*params.Action)
+			if err != nil {
+				return nil, err
+			}
+			input.Actions = []elbv2types.Action{action}
+		}
+
+		if _, err := c.elbv2.ModifyRule(ctx, input); err != nil {
+			return nil, fmt.Errorf("failed to modify listener rule %s: %w", params.RuleArn, err)
+		}
+	}
+
+	if params.Priority > 0 {
+		_, err := c.elbv2.SetRulePriorities(ctx, &elasticloadbalancingv2.SetRulePrioritiesInput{
+			RulePriorities: []elbv2types.RulePriorityPair{
+				{
+					RuleArn:  aws.String(params.RuleArn),
+					Priority: aws.Int32(params.Priority),
+				},
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to set priority of listener rule %s: %w", params.RuleArn, err)
+		}
+	}
+
+	c.logger.WithField("ruleArn", params.RuleArn).Info("Listener rule modified successfully")
+	return c.GetListenerRule(ctx, params.RuleArn)
+}
+
+// DeleteListenerRule deletes a non-default listener rule
+func (c *Client) DeleteListenerRule(ctx context.Context, ruleArn string) error {
+	_, err := c.elbv2.DeleteRule(ctx, &elasticloadbalancingv2.DeleteRuleInput{
+		RuleArn: aws.String(ruleArn),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete listener rule %s: %w", ruleArn, err)
+	}
+
+	c.logger.WithField("ruleArn", ruleArn).Info("Listener rule deleted successfully")
+	return nil
+}
+
+// DescribeListenerRules lists the rules of a listener, including its default rule
+func (c *Client) DescribeListenerRules(ctx context.Context, listenerArn string) ([]*types.AWSResource, error) {
+	result, err := c.elbv2.DescribeRules(ctx, &elasticloadbalancingv2.DescribeRulesInput{
+		ListenerArn: aws.String(listenerArn),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe rules for listener %s: %w", listenerArn, err)
+	}
+
+	var resources []*types.AWSResource
+	for _, rule := range result.Rules {
+		resources = append(resources, c.convertListenerRule(rule, listenerArn))
+	}
+
+	return resources, nil
+}
+
+// GetListenerRule gets a specific listener rule by ARN
+func (c *Client) GetListenerRule(ctx context.Context, ruleArn string) (*types.AWSResource, error) {
+	result, err := c.elbv2.DescribeRules(ctx, &elasticloadbalancingv2.DescribeRulesInput{
+		RuleArns: []string{ruleArn},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe listener rule %s: %w", ruleArn, err)
+	}
+
+	if len(result.Rules) == 0 {
+		return nil, fmt.Errorf("listener rule %s not found", ruleArn)
+	}
+
+	return c.convertListenerRule(result.Rules[0], listenerArnFromRuleArn(ruleArn)), nil
+}
+
+// buildListenerAction converts action parameters into an ELBv2 action
+func buildListenerAction(params ListenerActionParams) (elbv2types.Action, error) {
+	switch params.Type {
+	case "", "forward":
+		if params.TargetGroupArn == "" {
+			return elbv2types.Action{}, fmt.Errorf("forward actions require a targetGroupArn")
+		}
+		return elbv2types.Action{
+			Type:           elbv2types.ActionTypeEnumForward,
+			TargetGroupArn: aws.String(params.TargetGroupArn),
+		}, nil
+
+	case "redirect":
+		statusCode := params.RedirectStatusCode
+		if statusCode == "" {
+			statusCode = "HTTP_301"
+		}
+
+		redirect := &elbv2types.RedirectActionConfig{
+			StatusCode: elbv2types.RedirectActionStatusCodeEnum(statusCode),
+		}
+		if params.RedirectProtocol != "" {
+			redirect.Protocol = aws.String(params.RedirectProtocol)
+		}
+		if params.RedirectPort != "" {
+			redirect.Port = aws.String(params.RedirectPort)
+		}
+		if params.RedirectHost != "" {
+			redirect.Host = aws.String(params.RedirectHost)
+		}
+		if params.RedirectPath != "" {
+			redirect.Path = aws.String(params.RedirectPath)
+		}
+		if params.RedirectQuery != "" {
+			redirect.Query = aws.String(params.RedirectQuery)
+		}
+
+		return elbv2types.Action{
+			Type:           elbv2types.ActionTypeEnumRedirect,
+			RedirectConfig: redirect,
+		}, nil
+
+	case "fixed-response":
+		statusCode := params.FixedResponseStatusCode
+		if statusCode == "" {
+			statusCode = "404"
+		}
+		contentType := params.FixedResponseContentType
+		if contentType == "" {
+			contentType = "text/plain"
+		}
+
+		fixedResponse := &elbv2types.FixedResponseActionConfig{
+			StatusCode:  aws.String(statusCode),
+			ContentType: aws.String(contentType),
+		}
+		if params.FixedResponseBody != "" {
+			fixedResponse.MessageBody = aws.String(params.FixedResponseBody)
+		}
+
+		return elbv2types.Action{
+			Type:                elbv2types.ActionTypeEnumFixedResponse,
+			FixedResponseConfig: fixedResponse,
+		}, nil
+
+	default:
+		return elbv2types.Action{}, fmt.Errorf("unsupported listener action type: %s (use forward, redirect or fixed-response)", params.Type)
+	}
+}
+
+// buildRuleConditions converts condition parameters into ELBv2 rule conditions
+func buildRuleConditions(params ListenerRuleConditionParams) []elbv2types.RuleCondition {
+	var conditions []elbv2types.RuleCondition
+
+	if len(params.PathPatterns) > 0 {
+		conditions = append(conditions, elbv2types.RuleCondition{
+			Field:             aws.String("path-pattern"),
+			PathPatternConfig: &elbv2types.PathPatternConditionConfig{Values: params.PathPatterns},
+		})
+	}
+	if len(params.HostHeaders) > 0 {
+		conditions = append(conditions, elbv2types.RuleCondition{
+			Field:            aws.String("host-header"),
+			HostHeaderConfig: &elbv2types.HostHeaderConditionConfig{Values: params.HostHeaders},
+		})
+	}
+	if len(params.HTTPMethods) > 0 {
+		conditions = append(conditions, elbv2types.RuleCondition{
+			Field:                   aws.String("http-request-method"),
+			HttpRequestMethodConfig: &elbv2types.HttpRequestMethodConditionConfig{Values: params.HTTPMethods},
+		})
+	}
+	if len(params.SourceIPs) > 0 {
+		conditions = append(conditions, elbv2types.RuleCondition{
+			Field:          aws.String("source-ip"),
+			SourceIpConfig: &elbv2types.SourceIpConditionConfig{Values: params.SourceIPs},
+		})
+	}
+	if params.HTTPHeader != "" && len(params.HeaderValues) > 0 {
+		conditions = append(conditions, elbv2types.RuleCondition{
+			Field: aws.String("http-header"),
+			HttpHeaderConfig: &elbv2types.HttpHeaderConditionConfig{
+				HttpHeaderName: aws.String(params.HTTPHeader),
+				Values:         params.HeaderValues,
+			},
+		})
+	}
+
+	return conditions
+}
+
+// listenerArnFromRuleArn derives the listener ARN from a listener rule ARN
+// (arn:...:listener-rule/app/name/lb-id/listener-id/rule-id)
+func listenerArnFromRuleArn(ruleArn string) string {
+	lastSlash := strings.LastIndex(ruleArn, "/")
+	if lastSlash < 0 {
+		return ""
+	}
+	return strings.Replace(ruleArn[:lastSlash], ":listener-rule/", ":listener/", 1)
+}
+
+// convertListener converts a listener to our internal resource representation
+func (c *Client) convertListener(listener elbv2types.Listener) *types.AWSResource {
+	details := map[string]interface{}{
+		"listenerArn":     aws.ToString(listener.ListenerArn),
+		"loadBalancerArn": aws.ToString(listener.LoadBalancerArn),
+		"protocol":        string(listener.Protocol),
+		"port":            aws.ToInt32(listener.Port),
+		"sslPolicy":       aws.ToString(listener.SslPolicy),
+	}
+
+	if len(listener.Certificates) > 0 {
+		details["certificateArn"] = aws.ToString(listener.Certificates[0].CertificateArn)
+	}
+
+	if len(listener.DefaultActions) > 0 {
+		action := listener.DefaultActions[0]
+		details["defaultActionType"] = string(action.Type)
+		if action.TargetGroupArn != nil {
+			details["targetGroupArn"] = aws.ToString(action.TargetGroupArn)
+		}
+	}
+
+	return &types.AWSResource{
+		ID:       aws.ToString(listener.ListenerArn),
+		Type:     "listener",
+		Region:   c.cfg.Region,
+		State:    "active",
+		Tags:     make(map[string]string),
+		Details:  details,
+		LastSeen: time.Now(),
+	}
+}
+
+// convertListenerRule converts a listener rule to our internal resource representation
+func (c *Client) convertListenerRule(rule elbv2types.Rule, listenerArn string) *types.AWSResource {
+	details := map[string]interface{}{
+		"ruleArn":     aws.ToString(rule.RuleArn),
+		"listenerArn": listenerArn,
+		"priority":    aws.ToString(rule.Priority),
+		"isDefault":   aws.ToBool(rule.IsDefault),
+	}
+
+	var conditions []map[string]interface{}
+	for _, condition := range rule.Conditions {
+		entry := map[string]interface{}{"field": aws.ToString(condition.Field)}
+		switch {
+		case condition.PathPatternConfig != nil:
+			entry["values"] = condition.PathPatternConfig.Values
+		case condition.HostHeaderConfig != nil:
+			entry["values"] = condition.HostHeaderConfig.Values
+		case condition.HttpRequestMethodConfig != nil:
+			entry["values"] = condition.HttpRequestMethodConfig.Values
+		case condition.SourceIpConfig != nil:
+			entry["values"] = condition.SourceIpConfig.Values
+		case condition.HttpHeaderConfig != nil:
+			entry["header"] = aws.ToString(condition.HttpHeaderConfig.HttpHeaderName)
+			entry["values"] = condition.HttpHeaderConfig.Values
+		default:
+			entry["values"] = condition.Values
+		}
+		conditions = append(conditions, entry)
+	}
+	details["conditions"] = conditions
+
+	if len(rule.Actions) > 0 {
+		action := rule.Actions[0]
+		details["actionType"] = string(action.Type)
+		if action.TargetGroupArn != nil {
+			details["targetGroupArn"] = aws.ToString(action.TargetGroupArn)
+		}
+		if action.RedirectConfig != nil {
+			details["redirectProtocol"] = aws.ToString(action.RedirectConfig.Protocol)
+			details["redirectPort"] = aws.ToString(action.RedirectConfig.Port)
+			details["redirectStatusCode"] = string(action.RedirectConfig.StatusCode)
+		}
+	}
+
+	return &types.AWSResource{
+		ID:       aws.ToString(rule.RuleArn),
+		Type:     "listener_rule",
+		Region:   c.cfg.Region,
+		State:    "active",
+		Tags:     make(map[string]string),
+		Details:  details,
+		LastSeen: time.Now(),
+	}
+}
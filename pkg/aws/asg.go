@@ -3,6 +3,7 @@ package aws
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -39,8 +40,11 @@ func (c *Client) CreateLaunchTemplate(ctx context.Context, params CreateLaunchTe
 	}
 
 	if params.IamInstanceProfile != "" {
-		templateData.IamInstanceProfile = &ec2types.LaunchTemplateIamInstanceProfileSpecificationRequest{
-			Name: aws.String(params.IamInstanceProfile),
+		templateData.IamInstanceProfile = &ec2types.LaunchTemplateIamInstanceProfileSpecificationRequest{}
+		if strings.HasPrefix(params.IamInstanceProfile, "arn:") {
+			templateData.IamInstanceProfile.Arn = aws.String(params.IamInstanceProfile)
+		} else {
+			templateData.IamInstanceProfile.Name = aws.String(params.IamInstanceProfile)
 		}
 	}
 
@@ -50,18 +54,19 @@ func (c *Client) CreateLaunchTemplate(ctx context.Context, params CreateLaunchTe
 	}
 
 	// Add tag specifications during creation if tags are provided
-	if len(params.Tags) > 0 {
-		var ec2Tags []ec2types.Tag
-		for key, value := range params.Tags {
-			ec2Tags = append(ec2Tags, ec2types.Tag{
-				Key:   aws.String(key),
-				Value: aws.String(value),
-			})
-		}
+	if tags := resourceTags(ctx, params.Tags); len(tags) > 0 {
 		input.TagSpecifications = []ec2types.TagSpecification{
 			{
 				ResourceType: ec2types.ResourceTypeLaunchTemplate,
-				Tags:         ec2Tags,
+				Tags:         toEC2Tags(tags),
+			},
+		}
+
+		// Tag the instances launched from the template with the same tags
+		templateData.TagSpecifications = []ec2types.LaunchTemplateTagSpecificationRequest{
+			{
+				ResourceType: ec2types.ResourceTypeInstance,
+				Tags:         toEC2Tags(tags),
 			},
 		}
 	}
@@ -122,6 +127,15 @@ func (c *Client) CreateAutoScalingGroup(ctx context.Context, params CreateAutoSc
 		input.TargetGroupARNs = params.TargetGroupARNs
 	}
 
+	// Tag the group and propagate the tags to the instances it launches
+	for key, value := range resourceTags(ctx, params.Tags) {
+		input.Tags = append(input.Tags, autoscalingtypes.Tag{
+			Key:               aws.String(key),
+			Value:             aws.String(value),
+			PropagateAtLaunch: aws.Bool(true),
+		})
+	}
+
 	_, err := c.autoscaling.CreateAutoScalingGroup(ctx, input)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create auto scaling group: %w", err)
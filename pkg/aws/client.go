@@ -6,10 +6,16 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/acm"
 	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
 	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
 
 	"github.com/versus-control/ai-infrastructure-agent/internal/logging"
 )
@@ -20,6 +26,12 @@ type Client struct {
 	autoscaling *autoscaling.Client
 	elbv2       *elasticloadbalancingv2.Client
 	rds         *rds.Client
+	s3          *s3.Client
+	iam         *iam.Client
+	acm         *acm.Client
+	secrets     *secretsmanager.Client
+	kms         *kms.Client
+	cloudwatch  *cloudwatch.Client
 	logger      *logging.Logger
 }
 
@@ -38,6 +50,12 @@ func NewClient(region string, logger *logging.Logger) (*Client, error) {
 		autoscaling: autoscaling.NewFromConfig(cfg),
 		elbv2:       elasticloadbalancingv2.NewFromConfig(cfg),
 		rds:         rds.NewFromConfig(cfg),
+		s3:          s3.NewFromConfig(cfg),
+		iam:         iam.NewFromConfig(cfg),
+		acm:         acm.NewFromConfig(cfg),
+		secrets:     secretsmanager.NewFromConfig(cfg),
+		kms:         kms.NewFromConfig(cfg),
+		cloudwatch:  cloudwatch.NewFromConfig(cfg),
 		logger:      logger,
 	}, nil
 }
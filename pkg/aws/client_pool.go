@@ -0,0 +1,121 @@
+package aws
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/versus-control/ai-infrastructure-agent/internal/logging"
+)
+
+// RegionsEnvVar lists the regions that multi-region discovery fans out across
+// (comma separated). When unset, only the default region is scanned.
+const RegionsEnvVar = "AWS_REGIONS"
+
+// ClientPool holds one Client per region so that tools, discovery and plan steps
+// can target a region other than the one the server was started with
+type ClientPool struct {
+	defaultRegion string
+	regions       []string
+	clients       map[string]*Client
+	mutex         sync.Mutex
+	logger        *logging.Logger
+}
+
+// NewClientPool creates a client pool seeded with the default region client.
+// Additional regional clients are created lazily on first use.
+func NewClientPool(defaultClient *Client, regions []string, logger *logging.Logger) *ClientPool {
+	pool := &ClientPool{
+		clients: make(map[string]*Client),
+		logger:  logger,
+	}
+
+	if defaultClient != nil {
+		pool.defaultRegion = defaultClient.GetRegion()
+		pool.clients[pool.defaultRegion] = defaultClient
+	}
+
+	pool.regions = normalizeRegions(pool.defaultRegion, regions)
+	return pool
+}
+
+// RegionsFromEnv returns the configured discovery regions from AWS_REGIONS,
+// always including the default region
+func RegionsFromEnv(defaultRegion string) []string {
+	return normalizeRegions(defaultRegion, strings.Split(os.Getenv(RegionsEnvVar), ","))
+}
+
+// GetClient returns the client for a region, creating it if necessary.
+// An empty region resolves to the default region client.
+func (p *ClientPool) GetClient(region string) (*Client, error) {
+	region = strings.TrimSpace(region)
+	if region == "" {
+		region = p.defaultRegion
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if client, exists := p.clients[region]; exists {
+		return client, nil
+	}
+
+	client, err := NewClient(region, p.logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AWS client for region %s: %w", region, err)
+	}
+
+	p.clients[region] = client
+	p.logger.WithField("region", region).Info("Created regional AWS client")
+	return client, nil
+}
+
+// DefaultClient returns the client for the default region
+func (p *ClientPool) DefaultClient() *Client {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.clients[p.defaultRegion]
+}
+
+// DefaultRegion returns the region the pool falls back to when none is given
+func (p *ClientPool) DefaultRegion() string {
+	return p.defaultRegion
+}
+
+// Regions returns the configured region list used for discovery fan-out
+func (p *ClientPool) Regions() []string {
+	result := make([]string, len(p.regions))
+	copy(result, p.regions)
+	return result
+}
+
+// IsDefaultRegion reports whether the region resolves to the default client
+func (p *ClientPool) IsDefaultRegion(region string) bool {
+	region = strings.TrimSpace(region)
+	return region == "" || region == p.defaultRegion
+}
+
+// normalizeRegions trims, de-duplicates and sorts a region list, keeping the
+// default region first
+func normalizeRegions(defaultRegion string, regions []string) []string {
+	seen := make(map[string]bool)
+	var others []string
+
+	for _, region := range regions {
+		region = strings.TrimSpace(region)
+		if region == "" || region == defaultRegion || seen[region] {
+			continue
+		}
+		seen[region] = true
+		others = append(others, region)
+	}
+	sort.Strings(others)
+
+	var result []string
+	if defaultRegion != "" {
+		result = append(result, defaultRegion)
+	}
+	return append(result, others...)
+}
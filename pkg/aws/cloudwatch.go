@@ -0,0 +1,77 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+)
+
+// CPU utilization metrics of the resources cost reports check for oversizing
+const (
+	cpuMetricName         = "CPUUtilization"
+	ec2MetricNamespace    = "AWS/EC2"
+	ec2InstanceDim        = "InstanceId"
+	rdsMetricNamespace    = "AWS/RDS"
+	rdsInstanceDim        = "DBInstanceIdentifier"
+	utilizationPeriodSecs = 24 * 60 * 60
+)
+
+// CPUUtilization summarizes the CPU utilization of a resource, in percent, over a number of days
+type CPUUtilization struct {
+	Average float64 `json:"average"`
+	Maximum float64 `json:"maximum"`
+	Days    int     `json:"days"` // Days with datapoints
+}
+
+// ========== CloudWatch Methods ==========
+
+// GetInstanceCPUUtilization returns the CPU utilization of an EC2 instance over the last days,
+// nil when CloudWatch has no datapoints for it
+func (c *Client) GetInstanceCPUUtilization(ctx context.Context, instanceID string, days int) (*CPUUtilization, error) {
+	return c.getCPUUtilization(ctx, ec2MetricNamespace, ec2InstanceDim, instanceID, days)
+}
+
+// GetDBInstanceCPUUtilization returns the CPU utilization of an RDS instance over the last days,
+// nil when CloudWatch has no datapoints for it
+func (c *Client) GetDBInstanceCPUUtilization(ctx context.Context, dbInstanceIdentifier string, days int) (*CPUUtilization, error) {
+	return c.getCPUUtilization(ctx, rdsMetricNamespace, rdsInstanceDim, dbInstanceIdentifier, days)
+}
+
+// getCPUUtilization averages the daily CPUUtilization datapoints of a resource and keeps the
+// highest daily maximum
+func (c *Client) getCPUUtilization(ctx context.Context, namespace, dimension, id string, days int) (*CPUUtilization, error) {
+	end := time.Now().UTC()
+	result, err := c.cloudwatch.GetMetricStatistics(ctx, &cloudwatch.GetMetricStatisticsInput{
+		Namespace:  aws.String(namespace),
+		MetricName: aws.String(cpuMetricName),
+		Dimensions: []cwtypes.Dimension{{
+			Name:  aws.String(dimension),
+			Value: aws.String(id),
+		}},
+		StartTime:  aws.Time(end.AddDate(0, 0, -days)),
+		EndTime:    aws.Time(end),
+		Period:     aws.Int32(utilizationPeriodSecs),
+		Statistics: []cwtypes.Statistic{cwtypes.StatisticAverage, cwtypes.StatisticMaximum},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get CPU utilization of %s: %w", id, err)
+	}
+	if len(result.Datapoints) == 0 {
+		return nil, nil
+	}
+
+	utilization := &CPUUtilization{Days: len(result.Datapoints)}
+	for _, datapoint := range result.Datapoints {
+		utilization.Average += aws.ToFloat64(datapoint.Average)
+		if maximum := aws.ToFloat64(datapoint.Maximum); maximum > utilization.Maximum {
+			utilization.Maximum = maximum
+		}
+	}
+	utilization.Average /= float64(len(result.Datapoints))
+
+	return utilization, nil
+}
@@ -25,6 +25,7 @@ func (c *Client) CreateEC2Instance(ctx context.Context, params CreateInstancePar
 		"securityGroupId": params.SecurityGroupID,
 		"subnetId":        params.SubnetID,
 		"name":            params.Name,
+		"instanceProfile": params.IamInstanceProfile,
 	}).Info("CreateEC2Instance called with parameters")
 
 	input := &ec2.RunInstancesInput{
@@ -48,22 +49,41 @@ func (c *Client) CreateEC2Instance(ctx context.Context, params CreateInstancePar
 		c.logger.WithField("subnetId", params.SubnetID).Debug("Subnet ID set")
 	}
 
-	// Add tag specifications during creation if name is provided
+	if params.IamInstanceProfile != "" {
+		input.IamInstanceProfile = instanceProfileSpecification(params.IamInstanceProfile)
+	}
+
+	// Add tag specifications during creation
+	tags := resourceTags(ctx, nil)
 	if params.Name != "" {
+		tags["Name"] = params.Name
+	}
+	if len(tags) > 0 {
 		input.TagSpecifications = []ec2types.TagSpecification{
 			{
 				ResourceType: ec2types.ResourceTypeInstance,
-				Tags: []ec2types.Tag{
-					{
-						Key:   aws.String("Name"),
-						Value: aws.String(params.Name),
-					},
-				},
+				Tags:         toEC2Tags(tags),
+			},
+			{
+				ResourceType: ec2types.ResourceTypeVolume,
+				Tags:         toEC2Tags(tags),
 			},
 		}
 	}
 
 	result, err := c.ec2.RunInstances(ctx, input)
+
+	// A freshly created instance profile can take a few seconds to reach EC2
+	for attempt := 1; err != nil && params.IamInstanceProfile != "" && isInstanceProfilePropagationError(err) && attempt <= 5; attempt++ {
+		c.logger.WithField("attempt", attempt).Warn("Instance profile not yet visible to EC2, retrying")
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Duration(attempt*3) * time.Second):
+		}
+		result, err = c.ec2.RunInstances(ctx, input)
+	}
+
 	if err != nil {
 		c.logger.WithError(err).Error("Failed to create EC2 instance")
 		return nil, fmt.Errorf("failed to create instance: %w", err)
@@ -327,12 +347,12 @@ func (c *Client) CreateAMI(ctx context.Context, instanceID, name, description st
 		TagSpecifications: []ec2types.TagSpecification{
 			{
 				ResourceType: ec2types.ResourceTypeImage,
-				Tags: []ec2types.Tag{
-					{Key: aws.String("Name"), Value: aws.String(name)},
-					{Key: aws.String("Source"), Value: aws.String(instanceID)},
-					{Key: aws.String("Environment"), Value: aws.String("production-ready")},
-					{Key: aws.String("CreatedBy"), Value: aws.String("github.com/versus-control/ai-infrastructure-agent")},
-				},
+				Tags: toEC2Tags(resourceTags(ctx, map[string]string{
+					"Name":        name,
+					"Source":      instanceID,
+					"Environment": "production-ready",
+					"CreatedBy":   "github.com/versus-control/ai-infrastructure-agent",
+				})),
 			},
 		},
 	}
@@ -368,46 +388,31 @@ func (c *Client) CreateAMI(ctx context.Context, instanceID, name, description st
 
 // WaitForAMI waits for an AMI to become available
 func (c *Client) WaitForAMI(ctx context.Context, amiID string) error {
-	maxWaitTime := 30 * time.Minute
-	pollInterval := 30 * time.Second
+	description := fmt.Sprintf("AMI %s", amiID)
 
-	ctxWithTimeout, cancel := context.WithTimeout(ctx, maxWaitTime)
-	defer cancel()
-
-	for {
-		select {
-		case <-ctxWithTimeout.Done():
-			return fmt.Errorf("timeout waiting for AMI %s to become available", amiID)
-		default:
-			result, err := c.ec2.DescribeImages(ctx, &ec2.DescribeImagesInput{
-				ImageIds: []string{amiID},
-			})
-			if err != nil {
-				return fmt.Errorf("failed to describe AMI %s: %w", amiID, err)
-			}
+	// AMIs take about 10 minutes, longer for instances with large volumes
+	return c.waitForResource(ctx, description, 10*time.Minute, 30*time.Minute, 30*time.Second, func(ctx context.Context) (string, bool, error) {
+		result, err := c.ec2.DescribeImages(ctx, &ec2.DescribeImagesInput{
+			ImageIds: []string{amiID},
+		})
+		if err != nil {
+			return "", false, fmt.Errorf("failed to describe AMI %s: %w", amiID, err)
+		}
 
-			if len(result.Images) == 0 {
-				return fmt.Errorf("AMI %s not found", amiID)
-			}
+		if len(result.Images) == 0 {
+			return "", false, fmt.Errorf("AMI %s not found", amiID)
+		}
 
-			state := result.Images[0].State
-			logrus.WithFields(logrus.Fields{
-				"ami_id": amiID,
-				"state":  state,
-			}).Info("AMI status check")
-
-			switch state {
-			case ec2types.ImageStateAvailable:
-				return nil
-			case ec2types.ImageStateFailed:
-				return fmt.Errorf("AMI %s creation failed", amiID)
-			case ec2types.ImageStatePending:
-				time.Sleep(pollInterval)
-			default:
-				time.Sleep(pollInterval)
-			}
+		state := result.Images[0].State
+		switch state {
+		case ec2types.ImageStateAvailable:
+			return string(state), true, nil
+		case ec2types.ImageStateFailed:
+			return string(state), false, fmt.Errorf("AMI %s creation failed", amiID)
+		default:
+			return string(state), false, nil
 		}
-	}
+	})
 }
 
 // GetAvailabilityZones retrieves all available availability zones in the current region
@@ -1071,18 +1076,12 @@ func (c *Client) CreateKeyPair(ctx context.Context, params CreateKeyPairParams)
 	}
 
 	// Add tag specifications if provided
-	if len(params.TagSpecs) > 0 {
-		tags := make([]ec2types.Tag, 0, len(params.TagSpecs))
-		for key, value := range params.TagSpecs {
-			tags = append(tags, ec2types.Tag{
-				Key:   aws.String(key),
-				Value: aws.String(value),
-			})
-		}
+	tags := resourceTags(ctx, params.TagSpecs)
+	if len(tags) > 0 {
 		input.TagSpecifications = []ec2types.TagSpecification{
 			{
 				ResourceType: ec2types.ResourceTypeKeyPair,
-				Tags:         tags,
+				Tags:         toEC2Tags(tags),
 			},
 		}
 	}
@@ -1093,12 +1092,6 @@ func (c *Client) CreateKeyPair(ctx context.Context, params CreateKeyPairParams)
 		return nil, fmt.Errorf("failed to create key pair: %w", err)
 	}
 
-	// Convert tags from input for the resource
-	tags := make(map[string]string)
-	for key, value := range params.TagSpecs {
-		tags[key] = value
-	}
-
 	resource := &types.AWSResource{
 		ID:     aws.ToString(result.KeyPairId),
 		Type:   "key_pair",
@@ -1135,18 +1128,12 @@ func (c *Client) ImportKeyPair(ctx context.Context, params ImportKeyPairParams)
 	}
 
 	// Add tag specifications if provided
-	if len(params.TagSpecs) > 0 {
-		tags := make([]ec2types.Tag, 0, len(params.TagSpecs))
-		for key, value := range params.TagSpecs {
-			tags = append(tags, ec2types.Tag{
-				Key:   aws.String(key),
-				Value: aws.String(value),
-			})
-		}
+	tags := resourceTags(ctx, params.TagSpecs)
+	if len(tags) > 0 {
 		input.TagSpecifications = []ec2types.TagSpecification{
 			{
 				ResourceType: ec2types.ResourceTypeKeyPair,
-				Tags:         tags,
+				Tags:         toEC2Tags(tags),
 			},
 		}
 	}
@@ -1157,12 +1144,6 @@ func (c *Client) ImportKeyPair(ctx context.Context, params ImportKeyPairParams)
 		return nil, fmt.Errorf("failed to import key pair: %w", err)
 	}
 
-	// Convert tags from input for the resource
-	tags := make(map[string]string)
-	for key, value := range params.TagSpecs {
-		tags[key] = value
-	}
-
 	resource := &types.AWSResource{
 		ID:     aws.ToString(result.KeyPairId),
 		Type:   "key_pair",
@@ -1233,6 +1214,19 @@ func (c *Client) ListKeyPairs(ctx context.Context) ([]*types.AWSResource, error)
 	return resources, nil
 }
 
+// DeleteKeyPair deletes a key pair by name
+func (c *Client) DeleteKeyPair(ctx context.Context, keyName string) error {
+	_, err := c.ec2.DeleteKeyPair(ctx, &ec2.DeleteKeyPairInput{
+		KeyName: aws.String(keyName),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete key pair %s: %w", keyName, err)
+	}
+
+	c.logger.WithField("keyName", keyName).Info("Key pair deleted successfully")
+	return nil
+}
+
 // GetKeyPair retrieves a specific key pair by name
 func (c *Client) GetKeyPair(ctx context.Context, keyName string) (*types.AWSResource, error) {
 	c.logger.WithField("keyName", keyName).Info("GetKeyPair called")
@@ -1283,3 +1277,160 @@ func (c *Client) GetKeyPair(ctx context.Context, keyName string) (*types.AWSReso
 
 	return resource, nil
 }
+
+// ========== EBS Volume and Elastic IP Methods ==========
+
+// DescribeVolumes lists the EBS volumes in the region
+func (c *Client) DescribeVolumes(ctx context.Context) ([]*types.AWSResource, error) {
+	var resources []*types.AWSResource
+
+	paginator := ec2.NewDescribeVolumesPaginator(c.ec2, &ec2.DescribeVolumesInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe volumes: %w", err)
+		}
+		for _, volume := range page.Volumes {
+			resources = append(resources, c.convertVolume(volume))
+		}
+	}
+
+	return resources, nil
+}
+
+// DescribeAddresses lists the Elastic IP addresses in the region
+func (c *Client) DescribeAddresses(ctx context.Context) ([]*types.AWSResource, error) {
+	result, err := c.ec2.DescribeAddresses(ctx, &ec2.DescribeAddressesInput{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe addresses: %w", err)
+	}
+
+	var resources []*types.AWSResource
+	for _, address := range result.Addresses {
+		resources = append(resources, c.convertAddress(address))
+	}
+
+	return resources, nil
+}
+
+// DeleteVolume deletes an EBS volume that is not attached to an instance
+func (c *Client) DeleteVolume(ctx context.Context, volumeID string) error {
+	_, err := c.ec2.DeleteVolume(ctx, &ec2.DeleteVolumeInput{
+		VolumeId: aws.String(volumeID),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete volume %s: %w", volumeID, err)
+	}
+
+	c.logger.WithField("volumeId", volumeID).Info("EBS volume deleted")
+	return nil
+}
+
+// ReleaseAddress releases an Elastic IP address, disassociating it first when it is associated
+func (c *Client) ReleaseAddress(ctx context.Context, allocationID string) error {
+	result, err := c.ec2.DescribeAddresses(ctx, &ec2.DescribeAddressesInput{
+		AllocationIds: []string{allocationID},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to describe address %s: %w", allocationID, err)
+	}
+
+	for _, address := range result.Addresses {
+		if address.AssociationId == nil {
+			continue
+		}
+		if _, err := c.ec2.DisassociateAddress(ctx, &ec2.DisassociateAddressInput{
+			AssociationId: address.AssociationId,
+		}); err != nil {
+			return fmt.Errorf("failed to disassociate address %s: %w", allocationID, err)
+		}
+	}
+
+	if _, err := c.ec2.ReleaseAddress(ctx, &ec2.ReleaseAddressInput{
+		AllocationId: aws.String(allocationID),
+	}); err != nil {
+		return fmt.Errorf("failed to release address %s: %w", allocationID, err)
+	}
+
+	c.logger.WithField("allocationId", allocationID).Info("Elastic IP address released")
+	return nil
+}
+
+// convertVolume converts an EBS volume to our internal resource representation
+func (c *Client) convertVolume(volume ec2types.Volume) *types.AWSResource {
+	tags := make(map[string]string)
+	for _, tag := range volume.Tags {
+		if tag.Key != nil && tag.Value != nil {
+			tags[*tag.Key] = *tag.Value
+		}
+	}
+
+	var attachedInstances []string
+	for _, attachment := range volume.Attachments {
+		if attachment.InstanceId != nil {
+			attachedInstances = append(attachedInstances, *attachment.InstanceId)
+		}
+	}
+
+	details := map[string]interface{}{
+		"size":              aws.ToInt32(volume.Size),
+		"volumeType":        string(volume.VolumeType),
+		"iops":              aws.ToInt32(volume.Iops),
+		"throughput":        aws.ToInt32(volume.Throughput),
+		"encrypted":         aws.ToBool(volume.Encrypted),
+		"availabilityZone":  aws.ToString(volume.AvailabilityZone),
+		"snapshotId":        aws.ToString(volume.SnapshotId),
+		"attachedInstances": attachedInstances,
+		"createTime":        volume.CreateTime,
+	}
+
+	return &types.AWSResource{
+		ID:       aws.ToString(volume.VolumeId),
+		Type:     "volume",
+		Region:   c.cfg.Region,
+		State:    string(volume.State),
+		Tags:     tags,
+		Details:  details,
+		LastSeen: time.Now(),
+	}
+}
+
+// convertAddress converts an Elastic IP address to our internal resource representation
+func (c *Client) convertAddress(address ec2types.Address) *types.AWSResource {
+	tags := make(map[string]string)
+	for _, tag := range address.Tags {
+		if tag.Key != nil && tag.Value != nil {
+			tags[*tag.Key] = *tag.Value
+		}
+	}
+
+	details := map[string]interface{}{
+		"publicIp":           aws.ToString(address.PublicIp),
+		"allocationId":       aws.ToString(address.AllocationId),
+		"associationId":      aws.ToString(address.AssociationId),
+		"instanceId":         aws.ToString(address.InstanceId),
+		"networkInterfaceId": aws.ToString(address.NetworkInterfaceId),
+		"privateIpAddress":   aws.ToString(address.PrivateIpAddress),
+		"domain":             string(address.Domain),
+	}
+
+	state := "unassociated"
+	if address.AssociationId != nil {
+		state = "associated"
+	}
+
+	id := aws.ToString(address.AllocationId)
+	if id == "" {
+		id = aws.ToString(address.PublicIp)
+	}
+
+	return &types.AWSResource{
+		ID:       id,
+		Type:     "elastic-ip",
+		Region:   c.cfg.Region,
+		State:    state,
+		Tags:     tags,
+		Details:  details,
+		LastSeen: time.Now(),
+	}
+}
@@ -0,0 +1,590 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/aws/smithy-go"
+	"github.com/sirupsen/logrus"
+
+	"github.com/versus-control/ai-infrastructure-agent/pkg/types"
+)
+
+// ========== IAM Role and Instance Profile Methods ==========
+
+// IAMPolicyTemplate describes a least-privilege permission set that can be
+// attached to a role, either as an AWS managed policy or as a rendered inline policy
+type IAMPolicyTemplate struct {
+	Name             string   `json:"name"`
+	Description      string   `json:"description"`
+	ManagedPolicyARN string   `json:"managedPolicyArn,omitempty"`
+	Actions          []string `json:"actions,omitempty"`
+	ResourceFormats  []string `json:"-"` // fmt patterns applied to each requested resource
+	RequiresResource bool     `json:"requiresResources"`
+}
+
+// iamPolicyTemplates are the built-in permission sets offered to the agent
+var iamPolicyTemplates = map[string]IAMPolicyTemplate{
+	"ssm-managed-instance": {
+		Name:             "ssm-managed-instance",
+		Description:      "Lets the instance register with Systems Manager (Session Manager, Run Command, Patch Manager)",
+		ManagedPolicyARN: "arn:aws:iam::aws:policy/AmazonSSMManagedInstanceCore",
+	},
+	"cloudwatch-agent": {
+		Name:             "cloudwatch-agent",
+		Description:      "Lets the CloudWatch agent publish metrics and logs",
+		ManagedPolicyARN: "arn:aws:iam::aws:policy/CloudWatchAgentServerPolicy",
+	},
+	"s3-read-only": {
+		Name:             "s3-read-only",
+		Description:      "Read objects from the given buckets",
+		Actions:          []string{"s3:GetObject", "s3:ListBucket"},
+		ResourceFormats:  []string{"arn:aws:s3:::%s", "arn:aws:s3:::%s/*"},
+		RequiresResource: true,
+	},
+	"s3-read-write": {
+		Name:             "s3-read-write",
+		Description:      "Read, write and delete objects in the given buckets",
+		Actions:          []string{"s3:GetObject", "s3:PutObject", "s3:DeleteObject", "s3:ListBucket"},
+		ResourceFormats:  []string{"arn:aws:s3:::%s", "arn:aws:s3:::%s/*"},
+		RequiresResource: true,
+	},
+	"ssm-parameter-read": {
+		Name:             "ssm-parameter-read",
+		Description:      "Read SSM parameters under the given paths (e.g. /myapp/prod)",
+		Actions:          []string{"ssm:GetParameter", "ssm:GetParameters", "ssm:GetParametersByPath"},
+		ResourceFormats:  []string{"arn:aws:ssm:*:*:parameter%s", "arn:aws:ssm:*:*:parameter%s/*"},
+		RequiresResource: true,
+	},
+	"secrets-read": {
+		Name:             "secrets-read",
+		Description:      "Read the values of the given Secrets Manager secret ARNs",
+		Actions:          []string{"secretsmanager:GetSecretValue", "secretsmanager:DescribeSecret"},
+		ResourceFormats:  []string{"%s"},
+		RequiresResource: true,
+	},
+	"cloudwatch-logs-write": {
+		Name:             "cloudwatch-logs-write",
+		Description:      "Write to the given CloudWatch log groups",
+		Actions:          []string{"logs:CreateLogGroup", "logs:CreateLogStream", "logs:PutLogEvents", "logs:DescribeLogStreams"},
+		ResourceFormats:  []string{"arn:aws:logs:*:*:log-group:%s", "arn:aws:logs:*:*:log-group:%s:*"},
+		RequiresResource: true,
+	},
+}
+
+// ListIAMPolicyTemplates returns the built-in policy templates sorted by name
+func ListIAMPolicyTemplates() []IAMPolicyTemplate {
+	names := make([]string, 0, len(iamPolicyTemplates))
+	for name := range iamPolicyTemplates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	templates := make([]IAMPolicyTemplate, 0, len(names))
+	for _, name := range names {
+		templates = append(templates, iamPolicyTemplates[name])
+	}
+	return templates
+}
+
+// RenderIAMPolicyTemplate returns either the managed policy ARN of a template or,
+// for resource-scoped templates, an inline policy document limited to the given resources
+func RenderIAMPolicyTemplate(name string, resources []string) (managedPolicyARN string, policyDocument string, err error) {
+	template, exists := iamPolicyTemplates[name]
+	if !exists {
+		return "", "", fmt.Errorf("unknown IAM policy template: %s", name)
+	}
+
+	if template.ManagedPolicyARN != "" {
+		return template.ManagedPolicyARN, "", nil
+	}
+
+	if template.RequiresResource && len(resources) == 0 {
+		return "", "", fmt.Errorf("policy template %s requires at least one resource", name)
+	}
+
+	var resourceARNs []string
+	for _, resource := range resources {
+		resource = strings.TrimSpace(resource)
+		if resource == "" || resource == "*" {
+			return "", "", fmt.Errorf("policy template %s must be scoped to specific resources, not a wildcard", name)
+		}
+		for _, format := range template.ResourceFormats {
+			resourceARNs = append(resourceARNs, fmt.Sprintf(format, resource))
+		}
+	}
+
+	document := map[string]interface{}{
+		"Version": "2012-10-17",
+		"Statement": []map[string]interface{}{
+			{
+				"Effect":   "Allow",
+				"Action":   template.Actions,
+				"Resource": resourceARNs,
+			},
+		},
+	}
+
+	documentBytes, err := json.Marshal(document)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to render policy template %s: %w", name, err)
+	}
+
+	return "", string(documentBytes), nil
+}
+
+// serviceTrustPolicy builds an assume-role policy document for an AWS service principal
+func serviceTrustPolicy(service string) (string, error) {
+	document := map[string]interface{}{
+		"Version": "2012-10-17",
+		"Statement": []map[string]interface{}{
+			{
+				"Effect":    "Allow",
+				"Principal": map[string]interface{}{"Service": service},
+				"Action":    "sts:AssumeRole",
+			},
+		},
+	}
+
+	documentBytes, err := json.Marshal(document)
+	if err != nil {
+		return "", fmt.Errorf("failed to build trust policy: %w", err)
+	}
+	return string(documentBytes), nil
+}
+
+// CreateRole creates an IAM role, attaches its managed policies and adds its inline policies
+func (c *Client) CreateRole(ctx context.Context, params CreateRoleParams) (*types.AWSResource, error) {
+	c.logger.WithFields(logrus.Fields{
+		"roleName":        params.RoleName,
+		"trustedService":  params.TrustedService,
+		"managedPolicies": len(params.ManagedPolicyARNs),
+		"inlinePolicies":  len(params.InlinePolicies),
+	}).Info("CreateRole called with parameters")
+
+	trustPolicy := params.AssumeRolePolicyDocument
+	if trustPolicy == "" {
+		service := params.TrustedService
+		if service == "" {
+			service = "ec2.amazonaws.com"
+		}
+
+		var err error
+		if trustPolicy, err = serviceTrustPolicy(service); err != nil {
+			return nil, err
+		}
+	}
+
+	input := &iam.CreateRoleInput{
+		RoleName:                 aws.String(params.RoleName),
+		AssumeRolePolicyDocument: aws.String(trustPolicy),
+	}
+
+	if params.Description != "" {
+		input.Description = aws.String(params.Description)
+	}
+	if params.Path != "" {
+		input.Path = aws.String(params.Path)
+	}
+	if params.MaxSessionDuration > 0 {
+		input.MaxSessionDuration = aws.Int32(params.MaxSessionDuration)
+	}
+	if tags := resourceTags(ctx, params.Tags); len(tags) > 0 {
+		input.Tags = toIAMTags(tags)
+	}
+
+	if _, err := c.iam.CreateRole(ctx, input); err != nil {
+		return nil, fmt.Errorf("failed to create role %s: %w", params.RoleName, err)
+	}
+
+	for _, policyARN := range params.ManagedPolicyARNs {
+		if err := c.AttachRolePolicy(ctx, AttachRolePolicyParams{RoleName: params.RoleName, PolicyARN: policyARN}); err != nil {
+			return nil, err
+		}
+	}
+
+	for policyName, document := range params.InlinePolicies {
+		if err := c.PutRolePolicy(ctx, PutRolePolicyParams{RoleName: params.RoleName, PolicyName: policyName, PolicyDocument: document}); err != nil {
+			return nil, err
+		}
+	}
+
+	c.logger.WithField("roleName", params.RoleName).Info("IAM role created successfully")
+	return c.GetRole(ctx, params.RoleName)
+}
+
+// AttachRolePolicy attaches a managed policy to a role
+func (c *Client) AttachRolePolicy(ctx context.Context, params AttachRolePolicyParams) error {
+	_, err := c.iam.AttachRolePolicy(ctx, &iam.AttachRolePolicyInput{
+		RoleName:  aws.String(params.RoleName),
+		PolicyArn: aws.String(params.PolicyARN),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to attach policy %s to role %s: %w", params.PolicyARN, params.RoleName, err)
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"roleName":  params.RoleName,
+		"policyArn": params.PolicyARN,
+	}).Info("Managed policy attached to role")
+	return nil
+}
+
+// PutRolePolicy adds or replaces an inline policy on a role
+func (c *Client) PutRolePolicy(ctx context.Context, params PutRolePolicyParams) error {
+	if !json.Valid([]byte(params.PolicyDocument)) {
+		return fmt.Errorf("policy document for %s is not valid JSON", params.PolicyName)
+	}
+
+	_, err := c.iam.PutRolePolicy(ctx, &iam.PutRolePolicyInput{
+		RoleName:       aws.String(params.RoleName),
+		PolicyName:     aws.String(params.PolicyName),
+		PolicyDocument: aws.String(params.PolicyDocument),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to put inline policy %s on role %s: %w", params.PolicyName, params.RoleName, err)
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"roleName":   params.RoleName,
+		"policyName": params.PolicyName,
+	}).Info("Inline policy added to role")
+	return nil
+}
+
+// GetRole retrieves a role with its attached and inline policies and instance profiles
+func (c *Client) GetRole(ctx context.Context, roleName string) (*types.AWSResource, error) {
+	result, err := c.iam.GetRole(ctx, &iam.GetRoleInput{RoleName: aws.String(roleName)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get role %s: %w", roleName, err)
+	}
+
+	resource := c.convertIAMRole(*result.Role)
+
+	attachedPolicies, err := c.listAttachedRolePolicyARNs(ctx, roleName)
+	if err != nil {
+		return nil, err
+	}
+	resource.Details["attachedPolicies"] = attachedPolicies
+
+	inlinePolicies, err := c.iam.ListRolePolicies(ctx, &iam.ListRolePoliciesInput{RoleName: aws.String(roleName)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list inline policies for role %s: %w", roleName, err)
+	}
+	resource.Details["inlinePolicies"] = inlinePolicies.PolicyNames
+
+	profiles, err := c.iam.ListInstanceProfilesForRole(ctx, &iam.ListInstanceProfilesForRoleInput{RoleName: aws.String(roleName)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list instance profiles for role %s: %w", roleName, err)
+	}
+	var profileNames []string
+	for _, profile := range profiles.InstanceProfiles {
+		profileNames = append(profileNames, aws.ToString(profile.InstanceProfileName))
+	}
+	resource.Details["instanceProfiles"] = profileNames
+
+	return resource, nil
+}
+
+// ListRoles lists IAM roles, optionally restricted to a path prefix.
+// Service-linked roles are excluded since they cannot be managed directly.
+func (c *Client) ListRoles(ctx context.Context, pathPrefix string) ([]*types.AWSResource, error) {
+	input := &iam.ListRolesInput{}
+	if pathPrefix != "" {
+		input.PathPrefix = aws.String(pathPrefix)
+	}
+
+	var roles []*types.AWSResource
+	paginator := iam.NewListRolesPaginator(c.iam, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list roles: %w", err)
+		}
+
+		for _, role := range page.Roles {
+			if strings.HasPrefix(aws.ToString(role.Path), "/aws-service-role/") {
+				continue
+			}
+			roles = append(roles, c.convertIAMRole(role))
+		}
+	}
+
+	return roles, nil
+}
+
+// DeleteRole detaches managed policies, deletes inline policies and removes the
+// role from its instance profiles before deleting the role itself
+func (c *Client) DeleteRole(ctx context.Context, roleName string) error {
+	attachedPolicies, err := c.listAttachedRolePolicyARNs(ctx, roleName)
+	if err != nil {
+		return err
+	}
+	for _, policyARN := range attachedPolicies {
+		if _, err := c.iam.DetachRolePolicy(ctx, &iam.DetachRolePolicyInput{
+			RoleName:  aws.String(roleName),
+			PolicyArn: aws.String(policyARN),
+		}); err != nil {
+			return fmt.Errorf("failed to detach policy %s from role %s: %w", policyARN, roleName, err)
+		}
+	}
+
+	inlinePolicies, err := c.iam.ListRolePolicies(ctx, &iam.ListRolePoliciesInput{RoleName: aws.String(roleName)})
+	if err != nil {
+		return fmt.Errorf("failed to list inline policies for role %s: %w", roleName, err)
+	}
+	for _, policyName := range inlinePolicies.PolicyNames {
+		if _, err := c.iam.DeleteRolePolicy(ctx, &iam.DeleteRolePolicyInput{
+			RoleName:   aws.String(roleName),
+			PolicyName: aws.String(policyName),
+		}); err != nil {
+			return fmt.Errorf("failed to delete inline policy %s from role %s: %w", policyName, roleName, err)
+		}
+	}
+
+	profiles, err := c.iam.ListInstanceProfilesForRole(ctx, &iam.ListInstanceProfilesForRoleInput{RoleName: aws.String(roleName)})
+	if err != nil {
+		return fmt.Errorf("failed to list instance profiles for role %s: %w", roleName, err)
+	}
+	for _, profile := range profiles.InstanceProfiles {
+		if _, err := c.iam.RemoveRoleFromInstanceProfile(ctx, &iam.RemoveRoleFromInstanceProfileInput{
+			RoleName:            aws.String(roleName),
+			InstanceProfileName: profile.InstanceProfileName,
+		}); err != nil {
+			return fmt.Errorf("failed to remove role %s from instance profile %s: %w", roleName, aws.ToString(profile.InstanceProfileName), err)
+		}
+	}
+
+	if _, err := c.iam.DeleteRole(ctx, &iam.DeleteRoleInput{RoleName: aws.String(roleName)}); err != nil {
+		return fmt.Errorf("failed to delete role %s: %w", roleName, err)
+	}
+
+	c.logger.WithField("roleName", roleName).Info("IAM role deleted successfully")
+	return nil
+}
+
+// CreateInstanceProfile creates an instance profile and adds the role to it
+func (c *Client) CreateInstanceProfile(ctx context.Context, params CreateInstanceProfileParams) (*types.AWSResource, error) {
+	input := &iam.CreateInstanceProfileInput{
+		InstanceProfileName: aws.String(params.InstanceProfileName),
+	}
+	if params.Path != "" {
+		input.Path = aws.String(params.Path)
+	}
+	if tags := resourceTags(ctx, params.Tags); len(tags) > 0 {
+		input.Tags = toIAMTags(tags)
+	}
+
+	if _, err := c.iam.CreateInstanceProfile(ctx, input); err != nil {
+		return nil, fmt.Errorf("failed to create instance profile %s: %w", params.InstanceProfileName, err)
+	}
+
+	if params.RoleName != "" {
+		if _, err := c.iam.AddRoleToInstanceProfile(ctx, &iam.AddRoleToInstanceProfileInput{
+			InstanceProfileName: aws.String(params.InstanceProfileName),
+			RoleName:            aws.String(params.RoleName),
+		}); err != nil {
+			return nil, fmt.Errorf("failed to add role %s to instance profile %s: %w", params.RoleName, params.InstanceProfileName, err)
+		}
+	}
+
+	// Wait until IAM reports the profile so EC2 calls made right afterwards can find it
+	waiter := iam.NewInstanceProfileExistsWaiter(c.iam)
+	if err := waiter.Wait(ctx, &iam.GetInstanceProfileInput{
+		InstanceProfileName: aws.String(params.InstanceProfileName),
+	}, 2*time.Minute); err != nil {
+		c.logger.WithError(err).Warn("Instance profile not visible yet, continuing")
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"instanceProfileName": params.InstanceProfileName,
+		"roleName":            params.RoleName,
+	}).Info("Instance profile created successfully")
+
+	return c.GetInstanceProfile(ctx, params.InstanceProfileName)
+}
+
+// GetInstanceProfile retrieves an instance profile and the roles it contains
+func (c *Client) GetInstanceProfile(ctx context.Context, name string) (*types.AWSResource, error) {
+	result, err := c.iam.GetInstanceProfile(ctx, &iam.GetInstanceProfileInput{
+		InstanceProfileName: aws.String(name),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get instance profile %s: %w", name, err)
+	}
+
+	return c.convertInstanceProfile(*result.InstanceProfile), nil
+}
+
+// ListInstanceProfiles lists all instance profiles
+func (c *Client) ListInstanceProfiles(ctx context.Context) ([]*types.AWSResource, error) {
+	var profiles []*types.AWSResource
+	paginator := iam.NewListInstanceProfilesPaginator(c.iam, &iam.ListInstanceProfilesInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list instance profiles: %w", err)
+		}
+
+		for _, profile := range page.InstanceProfiles {
+			profiles = append(profiles, c.convertInstanceProfile(profile))
+		}
+	}
+
+	return profiles, nil
+}
+
+// DeleteInstanceProfile removes all roles from an instance profile and deletes it
+func (c *Client) DeleteInstanceProfile(ctx context.Context, name string) error {
+	result, err := c.iam.GetInstanceProfile(ctx, &iam.GetInstanceProfileInput{
+		InstanceProfileName: aws.String(name),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get instance profile %s: %w", name, err)
+	}
+
+	for _, role := range result.InstanceProfile.Roles {
+		if _, err := c.iam.RemoveRoleFromInstanceProfile(ctx, &iam.RemoveRoleFromInstanceProfileInput{
+			InstanceProfileName: aws.String(name),
+			RoleName:            role.RoleName,
+		}); err != nil {
+			return fmt.Errorf("failed to remove role %s from instance profile %s: %w", aws.ToString(role.RoleName), name, err)
+		}
+	}
+
+	if _, err := c.iam.DeleteInstanceProfile(ctx, &iam.DeleteInstanceProfileInput{
+		InstanceProfileName: aws.String(name),
+	}); err != nil {
+		return fmt.Errorf("failed to delete instance profile %s: %w", name, err)
+	}
+
+	c.logger.WithField("instanceProfileName", name).Info("Instance profile deleted successfully")
+	return nil
+}
+
+// listAttachedRolePolicyARNs returns the ARNs of all managed policies attached to a role
+func (c *Client) listAttachedRolePolicyARNs(ctx context.Context, roleName string) ([]string, error) {
+	var policyARNs []string
+	paginator := iam.NewListAttachedRolePoliciesPaginator(c.iam, &iam.ListAttachedRolePoliciesInput{
+		RoleName: aws.String(roleName),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list attached policies for role %s: %w", roleName, err)
+		}
+		for _, policy := range page.AttachedPolicies {
+			policyARNs = append(policyARNs, aws.ToString(policy.PolicyArn))
+		}
+	}
+	return policyARNs, nil
+}
+
+// convertIAMRole converts an IAM role to the internal resource format
+func (c *Client) convertIAMRole(role iamtypes.Role) *types.AWSResource {
+	tags := make(map[string]string)
+	for _, tag := range role.Tags {
+		if tag.Key != nil && tag.Value != nil {
+			tags[*tag.Key] = *tag.Value
+		}
+	}
+
+	details := map[string]interface{}{
+		"roleName":    aws.ToString(role.RoleName),
+		"roleArn":     aws.ToString(role.Arn),
+		"roleId":      aws.ToString(role.RoleId),
+		"path":        aws.ToString(role.Path),
+		"description": aws.ToString(role.Description),
+	}
+
+	// The trust policy comes back URL-encoded
+	if role.AssumeRolePolicyDocument != nil {
+		if decoded, err := url.QueryUnescape(*role.AssumeRolePolicyDocument); err == nil {
+			details["assumeRolePolicyDocument"] = decoded
+		}
+	}
+	if role.CreateDate != nil {
+		details["createDate"] = *role.CreateDate
+	}
+
+	return &types.AWSResource{
+		ID:       aws.ToString(role.RoleName),
+		Type:     "iam_role",
+		Region:   "global",
+		State:    "available",
+		Tags:     tags,
+		Details:  details,
+		LastSeen: time.Now(),
+	}
+}
+
+// convertInstanceProfile converts an IAM instance profile to the internal resource format
+func (c *Client) convertInstanceProfile(profile iamtypes.InstanceProfile) *types.AWSResource {
+	tags := make(map[string]string)
+	for _, tag := range profile.Tags {
+		if tag.Key != nil && tag.Value != nil {
+			tags[*tag.Key] = *tag.Value
+		}
+	}
+
+	var roleNames []string
+	for _, role := range profile.Roles {
+		roleNames = append(roleNames, aws.ToString(role.RoleName))
+	}
+
+	return &types.AWSResource{
+		ID:     aws.ToString(profile.InstanceProfileName),
+		Type:   "iam_instance_profile",
+		Region: "global",
+		State:  "available",
+		Tags:   tags,
+		Details: map[string]interface{}{
+			"instanceProfileName": aws.ToString(profile.InstanceProfileName),
+			"instanceProfileArn":  aws.ToString(profile.Arn),
+			"instanceProfileId":   aws.ToString(profile.InstanceProfileId),
+			"path":                aws.ToString(profile.Path),
+			"roles":               roleNames,
+		},
+		LastSeen: time.Now(),
+	}
+}
+
+// toIAMTags converts a tag map to IAM tags
+func toIAMTags(tags map[string]string) []iamtypes.Tag {
+	var iamTags []iamtypes.Tag
+	for key, value := range tags {
+		iamTags = append(iamTags, iamtypes.Tag{
+			Key:   aws.String(key),
+			Value: aws.String(value),
+		})
+	}
+	return iamTags
+}
+
+// instanceProfileSpecification accepts either an instance profile name or ARN
+func instanceProfileSpecification(profile string) *ec2types.IamInstanceProfileSpecification {
+	if strings.HasPrefix(profile, "arn:") {
+		return &ec2types.IamInstanceProfileSpecification{Arn: aws.String(profile)}
+	}
+	return &ec2types.IamInstanceProfileSpecification{Name: aws.String(profile)}
+}
+
+// isInstanceProfilePropagationError reports whether EC2 rejected an instance
+// profile that IAM has created but not yet propagated
+func isInstanceProfilePropagationError(err error) bool {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		return strings.Contains(apiErr.ErrorMessage(), "Invalid IAM Instance Profile")
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	kmstypes "github.com/aws/aws-sdk-go-v2/service/kms/types"
+)
+
+// ========== KMS Methods ==========
+
+// GenerateDataKey creates a 256-bit data key under a KMS key. It returns the plaintext key, the
+// key encrypted by KMS and the ARN of the KMS key. The encryption context must be passed again
+// to DecryptDataKey.
+func (c *Client) GenerateDataKey(ctx context.Context, keyID string, encryptionContext map[string]string) ([]byte, []byte, string, error) {
+	result, err := c.kms.GenerateDataKey(ctx, &kms.GenerateDataKeyInput{
+		KeyId:             aws.String(keyID),
+		KeySpec:           kmstypes.DataKeySpecAes256,
+		EncryptionContext: encryptionContext,
+	})
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("failed to generate data key with KMS key %s: %w", keyID, err)
+	}
+
+	return result.Plaintext, result.CiphertextBlob, aws.ToString(result.KeyId), nil
+}
+
+// DecryptDataKey decrypts a data key produced by GenerateDataKey
+func (c *Client) DecryptDataKey(ctx context.Context, keyID string, encryptedKey []byte, encryptionContext map[string]string) ([]byte, error) {
+	input := &kms.DecryptInput{
+		CiphertextBlob:    encryptedKey,
+		EncryptionContext: encryptionContext,
+	}
+	if keyID != "" {
+		input.KeyId = aws.String(keyID)
+	}
+
+	result, err := c.kms.Decrypt(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt data key with KMS: %w", err)
+	}
+
+	return result.Plaintext, nil
+}
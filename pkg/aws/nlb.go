@@ -0,0 +1,272 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+
+	"github.com/versus-control/ai-infrastructure-agent/pkg/types"
+
+	"github.com/sirupsen/logrus"
+)
+
+// crossZoneAttributeKey is the load balancer attribute controlling cross-zone load balancing
+const crossZoneAttributeKey = "load_balancing.cross_zone.enabled"
+
+// loadBalancerAddressTagKey tags the Elastic IPs the agent allocates for a Network Load Balancer
+// with its name, so they are released when the load balancer is deleted. Addresses the caller
+// brought are never tagged and never released.
+const loadBalancerAddressTagKey = "LoadBalancerName"
+
+// ========== Network Load Balancer Methods ==========
+
+// CreateNetworkLoadBalancer creates a Network Load Balancer. Each subnet mapping may carry
+// a static Elastic IP (internet-facing) or private address (internal); with AllocateElasticIPs
+// set, Elastic IPs are allocated for mappings that do not name one.
+func (c *Client) CreateNetworkLoadBalancer(ctx context.Context, params CreateLoadBalancerParams) (*types.AWSResource, error) {
+	mappings := params.SubnetMappings
+	if len(mappings) == 0 {
+		for _, subnetID := range params.Subnets {
+			mappings = append(mappings, SubnetMappingParams{SubnetID: subnetID})
+		}
+	}
+	if len(mappings) == 0 {
+		return nil, fmt.Errorf("at least one subnet must be specified for Network Load Balancer creation")
+	}
+
+	scheme := params.Scheme
+	if scheme == "" {
+		scheme = "internet-facing"
+	}
+
+	// Allocate Elastic IPs up front so the load balancer gets static addresses from the start
+	var allocatedIDs []string
+	if params.AllocateElasticIPs {
+		if scheme != "internet-facing" {
+			return nil, fmt.Errorf("elastic IPs can only be assigned to internet-facing Network Load Balancers")
+		}
+
+		for i := range mappings {
+			if mappings[i].AllocationID != "" {
+				continue
+			}
+
+			allocationID, err := c.allocateLoadBalancerAddress(ctx, params.Name, mappings[i].SubnetID, resourceTags(ctx, params.Tags))
+			if err != nil {
+				c.releaseAddresses(ctx, allocatedIDs)
+				return nil, err
+			}
+			mappings[i].AllocationID = allocationID
+			allocatedIDs = append(allocatedIDs, allocationID)
+		}
+	}
+
+	input := &elasticloadbalancingv2.CreateLoadBalancerInput{
+		Name:   aws.String(params.Name),
+		Scheme: elbv2types.LoadBalancerSchemeEnum(scheme),
+		Type:   elbv2types.LoadBalancerTypeEnumNetwork,
+	}
+	if params.IpAddressType != "" {
+		input.IpAddressType = elbv2types.IpAddressType(params.IpAddressType)
+	}
+	if len(params.SecurityGroups) > 0 {
+		input.SecurityGroups = params.SecurityGroups
+	}
+
+	for _, mapping := range mappings {
+		subnetMapping := elbv2types.SubnetMapping{
+			SubnetId: aws.String(mapping.SubnetID),
+		}
+		if mapping.AllocationID != "" {
+			subnetMapping.AllocationId = aws.String(mapping.AllocationID)
+		}
+		if mapping.PrivateIPv4Address != "" {
+			subnetMapping.PrivateIPv4Address = aws.String(mapping.PrivateIPv4Address)
+		}
+		input.SubnetMappings = append(input.SubnetMappings, subnetMapping)
+	}
+
+	// Add tags
+	input.Tags = toELBTags(resourceTags(ctx, params.Tags))
+
+	result, err := c.elbv2.CreateLoadBalancer(ctx, input)
+	if err != nil {
+		c.releaseAddresses(ctx, allocatedIDs)
+		return nil, fmt.Errorf("failed to create network load balancer: %w", err)
+	}
+
+	if len(result.LoadBalancers) == 0 {
+		c.releaseAddresses(ctx, allocatedIDs)
+		return nil, fmt.Errorf("no load balancer created")
+	}
+
+	lb := result.LoadBalancers[0]
+	c.logger.WithFields(logrus.Fields{
+		"lbArn":       aws.ToString(lb.LoadBalancerArn),
+		"lbName":      aws.ToString(lb.LoadBalancerName),
+		"dnsName":     aws.ToString(lb.DNSName),
+		"subnetCount": len(mappings),
+	}).Info("Network Load Balancer created successfully")
+
+	resource := c.convertLoadBalancer(lb)
+
+	var allocationIDs []string
+	for _, mapping := range mappings {
+		if mapping.AllocationID != "" {
+			allocationIDs = append(allocationIDs, mapping.AllocationID)
+		}
+	}
+	if len(allocationIDs) > 0 {
+		resource.Details["elasticIpAllocationIds"] = allocationIDs
+	}
+
+	// The load balancer and its addresses exist from here on, so a failure returns them with
+	// the error for the caller to record
+	if params.CrossZoneEnabled != nil {
+		if err := c.SetLoadBalancerCrossZone(ctx, resource.ID, *params.CrossZoneEnabled); err != nil {
+			return resource, fmt.Errorf("network load balancer %s was created but not fully configured: %w", params.Name, err)
+		}
+		resource.Details["crossZoneEnabled"] = *params.CrossZoneEnabled
+	}
+
+	return resource, nil
+}
+
+// SetLoadBalancerCrossZone turns cross-zone load balancing on or off for a load balancer
+func (c *Client) SetLoadBalancerCrossZone(ctx context.Context, loadBalancerArn string, enabled bool) error {
+	_, err := c.elbv2.ModifyLoadBalancerAttributes(ctx, &elasticloadbalancingv2.ModifyLoadBalancerAttributesInput{
+		LoadBalancerArn: aws.String(loadBalancerArn),
+		Attributes: []elbv2types.LoadBalancerAttribute{
+			{
+				Key:   aws.String(crossZoneAttributeKey),
+				Value: aws.String(strconv.FormatBool(enabled)),
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set cross-zone load balancing on %s: %w", loadBalancerArn, err)
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"loadBalancerArn": loadBalancerArn,
+		"crossZone":       enabled,
+	}).Info("Cross-zone load balancing updated")
+
+	return nil
+}
+
+// GetLoadBalancerCrossZone reports whether cross-zone load balancing is enabled for a load balancer
+func (c *Client) GetLoadBalancerCrossZone(ctx context.Context, loadBalancerArn string) (bool, error) {
+	result, err := c.elbv2.DescribeLoadBalancerAttributes(ctx, &elasticloadbalancingv2.DescribeLoadBalancerAttributesInput{
+		LoadBalancerArn: aws.String(loadBalancerArn),
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to describe attributes of %s: %w", loadBalancerArn, err)
+	}
+
+	for _, attribute := range result.Attributes {
+		if aws.ToString(attribute.Key) == crossZoneAttributeKey {
+			return strconv.ParseBool(aws.ToString(attribute.Value))
+		}
+	}
+
+	return false, nil
+}
+
+// allocateLoadBalancerAddress allocates an Elastic IP for one subnet of a Network Load Balancer
+func (c *Client) allocateLoadBalancerAddress(ctx context.Context, lbName, subnetID string, tags map[string]string) (string, error) {
+	ec2Tags := []ec2types.Tag{
+		{Key: aws.String("Name"), Value: aws.String(fmt.Sprintf("%s-%s", lbName, subnetID))},
+		{Key: aws.String(loadBalancerAddressTagKey), Value: aws.String(lbName)},
+	}
+	for k, v := range tags {
+		if k == "Name" || k == loadBalancerAddressTagKey {
+			continue
+		}
+		ec2Tags = append(ec2Tags, ec2types.Tag{Key: aws.String(k), Value: aws.String(v)})
+	}
+
+	result, err := c.ec2.AllocateAddress(ctx, &ec2.AllocateAddressInput{
+		Domain: ec2types.DomainTypeVpc,
+		TagSpecifications: []ec2types.TagSpecification{
+			{
+				ResourceType: ec2types.ResourceTypeElasticIp,
+				Tags:         ec2Tags,
+			},
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to allocate Elastic IP for subnet %s: %w", subnetID, err)
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"allocationId": aws.ToString(result.AllocationId),
+		"publicIp":     aws.ToString(result.PublicIp),
+		"subnetId":     subnetID,
+	}).Info("Elastic IP allocated for Network Load Balancer")
+
+	return aws.ToString(result.AllocationId), nil
+}
+
+// releaseLoadBalancerAddresses releases the Elastic IPs the agent allocated for a deleted
+// Network Load Balancer, waiting until the load balancer has let go of them
+func (c *Client) releaseLoadBalancerAddresses(ctx context.Context, lbName string) error {
+	var allocationIDs []string
+	check := func(ctx context.Context) (string, bool, error) {
+		result, err := c.ec2.DescribeAddresses(ctx, &ec2.DescribeAddressesInput{
+			Filters: []ec2types.Filter{
+				{Name: aws.String("tag:" + loadBalancerAddressTagKey), Values: []string{lbName}},
+			},
+		})
+		if err != nil {
+			return "", false, fmt.Errorf("failed to describe Elastic IPs of %s: %w", lbName, err)
+		}
+
+		allocationIDs = allocationIDs[:0]
+		inUse := 0
+		for _, address := range result.Addresses {
+			allocationIDs = append(allocationIDs, aws.ToString(address.AllocationId))
+			if address.AssociationId != nil || address.NetworkInterfaceId != nil {
+				inUse++
+			}
+		}
+		return fmt.Sprintf("%d of %d in use", inUse, len(result.Addresses)), inUse == 0, nil
+	}
+
+	description := fmt.Sprintf("Elastic IPs of network load balancer %s", lbName)
+	if err := c.waitForResource(ctx, description, 30*time.Second, 5*time.Minute, 10*time.Second, check); err != nil {
+		return err
+	}
+
+	c.releaseAddresses(ctx, allocationIDs)
+	return nil
+}
+
+// releaseAddresses releases Elastic IPs allocated for a load balancer that failed to create or
+// was deleted
+func (c *Client) releaseAddresses(ctx context.Context, allocationIDs []string) {
+	for _, allocationID := range allocationIDs {
+		if _, err := c.ec2.ReleaseAddress(ctx, &ec2.ReleaseAddressInput{
+			AllocationId: aws.String(allocationID),
+		}); err != nil {
+			c.logger.WithError(err).WithField("allocationId", allocationID).Warn("Failed to release Elastic IP")
+		}
+	}
+}
+
+// isNetworkProtocol reports whether a listener or target group protocol is a layer 4 protocol
+func isNetworkProtocol(protocol string) bool {
+	switch protocol {
+	case "TCP", "UDP", "TCP_UDP", "TLS":
+		return true
+	default:
+		return false
+	}
+}
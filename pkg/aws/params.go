@@ -2,12 +2,13 @@ package aws
 
 // EC2 Instance Parameters
 type CreateInstanceParams struct {
-	ImageID         string
-	InstanceType    string
-	KeyName         string
-	SecurityGroupID string
-	SubnetID        string
-	Name            string
+	ImageID            string
+	InstanceType       string
+	KeyName            string
+	SecurityGroupID    string
+	SubnetID           string
+	Name               string
+	IamInstanceProfile string // Instance profile name or ARN
 }
 
 // Key Pair Parameters
@@ -95,13 +96,25 @@ type CreateLoadBalancerParams struct {
 	Type           string   // "application", "network", or "gateway"
 	IpAddressType  string   // "ipv4" or "dualstack"
 	Subnets        []string // Subnet IDs
-	SecurityGroups []string // Security Group IDs (for ALB only)
+	SecurityGroups []string // Security Group IDs (optional for NLB)
 	Tags           map[string]string
+
+	// Network Load Balancer settings
+	SubnetMappings     []SubnetMappingParams // Takes precedence over Subnets
+	AllocateElasticIPs bool                  // Allocate an Elastic IP for each mapping without one (internet-facing only)
+	CrossZoneEnabled   *bool                 // nil keeps the AWS default (off for NLB, on for ALB)
+}
+
+// SubnetMappingParams places a load balancer node in a subnet, optionally with a static address
+type SubnetMappingParams struct {
+	SubnetID           string
+	AllocationID       string // Elastic IP allocation for internet-facing NLBs
+	PrivateIPv4Address string // Static private address for internal NLBs
 }
 
 type CreateTargetGroupParams struct {
 	Name                       string // Name of the target group
-	Protocol                   string // "HTTP", "HTTPS", "TCP", etc.
+	Protocol                   string // "HTTP", "HTTPS", "TCP", "UDP", "TCP_UDP" or "TLS"
 	Port                       int32
 	VpcID                      string
 	TargetType                 string // "instance", "ip", or "lambda"
@@ -121,7 +134,56 @@ type CreateListenerParams struct {
 	Protocol              string // "HTTP", "HTTPS", "TCP", etc.
 	Port                  int32
 	DefaultTargetGroupArn string // ARN of the default target group
-	CertificateArn        string // For HTTPS listeners
+	CertificateArn        string // For HTTPS and TLS listeners
+	CertificateDomain     string // Looked up in ACM when CertificateArn is empty
+	SslPolicy             string // For HTTPS and TLS listeners, defaults to the ELB recommended policy
+	AlpnPolicy            string // For TLS listeners, e.g. "HTTP2Preferred"
+	DefaultAction         *ListenerActionParams
+}
+
+// ListenerActionParams describes a listener or listener rule action
+type ListenerActionParams struct {
+	Type           string // "forward", "redirect" or "fixed-response"
+	TargetGroupArn string // For forward actions
+
+	// Redirect actions; empty fields keep the original request value
+	RedirectProtocol   string
+	RedirectPort       string
+	RedirectHost       string
+	RedirectPath       string
+	RedirectQuery      string
+	RedirectStatusCode string // "HTTP_301" (default) or "HTTP_302"
+
+	// Fixed response actions
+	FixedResponseStatusCode  string
+	FixedResponseContentType string
+	FixedResponseBody        string
+}
+
+// ListenerRuleConditionParams holds the match conditions of a listener rule.
+// All non-empty conditions must match for the rule to apply.
+type ListenerRuleConditionParams struct {
+	PathPatterns []string
+	HostHeaders  []string
+	HTTPMethods  []string
+	SourceIPs    []string
+	HTTPHeader   string
+	HeaderValues []string
+}
+
+type CreateListenerRuleParams struct {
+	ListenerArn string
+	Priority    int32 // 1-50000, lower numbers are evaluated first
+	Conditions  ListenerRuleConditionParams
+	Action      ListenerActionParams
+	Tags        map[string]string
+}
+
+type ModifyListenerRuleParams struct {
+	RuleArn    string
+	Priority   int32 // Zero keeps the current priority
+	Conditions *ListenerRuleConditionParams
+	Action     *ListenerActionParams
 }
 
 // RDS Parameters
@@ -150,6 +212,11 @@ type CreateDBInstanceParams struct {
 	MultiAZ                    bool
 	PubliclyAccessible         bool
 	Tags                       map[string]string
+
+	// ManageMasterUserPassword lets RDS generate the password and keep it in Secrets Manager;
+	// MasterUserPassword must be empty when it is set
+	ManageMasterUserPassword bool
+	MasterUserSecretKmsKeyID string
 }
 
 type CreateDBSnapshotParams struct {
@@ -157,3 +224,137 @@ type CreateDBSnapshotParams struct {
 	DBSnapshotIdentifier string
 	Tags                 map[string]string
 }
+
+// CreateDBCredentialsSecretParams describes a Secrets Manager secret holding generated database credentials
+type CreateDBCredentialsSecretParams struct {
+	Name                 string
+	Description          string
+	Username             string
+	Engine               string
+	DBInstanceIdentifier string
+	KmsKeyID             string
+	Tags                 map[string]string
+}
+
+// ModifyDBInstanceParams holds the instance settings to change; zero values are left untouched
+type ModifyDBInstanceParams struct {
+	DBInstanceIdentifier  string
+	DBInstanceClass       string
+	AllocatedStorage      int32
+	MaxAllocatedStorage   int32
+	StorageType           string
+	Iops                  int32
+	MultiAZ               *bool
+	BackupRetentionPeriod *int32
+	DBParameterGroupName  string
+	VpcSecurityGroupIDs   []string
+	ApplyImmediately      bool
+}
+
+type CreateDBReadReplicaParams struct {
+	DBInstanceIdentifier       string
+	SourceDBInstanceIdentifier string
+	DBInstanceClass            string
+	AvailabilityZone           string
+	DBSubnetGroupName          string
+	VpcSecurityGroupIDs        []string
+	DBParameterGroupName       string
+	MultiAZ                    bool
+	PubliclyAccessible         bool
+	Tags                       map[string]string
+}
+
+type RestoreDBInstanceFromSnapshotParams struct {
+	DBInstanceIdentifier string
+	DBSnapshotIdentifier string
+	DBInstanceClass      string
+	DBSubnetGroupName    string
+	VpcSecurityGroupIDs  []string
+	DBParameterGroupName string
+	MultiAZ              bool
+	PubliclyAccessible   bool
+	Tags                 map[string]string
+}
+
+type CreateDBParameterGroupParams struct {
+	DBParameterGroupName   string
+	DBParameterGroupFamily string
+	Description            string
+	Tags                   map[string]string
+}
+
+type ModifyDBParameterGroupParams struct {
+	DBParameterGroupName string
+	Parameters           []DBParameterParams
+}
+
+// DBParameterParams sets one engine parameter; ApplyMethod is "immediate" or "pending-reboot"
+type DBParameterParams struct {
+	Name        string
+	Value       string
+	ApplyMethod string
+}
+
+// S3 Parameters
+type CreateBucketParams struct {
+	BucketName        string
+	Versioning        bool
+	Encryption        string // "AES256" or "aws:kms"
+	KMSKeyID          string // Only used with "aws:kms" encryption
+	BlockPublicAccess bool
+	LifecycleRules    []S3LifecycleRuleParams
+	Policy            string // Bucket policy JSON document
+	Tags              map[string]string
+}
+
+// UpdateBucketParams changes bucket settings; nil or empty fields are left unchanged
+type UpdateBucketParams struct {
+	Versioning        *bool
+	Encryption        string
+	KMSKeyID          string
+	BlockPublicAccess *bool
+	LifecycleRules    []S3LifecycleRuleParams
+	Policy            string
+	Tags              map[string]string
+}
+
+type S3LifecycleRuleParams struct {
+	ID                              string
+	Prefix                          string
+	ExpirationDays                  int32
+	TransitionDays                  int32
+	TransitionStorageClass          string // e.g. "STANDARD_IA", "GLACIER"
+	NoncurrentVersionExpirationDays int32
+	AbortIncompleteUploadDays       int32
+}
+
+// IAM Parameters
+type CreateRoleParams struct {
+	RoleName                 string
+	Description              string
+	Path                     string
+	TrustedService           string // Service principal allowed to assume the role, e.g. "ec2.amazonaws.com"
+	AssumeRolePolicyDocument string // Overrides TrustedService when set
+	ManagedPolicyARNs        []string
+	InlinePolicies           map[string]string // Policy name -> policy document
+	MaxSessionDuration       int32
+	Tags                     map[string]string
+}
+
+type PutRolePolicyParams struct {
+	RoleName       string
+	PolicyName     string
+	PolicyDocument string
+}
+
+type AttachRolePolicyParams struct {
+	RoleName  string
+	PolicyARN string
+}
+
+type CreateInstanceProfileParams struct {
+	InstanceProfileName string
+	RoleName            string
+	Path                string
+	Tags                map[string]string
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/rds"
 	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+	"github.com/sirupsen/logrus"
 
 	awstypes "github.com/versus-control/ai-infrastructure-agent/pkg/types"
 )
@@ -23,16 +24,7 @@ func (c *Client) CreateDBSubnetGroup(ctx context.Context, params CreateDBSubnetG
 	}
 
 	// Add tag specifications during creation if tags are provided
-	if len(params.Tags) > 0 {
-		var rdsTags []types.Tag
-		for key, value := range params.Tags {
-			rdsTags = append(rdsTags, types.Tag{
-				Key:   aws.String(key),
-				Value: aws.String(value),
-			})
-		}
-		input.Tags = rdsTags
-	}
+	input.Tags = rdsTags(resourceTags(ctx, params.Tags))
 
 	result, err := c.rds.CreateDBSubnetGroup(ctx, input)
 	if err != nil {
@@ -50,10 +42,19 @@ func (c *Client) CreateDBInstance(ctx context.Context, params CreateDBInstancePa
 		DBInstanceClass:      aws.String(params.DBInstanceClass),
 		Engine:               aws.String(params.Engine),
 		MasterUsername:       aws.String(params.MasterUsername),
-		MasterUserPassword:   aws.String(params.MasterUserPassword),
 		AllocatedStorage:     aws.Int32(params.AllocatedStorage),
 	}
 
+	// Either RDS manages the password in Secrets Manager or the caller supplies one
+	if params.ManageMasterUserPassword {
+		input.ManageMasterUserPassword = aws.Bool(true)
+		if params.MasterUserSecretKmsKeyID != "" {
+			input.MasterUserSecretKmsKeyId = aws.String(params.MasterUserSecretKmsKeyID)
+		}
+	} else {
+		input.MasterUserPassword = aws.String(params.MasterUserPassword)
+	}
+
 	// Optional parameters
 	if params.EngineVersion != "" {
 		input.EngineVersion = aws.String(params.EngineVersion)
@@ -87,16 +88,7 @@ func (c *Client) CreateDBInstance(ctx context.Context, params CreateDBInstancePa
 	}
 
 	// Add tag specifications during creation if tags are provided
-	if len(params.Tags) > 0 {
-		var rdsTags []types.Tag
-		for key, value := range params.Tags {
-			rdsTags = append(rdsTags, types.Tag{
-				Key:   aws.String(key),
-				Value: aws.String(value),
-			})
-		}
-		input.Tags = rdsTags
-	}
+	input.Tags = rdsTags(resourceTags(ctx, params.Tags))
 
 	result, err := c.rds.CreateDBInstance(ctx, input)
 	if err != nil {
@@ -144,6 +136,38 @@ func (c *Client) GetDBInstance(ctx context.Context, dbInstanceIdentifier string)
 	return c.convertDBInstance(result.DBInstances[0]), nil
 }
 
+// WaitForDBInstance waits for a DB instance to become available
+func (c *Client) WaitForDBInstance(ctx context.Context, dbInstanceIdentifier string) error {
+	description := fmt.Sprintf("DB instance %s", dbInstanceIdentifier)
+
+	// New instances typically take 5-10 minutes
+	return c.waitForResource(ctx, description, 10*time.Minute, 30*time.Minute, 30*time.Second, func(ctx context.Context) (string, bool, error) {
+		result, err := c.rds.DescribeDBInstances(ctx, &rds.DescribeDBInstancesInput{
+			DBInstanceIdentifier: aws.String(dbInstanceIdentifier),
+		})
+		if err != nil {
+			return "", false, fmt.Errorf("failed to describe DB instance %s: %w", dbInstanceIdentifier, err)
+		}
+
+		if len(result.DBInstances) == 0 {
+			return "", false, fmt.Errorf("DB instance %s not found", dbInstanceIdentifier)
+		}
+
+		status := aws.ToString(result.DBInstances[0].DBInstanceStatus)
+		switch status {
+		case "available", "storage-optimization":
+			// storage-optimization runs in the background with the instance fully usable
+			return status, true, nil
+		case "failed", "storage-full", "inaccessible-encryption-credentials",
+			"incompatible-network", "incompatible-option-group", "incompatible-parameters",
+			"incompatible-restore", "restore-error":
+			return status, false, fmt.Errorf("DB instance %s entered status %s", dbInstanceIdentifier, status)
+		default:
+			return status, false, nil
+		}
+	})
+}
+
 // StartDBInstance starts a stopped RDS instance
 func (c *Client) StartDBInstance(ctx context.Context, dbInstanceIdentifier string) error {
 	input := &rds.StartDBInstanceInput{
@@ -202,16 +226,7 @@ func (c *Client) CreateDBSnapshot(ctx context.Context, params CreateDBSnapshotPa
 	}
 
 	// Add tag specifications during creation if tags are provided
-	if len(params.Tags) > 0 {
-		var rdsTags []types.Tag
-		for key, value := range params.Tags {
-			rdsTags = append(rdsTags, types.Tag{
-				Key:   aws.String(key),
-				Value: aws.String(value),
-			})
-		}
-		input.Tags = rdsTags
-	}
+	input.Tags = rdsTags(resourceTags(ctx, params.Tags))
 
 	result, err := c.rds.CreateDBSnapshot(ctx, input)
 	if err != nil {
@@ -243,6 +258,154 @@ func (c *Client) ListDBSnapshots(ctx context.Context) ([]awstypes.AWSResource, e
 	return resources, nil
 }
 
+// ModifyDBInstance changes the class, storage, Multi-AZ, backup or parameter group settings
+// of an instance. Without ApplyImmediately the changes wait for the next maintenance window.
+func (c *Client) ModifyDBInstance(ctx context.Context, params ModifyDBInstanceParams) (*awstypes.AWSResource, error) {
+	input := &rds.ModifyDBInstanceInput{
+		DBInstanceIdentifier: aws.String(params.DBInstanceIdentifier),
+		ApplyImmediately:     aws.Bool(params.ApplyImmediately),
+	}
+
+	if params.DBInstanceClass != "" {
+		input.DBInstanceClass = aws.String(params.DBInstanceClass)
+	}
+	if params.AllocatedStorage > 0 {
+		input.AllocatedStorage = aws.Int32(params.AllocatedStorage)
+	}
+	if params.MaxAllocatedStorage > 0 {
+		input.MaxAllocatedStorage = aws.Int32(params.MaxAllocatedStorage)
+	}
+	if params.StorageType != "" {
+		input.StorageType = aws.String(params.StorageType)
+	}
+	if params.Iops > 0 {
+		input.Iops = aws.Int32(params.Iops)
+	}
+	if params.MultiAZ != nil {
+		input.MultiAZ = params.MultiAZ
+	}
+	if params.BackupRetentionPeriod != nil {
+		input.BackupRetentionPeriod = params.BackupRetentionPeriod
+	}
+	if params.DBParameterGroupName != "" {
+		input.DBParameterGroupName = aws.String(params.DBParameterGroupName)
+	}
+	if len(params.VpcSecurityGroupIDs) > 0 {
+		input.VpcSecurityGroupIds = params.VpcSecurityGroupIDs
+	}
+
+	result, err := c.rds.ModifyDBInstance(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to modify DB instance %s: %w", params.DBInstanceIdentifier, err)
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"dbInstanceIdentifier": params.DBInstanceIdentifier,
+		"applyImmediately":     params.ApplyImmediately,
+	}).Info("DB instance modification initiated")
+
+	return c.convertDBInstance(*result.DBInstance), nil
+}
+
+// CreateDBInstanceReadReplica creates a read replica of an existing instance
+func (c *Client) CreateDBInstanceReadReplica(ctx context.Context, params CreateDBReadReplicaParams) (*awstypes.AWSResource, error) {
+	input := &rds.CreateDBInstanceReadReplicaInput{
+		DBInstanceIdentifier:       aws.String(params.DBInstanceIdentifier),
+		SourceDBInstanceIdentifier: aws.String(params.SourceDBInstanceIdentifier),
+	}
+
+	if params.DBInstanceClass != "" {
+		input.DBInstanceClass = aws.String(params.DBInstanceClass)
+	}
+	if params.AvailabilityZone != "" {
+		input.AvailabilityZone = aws.String(params.AvailabilityZone)
+	}
+	if params.DBSubnetGroupName != "" {
+		input.DBSubnetGroupName = aws.String(params.DBSubnetGroupName)
+	}
+	if len(params.VpcSecurityGroupIDs) > 0 {
+		input.VpcSecurityGroupIds = params.VpcSecurityGroupIDs
+	}
+	if params.DBParameterGroupName != "" {
+		input.DBParameterGroupName = aws.String(params.DBParameterGroupName)
+	}
+	if params.MultiAZ {
+		input.MultiAZ = aws.Bool(params.MultiAZ)
+	}
+	if params.PubliclyAccessible {
+		input.PubliclyAccessible = aws.Bool(params.PubliclyAccessible)
+	}
+	input.Tags = rdsTags(resourceTags(ctx, params.Tags))
+
+	result, err := c.rds.CreateDBInstanceReadReplica(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create read replica of %s: %w", params.SourceDBInstanceIdentifier, err)
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"dbInstanceIdentifier": params.DBInstanceIdentifier,
+		"sourceInstance":       params.SourceDBInstanceIdentifier,
+	}).Info("DB read replica creation initiated")
+
+	return c.convertDBInstance(*result.DBInstance), nil
+}
+
+// RestoreDBInstanceFromSnapshot creates a new instance from a manual or automated snapshot
+func (c *Client) RestoreDBInstanceFromSnapshot(ctx context.Context, params RestoreDBInstanceFromSnapshotParams) (*awstypes.AWSResource, error) {
+	input := &rds.RestoreDBInstanceFromDBSnapshotInput{
+		DBInstanceIdentifier: aws.String(params.DBInstanceIdentifier),
+		DBSnapshotIdentifier: aws.String(params.DBSnapshotIdentifier),
+	}
+
+	if params.DBInstanceClass != "" {
+		input.DBInstanceClass = aws.String(params.DBInstanceClass)
+	}
+	if params.DBSubnetGroupName != "" {
+		input.DBSubnetGroupName = aws.String(params.DBSubnetGroupName)
+	}
+	if len(params.VpcSecurityGroupIDs) > 0 {
+		input.VpcSecurityGroupIds = params.VpcSecurityGroupIDs
+	}
+	if params.DBParameterGroupName != "" {
+		input.DBParameterGroupName = aws.String(params.DBParameterGroupName)
+	}
+	if params.MultiAZ {
+		input.MultiAZ = aws.Bool(params.MultiAZ)
+	}
+	if params.PubliclyAccessible {
+		input.PubliclyAccessible = aws.Bool(params.PubliclyAccessible)
+	}
+	input.Tags = rdsTags(resourceTags(ctx, params.Tags))
+
+	result, err := c.rds.RestoreDBInstanceFromDBSnapshot(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore DB instance from snapshot %s: %w", params.DBSnapshotIdentifier, err)
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"dbInstanceIdentifier": params.DBInstanceIdentifier,
+		"dbSnapshotIdentifier": params.DBSnapshotIdentifier,
+	}).Info("DB instance restore initiated")
+
+	return c.convertDBInstance(*result.DBInstance), nil
+}
+
+// rdsTags converts a tag map to RDS tags, returning nil for an empty map
+func rdsTags(tags map[string]string) []types.Tag {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	var result []types.Tag
+	for key, value := range tags {
+		result = append(result, types.Tag{
+			Key:   aws.String(key),
+			Value: aws.String(value),
+		})
+	}
+	return result
+}
+
 // convertDBInstance converts an RDS DB instance to our standard resource format
 func (c *Client) convertDBInstance(dbInstance types.DBInstance) *awstypes.AWSResource {
 	tags := make(map[string]string)
@@ -276,6 +439,33 @@ func (c *Client) convertDBInstance(dbInstance types.DBInstance) *awstypes.AWSRes
 	if dbInstance.DBSubnetGroup != nil {
 		details["subnet_group"] = aws.ToString(dbInstance.DBSubnetGroup.DBSubnetGroupName)
 	}
+	if dbInstance.MasterUserSecret != nil {
+		details["master_user_secret_arn"] = aws.ToString(dbInstance.MasterUserSecret.SecretArn)
+	}
+	if dbInstance.MaxAllocatedStorage != nil {
+		details["max_allocated_storage"] = aws.ToInt32(dbInstance.MaxAllocatedStorage)
+	}
+	if dbInstance.ReadReplicaSourceDBInstanceIdentifier != nil {
+		details["read_replica_source"] = aws.ToString(dbInstance.ReadReplicaSourceDBInstanceIdentifier)
+	}
+	if len(dbInstance.ReadReplicaDBInstanceIdentifiers) > 0 {
+		details["read_replicas"] = dbInstance.ReadReplicaDBInstanceIdentifiers
+	}
+
+	var parameterGroups []string
+	for _, group := range dbInstance.DBParameterGroups {
+		parameterGroups = append(parameterGroups, aws.ToString(group.DBParameterGroupName))
+		if aws.ToString(group.ParameterApplyStatus) == "pending-reboot" {
+			details["parameter_apply_status"] = "pending-reboot"
+		}
+	}
+	if len(parameterGroups) > 0 {
+		details["parameter_groups"] = parameterGroups
+	}
+
+	if pending := convertPendingModifications(dbInstance.PendingModifiedValues); len(pending) > 0 {
+		details["pending_modifications"] = pending
+	}
 
 	return &awstypes.AWSResource{
 		ID:       aws.ToString(dbInstance.DBInstanceIdentifier),
@@ -288,6 +478,38 @@ func (c *Client) convertDBInstance(dbInstance types.DBInstance) *awstypes.AWSRes
 	}
 }
 
+// convertPendingModifications lists the instance changes still waiting to be applied
+func convertPendingModifications(pending *types.PendingModifiedValues) map[string]interface{} {
+	result := make(map[string]interface{})
+	if pending == nil {
+		return result
+	}
+
+	if pending.DBInstanceClass != nil {
+		result["instance_class"] = aws.ToString(pending.DBInstanceClass)
+	}
+	if pending.AllocatedStorage != nil {
+		result["allocated_storage"] = aws.ToInt32(pending.AllocatedStorage)
+	}
+	if pending.StorageType != nil {
+		result["storage_type"] = aws.ToString(pending.StorageType)
+	}
+	if pending.Iops != nil {
+		result["iops"] = aws.ToInt32(pending.Iops)
+	}
+	if pending.MultiAZ != nil {
+		result["multi_az"] = aws.ToBool(pending.MultiAZ)
+	}
+	if pending.BackupRetentionPeriod != nil {
+		result["backup_retention"] = aws.ToInt32(pending.BackupRetentionPeriod)
+	}
+	if pending.EngineVersion != nil {
+		result["engine_version"] = aws.ToString(pending.EngineVersion)
+	}
+
+	return result
+}
+
 // convertDBSnapshot converts an RDS DB snapshot to our standard resource format
 func (c *Client) convertDBSnapshot(snapshot types.DBSnapshot) *awstypes.AWSResource {
 	tags := make(map[string]string)
@@ -0,0 +1,166 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+	"github.com/sirupsen/logrus"
+
+	awstypes "github.com/versus-control/ai-infrastructure-agent/pkg/types"
+)
+
+// maxParametersPerModify is the number of parameters RDS accepts in one ModifyDBParameterGroup call
+const maxParametersPerModify = 20
+
+// ========== RDS Parameter Group Methods ==========
+
+// CreateDBParameterGroup creates a DB parameter group for an engine family such as mysql8.0
+func (c *Client) CreateDBParameterGroup(ctx context.Context, params CreateDBParameterGroupParams) (*awstypes.AWSResource, error) {
+	description := params.Description
+	if description == "" {
+		description = fmt.Sprintf("Parameter group %s", params.DBParameterGroupName)
+	}
+
+	result, err := c.rds.CreateDBParameterGroup(ctx, &rds.CreateDBParameterGroupInput{
+		DBParameterGroupName:   aws.String(params.DBParameterGroupName),
+		DBParameterGroupFamily: aws.String(params.DBParameterGroupFamily),
+		Description:            aws.String(description),
+		Tags:                   rdsTags(resourceTags(ctx, params.Tags)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create DB parameter group %s: %w", params.DBParameterGroupName, err)
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"dbParameterGroupName": params.DBParameterGroupName,
+		"family":               params.DBParameterGroupFamily,
+	}).Info("DB parameter group created successfully")
+
+	return c.convertDBParameterGroup(*result.DBParameterGroup), nil
+}
+
+// ModifyDBParameterGroup sets engine parameters on a parameter group. Static parameters
+// must use the pending-reboot apply method and take effect after the instance reboots.
+func (c *Client) ModifyDBParameterGroup(ctx context.Context, params ModifyDBParameterGroupParams) error {
+	if len(params.Parameters) == 0 {
+		return fmt.Errorf("at least one parameter is required to modify DB parameter group %s", params.DBParameterGroupName)
+	}
+
+	var parameters []types.Parameter
+	for _, parameter := range params.Parameters {
+		applyMethod := parameter.ApplyMethod
+		if applyMethod == "" {
+			applyMethod = string(types.ApplyMethodImmediate)
+		}
+		parameters = append(parameters, types.Parameter{
+			ParameterName:  aws.String(parameter.Name),
+			ParameterValue: aws.String(parameter.Value),
+			ApplyMethod:    types.ApplyMethod(applyMethod),
+		})
+	}
+
+	for start := 0; start < len(parameters); start += maxParametersPerModify {
+		end := start + maxParametersPerModify
+		if end > len(parameters) {
+			end = len(parameters)
+		}
+
+		_, err := c.rds.ModifyDBParameterGroup(ctx, &rds.ModifyDBParameterGroupInput{
+			DBParameterGroupName: aws.String(params.DBParameterGroupName),
+			Parameters:           parameters[start:end],
+		})
+		if err != nil {
+			return fmt.Errorf("failed to modify DB parameter group %s: %w", params.DBParameterGroupName, err)
+		}
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"dbParameterGroupName": params.DBParameterGroupName,
+		"parameterCount":       len(parameters),
+	}).Info("DB parameter group modified successfully")
+
+	return nil
+}
+
+// ListDBParameterGroups returns all DB parameter groups in the region
+func (c *Client) ListDBParameterGroups(ctx context.Context) ([]*awstypes.AWSResource, error) {
+	var groups []*awstypes.AWSResource
+
+	paginator := rds.NewDescribeDBParameterGroupsPaginator(c.rds, &rds.DescribeDBParameterGroupsInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe DB parameter groups: %w", err)
+		}
+
+		for _, group := range page.DBParameterGroups {
+			groups = append(groups, c.convertDBParameterGroup(group))
+		}
+	}
+
+	c.logger.WithField("count", len(groups)).Info("Retrieved DB parameter groups")
+	return groups, nil
+}
+
+// DescribeDBParameters returns the parameters of a group that have been changed from the
+// engine defaults
+func (c *Client) DescribeDBParameters(ctx context.Context, groupName string) ([]map[string]interface{}, error) {
+	var parameters []map[string]interface{}
+
+	paginator := rds.NewDescribeDBParametersPaginator(c.rds, &rds.DescribeDBParametersInput{
+		DBParameterGroupName: aws.String(groupName),
+		Source:               aws.String("user"),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe parameters of %s: %w", groupName, err)
+		}
+
+		for _, parameter := range page.Parameters {
+			parameters = append(parameters, map[string]interface{}{
+				"name":        aws.ToString(parameter.ParameterName),
+				"value":       aws.ToString(parameter.ParameterValue),
+				"applyType":   aws.ToString(parameter.ApplyType),
+				"applyMethod": string(parameter.ApplyMethod),
+			})
+		}
+	}
+
+	return parameters, nil
+}
+
+// DeleteDBParameterGroup deletes a DB parameter group that is no longer used by any instance
+func (c *Client) DeleteDBParameterGroup(ctx context.Context, groupName string) error {
+	_, err := c.rds.DeleteDBParameterGroup(ctx, &rds.DeleteDBParameterGroupInput{
+		DBParameterGroupName: aws.String(groupName),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete DB parameter group %s: %w", groupName, err)
+	}
+
+	c.logger.WithField("dbParameterGroupName", groupName).Info("DB parameter group deleted successfully")
+	return nil
+}
+
+// convertDBParameterGroup converts an RDS DB parameter group to our standard resource format
+func (c *Client) convertDBParameterGroup(group types.DBParameterGroup) *awstypes.AWSResource {
+	return &awstypes.AWSResource{
+		ID:     aws.ToString(group.DBParameterGroupName),
+		Type:   "db-parameter-group",
+		Region: c.cfg.Region,
+		State:  "available",
+		Tags:   make(map[string]string),
+		Details: map[string]interface{}{
+			"dbParameterGroupName": aws.ToString(group.DBParameterGroupName),
+			"dbParameterGroupArn":  aws.ToString(group.DBParameterGroupArn),
+			"family":               aws.ToString(group.DBParameterGroupFamily),
+			"description":          aws.ToString(group.Description),
+		},
+		LastSeen: time.Now(),
+	}
+}
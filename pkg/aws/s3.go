@@ -0,0 +1,453 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+	"github.com/sirupsen/logrus"
+
+	awstypes "github.com/versus-control/ai-infrastructure-agent/pkg/types"
+)
+
+// ========== S3 Bucket Management Methods ==========
+
+// CreateBucket creates an S3 bucket in the client's region and applies the requested
+// versioning, encryption, public access block, lifecycle, policy and tag settings
+func (c *Client) CreateBucket(ctx context.Context, params CreateBucketParams) (*awstypes.AWSResource, error) {
+	c.logger.WithFields(logrus.Fields{
+		"bucketName":        params.BucketName,
+		"versioning":        params.Versioning,
+		"encryption":        params.Encryption,
+		"blockPublicAccess": params.BlockPublicAccess,
+		"lifecycleRules":    len(params.LifecycleRules),
+	}).Info("CreateBucket called with parameters")
+
+	input := &s3.CreateBucketInput{
+		Bucket: aws.String(params.BucketName),
+	}
+
+	// us-east-1 is the only region that rejects an explicit location constraint
+	if c.cfg.Region != "" && c.cfg.Region != "us-east-1" {
+		input.CreateBucketConfiguration = &types.CreateBucketConfiguration{
+			LocationConstraint: types.BucketLocationConstraint(c.cfg.Region),
+		}
+	}
+
+	if _, err := c.s3.CreateBucket(ctx, input); err != nil {
+		return nil, fmt.Errorf("failed to create bucket %s: %w", params.BucketName, err)
+	}
+
+	c.logger.WithField("bucketName", params.BucketName).Info("S3 bucket created successfully")
+
+	// The bucket exists from here on, so a failing setting returns it with the error for the
+	// caller to record
+	if err := c.configureBucket(ctx, params); err != nil {
+		return &awstypes.AWSResource{
+			ID:       params.BucketName,
+			Type:     "s3_bucket",
+			Region:   c.cfg.Region,
+			State:    "created",
+			Details:  map[string]interface{}{"bucketName": params.BucketName},
+			LastSeen: time.Now(),
+		}, fmt.Errorf("bucket %s was created but not fully configured: %w", params.BucketName, err)
+	}
+
+	return c.GetBucket(ctx, params.BucketName)
+}
+
+// configureBucket applies the settings of a new bucket
+func (c *Client) configureBucket(ctx context.Context, params CreateBucketParams) error {
+	// Public access block is applied first so the bucket is never briefly public. It is also
+	// sent to open a bucket up, as new buckets block public access by default.
+	if err := c.PutPublicAccessBlock(ctx, params.BucketName, params.BlockPublicAccess); err != nil {
+		return err
+	}
+	if params.Versioning {
+		if err := c.PutBucketVersioning(ctx, params.BucketName, true); err != nil {
+			return err
+		}
+	}
+	if params.Encryption != "" {
+		if err := c.PutBucketEncryption(ctx, params.BucketName, params.Encryption, params.KMSKeyID); err != nil {
+			return err
+		}
+	}
+	if len(params.LifecycleRules) > 0 {
+		if err := c.PutBucketLifecycle(ctx, params.BucketName, params.LifecycleRules); err != nil {
+			return err
+		}
+	}
+	if params.Policy != "" {
+		if err := c.PutBucketPolicy(ctx, params.BucketName, params.Policy); err != nil {
+			return err
+		}
+	}
+	if tags := resourceTags(ctx, params.Tags); len(tags) > 0 {
+		if err := c.PutBucketTags(ctx, params.BucketName, tags); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PutBucketVersioning enables or suspends versioning on a bucket
+func (c *Client) PutBucketVersioning(ctx context.Context, bucketName string, enabled bool) error {
+	status := types.BucketVersioningStatusSuspended
+	if enabled {
+		status = types.BucketVersioningStatusEnabled
+	}
+
+	_, err := c.s3.PutBucketVersioning(ctx, &s3.PutBucketVersioningInput{
+		Bucket: aws.String(bucketName),
+		VersioningConfiguration: &types.VersioningConfiguration{
+			Status: status,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set versioning on bucket %s: %w", bucketName, err)
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"bucketName": bucketName,
+		"status":     string(status),
+	}).Info("S3 bucket versioning updated")
+	return nil
+}
+
+// PutBucketEncryption sets default server-side encryption ("AES256" or "aws:kms") on a bucket
+func (c *Client) PutBucketEncryption(ctx context.Context, bucketName, algorithm, kmsKeyID string) error {
+	defaultEncryption := &types.ServerSideEncryptionByDefault{}
+
+	switch algorithm {
+	case "AES256", "aes256", "":
+		defaultEncryption.SSEAlgorithm = types.ServerSideEncryptionAes256
+	case "aws:kms", "kms":
+		defaultEncryption.SSEAlgorithm = types.ServerSideEncryptionAwsKms
+		if kmsKeyID != "" {
+			defaultEncryption.KMSMasterKeyID = aws.String(kmsKeyID)
+		}
+	default:
+		return fmt.Errorf("unsupported encryption algorithm %s, expected AES256 or aws:kms", algorithm)
+	}
+
+	_, err := c.s3.PutBucketEncryption(ctx, &s3.PutBucketEncryptionInput{
+		Bucket: aws.String(bucketName),
+		ServerSideEncryptionConfiguration: &types.ServerSideEncryptionConfiguration{
+			Rules: []types.ServerSideEncryptionRule{
+				{
+					ApplyServerSideEncryptionByDefault: defaultEncryption,
+					BucketKeyEnabled:                   aws.Bool(defaultEncryption.SSEAlgorithm == types.ServerSideEncryptionAwsKms),
+				},
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set encryption on bucket %s: %w", bucketName, err)
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"bucketName": bucketName,
+		"algorithm":  string(defaultEncryption.SSEAlgorithm),
+	}).Info("S3 bucket encryption updated")
+	return nil
+}
+
+// PutPublicAccessBlock turns all four public access block settings on or off for a bucket
+func (c *Client) PutPublicAccessBlock(ctx context.Context, bucketName string, block bool) error {
+	_, err := c.s3.PutPublicAccessBlock(ctx, &s3.PutPublicAccessBlockInput{
+		Bucket: aws.String(bucketName),
+		PublicAccessBlockConfiguration: &types.PublicAccessBlockConfiguration{
+			BlockPublicAcls:       aws.Bool(block),
+			IgnorePublicAcls:      aws.Bool(block),
+			BlockPublicPolicy:     aws.Bool(block),
+			RestrictPublicBuckets: aws.Bool(block),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set public access block on bucket %s: %w", bucketName, err)
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"bucketName": bucketName,
+		"block":      block,
+	}).Info("S3 bucket public access block updated")
+	return nil
+}
+
+// PutBucketLifecycle replaces the lifecycle configuration of a bucket
+func (c *Client) PutBucketLifecycle(ctx context.Context, bucketName string, rules []S3LifecycleRuleParams) error {
+	var lifecycleRules []types.LifecycleRule
+	for i, rule := range rules {
+		ruleID := rule.ID
+		if ruleID == "" {
+			ruleID = fmt.Sprintf("rule-%d", i+1)
+		}
+
+		lifecycleRule := types.LifecycleRule{
+			ID:     aws.String(ruleID),
+			Status: types.ExpirationStatusEnabled,
+			Filter: &types.LifecycleRuleFilter{
+				Prefix: aws.String(rule.Prefix),
+			},
+		}
+
+		if rule.ExpirationDays > 0 {
+			lifecycleRule.Expiration = &types.LifecycleExpiration{
+				Days: aws.Int32(rule.ExpirationDays),
+			}
+		}
+		if rule.TransitionDays > 0 && rule.TransitionStorageClass != "" {
+			lifecycleRule.Transitions = []types.Transition{
+				{
+					Days:         aws.Int32(rule.TransitionDays),
+					StorageClass: types.TransitionStorageClass(rule.TransitionStorageClass),
+				},
+			}
+		}
+		if rule.NoncurrentVersionExpirationDays > 0 {
+			lifecycleRule.NoncurrentVersionExpiration = &types.NoncurrentVersionExpiration{
+				NoncurrentDays: aws.Int32(rule.NoncurrentVersionExpirationDays),
+			}
+		}
+		if rule.AbortIncompleteUploadDays > 0 {
+			lifecycleRule.AbortIncompleteMultipartUpload = &types.AbortIncompleteMultipartUpload{
+				DaysAfterInitiation: aws.Int32(rule.AbortIncompleteUploadDays),
+			}
+		}
+
+		lifecycleRules = append(lifecycleRules, lifecycleRule)
+	}
+
+	_, err := c.s3.PutBucketLifecycleConfiguration(ctx, &s3.PutBucketLifecycleConfigurationInput{
+		Bucket: aws.String(bucketName),
+		LifecycleConfiguration: &types.BucketLifecycleConfiguration{
+			Rules: lifecycleRules,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set lifecycle rules on bucket %s: %w", bucketName, err)
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"bucketName": bucketName,
+		"ruleCount":  len(lifecycleRules),
+	}).Info("S3 bucket lifecycle configuration updated")
+	return nil
+}
+
+// PutBucketPolicy attaches a bucket policy document to a bucket
+func (c *Client) PutBucketPolicy(ctx context.Context, bucketName, policy string) error {
+	_, err := c.s3.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
+		Bucket: aws.String(bucketName),
+		Policy: aws.String(policy),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set policy on bucket %s: %w", bucketName, err)
+	}
+
+	c.logger.WithField("bucketName", bucketName).Info("S3 bucket policy updated")
+	return nil
+}
+
+// PutBucketTags replaces the tag set of a bucket
+func (c *Client) PutBucketTags(ctx context.Context, bucketName string, tags map[string]string) error {
+	tagSet := make([]types.Tag, 0, len(tags))
+	for key, value := range tags {
+		tagSet = append(tagSet, types.Tag{
+			Key:   aws.String(key),
+			Value: aws.String(value),
+		})
+	}
+
+	_, err := c.s3.PutBucketTagging(ctx, &s3.PutBucketTaggingInput{
+		Bucket: aws.String(bucketName),
+		Tagging: &types.Tagging{
+			TagSet: tagSet,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to tag bucket %s: %w", bucketName, err)
+	}
+	return nil
+}
+
+// BucketLocation is a bucket of the account with the region it lives in
+type BucketLocation struct {
+	Name         string
+	Region       string
+	CreationDate *time.Time
+}
+
+// ListBuckets returns the S3 buckets that live in the client's region
+func (c *Client) ListBuckets(ctx context.Context) ([]*awstypes.AWSResource, error) {
+	locations, err := c.ListBucketLocations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var buckets []BucketLocation
+	for _, location := range locations {
+		if location.Region == c.cfg.Region {
+			buckets = append(buckets, location)
+		}
+	}
+
+	resources := c.DescribeBuckets(ctx, buckets)
+	c.logger.WithField("count", len(resources)).Info("Retrieved S3 buckets")
+	return resources, nil
+}
+
+// ListBucketLocations lists every bucket of the account with its region. Bucket listing is
+// global, so callers scanning several regions list once and describe each bucket with the
+// client of its region. Buckets whose location cannot be read are skipped.
+func (c *Client) ListBucketLocations(ctx context.Context) ([]BucketLocation, error) {
+	result, err := c.s3.ListBuckets(ctx, &s3.ListBucketsInput{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list buckets: %w", err)
+	}
+
+	var locations []BucketLocation
+	for _, bucket := range result.Buckets {
+		bucketName := aws.ToString(bucket.Name)
+		region, err := c.getBucketRegion(ctx, bucketName)
+		if err != nil {
+			c.logger.WithError(err).WithField("bucketName", bucketName).Warn("Failed to get bucket location, skipping")
+			continue
+		}
+		locations = append(locations, BucketLocation{Name: bucketName, Region: region, CreationDate: bucket.CreationDate})
+	}
+	return locations, nil
+}
+
+// DescribeBuckets describes buckets located in the client's region, skipping the ones that
+// cannot be read
+func (c *Client) DescribeBuckets(ctx context.Context, buckets []BucketLocation) []*awstypes.AWSResource {
+	var resources []*awstypes.AWSResource
+	for _, bucket := range buckets {
+		resource, err := c.GetBucket(ctx, bucket.Name)
+		if err != nil {
+			c.logger.WithError(err).WithField("bucketName", bucket.Name).Warn("Failed to describe bucket, skipping")
+			continue
+		}
+		if bucket.CreationDate != nil {
+			resource.Details["creationDate"] = bucket.CreationDate.Format(time.RFC3339)
+		}
+		resources = append(resources, resource)
+	}
+	return resources
+}
+
+// GetBucket retrieves a bucket together with its versioning, encryption,
+// public access block, lifecycle, policy and tag settings
+func (c *Client) GetBucket(ctx context.Context, bucketName string) (*awstypes.AWSResource, error) {
+	if _, err := c.s3.HeadBucket(ctx, &s3.HeadBucketInput{Bucket: aws.String(bucketName)}); err != nil {
+		return nil, fmt.Errorf("failed to get bucket %s: %w", bucketName, err)
+	}
+
+	details := map[string]interface{}{
+		"bucketName": bucketName,
+		"versioning": "Disabled",
+		"encryption": "",
+		"publicAccessBlock": map[string]interface{}{
+			"blockPublicAcls":       false,
+			"ignorePublicAcls":      false,
+			"blockPublicPolicy":     false,
+			"restrictPublicBuckets": false,
+		},
+		"lifecycleRuleCount": 0,
+		"hasPolicy":          false,
+	}
+
+	// Optional settings return "not configured" errors, which simply leave the defaults above
+	if versioning, err := c.s3.GetBucketVersioning(ctx, &s3.GetBucketVersioningInput{Bucket: aws.String(bucketName)}); err == nil && versioning.Status != "" {
+		details["versioning"] = string(versioning.Status)
+	}
+
+	if encryption, err := c.s3.GetBucketEncryption(ctx, &s3.GetBucketEncryptionInput{Bucket: aws.String(bucketName)}); err == nil &&
+		encryption.ServerSideEncryptionConfiguration != nil && len(encryption.ServerSideEncryptionConfiguration.Rules) > 0 {
+		if defaults := encryption.ServerSideEncryptionConfiguration.Rules[0].ApplyServerSideEncryptionByDefault; defaults != nil {
+			details["encryption"] = string(defaults.SSEAlgorithm)
+			details["kmsKeyId"] = aws.ToString(defaults.KMSMasterKeyID)
+		}
+	}
+
+	if pab, err := c.s3.GetPublicAccessBlock(ctx, &s3.GetPublicAccessBlockInput{Bucket: aws.String(bucketName)}); err == nil && pab.PublicAccessBlockConfiguration != nil {
+		config := pab.PublicAccessBlockConfiguration
+		details["publicAccessBlock"] = map[string]interface{}{
+			"blockPublicAcls":       aws.ToBool(config.BlockPublicAcls),
+			"ignorePublicAcls":      aws.ToBool(config.IgnorePublicAcls),
+			"blockPublicPolicy":     aws.ToBool(config.BlockPublicPolicy),
+			"restrictPublicBuckets": aws.ToBool(config.RestrictPublicBuckets),
+		}
+	}
+
+	if lifecycle, err := c.s3.GetBucketLifecycleConfiguration(ctx, &s3.GetBucketLifecycleConfigurationInput{Bucket: aws.String(bucketName)}); err == nil {
+		details["lifecycleRuleCount"] = len(lifecycle.Rules)
+	}
+
+	if policy, err := c.s3.GetBucketPolicy(ctx, &s3.GetBucketPolicyInput{Bucket: aws.String(bucketName)}); err == nil {
+		details["hasPolicy"] = aws.ToString(policy.Policy) != ""
+	}
+
+	tags := make(map[string]string)
+	if tagging, err := c.s3.GetBucketTagging(ctx, &s3.GetBucketTaggingInput{Bucket: aws.String(bucketName)}); err == nil {
+		for _, tag := range tagging.TagSet {
+			if tag.Key != nil && tag.Value != nil {
+				tags[*tag.Key] = *tag.Value
+			}
+		}
+	}
+
+	return &awstypes.AWSResource{
+		ID:       bucketName,
+		Type:     "s3_bucket",
+		Region:   c.cfg.Region,
+		State:    "available",
+		Tags:     tags,
+		Details:  details,
+		LastSeen: time.Now(),
+	}, nil
+}
+
+// DeleteBucket deletes an empty S3 bucket
+func (c *Client) DeleteBucket(ctx context.Context, bucketName string) error {
+	_, err := c.s3.DeleteBucket(ctx, &s3.DeleteBucketInput{
+		Bucket: aws.String(bucketName),
+	})
+	if err != nil {
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) && apiErr.ErrorCode() == "BucketNotEmpty" {
+			return fmt.Errorf("bucket %s is not empty, remove its objects before deleting it", bucketName)
+		}
+		return fmt.Errorf("failed to delete bucket %s: %w", bucketName, err)
+	}
+
+	c.logger.WithField("bucketName", bucketName).Info("S3 bucket deleted successfully")
+	return nil
+}
+
+// getBucketRegion returns the region a bucket lives in
+func (c *Client) getBucketRegion(ctx context.Context, bucketName string) (string, error) {
+	result, err := c.s3.GetBucketLocation(ctx, &s3.GetBucketLocationInput{
+		Bucket: aws.String(bucketName),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	// An empty location constraint means us-east-1, and "EU" is the legacy name for eu-west-1
+	switch result.LocationConstraint {
+	case "":
+		return "us-east-1", nil
+	case types.BucketLocationConstraintEu:
+		return "eu-west-1", nil
+	default:
+		return string(result.LocationConstraint), nil
+	}
+}
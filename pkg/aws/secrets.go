@@ -0,0 +1,162 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	smtypes "github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// generatedPasswordLength is the length of generated database master passwords
+	generatedPasswordLength = 32
+
+	// rdsPasswordExcludedCharacters are characters RDS does not accept in master passwords
+	rdsPasswordExcludedCharacters = "/@\"' \\"
+)
+
+// ========== Secrets Manager Methods ==========
+
+// DBCredentials is the JSON layout of a database credentials secret. It follows the format
+// used by the Secrets Manager rotation functions for RDS.
+type DBCredentials struct {
+	Username             string `json:"username"`
+	Password             string `json:"password"`
+	Engine               string `json:"engine,omitempty"`
+	DBInstanceIdentifier string `json:"dbInstanceIdentifier,omitempty"`
+}
+
+// CreateDBCredentialsSecret generates a master password and stores it with the username in a
+// new Secrets Manager secret. The password is returned only so it can be passed to RDS; callers
+// must never log or return it.
+func (c *Client) CreateDBCredentialsSecret(ctx context.Context, params CreateDBCredentialsSecretParams) (string, string, error) {
+	passwordResult, err := c.secrets.GetRandomPassword(ctx, &secretsmanager.GetRandomPasswordInput{
+		PasswordLength:          aws.Int64(generatedPasswordLength),
+		ExcludeCharacters:       aws.String(rdsPasswordExcludedCharacters),
+		RequireEachIncludedType: aws.Bool(true),
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate database password: %w", err)
+	}
+	password := aws.ToString(passwordResult.RandomPassword)
+
+	secretString, err := json.Marshal(DBCredentials{
+		Username:             params.Username,
+		Password:             password,
+		Engine:               params.Engine,
+		DBInstanceIdentifier: params.DBInstanceIdentifier,
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to encode database credentials: %w", err)
+	}
+
+	description := params.Description
+	if description == "" {
+		description = fmt.Sprintf("Master credentials for RDS instance %s", params.DBInstanceIdentifier)
+	}
+
+	input := &secretsmanager.CreateSecretInput{
+		Name:         aws.String(params.Name),
+		Description:  aws.String(description),
+		SecretString: aws.String(string(secretString)),
+	}
+	if params.KmsKeyID != "" {
+		input.KmsKeyId = aws.String(params.KmsKeyID)
+	}
+	for key, value := range resourceTags(ctx, params.Tags) {
+		input.Tags = append(input.Tags, smtypes.Tag{
+			Key:   aws.String(key),
+			Value: aws.String(value),
+		})
+	}
+
+	result, err := c.secrets.CreateSecret(ctx, input)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create secret %s: %w", params.Name, err)
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"secretArn":            aws.ToString(result.ARN),
+		"dbInstanceIdentifier": params.DBInstanceIdentifier,
+	}).Info("Database credentials secret created")
+
+	return aws.ToString(result.ARN), password, nil
+}
+
+// CreateSecretString stores a string value in a new secret and returns the secret ARN
+func (c *Client) CreateSecretString(ctx context.Context, name, description, value string, tags map[string]string) (string, error) {
+	input := &secretsmanager.CreateSecretInput{
+		Name:         aws.String(name),
+		Description:  aws.String(description),
+		SecretString: aws.String(value),
+	}
+	for key, tagValue := range resourceTags(ctx, tags) {
+		input.Tags = append(input.Tags, smtypes.Tag{
+			Key:   aws.String(key),
+			Value: aws.String(tagValue),
+		})
+	}
+
+	result, err := c.secrets.CreateSecret(ctx, input)
+	if err != nil {
+		return "", fmt.Errorf("failed to create secret %s: %w", name, err)
+	}
+
+	c.logger.WithField("secretArn", aws.ToString(result.ARN)).Info("Secret created")
+	return aws.ToString(result.ARN), nil
+}
+
+// GetSecretString returns the string value of a secret
+func (c *Client) GetSecretString(ctx context.Context, secretID string) (string, error) {
+	result, err := c.secrets.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(secretID),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret %s: %w", secretID, err)
+	}
+
+	secretString := aws.ToString(result.SecretString)
+	if secretString == "" {
+		return "", fmt.Errorf("secret %s has no string value", secretID)
+	}
+
+	return secretString, nil
+}
+
+// GetSecretPassword reads a password from a secret. The secret may hold the password as plain
+// text or as JSON credentials with a "password" key.
+func (c *Client) GetSecretPassword(ctx context.Context, secretID string) (string, error) {
+	secretString, err := c.GetSecretString(ctx, secretID)
+	if err != nil {
+		return "", err
+	}
+
+	var credentials DBCredentials
+	if err := json.Unmarshal([]byte(secretString), &credentials); err == nil {
+		if credentials.Password == "" {
+			return "", fmt.Errorf("secret %s does not contain a password field", secretID)
+		}
+		return credentials.Password, nil
+	}
+
+	return secretString, nil
+}
+
+// DeleteSecret removes a secret immediately, without a recovery window. It is used to clean up
+// generated credentials when the database they were created for could not be created.
+func (c *Client) DeleteSecret(ctx context.Context, secretID string) error {
+	_, err := c.secrets.DeleteSecret(ctx, &secretsmanager.DeleteSecretInput{
+		SecretId:                   aws.String(secretID),
+		ForceDeleteWithoutRecovery: aws.Bool(true),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete secret %s: %w", secretID, err)
+	}
+
+	c.logger.WithField("secretId", secretID).Info("Secret deleted")
+	return nil
+}
@@ -3,6 +3,8 @@ package aws
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
@@ -17,15 +19,45 @@ type SecurityGroupParams struct {
 	Tags        map[string]string
 }
 
-// SecurityGroupRuleParams contains parameters for adding rules to a security group
+// SecurityGroupRuleParams contains parameters for adding or revoking security group rules
 type SecurityGroupRuleParams struct {
-	GroupID    string
-	Type       string // "ingress" or "egress"
-	Protocol   string // "tcp", "udp", "icmp", or "-1" for all
-	FromPort   int32
-	ToPort     int32
-	CidrBlocks []string
-	SourceSG   string // Source security group ID for SG-to-SG rules
+	GroupID       string
+	Type          string // "ingress" or "egress"
+	Protocol      string // "tcp", "udp", "icmp", "icmpv6", or "-1" for all
+	FromPort      int32  // First port, or the ICMP type (-1 for all types)
+	ToPort        int32  // Last port, or the ICMP code (-1 for all codes)
+	CidrBlocks    []string
+	SourceSG      string   // Source (or, for egress, destination) security group ID for SG-to-SG rules
+	PrefixListIDs []string // Managed prefix lists, e.g. pl-12345678
+	Description   string
+}
+
+// SetSecurityGroupRulesParams describes the complete desired rule set of a security group
+type SetSecurityGroupRulesParams struct {
+	GroupID      string
+	Ingress      []SecurityGroupRuleParams
+	Egress       []SecurityGroupRuleParams
+	ManageEgress bool // Egress is only reconciled when set, so omitting it keeps the default allow-all rule
+	DryRun       bool // Report the changes without applying them
+}
+
+// SecurityGroupRuleChanges summarises a rule reconciliation
+type SecurityGroupRuleChanges struct {
+	Added     []string
+	Removed   []string
+	Unchanged []string
+}
+
+// securityGroupRule is a single live or desired rule with exactly one source or destination
+type securityGroupRule struct {
+	ruleID      string
+	direction   string
+	protocol    string
+	fromPort    int32
+	toPort      int32
+	peerType    string // "cidr", "cidr6", "sg" or "pl"
+	peer        string
+	description string
 }
 
 // CreateSecurityGroup creates a new security group
@@ -40,18 +72,11 @@ func (c *Client) CreateSecurityGroup(ctx context.Context, params SecurityGroupPa
 	}
 
 	// Add tag specifications during creation
-	if len(params.Tags) > 0 {
-		var ec2Tags []types.Tag
-		for key, value := range params.Tags {
-			ec2Tags = append(ec2Tags, types.Tag{
-				Key:   aws.String(key),
-				Value: aws.String(value),
-			})
-		}
+	if tags := resourceTags(ctx, params.Tags); len(tags) > 0 {
 		input.TagSpecifications = []types.TagSpecification{
 			{
 				ResourceType: types.ResourceTypeSecurityGroup,
-				Tags:         ec2Tags,
+				Tags:         toEC2Tags(tags),
 			},
 		}
 	}
@@ -87,84 +112,440 @@ func (c *Client) AddSecurityGroupRule(ctx context.Context, params SecurityGroupR
 
 // addIngressRule adds an ingress rule to the security group
 func (c *Client) addIngressRule(ctx context.Context, params SecurityGroupRuleParams) error {
-	var ipPermissions []types.IpPermission
+	input := &ec2.AuthorizeSecurityGroupIngressInput{
+		GroupId:       aws.String(params.GroupID),
+		IpPermissions: []types.IpPermission{buildIpPermission(params)},
+	}
 
-	permission := types.IpPermission{
-		IpProtocol: aws.String(params.Protocol),
+	_, err := c.ec2.AuthorizeSecurityGroupIngress(ctx, input)
+	return err
+}
+
+// addEgressRule adds an egress rule to the security group
+func (c *Client) addEgressRule(ctx context.Context, params SecurityGroupRuleParams) error {
+	input := &ec2.AuthorizeSecurityGroupEgressInput{
+		GroupId:       aws.String(params.GroupID),
+		IpPermissions: []types.IpPermission{buildIpPermission(params)},
 	}
 
-	// Set ports only for TCP/UDP protocols
-	if params.Protocol == "tcp" || params.Protocol == "udp" {
-		permission.FromPort = aws.Int32(params.FromPort)
-		permission.ToPort = aws.Int32(params.ToPort)
+	_, err := c.ec2.AuthorizeSecurityGroupEgress(ctx, input)
+	return err
+}
+
+// RevokeSecurityGroupRule removes an ingress or egress rule from a security group.
+// The rule must match an existing rule exactly (protocol, ports and source).
+func (c *Client) RevokeSecurityGroupRule(ctx context.Context, params SecurityGroupRuleParams) error {
+	permissions := []types.IpPermission{buildIpPermission(params)}
+
+	var err error
+	switch params.Type {
+	case "ingress":
+		var result *ec2.RevokeSecurityGroupIngressOutput
+		result, err = c.ec2.RevokeSecurityGroupIngress(ctx, &ec2.RevokeSecurityGroupIngressInput{
+			GroupId:       aws.String(params.GroupID),
+			IpPermissions: permissions,
+		})
+		if err == nil && len(result.UnknownIpPermissions) > 0 {
+			err = fmt.Errorf("no matching ingress rule found")
+		}
+	case "egress":
+		var result *ec2.RevokeSecurityGroupEgressOutput
+		result, err = c.ec2.RevokeSecurityGroupEgress(ctx, &ec2.RevokeSecurityGroupEgressInput{
+			GroupId:       aws.String(params.GroupID),
+			IpPermissions: permissions,
+		})
+		if err == nil && len(result.UnknownIpPermissions) > 0 {
+			err = fmt.Errorf("no matching egress rule found")
+		}
+	default:
+		return fmt.Errorf("invalid rule type: %s (must be 'ingress' or 'egress')", params.Type)
 	}
 
-	// Add CIDR blocks if provided
-	if len(params.CidrBlocks) > 0 {
-		for _, cidr := range params.CidrBlocks {
-			permission.IpRanges = append(permission.IpRanges, types.IpRange{
-				CidrIp: aws.String(cidr),
-			})
+	if err != nil {
+		return fmt.Errorf("failed to revoke %s rule: %w", params.Type, err)
+	}
+
+	c.logger.WithField("groupId", params.GroupID).WithField("type", params.Type).Info("Security group rule revoked successfully")
+	return nil
+}
+
+// ListSecurityGroupRules returns the live rules of a security group, one entry per source or destination
+func (c *Client) ListSecurityGroupRules(ctx context.Context, groupID string) ([]map[string]interface{}, error) {
+	rules, err := c.describeSecurityGroupRules(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]map[string]interface{}, 0, len(rules))
+	for _, rule := range rules {
+		result = append(result, rule.toMap())
+	}
+
+	return result, nil
+}
+
+// SetSecurityGroupRules reconciles a security group against a desired rule list. Running it
+// again with the same rules makes no changes.
+//
+// Missing rules are authorized before unwanted ones are revoked, so traffic that stays allowed
+// is never cut off; the group briefly holds both, which must fit in the rules-per-group quota.
+// Rules are matched on direction, protocol, ports and peer only: a rule whose description alone
+// differs is left as it is.
+func (c *Client) SetSecurityGroupRules(ctx context.Context, params SetSecurityGroupRulesParams) (*SecurityGroupRuleChanges, error) {
+	live, err := c.describeSecurityGroupRules(ctx, params.GroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, toAdd, toRevoke := diffSecurityGroupRules(live, params)
+
+	for _, rule := range toAdd {
+		if !params.DryRun {
+			if err := c.AddSecurityGroupRule(ctx, rule.toParams(params.GroupID)); err != nil {
+				return changes, err
+			}
 		}
+		changes.Added = append(changes.Added, rule.String())
 	}
 
-	// Add source security group if provided
-	if params.SourceSG != "" {
-		permission.UserIdGroupPairs = append(permission.UserIdGroupPairs, types.UserIdGroupPair{
-			GroupId: aws.String(params.SourceSG),
-		})
+	for _, rule := range toRevoke {
+		if !params.DryRun {
+			if err := c.revokeSecurityGroupRuleByID(ctx, params.GroupID, rule); err != nil {
+				return changes, err
+			}
+		}
+		changes.Removed = append(changes.Removed, rule.String())
 	}
 
-	ipPermissions = append(ipPermissions, permission)
+	c.logger.WithField("groupId", params.GroupID).
+		WithField("added", len(changes.Added)).
+		WithField("removed", len(changes.Removed)).
+		WithField("unchanged", len(changes.Unchanged)).
+		WithField("dryRun", params.DryRun).
+		Info("Security group rules reconciled")
 
-	input := &ec2.AuthorizeSecurityGroupIngressInput{
-		GroupId:       aws.String(params.GroupID),
-		IpPermissions: ipPermissions,
+	return changes, nil
+}
+
+// diffSecurityGroupRules compares the live rules of a group with the desired rule set. It
+// returns the unchanged rules, and the rules to add and revoke in the order they are applied.
+// Live egress rules are kept when egress is not managed.
+func diffSecurityGroupRules(live []securityGroupRule, params SetSecurityGroupRulesParams) (*SecurityGroupRuleChanges, []securityGroupRule, []securityGroupRule) {
+	desired := make(map[string]securityGroupRule)
+	for _, ruleParams := range params.Ingress {
+		ruleParams.Type = "ingress"
+		for _, rule := range expandSecurityGroupRule(ruleParams) {
+			desired[rule.key()] = rule
+		}
+	}
+	if params.ManageEgress {
+		for _, ruleParams := range params.Egress {
+			ruleParams.Type = "egress"
+			for _, rule := range expandSecurityGroupRule(ruleParams) {
+				desired[rule.key()] = rule
+			}
+		}
 	}
 
-	_, err := c.ec2.AuthorizeSecurityGroupIngress(ctx, input)
-	return err
+	changes := &SecurityGroupRuleChanges{}
+	liveKeys := make(map[string]bool)
+	var toRevoke []securityGroupRule
+	for _, rule := range live {
+		key := rule.key()
+		liveKeys[key] = true
+
+		if _, wanted := desired[key]; wanted {
+			changes.Unchanged = append(changes.Unchanged, rule.String())
+			continue
+		}
+		if rule.direction == "egress" && !params.ManageEgress {
+			continue
+		}
+		toRevoke = append(toRevoke, rule)
+	}
+
+	var toAdd []securityGroupRule
+	for key, rule := range desired {
+		if !liveKeys[key] {
+			toAdd = append(toAdd, rule)
+		}
+	}
+	sort.Slice(toAdd, func(i, j int) bool { return toAdd[i].String() < toAdd[j].String() })
+
+	return changes, toAdd, toRevoke
 }
 
-// addEgressRule adds an egress rule to the security group
-func (c *Client) addEgressRule(ctx context.Context, params SecurityGroupRuleParams) error {
-	var ipPermissions []types.IpPermission
+// describeSecurityGroupRules lists the live rules of a security group
+func (c *Client) describeSecurityGroupRules(ctx context.Context, groupID string) ([]securityGroupRule, error) {
+	var rules []securityGroupRule
+
+	paginator := ec2.NewDescribeSecurityGroupRulesPaginator(c.ec2, &ec2.DescribeSecurityGroupRulesInput{
+		Filters: []types.Filter{
+			{
+				Name:   aws.String("group-id"),
+				Values: []string{groupID},
+			},
+		},
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe rules of security group %s: %w", groupID, err)
+		}
+
+		for _, liveRule := range page.SecurityGroupRules {
+			rule := securityGroupRule{
+				ruleID:      aws.ToString(liveRule.SecurityGroupRuleId),
+				direction:   "ingress",
+				protocol:    aws.ToString(liveRule.IpProtocol),
+				fromPort:    aws.ToInt32(liveRule.FromPort),
+				toPort:      aws.ToInt32(liveRule.ToPort),
+				description: aws.ToString(liveRule.Description),
+			}
+			if aws.ToBool(liveRule.IsEgress) {
+				rule.direction = "egress"
+			}
+
+			switch {
+			case liveRule.CidrIpv4 != nil:
+				rule.peerType, rule.peer = "cidr", aws.ToString(liveRule.CidrIpv4)
+			case liveRule.CidrIpv6 != nil:
+				rule.peerType, rule.peer = "cidr6", aws.ToString(liveRule.CidrIpv6)
+			case liveRule.PrefixListId != nil:
+				rule.peerType, rule.peer = "pl", aws.ToString(liveRule.PrefixListId)
+			case liveRule.ReferencedGroupInfo != nil:
+				rule.peerType, rule.peer = "sg", aws.ToString(liveRule.ReferencedGroupInfo.GroupId)
+			}
+
+			rules = append(rules, rule.normalized())
+		}
+	}
+
+	return rules, nil
+}
+
+// revokeSecurityGroupRuleByID revokes a single live rule using its rule ID
+func (c *Client) revokeSecurityGroupRuleByID(ctx context.Context, groupID string, rule securityGroupRule) error {
+	var err error
+	if rule.direction == "egress" {
+		_, err = c.ec2.RevokeSecurityGroupEgress(ctx, &ec2.RevokeSecurityGroupEgressInput{
+			GroupId:              aws.String(groupID),
+			SecurityGroupRuleIds: []string{rule.ruleID},
+		})
+	} else {
+		_, err = c.ec2.RevokeSecurityGroupIngress(ctx, &ec2.RevokeSecurityGroupIngressInput{
+			GroupId:              aws.String(groupID),
+			SecurityGroupRuleIds: []string{rule.ruleID},
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to revoke %s: %w", rule.String(), err)
+	}
 
+	return nil
+}
+
+// buildIpPermission converts rule parameters into an EC2 IP permission
+func buildIpPermission(params SecurityGroupRuleParams) types.IpPermission {
+	protocol := normalizeRuleProtocol(params.Protocol)
 	permission := types.IpPermission{
-		IpProtocol: aws.String(params.Protocol),
+		IpProtocol: aws.String(protocol),
 	}
 
-	// Set ports only for TCP/UDP protocols
-	if params.Protocol == "tcp" || params.Protocol == "udp" {
+	// Ports, or the ICMP type and code, only apply to some protocols
+	if protocolUsesPorts(protocol) {
 		permission.FromPort = aws.Int32(params.FromPort)
 		permission.ToPort = aws.Int32(params.ToPort)
 	}
 
+	var description *string
+	if params.Description != "" {
+		description = aws.String(params.Description)
+	}
+
 	// Add CIDR blocks if provided
-	if len(params.CidrBlocks) > 0 {
-		for _, cidr := range params.CidrBlocks {
-			permission.IpRanges = append(permission.IpRanges, types.IpRange{
-				CidrIp: aws.String(cidr),
+	for _, cidr := range params.CidrBlocks {
+		if strings.Contains(cidr, ":") {
+			permission.Ipv6Ranges = append(permission.Ipv6Ranges, types.Ipv6Range{
+				CidrIpv6:    aws.String(cidr),
+				Description: description,
 			})
+			continue
 		}
+		permission.IpRanges = append(permission.IpRanges, types.IpRange{
+			CidrIp:      aws.String(cidr),
+			Description: description,
+		})
 	}
 
 	// Add source security group if provided
 	if params.SourceSG != "" {
 		permission.UserIdGroupPairs = append(permission.UserIdGroupPairs, types.UserIdGroupPair{
-			GroupId: aws.String(params.SourceSG),
+			GroupId:     aws.String(params.SourceSG),
+			Description: description,
 		})
 	}
 
-	ipPermissions = append(ipPermissions, permission)
+	// Add managed prefix lists if provided
+	for _, prefixListID := range params.PrefixListIDs {
+		permission.PrefixListIds = append(permission.PrefixListIds, types.PrefixListId{
+			PrefixListId: aws.String(prefixListID),
+			Description:  description,
+		})
+	}
 
-	input := &ec2.AuthorizeSecurityGroupEgressInput{
-		GroupId:       aws.String(params.GroupID),
-		IpPermissions: ipPermissions,
+	return permission
+}
+
+// expandSecurityGroupRule splits rule parameters into one rule per source or destination
+func expandSecurityGroupRule(params SecurityGroupRuleParams) []securityGroupRule {
+	base := securityGroupRule{
+		direction:   params.Type,
+		protocol:    params.Protocol,
+		fromPort:    params.FromPort,
+		toPort:      params.ToPort,
+		description: params.Description,
 	}
 
-	_, err := c.ec2.AuthorizeSecurityGroupEgress(ctx, input)
-	return err
+	var rules []securityGroupRule
+	for _, cidr := range params.CidrBlocks {
+		rule := base
+		rule.peerType, rule.peer = "cidr", cidr
+		if strings.Contains(cidr, ":") {
+			rule.peerType = "cidr6"
+		}
+		rules = append(rules, rule.normalized())
+	}
+	if params.SourceSG != "" {
+		rule := base
+		rule.peerType, rule.peer = "sg", params.SourceSG
+		rules = append(rules, rule.normalized())
+	}
+	for _, prefixListID := range params.PrefixListIDs {
+		rule := base
+		rule.peerType, rule.peer = "pl", prefixListID
+		rules = append(rules, rule.normalized())
+	}
+
+	return rules
+}
+
+// normalizeRuleProtocol maps protocol names and numbers to the form EC2 reports
+func normalizeRuleProtocol(protocol string) string {
+	switch protocol = strings.ToLower(strings.TrimSpace(protocol)); protocol {
+	case "6":
+		return "tcp"
+	case "17":
+		return "udp"
+	case "1":
+		return "icmp"
+	case "58":
+		return "icmpv6"
+	case "all", "-1", "":
+		return "-1"
+	default:
+		return protocol
+	}
+}
+
+// protocolUsesPorts reports whether EC2 keeps the port range of a normalized protocol. For
+// ICMP the range holds the type and code; every other protocol allows all ports.
+func protocolUsesPorts(protocol string) bool {
+	switch protocol {
+	case "tcp", "udp", "icmp", "icmpv6":
+		return true
+	}
+	return false
+}
+
+// IsICMPProtocol reports whether a protocol name or number is ICMP or ICMPv6, whose rules take
+// a type and code instead of ports
+func IsICMPProtocol(protocol string) bool {
+	switch normalizeRuleProtocol(protocol) {
+	case "icmp", "icmpv6":
+		return true
+	}
+	return false
+}
+
+// normalized normalizes the protocol and clears ports that EC2 ignores, so live and desired
+// rules compare equal
+func (r securityGroupRule) normalized() securityGroupRule {
+	r.protocol = normalizeRuleProtocol(r.protocol)
+	if !protocolUsesPorts(r.protocol) {
+		r.fromPort, r.toPort = -1, -1
+	}
+	return r
+}
+
+// key identifies a rule independently of its ID and description
+func (r securityGroupRule) key() string {
+	return fmt.Sprintf("%s|%s|%d|%d|%s:%s", r.direction, r.protocol, r.fromPort, r.toPort, r.peerType, r.peer)
+}
+
+// String renders a rule for change reports, e.g. "ingress tcp 443-443 from sg-123" or
+// "ingress icmp type 8 code -1 from 10.0.0.0/16"
+func (r securityGroupRule) String() string {
+	ports := fmt.Sprintf("%d-%d", r.fromPort, r.toPort)
+	switch {
+	case !protocolUsesPorts(r.protocol):
+		ports = "all"
+	case r.protocol == "icmp" || r.protocol == "icmpv6":
+		ports = fmt.Sprintf("type %d code %d", r.fromPort, r.toPort)
+	}
+	preposition := "from"
+	if r.direction == "egress" {
+		preposition = "to"
+	}
+	return fmt.Sprintf("%s %s %s %s %s", r.direction, r.protocol, ports, preposition, r.peer)
+}
+
+// toParams converts a single rule back to rule parameters
+func (r securityGroupRule) toParams(groupID string) SecurityGroupRuleParams {
+	params := SecurityGroupRuleParams{
+		GroupID:     groupID,
+		Type:        r.direction,
+		Protocol:    r.protocol,
+		FromPort:    r.fromPort,
+		ToPort:      r.toPort,
+		Description: r.description,
+	}
+
+	switch r.peerType {
+	case "cidr", "cidr6":
+		params.CidrBlocks = []string{r.peer}
+	case "sg":
+		params.SourceSG = r.peer
+	case "pl":
+		params.PrefixListIDs = []string{r.peer}
+	}
+
+	return params
+}
+
+// toMap converts a rule to the map representation returned by tools
+func (r securityGroupRule) toMap() map[string]interface{} {
+	rule := map[string]interface{}{
+		"ruleId":    r.ruleID,
+		"direction": r.direction,
+		"protocol":  r.protocol,
+		"fromPort":  r.fromPort,
+		"toPort":    r.toPort,
+	}
+	if r.description != "" {
+		rule["description"] = r.description
+	}
+
+	switch r.peerType {
+	case "cidr", "cidr6":
+		rule["cidrBlock"] = r.peer
+	case "sg":
+		rule["sourceSecurityGroupId"] = r.peer
+	case "pl":
+		rule["prefixListId"] = r.peer
+	}
+
+	return rule
 }
 
 // ListSecurityGroups lists all security groups in the region
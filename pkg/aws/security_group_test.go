@@ -0,0 +1,227 @@
+package aws
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+func TestNormalizeRuleProtocol(t *testing.T) {
+	tests := []struct {
+		protocol string
+		want     string
+	}{
+		{"tcp", "tcp"},
+		{"TCP", "tcp"},
+		{"6", "tcp"},
+		{"17", "udp"},
+		{"icmp", "icmp"},
+		{"1", "icmp"},
+		{"ICMPv6", "icmpv6"},
+		{"58", "icmpv6"},
+		{"-1", "-1"},
+		{"all", "-1"},
+		{"", "-1"},
+		{"50", "50"},
+	}
+	for _, tt := range tests {
+		if got := normalizeRuleProtocol(tt.protocol); got != tt.want {
+			t.Errorf("normalizeRuleProtocol(%q) = %q, want %q", tt.protocol, got, tt.want)
+		}
+	}
+}
+
+func TestExpandSecurityGroupRule(t *testing.T) {
+	tests := []struct {
+		name   string
+		params SecurityGroupRuleParams
+		want   []securityGroupRule
+	}{
+		{
+			name: "one rule per peer",
+			params: SecurityGroupRuleParams{
+				Type: "ingress", Protocol: "tcp", FromPort: 443, ToPort: 443,
+				CidrBlocks: []string{"10.0.0.0/16", "2001:db8::/32"}, SourceSG: "sg-alb", PrefixListIDs: []string{"pl-1"},
+				Description: "HTTPS",
+			},
+			want: []securityGroupRule{
+				{direction: "ingress", protocol: "tcp", fromPort: 443, toPort: 443, peerType: "cidr", peer: "10.0.0.0/16", description: "HTTPS"},
+				{direction: "ingress", protocol: "tcp", fromPort: 443, toPort: 443, peerType: "cidr6", peer: "2001:db8::/32", description: "HTTPS"},
+				{direction: "ingress", protocol: "tcp", fromPort: 443, toPort: 443, peerType: "sg", peer: "sg-alb", description: "HTTPS"},
+				{direction: "ingress", protocol: "tcp", fromPort: 443, toPort: 443, peerType: "pl", peer: "pl-1", description: "HTTPS"},
+			},
+		},
+		{
+			name:   "protocol number",
+			params: SecurityGroupRuleParams{Type: "ingress", Protocol: "6", FromPort: 22, ToPort: 22, CidrBlocks: []string{"10.0.0.0/8"}},
+			want: []securityGroupRule{
+				{direction: "ingress", protocol: "tcp", fromPort: 22, toPort: 22, peerType: "cidr", peer: "10.0.0.0/8"},
+			},
+		},
+		{
+			name:   "ICMP keeps its type and code",
+			params: SecurityGroupRuleParams{Type: "ingress", Protocol: "ICMP", FromPort: 8, ToPort: -1, CidrBlocks: []string{"10.0.0.0/16"}},
+			want: []securityGroupRule{
+				{direction: "ingress", protocol: "icmp", fromPort: 8, toPort: -1, peerType: "cidr", peer: "10.0.0.0/16"},
+			},
+		},
+		{
+			name:   "all traffic ignores ports",
+			params: SecurityGroupRuleParams{Type: "egress", Protocol: "-1", FromPort: 0, ToPort: 0, CidrBlocks: []string{"0.0.0.0/0"}},
+			want: []securityGroupRule{
+				{direction: "egress", protocol: "-1", fromPort: -1, toPort: -1, peerType: "cidr", peer: "0.0.0.0/0"},
+			},
+		},
+		{
+			name:   "other protocols ignore ports",
+			params: SecurityGroupRuleParams{Type: "ingress", Protocol: "50", FromPort: 500, ToPort: 500, SourceSG: "sg-vpn"},
+			want: []securityGroupRule{
+				{direction: "ingress", protocol: "50", fromPort: -1, toPort: -1, peerType: "sg", peer: "sg-vpn"},
+			},
+		},
+		{
+			name:   "no peer",
+			params: SecurityGroupRuleParams{Type: "ingress", Protocol: "tcp", FromPort: 80, ToPort: 80},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := expandSecurityGroupRule(tt.params); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("expandSecurityGroupRule() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildIpPermissionPorts(t *testing.T) {
+	tests := []struct {
+		name     string
+		params   SecurityGroupRuleParams
+		protocol string
+		from, to *int32
+	}{
+		{"tcp", SecurityGroupRuleParams{Protocol: "tcp", FromPort: 80, ToPort: 81}, "tcp", aws.Int32(80), aws.Int32(81)},
+		{"ICMP type and code", SecurityGroupRuleParams{Protocol: "icmp", FromPort: 8, ToPort: 0}, "icmp", aws.Int32(8), aws.Int32(0)},
+		{"all ICMP by number", SecurityGroupRuleParams{Protocol: "1", FromPort: -1, ToPort: -1}, "icmp", aws.Int32(-1), aws.Int32(-1)},
+		{"all traffic", SecurityGroupRuleParams{Protocol: "all", FromPort: 0, ToPort: 65535}, "-1", nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.params.CidrBlocks = []string{"10.0.0.0/16"}
+			permission := buildIpPermission(tt.params)
+			if aws.ToString(permission.IpProtocol) != tt.protocol {
+				t.Fatalf("IpProtocol = %q, want %q", aws.ToString(permission.IpProtocol), tt.protocol)
+			}
+			if !reflect.DeepEqual(permission.FromPort, tt.from) || !reflect.DeepEqual(permission.ToPort, tt.to) {
+				t.Fatalf("ports = %v-%v, want %v-%v", permission.FromPort, permission.ToPort, tt.from, tt.to)
+			}
+		})
+	}
+}
+
+func TestDiffSecurityGroupRules(t *testing.T) {
+	// liveRule is a rule as describeSecurityGroupRules reports it
+	liveRule := func(id, direction, protocol string, from, to int32, peerType, peer, description string) securityGroupRule {
+		return securityGroupRule{
+			ruleID: id, direction: direction, protocol: protocol, fromPort: from, toPort: to,
+			peerType: peerType, peer: peer, description: description,
+		}.normalized()
+	}
+
+	live := []securityGroupRule{
+		liveRule("sgr-https", "ingress", "tcp", 443, 443, "sg", "sg-alb", "from the load balancer"),
+		liveRule("sgr-ssh", "ingress", "tcp", 22, 22, "cidr", "0.0.0.0/0", ""),
+		liveRule("sgr-ping", "ingress", "icmp", -1, -1, "cidr", "10.0.0.0/16", ""),
+		liveRule("sgr-egress", "egress", "-1", -1, -1, "cidr", "0.0.0.0/0", ""),
+	}
+
+	tests := []struct {
+		name          string
+		params        SetSecurityGroupRulesParams
+		wantAdded     []string
+		wantRevoked   []string
+		wantUnchanged []string
+	}{
+		{
+			name: "same rules converge",
+			params: SetSecurityGroupRulesParams{
+				Ingress: []SecurityGroupRuleParams{
+					{Protocol: "tcp", FromPort: 443, ToPort: 443, SourceSG: "sg-alb", Description: "new description"},
+					{Protocol: "tcp", FromPort: 22, ToPort: 22, CidrBlocks: []string{"0.0.0.0/0"}},
+					{Protocol: "icmp", FromPort: -1, ToPort: -1, CidrBlocks: []string{"10.0.0.0/16"}},
+				},
+			},
+			wantUnchanged: []string{
+				"ingress tcp 443-443 from sg-alb",
+				"ingress tcp 22-22 from 0.0.0.0/0",
+				"ingress icmp type -1 code -1 from 10.0.0.0/16",
+			},
+		},
+		{
+			name: "add before revoke",
+			params: SetSecurityGroupRulesParams{
+				Ingress: []SecurityGroupRuleParams{
+					{Protocol: "6", FromPort: 443, ToPort: 443, SourceSG: "sg-alb"},
+					{Protocol: "tcp", FromPort: 22, ToPort: 22, CidrBlocks: []string{"10.0.0.0/8"}},
+					{Protocol: "icmp", FromPort: 8, ToPort: -1, CidrBlocks: []string{"10.0.0.0/16"}},
+				},
+			},
+			wantAdded:     []string{"ingress icmp type 8 code -1 from 10.0.0.0/16", "ingress tcp 22-22 from 10.0.0.0/8"},
+			wantRevoked:   []string{"ingress tcp 22-22 from 0.0.0.0/0", "ingress icmp type -1 code -1 from 10.0.0.0/16"},
+			wantUnchanged: []string{"ingress tcp 443-443 from sg-alb"},
+		},
+		{
+			name: "managed egress",
+			params: SetSecurityGroupRulesParams{
+				Ingress: []SecurityGroupRuleParams{
+					{Protocol: "tcp", FromPort: 443, ToPort: 443, SourceSG: "sg-alb"},
+					{Protocol: "tcp", FromPort: 22, ToPort: 22, CidrBlocks: []string{"0.0.0.0/0"}},
+					{Protocol: "icmp", FromPort: -1, ToPort: -1, CidrBlocks: []string{"10.0.0.0/16"}},
+				},
+				ManageEgress: true,
+				Egress: []SecurityGroupRuleParams{
+					{Protocol: "tcp", FromPort: 5432, ToPort: 5432, SourceSG: "sg-db"},
+				},
+			},
+			wantAdded:   []string{"egress tcp 5432-5432 to sg-db"},
+			wantRevoked: []string{"egress -1 all to 0.0.0.0/0"},
+			wantUnchanged: []string{
+				"ingress tcp 443-443 from sg-alb",
+				"ingress tcp 22-22 from 0.0.0.0/0",
+				"ingress icmp type -1 code -1 from 10.0.0.0/16",
+			},
+		},
+	}
+
+	rulesToStrings := func(rules []securityGroupRule) []string {
+		var result []string
+		for _, rule := range rules {
+			result = append(result, rule.String())
+		}
+		return result
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes, toAdd, toRevoke := diffSecurityGroupRules(live, tt.params)
+
+			if got := rulesToStrings(toAdd); !reflect.DeepEqual(got, tt.wantAdded) {
+				t.Errorf("added %v, want %v", got, tt.wantAdded)
+			}
+			if got := rulesToStrings(toRevoke); !reflect.DeepEqual(got, tt.wantRevoked) {
+				t.Errorf("revoked %v, want %v", got, tt.wantRevoked)
+			}
+			if !reflect.DeepEqual(changes.Unchanged, tt.wantUnchanged) {
+				t.Errorf("unchanged %v, want %v", changes.Unchanged, tt.wantUnchanged)
+			}
+			for _, rule := range toRevoke {
+				if rule.ruleID == "" {
+					t.Errorf("rule %s is revoked without its rule ID", rule)
+				}
+			}
+		})
+	}
+}
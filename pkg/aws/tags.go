@@ -0,0 +1,65 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+)
+
+// resourceTagsKey is the context key of the tags applied to every created resource
+type resourceTagsKey struct{}
+
+// WithResourceTags returns a context whose create calls tag the new resources with tags, in
+// addition to the tags passed in the call parameters. The MCP server uses it to apply the tag
+// policy to every create tool without each tool having to handle it.
+func WithResourceTags(ctx context.Context, tags map[string]string) context.Context {
+	if len(tags) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, resourceTagsKey{}, tags)
+}
+
+// ResourceTagsFromContext returns the tags set with WithResourceTags, if any
+func ResourceTagsFromContext(ctx context.Context) map[string]string {
+	tags, _ := ctx.Value(resourceTagsKey{}).(map[string]string)
+	return tags
+}
+
+// resourceTags merges the tags carried by the context into the tags of a create call. The
+// context tags have already been through the tag policy, so they win over the call tags.
+func resourceTags(ctx context.Context, tags map[string]string) map[string]string {
+	merged := make(map[string]string, len(tags))
+	for key, value := range tags {
+		merged[key] = value
+	}
+	for key, value := range ResourceTagsFromContext(ctx) {
+		merged[key] = value
+	}
+	return merged
+}
+
+// toEC2Tags converts a tag map to EC2 tags
+func toEC2Tags(tags map[string]string) []ec2types.Tag {
+	var ec2Tags []ec2types.Tag
+	for key, value := range tags {
+		ec2Tags = append(ec2Tags, ec2types.Tag{
+			Key:   aws.String(key),
+			Value: aws.String(value),
+		})
+	}
+	return ec2Tags
+}
+
+// toELBTags converts a tag map to Elastic Load Balancing tags
+func toELBTags(tags map[string]string) []elbv2types.Tag {
+	var elbTags []elbv2types.Tag
+	for key, value := range tags {
+		elbTags = append(elbTags, elbv2types.Tag{
+			Key:   aws.String(key),
+			Value: aws.String(value),
+		})
+	}
+	return elbTags
+}
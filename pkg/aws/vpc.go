@@ -23,27 +23,15 @@ func (c *Client) CreateVPC(ctx context.Context, params CreateVPCParams) (*types.
 	}
 
 	// Add tag specifications during creation
-	if params.Name != "" || len(params.Tags) > 0 {
-		tags := make(map[string]string)
-		for k, v := range params.Tags {
-			tags[k] = v
-		}
-		if params.Name != "" {
-			tags["Name"] = params.Name
-		}
-
-		var ec2Tags []ec2types.Tag
-		for key, value := range tags {
-			ec2Tags = append(ec2Tags, ec2types.Tag{
-				Key:   aws.String(key),
-				Value: aws.String(value),
-			})
-		}
-
+	tags := resourceTags(ctx, params.Tags)
+	if params.Name != "" {
+		tags["Name"] = params.Name
+	}
+	if len(tags) > 0 {
 		input.TagSpecifications = []ec2types.TagSpecification{
 			{
 				ResourceType: ec2types.ResourceTypeVpc,
-				Tags:         ec2Tags,
+				Tags:         toEC2Tags(tags),
 			},
 		}
 	}
@@ -99,27 +87,15 @@ func (c *Client) CreateSubnet(ctx context.Context, params CreateSubnetParams) (*
 	}
 
 	// Add tag specifications during creation
-	if params.Name != "" || len(params.Tags) > 0 {
-		tags := make(map[string]string)
-		for k, v := range params.Tags {
-			tags[k] = v
-		}
-		if params.Name != "" {
-			tags["Name"] = params.Name
-		}
-
-		var ec2Tags []ec2types.Tag
-		for key, value := range tags {
-			ec2Tags = append(ec2Tags, ec2types.Tag{
-				Key:   aws.String(key),
-				Value: aws.String(value),
-			})
-		}
-
+	tags := resourceTags(ctx, params.Tags)
+	if params.Name != "" {
+		tags["Name"] = params.Name
+	}
+	if len(tags) > 0 {
 		input.TagSpecifications = []ec2types.TagSpecification{
 			{
 				ResourceType: ec2types.ResourceTypeSubnet,
-				Tags:         ec2Tags,
+				Tags:         toEC2Tags(tags),
 			},
 		}
 	}
@@ -168,27 +144,15 @@ func (c *Client) CreateInternetGateway(ctx context.Context, params CreateInterne
 	input := &ec2.CreateInternetGatewayInput{}
 
 	// Add tag specifications during creation
-	if params.Name != "" || len(params.Tags) > 0 {
-		tags := make(map[string]string)
-		for k, v := range params.Tags {
-			tags[k] = v
-		}
-		if params.Name != "" {
-			tags["Name"] = params.Name
-		}
-
-		var ec2Tags []ec2types.Tag
-		for key, value := range tags {
-			ec2Tags = append(ec2Tags, ec2types.Tag{
-				Key:   aws.String(key),
-				Value: aws.String(value),
-			})
-		}
-
+	tags := resourceTags(ctx, params.Tags)
+	if params.Name != "" {
+		tags["Name"] = params.Name
+	}
+	if len(tags) > 0 {
 		input.TagSpecifications = []ec2types.TagSpecification{
 			{
 				ResourceType: ec2types.ResourceTypeInternetGateway,
-				Tags:         ec2Tags,
+				Tags:         toEC2Tags(tags),
 			},
 		}
 	}
@@ -240,17 +204,15 @@ func (c *Client) CreateRouteTable(ctx context.Context, vpcID, name string) (*typ
 	}
 
 	// Add tag specifications during creation
+	tags := resourceTags(ctx, nil)
 	if name != "" {
-		var ec2Tags []ec2types.Tag
-		ec2Tags = append(ec2Tags, ec2types.Tag{
-			Key:   aws.String("Name"),
-			Value: aws.String(name),
-		})
-
+		tags["Name"] = name
+	}
+	if len(tags) > 0 {
 		input.TagSpecifications = []ec2types.TagSpecification{
 			{
 				ResourceType: ec2types.ResourceTypeRouteTable,
-				Tags:         ec2Tags,
+				Tags:         toEC2Tags(tags),
 			},
 		}
 	}
@@ -324,10 +286,24 @@ func (c *Client) AssociateRouteTable(ctx context.Context, routeTableID, subnetID
 
 // CreateNATGateway creates a NAT Gateway in the specified public subnet
 func (c *Client) CreateNATGateway(ctx context.Context, params CreateNATGatewayParams) (*types.AWSResource, error) {
-	// First, allocate an Elastic IP
-	eipResult, err := c.ec2.AllocateAddress(ctx, &ec2.AllocateAddressInput{
+	tags := resourceTags(ctx, params.Tags)
+	if params.Name != "" {
+		tags["Name"] = params.Name
+	}
+
+	// First, allocate an Elastic IP, tagged like the NAT Gateway so it is attributed with it
+	eipInput := &ec2.AllocateAddressInput{
 		Domain: ec2types.DomainTypeVpc,
-	})
+	}
+	if len(tags) > 0 {
+		eipInput.TagSpecifications = []ec2types.TagSpecification{
+			{
+				ResourceType: ec2types.ResourceTypeElasticIp,
+				Tags:         toEC2Tags(tags),
+			},
+		}
+	}
+	eipResult, err := c.ec2.AllocateAddress(ctx, eipInput)
 	if err != nil {
 		return nil, fmt.Errorf("failed to allocate Elastic IP: %w", err)
 	}
@@ -342,27 +318,11 @@ func (c *Client) CreateNATGateway(ctx context.Context, params CreateNATGatewayPa
 	}
 
 	// Add tag specifications during creation
-	tags := make(map[string]string)
-	if params.Name != "" || len(params.Tags) > 0 {
-		for k, v := range params.Tags {
-			tags[k] = v
-		}
-		if params.Name != "" {
-			tags["Name"] = params.Name
-		}
-
-		var ec2Tags []ec2types.Tag
-		for key, value := range tags {
-			ec2Tags = append(ec2Tags, ec2types.Tag{
-				Key:   aws.String(key),
-				Value: aws.String(value),
-			})
-		}
-
+	if len(tags) > 0 {
 		input.TagSpecifications = []ec2types.TagSpecification{
 			{
 				ResourceType: ec2types.ResourceTypeNatgateway,
-				Tags:         ec2Tags,
+				Tags:         toEC2Tags(tags),
 			},
 		}
 	}
@@ -406,46 +366,31 @@ func (c *Client) CreateNATGateway(ctx context.Context, params CreateNATGatewayPa
 
 // WaitForNATGateway waits for a NAT Gateway to become available
 func (c *Client) WaitForNATGateway(ctx context.Context, natGatewayID string) error {
-	maxWaitTime := 10 * time.Minute
-	pollInterval := 30 * time.Second
+	description := fmt.Sprintf("NAT Gateway %s", natGatewayID)
+
+	// NAT Gateways typically take 2-3 minutes
+	return c.waitForResource(ctx, description, 3*time.Minute, 10*time.Minute, 30*time.Second, func(ctx context.Context) (string, bool, error) {
+		result, err := c.ec2.DescribeNatGateways(ctx, &ec2.DescribeNatGatewaysInput{
+			NatGatewayIds: []string{natGatewayID},
+		})
+		if err != nil {
+			return "", false, fmt.Errorf("failed to describe NAT Gateway %s: %w", natGatewayID, err)
+		}
 
-	ctxWithTimeout, cancel := context.WithTimeout(ctx, maxWaitTime)
-	defer cancel()
+		if len(result.NatGateways) == 0 {
+			return "", false, fmt.Errorf("NAT Gateway %s not found", natGatewayID)
+		}
 
-	for {
-		select {
-		case <-ctxWithTimeout.Done():
-			return fmt.Errorf("timeout waiting for NAT Gateway %s to become available", natGatewayID)
+		state := result.NatGateways[0].State
+		switch state {
+		case ec2types.NatGatewayStateAvailable:
+			return string(state), true, nil
+		case ec2types.NatGatewayStateFailed:
+			return string(state), false, fmt.Errorf("NAT Gateway %s creation failed", natGatewayID)
 		default:
-			result, err := c.ec2.DescribeNatGateways(ctx, &ec2.DescribeNatGatewaysInput{
-				NatGatewayIds: []string{natGatewayID},
-			})
-			if err != nil {
-				return fmt.Errorf("failed to describe NAT Gateway %s: %w", natGatewayID, err)
-			}
-
-			if len(result.NatGateways) == 0 {
-				return fmt.Errorf("NAT Gateway %s not found", natGatewayID)
-			}
-
-			state := result.NatGateways[0].State
-			c.logger.WithFields(logrus.Fields{
-				"natGatewayId": natGatewayID,
-				"state":        state,
-			}).Info("NAT Gateway status check")
-
-			switch state {
-			case ec2types.NatGatewayStateAvailable:
-				return nil
-			case ec2types.NatGatewayStateFailed:
-				return fmt.Errorf("NAT Gateway %s creation failed", natGatewayID)
-			case ec2types.NatGatewayStatePending:
-				time.Sleep(pollInterval)
-			default:
-				time.Sleep(pollInterval)
-			}
+			return string(state), false, nil
 		}
-	}
+	})
 }
 
 // CreateRouteForNAT creates a route to a NAT Gateway
@@ -622,6 +567,19 @@ func (c *Client) DescribeNATGateways(ctx context.Context, natGatewayIDs []string
 	return natGateways, nil
 }
 
+// DeleteNATGateway deletes a NAT gateway. The Elastic IP it used stays allocated.
+func (c *Client) DeleteNATGateway(ctx context.Context, natGatewayID string) error {
+	_, err := c.ec2.DeleteNatGateway(ctx, &ec2.DeleteNatGatewayInput{
+		NatGatewayId: aws.String(natGatewayID),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete NAT gateway %s: %w", natGatewayID, err)
+	}
+
+	c.logger.WithField("natGatewayId", natGatewayID).Info("NAT gateway deletion initiated")
+	return nil
+}
+
 // convertNATGatewayToResource converts an EC2 NAT Gateway to our AWSResource format
 func (c *Client) convertNATGatewayToResource(natGateway ec2types.NatGateway) *types.AWSResource {
 	tags := make(map[string]string)
@@ -669,3 +627,69 @@ func (c *Client) convertNATGatewayToResource(natGateway ec2types.NatGateway) *ty
 		LastSeen: time.Now(),
 	}
 }
+
+// DescribeRouteTables lists the route tables in the region
+func (c *Client) DescribeRouteTables(ctx context.Context) ([]*types.AWSResource, error) {
+	var resources []*types.AWSResource
+
+	paginator := ec2.NewDescribeRouteTablesPaginator(c.ec2, &ec2.DescribeRouteTablesInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe route tables: %w", err)
+		}
+		for _, routeTable := range page.RouteTables {
+			resources = append(resources, c.convertRouteTable(routeTable))
+		}
+	}
+
+	return resources, nil
+}
+
+// convertRouteTable converts a route table to our internal resource representation
+func (c *Client) convertRouteTable(routeTable ec2types.RouteTable) *types.AWSResource {
+	tags := make(map[string]string)
+	for _, tag := range routeTable.Tags {
+		if tag.Key != nil && tag.Value != nil {
+			tags[*tag.Key] = *tag.Value
+		}
+	}
+
+	var routes []map[string]interface{}
+	for _, route := range routeTable.Routes {
+		routes = append(routes, map[string]interface{}{
+			"destinationCidrBlock": aws.ToString(route.DestinationCidrBlock),
+			"gatewayId":            aws.ToString(route.GatewayId),
+			"natGatewayId":         aws.ToString(route.NatGatewayId),
+			"state":                string(route.State),
+		})
+	}
+
+	var subnetIDs []string
+	isMain := false
+	for _, association := range routeTable.Associations {
+		if aws.ToBool(association.Main) {
+			isMain = true
+		}
+		if association.SubnetId != nil {
+			subnetIDs = append(subnetIDs, *association.SubnetId)
+		}
+	}
+
+	details := map[string]interface{}{
+		"vpcId":     aws.ToString(routeTable.VpcId),
+		"routes":    routes,
+		"subnetIds": subnetIDs,
+		"main":      isMain,
+	}
+
+	return &types.AWSResource{
+		ID:       aws.ToString(routeTable.RouteTableId),
+		Type:     "route-table",
+		Region:   c.cfg.Region,
+		State:    "available",
+		Tags:     tags,
+		Details:  details,
+		LastSeen: time.Now(),
+	}
+}
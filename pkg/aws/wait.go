@@ -0,0 +1,84 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// WaitProgressFunc receives the progress of a wait for a resource to become available, as a
+// percentage of total
+type WaitProgressFunc func(progress, total float64, message string)
+
+// waitProgressKey is the context key of the function that receives wait progress
+type waitProgressKey struct{}
+
+// WithWaitProgress returns a context whose waits for resources report their progress to
+// report. The MCP server uses it to turn long waits into progress notifications without each
+// tool having to handle them.
+func WithWaitProgress(ctx context.Context, report WaitProgressFunc) context.Context {
+	if report == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, waitProgressKey{}, report)
+}
+
+// WaitProgressFromContext returns the function set with WithWaitProgress, if any
+func WaitProgressFromContext(ctx context.Context) WaitProgressFunc {
+	report, _ := ctx.Value(waitProgressKey{}).(WaitProgressFunc)
+	return report
+}
+
+// waitCheck returns the current state of the resource being waited for, and whether it is ready
+type waitCheck func(ctx context.Context) (state string, ready bool, err error)
+
+// waitForResource polls check every pollInterval until the resource is ready, for at most
+// maxWait. AWS does not report how far along a resource is, so progress is estimated from the
+// time a resource of this kind typically takes, and held below 100 until it is ready.
+func (c *Client) waitForResource(ctx context.Context, description string, typical, maxWait, pollInterval time.Duration, check waitCheck) error {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, maxWait)
+	defer cancel()
+
+	report := WaitProgressFromContext(ctx)
+	startTime := time.Now()
+
+	for {
+		state, ready, err := check(ctxWithTimeout)
+		if err != nil {
+			return err
+		}
+
+		elapsed := time.Since(startTime)
+		c.logger.WithFields(logrus.Fields{
+			"resource": description,
+			"state":    state,
+			"elapsed":  elapsed.Round(time.Second).String(),
+		}).Info("Resource status check")
+
+		if ready {
+			if report != nil {
+				report(100, 100, fmt.Sprintf("%s is %s", description, state))
+			}
+			return nil
+		}
+
+		if report != nil {
+			progress := 95 * elapsed.Seconds() / typical.Seconds()
+			if progress > 95 {
+				progress = 95
+			}
+			report(progress, 100, fmt.Sprintf("%s is %s after %s", description, state, elapsed.Round(time.Second)))
+		}
+
+		select {
+		case <-ctxWithTimeout.Done():
+			if ctx.Err() != nil {
+				return fmt.Errorf("stopped waiting for %s: %w", description, ctx.Err())
+			}
+			return fmt.Errorf("timeout waiting for %s to become available", description)
+		case <-time.After(pollInterval):
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package cost
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CatalogFileEnvVar points at the pricing catalog, DefaultCatalogFile when unset
+const CatalogFileEnvVar = "PRICING_FILE"
+
+// DefaultCatalogFile holds the pricing catalog shipped with the agent
+const DefaultCatalogFile = "settings/pricing.yaml"
+
+// defaultHoursPerMonth is the number of hours AWS uses to express monthly prices
+const defaultHoursPerMonth = 730
+
+// Catalog is an offline copy of the on-demand prices the estimator needs, per region
+type Catalog struct {
+	Version       string                   `yaml:"version"`
+	Currency      string                   `yaml:"currency"`
+	HoursPerMonth float64                  `yaml:"hoursPerMonth"`
+	Defaults      Defaults                 `yaml:"defaults"`
+	Regions       map[string]*RegionPrices `yaml:"regions"`
+}
+
+// Defaults are the usage assumptions for quantities a plan step does not specify
+type Defaults struct {
+	RootVolumeGB              float64 `yaml:"rootVolumeGb"`
+	RootVolumeType            string  `yaml:"rootVolumeType"`
+	NATGatewayDataGB          float64 `yaml:"natGatewayDataGb"`
+	LoadBalancerCapacityUnits float64 `yaml:"loadBalancerCapacityUnits"`
+	DBInstanceClass           string  `yaml:"dbInstanceClass"`
+	DBAllocatedStorageGB      float64 `yaml:"dbAllocatedStorageGb"`
+	DBStorageType             string  `yaml:"dbStorageType"`
+}
+
+// RegionPrices are the prices of one region. Hourly prices are per resource-hour, storage
+// prices per GB-month.
+type RegionPrices struct {
+	EC2          map[string]float64             `yaml:"ec2"`
+	EBS          map[string]float64             `yaml:"ebs"`
+	NATGateway   NATGatewayPrices               `yaml:"natGateway"`
+	LoadBalancer map[string]*LoadBalancerPrices `yaml:"loadBalancer"`
+	PublicIPv4   float64                        `yaml:"publicIpv4"`
+	RDS          RDSPrices                      `yaml:"rds"`
+}
+
+// NATGatewayPrices are the hourly and data processing prices of a NAT gateway
+type NATGatewayPrices struct {
+	Hourly float64 `yaml:"hourly"`
+	PerGB  float64 `yaml:"perGb"`
+}
+
+// LoadBalancerPrices are the hourly and capacity unit prices of a load balancer type
+type LoadBalancerPrices struct {
+	Hourly    float64 `yaml:"hourly"`
+	LCUHourly float64 `yaml:"lcuHourly"`
+}
+
+// RDSPrices are single-AZ prices for the open source engines (MySQL, PostgreSQL, MariaDB).
+// Multi-AZ deployments cost twice as much.
+type RDSPrices struct {
+	Instances map[string]float64 `yaml:"instances"`
+	Storage   map[string]float64 `yaml:"storage"`
+}
+
+// LoadCatalog reads a pricing catalog file
+func LoadCatalog(path string) (*Catalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var catalog Catalog
+	if err := yaml.Unmarshal(data, &catalog); err != nil {
+		return nil, fmt.Errorf("failed to parse pricing catalog %s: %w", path, err)
+	}
+	if len(catalog.Regions) == 0 {
+		return nil, fmt.Errorf("pricing catalog %s has no regions", path)
+	}
+
+	if catalog.Currency == "" {
+		catalog.Currency = "USD"
+	}
+	if catalog.HoursPerMonth <= 0 {
+		catalog.HoursPerMonth = defaultHoursPerMonth
+	}
+	return &catalog, nil
+}
+
+// LoadConfiguredCatalog loads the file named by PRICING_FILE, or the default pricing catalog.
+// Without either it returns nil and cost estimation is disabled.
+func LoadConfiguredCatalog() (*Catalog, error) {
+	if path := os.Getenv(CatalogFileEnvVar); path != "" {
+		return LoadCatalog(path)
+	}
+
+	catalog, err := LoadCatalog(DefaultCatalogFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return catalog, err
+}
+
+// Region returns the prices of a region, if the catalog has them
+func (c *Catalog) Region(region string) (*RegionPrices, bool) {
+	prices, exists := c.Regions[region]
+	return prices, exists && prices != nil
+}
@@ -0,0 +1,349 @@
+package cost
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/versus-control/ai-infrastructure-agent/pkg/types"
+)
+
+// openSourceEngines are the RDS engines the catalog's instance prices apply to
+var openSourceEngines = map[string]bool{
+	"":         true,
+	"mysql":    true,
+	"mariadb":  true,
+	"postgres": true,
+}
+
+// Estimator prices the create steps of execution plans from a catalog
+type Estimator struct {
+	catalog *Catalog
+}
+
+// NewEstimator creates an estimator for a catalog
+func NewEstimator(catalog *Catalog) *Estimator {
+	return &Estimator{catalog: catalog}
+}
+
+// Catalog returns the pricing catalog of the estimator
+func (e *Estimator) Catalog() *Catalog {
+	return e.catalog
+}
+
+// EstimateDecision estimates the monthly cost of every billable step of a decision's plan.
+// Steps without a region run in defaultRegion. Steps that create free resources (VPCs,
+// security groups, ...) are left out.
+func (e *Estimator) EstimateDecision(decision *types.AgentDecision, defaultRegion string) *types.CostEstimate {
+	estimate := &types.CostEstimate{
+		Currency:       e.catalog.Currency,
+		Steps:          []*types.StepCost{},
+		CatalogVersion: e.catalog.Version,
+		Assumptions:    e.assumptions(),
+	}
+
+	for _, step := range decision.ExecutionPlan {
+		stepCost := e.EstimateStep(step, decision.ExecutionPlan, defaultRegion)
+		if stepCost == nil {
+			continue
+		}
+		if stepCost.Note != "" && len(stepCost.LineItems) == 0 {
+			estimate.UnpricedSteps = append(estimate.UnpricedSteps, step.ID)
+		}
+		estimate.Steps = append(estimate.Steps, stepCost)
+		estimate.MonthlyTotal += stepCost.MonthlyCost
+	}
+
+	estimate.MonthlyTotal = roundCents(estimate.MonthlyTotal)
+	return estimate
+}
+
+// EstimateStep estimates the monthly cost of one step. plan is the whole execution plan, used
+// to find the launch template an auto scaling group refers to. It returns nil for steps that
+// do not create billable resources.
+func (e *Estimator) EstimateStep(step *types.ExecutionPlanStep, plan []*types.ExecutionPlanStep, defaultRegion string) *types.StepCost {
+	parameters := step.ToolParameters
+	if parameters == nil {
+		parameters = step.Parameters
+	}
+
+	var price func(*RegionPrices, map[string]interface{}, *types.StepCost)
+	switch step.MCPTool {
+	case "create-ec2-instance":
+		price = e.priceInstance
+	case "create-ebs-volume", "create-volume":
+		price = e.priceVolume
+	case "create-auto-scaling-group":
+		price = func(prices *RegionPrices, params map[string]interface{}, stepCost *types.StepCost) {
+			e.priceAutoScalingGroup(prices, params, plan, stepCost)
+		}
+	case "create-nat-gateway":
+		price = e.priceNATGateway
+	case "create-load-balancer":
+		price = e.priceLoadBalancer
+	case "create-network-load-balancer":
+		price = e.priceNetworkLoadBalancer
+	case "create-db-instance", "create-db-read-replica", "restore-db-instance-from-snapshot":
+		price = e.priceDBInstance
+	default:
+		return nil
+	}
+
+	stepCost := &types.StepCost{
+		StepID: step.ID,
+		Tool:   step.MCPTool,
+		Region: stepRegion(step, defaultRegion),
+	}
+
+	prices, exists := e.catalog.Region(stepCost.Region)
+	if !exists {
+		stepCost.Note = fmt.Sprintf("no prices for region %s in the pricing catalog", stepCost.Region)
+		return stepCost
+	}
+
+	price(prices, parameters, stepCost)
+	stepCost.MonthlyCost = roundCents(stepCost.MonthlyCost)
+	return stepCost
+}
+
+// priceInstance prices an EC2 instance and its volumes
+func (e *Estimator) priceInstance(prices *RegionPrices, params map[string]interface{}, stepCost *types.StepCost) {
+	instanceType := stringParam(params, "instanceType", "")
+	e.addInstances(prices, instanceType, 1, params, stepCost)
+}
+
+// priceVolume prices a standalone EBS volume from its size and type
+func (e *Estimator) priceVolume(prices *RegionPrices, params map[string]interface{}, stepCost *types.StepCost) {
+	sizeGB := numberParam(params, "size", numberParam(params, "volumeSize", 0))
+	if sizeGB <= 0 {
+		stepCost.Note = "volume size is not part of the plan"
+		return
+	}
+	volumeType := stringParam(params, "volumeType", e.catalog.Defaults.RootVolumeType)
+	e.addVolume(prices, "volume", sizeGB, volumeType, stepCost)
+}
+
+// priceAutoScalingGroup prices the desired capacity of an auto scaling group, using the
+// instance type and volumes of the launch template created by the same plan
+func (e *Estimator) priceAutoScalingGroup(prices *RegionPrices, params map[string]interface{}, plan []*types.ExecutionPlanStep, stepCost *types.StepCost) {
+	capacity := numberParam(params, "desiredCapacity", numberParam(params, "minSize", 1))
+
+	// The name may be a {{step-id.field}} reference to the step creating the template
+	templateName, _ := params["launchTemplateName"].(string)
+	if launchTemplate, ok := params["launchTemplate"].(map[string]interface{}); ok && templateName == "" {
+		templateName, _ = launchTemplate["launchTemplateName"].(string)
+	}
+
+	var templateParams map[string]interface{}
+	for _, step := range plan {
+		if step.MCPTool != "create-launch-template" {
+			continue
+		}
+		name := stringParam(step.ToolParameters, "launchTemplateName", "")
+		if (name != "" && name == templateName) || strings.Contains(templateName, "{{"+step.ID+".") {
+			templateParams = step.ToolParameters
+			break
+		}
+	}
+	instanceType := stringParam(templateParams, "instanceType", "")
+	if instanceType == "" {
+		stepCost.Note = "instance type of the launch template is not part of the plan"
+		return
+	}
+
+	e.addInstances(prices, instanceType, capacity, templateParams, stepCost)
+}
+
+// priceNATGateway prices a NAT gateway, its public address and the assumed data processed
+func (e *Estimator) priceNATGateway(prices *RegionPrices, _ map[string]interface{}, stepCost *types.StepCost) {
+	e.addHourly(stepCost, "NAT gateway", 1, prices.NATGateway.Hourly)
+	e.addHourly(stepCost, "Public IPv4 address", 1, prices.PublicIPv4)
+	addLineItem(stepCost, "NAT gateway data processing", e.catalog.Defaults.NATGatewayDataGB, "GB", prices.NATGateway.PerGB)
+}
+
+// priceLoadBalancer prices a load balancer of the type in the step parameters
+func (e *Estimator) priceLoadBalancer(prices *RegionPrices, params map[string]interface{}, stepCost *types.StepCost) {
+	e.addLoadBalancer(prices, stringParam(params, "type", "application"), stepCost)
+}
+
+// priceNetworkLoadBalancer prices a network load balancer and the Elastic IPs it allocates
+func (e *Estimator) priceNetworkLoadBalancer(prices *RegionPrices, params map[string]interface{}, stepCost *types.StepCost) {
+	e.addLoadBalancer(prices, "network", stepCost)
+
+	addresses := float64(len(listParam(params, "elasticIpAllocationIds")))
+	if allocate, _ := params["allocateElasticIps"].(bool); allocate {
+		addresses = float64(len(listParam(params, "subnetIds")))
+	}
+	if addresses > 0 {
+		e.addHourly(stepCost, "Public IPv4 address", addresses, prices.PublicIPv4)
+	}
+}
+
+// priceDBInstance prices an RDS instance and its storage
+func (e *Estimator) priceDBInstance(prices *RegionPrices, params map[string]interface{}, stepCost *types.StepCost) {
+	defaults := e.catalog.Defaults
+	instanceClass := stringParam(params, "dbInstanceClass", defaults.DBInstanceClass)
+	storageGB := numberParam(params, "allocatedStorage", defaults.DBAllocatedStorageGB)
+	storageType := stringParam(params, "storageType", defaults.DBStorageType)
+
+	multiplier := 1.0
+	deployment := "single-AZ"
+	if multiAZ, _ := params["multiAz"].(bool); multiAZ {
+		multiplier = 2
+		deployment = "Multi-AZ"
+	}
+
+	hourly, exists := prices.RDS.Instances[instanceClass]
+	if !exists {
+		stepCost.Note = fmt.Sprintf("no price for DB instance class %q in the pricing catalog", instanceClass)
+		return
+	}
+	e.addHourly(stepCost, fmt.Sprintf("RDS %s (%s)", instanceClass, deployment), 1, hourly*multiplier)
+
+	if storagePrice, exists := prices.RDS.Storage[storageType]; exists {
+		addLineItem(stepCost, fmt.Sprintf("RDS %s storage (%s)", storageType, deployment), storageGB, "GB-month", storagePrice*multiplier)
+	} else {
+		stepCost.Note = fmt.Sprintf("no price for RDS storage type %q in the pricing catalog", storageType)
+	}
+
+	if engine := strings.ToLower(stringParam(params, "engine", "")); !openSourceEngines[engine] {
+		stepCost.Note = fmt.Sprintf("priced as an open source engine; %s license costs are not included", engine)
+	}
+}
+
+// addInstances adds instance hours and volumes for a number of instances. params are the
+// parameters describing the instances' volumes; without any the default root volume is priced.
+func (e *Estimator) addInstances(prices *RegionPrices, instanceType string, count float64, params map[string]interface{}, stepCost *types.StepCost) {
+	hourly, exists := prices.EC2[instanceType]
+	if !exists {
+		stepCost.Note = fmt.Sprintf("no price for instance type %q in the pricing catalog", instanceType)
+		return
+	}
+	e.addHourly(stepCost, fmt.Sprintf("EC2 %s", instanceType), count, hourly)
+
+	// Block device mappings describe every volume; otherwise the step may size the root volume
+	defaults := e.catalog.Defaults
+	mappings := listParam(params, "blockDeviceMappings")
+	if len(mappings) == 0 {
+		sizeGB := numberParam(params, "volumeSize", defaults.RootVolumeGB)
+		volumeType := stringParam(params, "volumeType", defaults.RootVolumeType)
+		e.addVolume(prices, "root volume", count*sizeGB, volumeType, stepCost)
+		return
+	}
+	for _, mapping := range mappings {
+		device, _ := mapping.(map[string]interface{})
+		ebs, ok := device["ebs"].(map[string]interface{})
+		if !ok {
+			continue // Instance store volumes are part of the instance price
+		}
+		sizeGB := numberParam(ebs, "volumeSize", defaults.RootVolumeGB)
+		volumeType := stringParam(ebs, "volumeType", defaults.RootVolumeType)
+		e.addVolume(prices, fmt.Sprintf("volume %s", stringParam(device, "deviceName", "")), count*sizeGB, volumeType, stepCost)
+	}
+}
+
+// addVolume adds EBS storage of a volume type
+func (e *Estimator) addVolume(prices *RegionPrices, description string, sizeGB float64, volumeType string, stepCost *types.StepCost) {
+	volumePrice, exists := prices.EBS[volumeType]
+	if !exists {
+		stepCost.Note = fmt.Sprintf("no price for EBS volume type %q in the pricing catalog", volumeType)
+		return
+	}
+	addLineItem(stepCost, strings.TrimSpace(fmt.Sprintf("EBS %s %s", volumeType, description)), sizeGB, "GB-month", volumePrice)
+}
+
+// addLoadBalancer adds the hours and assumed capacity units of a load balancer
+func (e *Estimator) addLoadBalancer(prices *RegionPrices, lbType string, stepCost *types.StepCost) {
+	lbPrices, exists := prices.LoadBalancer[lbType]
+	if !exists || lbPrices == nil {
+		stepCost.Note = fmt.Sprintf("no price for %s load balancers in the pricing catalog", lbType)
+		return
+	}
+	e.addHourly(stepCost, fmt.Sprintf("Load balancer (%s)", lbType), 1, lbPrices.Hourly)
+	e.addHourly(stepCost, "Load balancer capacity units", e.catalog.Defaults.LoadBalancerCapacityUnits, lbPrices.LCUHourly)
+}
+
+// addHourly adds a line item for resources billed per hour, running the whole month
+func (e *Estimator) addHourly(stepCost *types.StepCost, description string, count, hourly float64) {
+	addLineItem(stepCost, description, count*e.catalog.HoursPerMonth, "hours", hourly)
+}
+
+// assumptions describes the usage the estimate assumes where plans do not say
+func (e *Estimator) assumptions() []string {
+	defaults := e.catalog.Defaults
+	return []string{
+		fmt.Sprintf("On-demand prices, resources running %.0f hours a month", e.catalog.HoursPerMonth),
+		fmt.Sprintf("%.0f GB %s root volume per EC2 instance unless the plan sizes its volumes", defaults.RootVolumeGB, defaults.RootVolumeType),
+		"Provisioned IOPS and throughput are not included",
+		fmt.Sprintf("%.0f GB processed per NAT gateway", defaults.NATGatewayDataGB),
+		fmt.Sprintf("%.0f capacity unit(s) per load balancer", defaults.LoadBalancerCapacityUnits),
+		"Data transfer, requests and snapshots are not included",
+	}
+}
+
+// addLineItem adds a priced component to a step, skipping zero quantities and prices
+func addLineItem(stepCost *types.StepCost, description string, quantity float64, unit string, unitPrice float64) {
+	if quantity <= 0 || unitPrice <= 0 {
+		return
+	}
+	monthly := roundCents(quantity * unitPrice)
+	stepCost.LineItems = append(stepCost.LineItems, &types.CostLineItem{
+		Description: description,
+		Quantity:    quantity,
+		Unit:        unit,
+		UnitPrice:   unitPrice,
+		MonthlyCost: monthly,
+	})
+	stepCost.MonthlyCost += monthly
+}
+
+// stepRegion returns the region a step runs in
+func stepRegion(step *types.ExecutionPlanStep, defaultRegion string) string {
+	if step.Region != "" {
+		return step.Region
+	}
+	if region, ok := step.ToolParameters["region"].(string); ok && region != "" {
+		return region
+	}
+	return defaultRegion
+}
+
+// stringParam returns a string parameter, or defaultValue when it is missing or still an
+// unresolved {{step-id.field}} reference
+func stringParam(params map[string]interface{}, key, defaultValue string) string {
+	value, ok := params[key].(string)
+	if !ok || value == "" || strings.Contains(value, "{{") {
+		return defaultValue
+	}
+	return value
+}
+
+// numberParam returns a numeric parameter given as a number or a numeric string
+func numberParam(params map[string]interface{}, key string, defaultValue float64) float64 {
+	switch v := params[key].(type) {
+	case float64:
+		return v
+	case int:
+		return float64(v)
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case string:
+		if number, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+			return number
+		}
+	}
+	return defaultValue
+}
+
+// listParam returns an array parameter
+func listParam(params map[string]interface{}, key string) []interface{} {
+	list, _ := params[key].([]interface{})
+	return list
+}
+
+func roundCents(value float64) float64 {
+	return math.Round(value*100) / 100
+}
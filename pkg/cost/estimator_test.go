@@ -0,0 +1,182 @@
+package cost
+
+import (
+	"testing"
+
+	"github.com/versus-control/ai-infrastructure-agent/pkg/policy"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/types"
+)
+
+func TestEstimateStep(t *testing.T) {
+	launchTemplate := &types.ExecutionPlanStep{
+		ID:      "step-template",
+		MCPTool: "create-launch-template",
+		ToolParameters: map[string]interface{}{
+			"launchTemplateName": "web",
+			"instanceType":       "m5.large",
+			"volumeSize":         50.0,
+		},
+	}
+
+	tests := []struct {
+		name      string
+		tool      string
+		params    map[string]interface{}
+		wantCost  float64
+		wantItems int
+		wantNote  bool
+	}{
+		{
+			name:      "instance with the default root volume",
+			tool:      "create-ec2-instance",
+			params:    map[string]interface{}{"instanceType": "t3.micro"},
+			wantCost:  7.3 + 0.64,
+			wantItems: 2,
+		},
+		{
+			name:      "instance with a sized root volume",
+			tool:      "create-ec2-instance",
+			params:    map[string]interface{}{"instanceType": "t3.micro", "volumeSize": 100.0, "volumeType": "gp2"},
+			wantCost:  7.3 + 10,
+			wantItems: 2,
+		},
+		{
+			name: "instance with block device mappings",
+			tool: "create-ec2-instance",
+			params: map[string]interface{}{
+				"instanceType": "t3.micro",
+				"blockDeviceMappings": []interface{}{
+					map[string]interface{}{"deviceName": "/dev/xvda", "ebs": map[string]interface{}{"volumeSize": 20.0}},
+					map[string]interface{}{"deviceName": "/dev/sdb", "ebs": map[string]interface{}{"volumeSize": 500.0, "volumeType": "io1"}},
+					map[string]interface{}{"deviceName": "/dev/sdc", "virtualName": "ephemeral0"},
+				},
+			},
+			wantCost:  7.3 + 1.6 + 62.5,
+			wantItems: 3,
+		},
+		{
+			name:      "standalone volume",
+			tool:      "create-ebs-volume",
+			params:    map[string]interface{}{"size": 1000.0, "volumeType": "gp2"},
+			wantCost:  100,
+			wantItems: 1,
+		},
+		{
+			name:      "volume size as a string and the default type",
+			tool:      "create-volume",
+			params:    map[string]interface{}{"volumeSize": "200"},
+			wantCost:  16,
+			wantItems: 1,
+		},
+		{
+			name:     "volume without a size",
+			tool:     "create-ebs-volume",
+			params:   map[string]interface{}{"volumeType": "gp3"},
+			wantNote: true,
+		},
+		{
+			name:     "unpriced volume type",
+			tool:     "create-ebs-volume",
+			params:   map[string]interface{}{"size": 100.0, "volumeType": "sc1"},
+			wantNote: true,
+		},
+		{
+			name:      "auto scaling group with the template's volumes",
+			tool:      "create-auto-scaling-group",
+			params:    map[string]interface{}{"launchTemplateName": "{{step-template.resourceId}}", "desiredCapacity": 3.0},
+			wantCost:  3*73 + 3*50*0.08,
+			wantItems: 2,
+		},
+	}
+
+	estimator := NewEstimator(newTestCatalog())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step := &types.ExecutionPlanStep{ID: "step-1", MCPTool: tt.tool, ToolParameters: tt.params}
+			stepCost := estimator.EstimateStep(step, []*types.ExecutionPlanStep{launchTemplate, step}, testRegion)
+			if stepCost == nil {
+				t.Fatal("step was not priced")
+			}
+
+			if stepCost.MonthlyCost != roundCents(tt.wantCost) || len(stepCost.LineItems) != tt.wantItems {
+				t.Fatalf("got %.2f in %d line items, want %.2f in %d", stepCost.MonthlyCost, len(stepCost.LineItems), tt.wantCost, tt.wantItems)
+			}
+			if (stepCost.Note != "") != tt.wantNote {
+				t.Fatalf("unexpected note %q", stepCost.Note)
+			}
+		})
+	}
+}
+
+func TestEstimateStepSkipsFreeResources(t *testing.T) {
+	step := &types.ExecutionPlanStep{ID: "step-vpc", MCPTool: "create-vpc", ToolParameters: map[string]interface{}{"cidrBlock": "10.0.0.0/16"}}
+	if stepCost := NewEstimator(newTestCatalog()).EstimateStep(step, nil, testRegion); stepCost != nil {
+		t.Fatalf("unexpected cost %+v", stepCost)
+	}
+}
+
+func TestEstimateDecisionUnpricedRegion(t *testing.T) {
+	decision := &types.AgentDecision{ExecutionPlan: []*types.ExecutionPlanStep{
+		{ID: "step-volume", MCPTool: "create-ebs-volume", Region: "ap-south-2", ToolParameters: map[string]interface{}{"size": 100.0}},
+	}}
+
+	estimate := NewEstimator(newTestCatalog()).EstimateDecision(decision, testRegion)
+	if estimate.MonthlyTotal != 0 || len(estimate.UnpricedSteps) != 1 || estimate.UnpricedSteps[0] != "step-volume" {
+		t.Fatalf("unexpected estimate %+v", estimate)
+	}
+}
+
+func TestBudgetThreshold(t *testing.T) {
+	// The monthly-budget policy of settings/policies.yaml
+	threshold := 500.0
+	engine, err := policy.NewEngine([]*policy.Policy{{
+		ID:         "monthly-budget",
+		Effect:     policy.EffectConfirm,
+		Level:      policy.LevelDecision,
+		Conditions: []*policy.Condition{{Field: "monthlyCost", GT: &threshold}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	instance := &types.ExecutionPlanStep{ID: "step-instance", MCPTool: "create-ec2-instance", ToolParameters: map[string]interface{}{"instanceType": "t3.micro"}}
+
+	tests := []struct {
+		name        string
+		plan        []*types.ExecutionPlanStep
+		wantConfirm bool
+	}{
+		{
+			name: "small plan",
+			plan: []*types.ExecutionPlanStep{instance},
+		},
+		{
+			name: "small instance with a large data volume",
+			plan: []*types.ExecutionPlanStep{
+				instance,
+				{ID: "step-data", MCPTool: "create-ebs-volume", ToolParameters: map[string]interface{}{"size": 4000.0, "volumeType": "io1"}},
+			},
+			wantConfirm: true,
+		},
+		{
+			name: "large root volume",
+			plan: []*types.ExecutionPlanStep{
+				{ID: "step-instance", MCPTool: "create-ec2-instance", ToolParameters: map[string]interface{}{"instanceType": "t3.micro", "volumeSize": 8000.0}},
+			},
+			wantConfirm: true,
+		},
+	}
+
+	estimator := NewEstimator(newTestCatalog())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision := &types.AgentDecision{ID: "decision-1", ExecutionPlan: tt.plan}
+			decision.CostEstimate = estimator.EstimateDecision(decision, testRegion)
+
+			violations := engine.EvaluateDecision(decision, testRegion)
+			if got := policy.RequiresConfirmation(violations); got != tt.wantConfirm {
+				t.Fatalf("RequiresConfirmation() = %v at %.2f a month, want %v", got, decision.CostEstimate.MonthlyTotal, tt.wantConfirm)
+			}
+		})
+	}
+}
@@ -0,0 +1,495 @@
+package cost
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/versus-control/ai-infrastructure-agent/pkg/tagging"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/types"
+)
+
+// Kinds of rightsizing findings
+const (
+	FindingStoppedInstanceWithEIP = "stopped-instance-with-eip"
+	FindingUnattachedVolume       = "unattached-volume"
+	FindingUnassociatedEIP        = "unassociated-eip"
+	FindingIdleNATGateway         = "idle-nat-gateway"
+	FindingOversizedInstance      = "oversized-instance"
+	FindingOversizedDBInstance    = "oversized-db-instance"
+)
+
+// Instances whose daily CPU utilization averaged under oversizedAverageCPU percent, and never
+// peaked above oversizedPeakCPU percent, over at least minUtilizationDays days are oversized
+const (
+	oversizedAverageCPU = 10.0
+	oversizedPeakCPU    = 40.0
+	minUtilizationDays  = 7
+)
+
+// untaggedValue groups the spend of resources without a tag in CostReport.ByTag
+const untaggedValue = "(untagged)"
+
+// DefaultReportTagKeys are the tag keys spend is grouped by when a report names none
+var DefaultReportTagKeys = []string{tagging.KeyWorkspace, "Environment"}
+
+// stoppedStates are instance states in which compute is not billed
+var stoppedStates = map[string]bool{
+	"stopping":      true,
+	"stopped":       true,
+	"shutting-down": true,
+	"terminated":    true,
+}
+
+// ReportOptions control how a cost report groups and labels resources
+type ReportOptions struct {
+	// DefaultRegion is used for resources that do not record a region
+	DefaultRegion string
+
+	// TagKeys are the tag keys spend is grouped by, DefaultReportTagKeys when empty
+	TagKeys []string
+
+	// ManagedIDs are the resources tracked in state or tagged as created by the agent
+	ManagedIDs map[string]bool
+}
+
+// reportIndex holds what findings need to know about the other resources of a report
+type reportIndex struct {
+	instances           map[string]*types.ResourceState
+	servedNATGateways   map[string]bool
+	routeTablesKnown    bool
+	volumesDiscovered   bool
+	addressesDiscovered bool
+}
+
+// EstimateResources estimates the monthly spend of existing resources, such as the managed
+// state merged with a live discovery scan, and flags the idle ones.
+//
+// Volumes, Elastic IPs and route tables are only known from a live scan. Without them instance
+// storage and public addresses are priced from the catalog defaults and NAT gateways are not
+// checked for private subnets.
+func (e *Estimator) EstimateResources(resources []*types.ResourceState, options ReportOptions) *types.CostReport {
+	tagKeys := options.TagKeys
+	if len(tagKeys) == 0 {
+		tagKeys = DefaultReportTagKeys
+	}
+
+	report := &types.CostReport{
+		GeneratedAt:    time.Now().UTC(),
+		Currency:       e.catalog.Currency,
+		ByType:         make(map[string]float64),
+		ByTag:          make(map[string]map[string]float64),
+		Resources:      []*types.ResourceCost{},
+		Findings:       []*types.RightsizingFinding{},
+		CatalogVersion: e.catalog.Version,
+		Assumptions:    e.reportAssumptions(),
+	}
+	for _, key := range tagKeys {
+		report.ByTag[key] = make(map[string]float64)
+	}
+
+	index := buildReportIndex(resources)
+
+	for _, resource := range resources {
+		resourceCost := e.estimateResource(resource, index, options.DefaultRegion)
+		if resourceCost == nil {
+			continue
+		}
+		resourceCost.Managed = options.ManagedIDs[resource.ID]
+
+		if len(resourceCost.LineItems) == 0 && resourceCost.Note != "" && !isStopped(resource) {
+			report.UnpricedResources = append(report.UnpricedResources, resource.ID)
+		}
+
+		report.Resources = append(report.Resources, resourceCost)
+		report.MonthlyTotal += resourceCost.MonthlyCost
+		report.ByType[resource.Type] += resourceCost.MonthlyCost
+		for _, key := range tagKeys {
+			value := resource.Tags[key]
+			if value == "" {
+				value = untaggedValue
+			}
+			report.ByTag[key][value] += resourceCost.MonthlyCost
+		}
+
+		if finding := e.findIdle(resource, resourceCost, index); finding != nil {
+			report.Findings = append(report.Findings, finding)
+			report.MonthlySavings += finding.MonthlySavings
+		}
+	}
+
+	report.MonthlyTotal = roundCents(report.MonthlyTotal)
+	report.MonthlySavings = roundCents(report.MonthlySavings)
+	for resourceType, total := range report.ByType {
+		report.ByType[resourceType] = roundCents(total)
+	}
+	for _, values := range report.ByTag {
+		for value, total := range values {
+			values[value] = roundCents(total)
+		}
+	}
+
+	sort.SliceStable(report.Resources, func(i, j int) bool {
+		if report.Resources[i].MonthlyCost != report.Resources[j].MonthlyCost {
+			return report.Resources[i].MonthlyCost > report.Resources[j].MonthlyCost
+		}
+		return report.Resources[i].ResourceID < report.Resources[j].ResourceID
+	})
+	sort.SliceStable(report.Findings, func(i, j int) bool {
+		if report.Findings[i].MonthlySavings != report.Findings[j].MonthlySavings {
+			return report.Findings[i].MonthlySavings > report.Findings[j].MonthlySavings
+		}
+		return report.Findings[i].ResourceID < report.Findings[j].ResourceID
+	})
+
+	return report
+}
+
+// estimateResource prices one resource. It returns nil for free resources (VPCs, security
+// groups, ...) and for auto scaling groups, whose instances are priced one by one.
+func (e *Estimator) estimateResource(resource *types.ResourceState, index *reportIndex, defaultRegion string) *types.ResourceCost {
+	props := resourceProperties(resource)
+
+	var price func(*RegionPrices, *types.StepCost)
+	switch resource.Type {
+	case "ec2_instance":
+		price = func(prices *RegionPrices, stepCost *types.StepCost) {
+			if isStopped(resource) {
+				stepCost.Note = "stopped; only its volumes and addresses are billed"
+				return
+			}
+			instanceType := stringParam(props, "instanceType", "")
+			if index.volumesDiscovered {
+				hourly, exists := prices.EC2[instanceType]
+				if !exists {
+					stepCost.Note = fmt.Sprintf("no price for instance type %q in the pricing catalog", instanceType)
+					return
+				}
+				e.addHourly(stepCost, fmt.Sprintf("EC2 %s", instanceType), 1, hourly)
+				return
+			}
+			e.addInstances(prices, instanceType, 1, nil, stepCost)
+		}
+	case "ebs_volume":
+		price = func(prices *RegionPrices, stepCost *types.StepCost) {
+			volumeType := stringParam(props, "volumeType", e.catalog.Defaults.RootVolumeType)
+			e.addVolume(prices, "volume", numberParam(props, "size", 0), volumeType, stepCost)
+		}
+	case "elastic_ip":
+		price = func(prices *RegionPrices, stepCost *types.StepCost) {
+			e.addHourly(stepCost, "Public IPv4 address", 1, prices.PublicIPv4)
+		}
+	case "nat_gateway":
+		price = func(prices *RegionPrices, stepCost *types.StepCost) {
+			e.addHourly(stepCost, "NAT gateway", 1, prices.NATGateway.Hourly)
+			if !index.addressesDiscovered {
+				e.addHourly(stepCost, "Public IPv4 address", 1, prices.PublicIPv4)
+			}
+			addLineItem(stepCost, "NAT gateway data processing", e.catalog.Defaults.NATGatewayDataGB, "GB", prices.NATGateway.PerGB)
+		}
+	case "load_balancer":
+		price = func(prices *RegionPrices, stepCost *types.StepCost) {
+			e.addLoadBalancer(prices, stringParam(props, "type", "application"), stepCost)
+		}
+	case "rds_instance":
+		price = func(prices *RegionPrices, stepCost *types.StepCost) {
+			// Discovered instances use snake_case details, created ones the tool parameter names
+			params := map[string]interface{}{
+				"dbInstanceClass":  firstValue(props, "instance_class", "dbInstanceClass"),
+				"allocatedStorage": firstValue(props, "allocated_storage", "allocatedStorage"),
+				"storageType":      firstValue(props, "storage_type", "storageType"),
+				"multiAz":          firstValue(props, "multi_az", "multiAz"),
+				"engine":           firstValue(props, "engine"),
+			}
+			e.priceDBInstance(prices, params, stepCost)
+		}
+	default:
+		return nil
+	}
+
+	region := resource.Region
+	if region == "" {
+		region = defaultRegion
+	}
+	resourceCost := &types.ResourceCost{
+		ResourceID:   resource.ID,
+		ResourceType: resource.Type,
+		Name:         resource.Name,
+		Region:       region,
+	}
+
+	prices, exists := e.catalog.Region(region)
+	if !exists {
+		resourceCost.Note = fmt.Sprintf("no prices for region %s in the pricing catalog", region)
+		return resourceCost
+	}
+
+	stepCost := &types.StepCost{}
+	price(prices, stepCost)
+	resourceCost.MonthlyCost = roundCents(stepCost.MonthlyCost)
+	resourceCost.LineItems = stepCost.LineItems
+	resourceCost.Note = stepCost.Note
+	return resourceCost
+}
+
+// findIdle returns the rightsizing finding for a resource, if it is idle or oversized
+func (e *Estimator) findIdle(resource *types.ResourceState, resourceCost *types.ResourceCost, index *reportIndex) *types.RightsizingFinding {
+	if resourceCost.MonthlyCost == 0 {
+		return nil
+	}
+
+	finding := &types.RightsizingFinding{
+		ResourceID:     resource.ID,
+		ResourceType:   resource.Type,
+		Region:         resourceCost.Region,
+		MonthlySavings: resourceCost.MonthlyCost,
+	}
+	props := resourceProperties(resource)
+
+	switch resource.Type {
+	case "elastic_ip":
+		publicIP := stringParam(props, "publicIp", resource.ID)
+		instanceID := stringParam(props, "instanceId", "")
+		if stringParam(props, "associationId", "") == "" {
+			finding.Kind = FindingUnassociatedEIP
+			finding.Description = fmt.Sprintf("Elastic IP %s is not associated with any instance or network interface", publicIP)
+			finding.Recommendation = fmt.Sprintf("Release Elastic IP %s", resource.ID)
+			return finding
+		}
+		if instance, exists := index.instances[instanceID]; exists && isStopped(instance) {
+			finding.Kind = FindingStoppedInstanceWithEIP
+			finding.Description = fmt.Sprintf("Elastic IP %s is associated with stopped instance %s", publicIP, instanceID)
+			finding.Recommendation = fmt.Sprintf("Release Elastic IP %s, or terminate instance %s if it is no longer needed", resource.ID, instanceID)
+			return finding
+		}
+	case "ebs_volume":
+		if resourceStatus(resource) == "available" {
+			finding.Kind = FindingUnattachedVolume
+			finding.Description = fmt.Sprintf("EBS volume %s (%.0f GB) is not attached to any instance", resource.ID, numberParam(props, "size", 0))
+			finding.Recommendation = fmt.Sprintf("Snapshot volume %s if its data is still needed, then delete it", resource.ID)
+			return finding
+		}
+	case "nat_gateway":
+		if index.routeTablesKnown && !index.servedNATGateways[resource.ID] {
+			finding.Kind = FindingIdleNATGateway
+			finding.Description = fmt.Sprintf("NAT gateway %s is not the default route of any subnet's route table, so no private subnet uses it", resource.ID)
+			finding.Recommendation = fmt.Sprintf("Delete NAT gateway %s and release its Elastic IP", resource.ID)
+			return finding
+		}
+	case "ec2_instance", "rds_instance":
+		return e.findOversized(resource, props, finding)
+	}
+	return nil
+}
+
+// findOversized returns the finding for an instance whose CPU utilization, attached by a live
+// scan, is low enough to run on the next smaller priced size of its family. The savings are
+// the price difference between the two sizes.
+func (e *Estimator) findOversized(resource *types.ResourceState, props map[string]interface{}, finding *types.RightsizingFinding) *types.RightsizingFinding {
+	utilization, _ := props["cpuUtilization"].(map[string]interface{})
+	average := numberParam(utilization, "average", 0)
+	peak := numberParam(utilization, "maximum", 0)
+	days := numberParam(utilization, "days", 0)
+	if days < minUtilizationDays || average >= oversizedAverageCPU || peak >= oversizedPeakCPU {
+		return nil
+	}
+
+	prices, exists := e.catalog.Region(finding.Region)
+	if !exists {
+		return nil
+	}
+
+	var sizes map[string]float64
+	var current, label string
+	multiplier := 1.0
+	if resource.Type == "ec2_instance" {
+		if isStopped(resource) {
+			return nil
+		}
+		sizes = prices.EC2
+		current = stringParam(props, "instanceType", "")
+		label = "EC2 instance"
+		finding.Kind = FindingOversizedInstance
+	} else {
+		sizes = prices.RDS.Instances
+		current, _ = firstValue(props, "instance_class", "dbInstanceClass").(string)
+		if multiAZ, _ := firstValue(props, "multi_az", "multiAz").(bool); multiAZ {
+			multiplier = 2
+		}
+		label = "DB instance"
+		finding.Kind = FindingOversizedDBInstance
+	}
+
+	smaller, exists := nextSmallerSize(sizes, current)
+	if !exists {
+		return nil
+	}
+
+	finding.MonthlySavings = roundCents((sizes[current] - sizes[smaller]) * multiplier * e.catalog.HoursPerMonth)
+	finding.Description = fmt.Sprintf("%s %s (%s) averaged %.1f%% CPU with a peak of %.1f%% over the last %.0f days", label, resource.ID, current, average, peak, days)
+	finding.Recommendation = fmt.Sprintf("Resize %s from %s to %s", resource.ID, current, smaller)
+	return finding
+}
+
+// nextSmallerSize returns the most expensive priced size of an instance type's family that is
+// cheaper than the type, e.g. m5.large for m5.xlarge or db.t3.small for db.t3.medium
+func nextSmallerSize(sizes map[string]float64, instanceType string) (string, bool) {
+	currentPrice, exists := sizes[instanceType]
+	separator := strings.LastIndex(instanceType, ".")
+	if !exists || separator < 0 {
+		return "", false
+	}
+	family := instanceType[:separator+1]
+
+	smaller := ""
+	for candidate, price := range sizes {
+		if !strings.HasPrefix(candidate, family) || strings.Contains(candidate[len(family):], ".") {
+			continue
+		}
+		if price <= 0 || price >= currentPrice {
+			continue
+		}
+		if smaller == "" || price > sizes[smaller] || (price == sizes[smaller] && candidate < smaller) {
+			smaller = candidate
+		}
+	}
+	return smaller, smaller != ""
+}
+
+// reportAssumptions describes the usage the report assumes where resources do not say
+func (e *Estimator) reportAssumptions() []string {
+	defaults := e.catalog.Defaults
+	return []string{
+		fmt.Sprintf("On-demand prices, resources running %.0f hours a month", e.catalog.HoursPerMonth),
+		fmt.Sprintf("%.0f GB %s root volume per running EC2 instance when volumes were not scanned", defaults.RootVolumeGB, defaults.RootVolumeType),
+		fmt.Sprintf("%.0f GB processed per NAT gateway", defaults.NATGatewayDataGB),
+		fmt.Sprintf("%.0f capacity unit(s) per load balancer", defaults.LoadBalancerCapacityUnits),
+		"Auto scaling groups are priced through their instances, which only a live scan finds",
+		fmt.Sprintf("Instances averaging under %.0f%% CPU, peaking under %.0f%%, over at least %d days of CloudWatch metrics are oversized; savings assume the next smaller size of their family", oversizedAverageCPU, oversizedPeakCPU, minUtilizationDays),
+		"Data transfer, requests and snapshots are not included",
+	}
+}
+
+// buildReportIndex collects the instances, discovered resource kinds and the NAT gateways
+// route tables send subnet traffic through
+func buildReportIndex(resources []*types.ResourceState) *reportIndex {
+	index := &reportIndex{
+		instances:         make(map[string]*types.ResourceState),
+		servedNATGateways: make(map[string]bool),
+	}
+
+	for _, resource := range resources {
+		switch resource.Type {
+		case "ec2_instance":
+			index.instances[resource.ID] = resource
+		case "ebs_volume":
+			index.volumesDiscovered = true
+		case "elastic_ip":
+			index.addressesDiscovered = true
+		case "route_table":
+			index.routeTablesKnown = true
+			props := resourceProperties(resource)
+
+			// The main route table serves every subnet without an explicit association
+			isMain, _ := props["main"].(bool)
+			if len(stringList(props["subnetIds"])) == 0 && !isMain {
+				continue
+			}
+			for _, route := range mapList(props["routes"]) {
+				if natGatewayID, _ := route["natGatewayId"].(string); natGatewayID != "" {
+					index.servedNATGateways[natGatewayID] = true
+				}
+			}
+		}
+	}
+
+	return index
+}
+
+// resourceProperties flattens the properties of a resource. Resources the agent created keep
+// the creating tool's response under mcp_response, with the AWS details nested under details.
+func resourceProperties(resource *types.ResourceState) map[string]interface{} {
+	props := make(map[string]interface{})
+	response, _ := resource.Properties["mcp_response"].(map[string]interface{})
+	if details, ok := response["details"].(map[string]interface{}); ok {
+		for key, value := range details {
+			props[key] = value
+		}
+	}
+	for key, value := range response {
+		props[key] = value
+	}
+	for key, value := range resource.Properties {
+		if key != "mcp_response" {
+			props[key] = value
+		}
+	}
+	return props
+}
+
+// resourceStatus returns the AWS state of a resource, preferring the state the creating tool
+// reported over the "created" status of resources added by the agent
+func resourceStatus(resource *types.ResourceState) string {
+	status := resource.CurrentState
+	if status == "" {
+		status = resource.Status
+	}
+	if status == "" || status == "created" {
+		if state, ok := resourceProperties(resource)["state"].(string); ok && state != "" {
+			return state
+		}
+	}
+	return status
+}
+
+// isStopped reports whether an instance is not running
+func isStopped(resource *types.ResourceState) bool {
+	return resource.Type == "ec2_instance" && stoppedStates[strings.ToLower(resourceStatus(resource))]
+}
+
+// firstValue returns the value of the first key present in props
+func firstValue(props map[string]interface{}, keys ...string) interface{} {
+	for _, key := range keys {
+		if value, exists := props[key]; exists && value != nil {
+			return value
+		}
+	}
+	return nil
+}
+
+// stringList returns a list of strings stored as []string, or as []interface{} once the
+// properties went through JSON
+func stringList(value interface{}) []string {
+	switch v := value.(type) {
+	case []string:
+		return v
+	case []interface{}:
+		var list []string
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				list = append(list, s)
+			}
+		}
+		return list
+	}
+	return nil
+}
+
+// mapList returns a list of maps stored as []map[string]interface{}, or as []interface{} once
+// the properties went through JSON
+func mapList(value interface{}) []map[string]interface{} {
+	switch v := value.(type) {
+	case []map[string]interface{}:
+		return v
+	case []interface{}:
+		var list []map[string]interface{}
+		for _, item := range v {
+			if m, ok := item.(map[string]interface{}); ok {
+				list = append(list, m)
+			}
+		}
+		return list
+	}
+	return nil
+}
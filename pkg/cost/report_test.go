@@ -0,0 +1,282 @@
+package cost
+
+import (
+	"testing"
+
+	"github.com/versus-control/ai-infrastructure-agent/pkg/types"
+)
+
+const testRegion = "us-east-1"
+
+// newTestCatalog returns a small catalog with round prices, independent of settings/pricing.yaml
+func newTestCatalog() *Catalog {
+	return &Catalog{
+		Version:       "test",
+		Currency:      "USD",
+		HoursPerMonth: 730,
+		Defaults: Defaults{
+			RootVolumeGB:              8,
+			RootVolumeType:            "gp3",
+			NATGatewayDataGB:          100,
+			LoadBalancerCapacityUnits: 1,
+			DBInstanceClass:           "db.t3.micro",
+			DBAllocatedStorageGB:      20,
+			DBStorageType:             "gp2",
+		},
+		Regions: map[string]*RegionPrices{
+			testRegion: {
+				EC2: map[string]float64{
+					"t3.micro":  0.01,
+					"t3.small":  0.02,
+					"m5.large":  0.1,
+					"m5.xlarge": 0.2,
+					"m5d.large": 0.12,
+				},
+				EBS:        map[string]float64{"gp3": 0.08, "gp2": 0.1, "io1": 0.125},
+				NATGateway: NATGatewayPrices{Hourly: 0.045, PerGB: 0.045},
+				LoadBalancer: map[string]*LoadBalancerPrices{
+					"application": {Hourly: 0.0225, LCUHourly: 0.008},
+				},
+				PublicIPv4: 0.005,
+				RDS: RDSPrices{
+					Instances: map[string]float64{
+						"db.t3.micro":  0.02,
+						"db.t3.small":  0.04,
+						"db.t3.medium": 0.08,
+					},
+					Storage: map[string]float64{"gp2": 0.115},
+				},
+			},
+		},
+	}
+}
+
+func testResource(id, resourceType, status string, properties map[string]interface{}) *types.ResourceState {
+	return &types.ResourceState{
+		ID:           id,
+		Name:         id,
+		Type:         resourceType,
+		Region:       testRegion,
+		Status:       status,
+		CurrentState: status,
+		Properties:   properties,
+	}
+}
+
+func lowCPU(days int) map[string]interface{} {
+	return map[string]interface{}{"average": 3.5, "maximum": 22.0, "days": days}
+}
+
+func TestFindIdle(t *testing.T) {
+	// Resources the findings below refer to
+	related := []*types.ResourceState{
+		testResource("i-running", "ec2_instance", "running", map[string]interface{}{"instanceType": "t3.micro"}),
+		testResource("i-stopped", "ec2_instance", "stopped", map[string]interface{}{"instanceType": "t3.micro"}),
+		testResource("rtb-private", "route_table", "available", map[string]interface{}{
+			"subnetIds": []interface{}{"subnet-private"},
+			"routes":    []interface{}{map[string]interface{}{"natGatewayId": "nat-served"}},
+		}),
+		testResource("vol-context", "ebs_volume", "in-use", map[string]interface{}{"size": 8.0, "volumeType": "gp3"}),
+	}
+
+	tests := []struct {
+		name        string
+		resource    *types.ResourceState
+		wantKind    string
+		wantSavings float64
+	}{
+		{
+			name:        "unassociated Elastic IP",
+			resource:    testResource("eipalloc-free", "elastic_ip", "", map[string]interface{}{"publicIp": "203.0.113.10"}),
+			wantKind:    FindingUnassociatedEIP,
+			wantSavings: 3.65,
+		},
+		{
+			name: "Elastic IP of a stopped instance",
+			resource: testResource("eipalloc-stopped", "elastic_ip", "", map[string]interface{}{
+				"associationId": "eipassoc-1", "instanceId": "i-stopped",
+			}),
+			wantKind:    FindingStoppedInstanceWithEIP,
+			wantSavings: 3.65,
+		},
+		{
+			name: "Elastic IP of a running instance",
+			resource: testResource("eipalloc-used", "elastic_ip", "", map[string]interface{}{
+				"associationId": "eipassoc-2", "instanceId": "i-running",
+			}),
+		},
+		{
+			name:        "unattached volume",
+			resource:    testResource("vol-free", "ebs_volume", "available", map[string]interface{}{"size": 100.0, "volumeType": "gp3"}),
+			wantKind:    FindingUnattachedVolume,
+			wantSavings: 8,
+		},
+		{
+			name:     "attached volume",
+			resource: testResource("vol-used", "ebs_volume", "in-use", map[string]interface{}{"size": 100.0, "volumeType": "gp3"}),
+		},
+		{
+			name:        "NAT gateway no route table uses",
+			resource:    testResource("nat-idle", "nat_gateway", "available", nil),
+			wantKind:    FindingIdleNATGateway,
+			wantSavings: 32.85 + 3.65 + 4.5, // Hours, its address as no Elastic IPs were scanned, and data
+		},
+		{
+			name:     "NAT gateway of a private subnet",
+			resource: testResource("nat-served", "nat_gateway", "available", nil),
+		},
+		{
+			name: "oversized instance",
+			resource: testResource("i-oversized", "ec2_instance", "running", map[string]interface{}{
+				"instanceType": "m5.xlarge", "cpuUtilization": lowCPU(14),
+			}),
+			wantKind:    FindingOversizedInstance,
+			wantSavings: 73,
+		},
+		{
+			name: "busy instance",
+			resource: testResource("i-busy", "ec2_instance", "running", map[string]interface{}{
+				"instanceType":   "m5.xlarge",
+				"cpuUtilization": map[string]interface{}{"average": 35.0, "maximum": 90.0, "days": 14},
+			}),
+		},
+		{
+			name: "instance with a short CPU history",
+			resource: testResource("i-new", "ec2_instance", "running", map[string]interface{}{
+				"instanceType": "m5.xlarge", "cpuUtilization": lowCPU(3),
+			}),
+		},
+		{
+			name: "instance without CPU metrics",
+			resource: testResource("i-unknown", "ec2_instance", "running", map[string]interface{}{
+				"instanceType": "m5.xlarge",
+			}),
+		},
+		{
+			name: "smallest size of its family",
+			resource: testResource("i-smallest", "ec2_instance", "running", map[string]interface{}{
+				"instanceType": "t3.micro", "cpuUtilization": lowCPU(14),
+			}),
+		},
+		{
+			name: "oversized Multi-AZ DB instance",
+			resource: testResource("orders-db", "rds_instance", "available", map[string]interface{}{
+				"instance_class": "db.t3.medium", "multi_az": true, "allocated_storage": 20, "cpuUtilization": lowCPU(30),
+			}),
+			wantKind:    FindingOversizedDBInstance,
+			wantSavings: 58.4,
+		},
+	}
+
+	estimator := NewEstimator(newTestCatalog())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			index := buildReportIndex(append(related, tt.resource))
+			resourceCost := estimator.estimateResource(tt.resource, index, testRegion)
+			if resourceCost == nil {
+				t.Fatal("resource was not priced")
+			}
+
+			finding := estimator.findIdle(tt.resource, resourceCost, index)
+			if tt.wantKind == "" {
+				if finding != nil {
+					t.Fatalf("unexpected finding %+v", finding)
+				}
+				return
+			}
+			if finding == nil {
+				t.Fatalf("no finding, want %s", tt.wantKind)
+			}
+			if finding.Kind != tt.wantKind || finding.MonthlySavings != roundCents(tt.wantSavings) {
+				t.Fatalf("got %s saving %.2f, want %s saving %.2f", finding.Kind, finding.MonthlySavings, tt.wantKind, tt.wantSavings)
+			}
+			if finding.ResourceID != tt.resource.ID || finding.Region != testRegion || finding.Recommendation == "" {
+				t.Fatalf("incomplete finding %+v", finding)
+			}
+		})
+	}
+}
+
+func TestFindIdleWithoutRouteTables(t *testing.T) {
+	// Without a scan of route tables nothing is known about which subnets use a NAT gateway
+	nat := testResource("nat-unknown", "nat_gateway", "available", nil)
+	index := buildReportIndex([]*types.ResourceState{nat})
+
+	estimator := NewEstimator(newTestCatalog())
+	if finding := estimator.findIdle(nat, estimator.estimateResource(nat, index, testRegion), index); finding != nil {
+		t.Fatalf("unexpected finding %+v", finding)
+	}
+}
+
+func TestNextSmallerSize(t *testing.T) {
+	sizes := newTestCatalog().Regions[testRegion].EC2
+	rdsSizes := newTestCatalog().Regions[testRegion].RDS.Instances
+
+	tests := []struct {
+		sizes        map[string]float64
+		instanceType string
+		want         string
+	}{
+		{sizes, "m5.xlarge", "m5.large"},
+		{sizes, "t3.small", "t3.micro"},
+		{sizes, "m5.large", ""}, // m5d is another family
+		{sizes, "t3.micro", ""},
+		{sizes, "c5.large", ""}, // Not priced
+		{rdsSizes, "db.t3.medium", "db.t3.small"},
+	}
+	for _, tt := range tests {
+		got, exists := nextSmallerSize(tt.sizes, tt.instanceType)
+		if got != tt.want || exists != (tt.want != "") {
+			t.Errorf("nextSmallerSize(%s) = %q, %v, want %q", tt.instanceType, got, exists, tt.want)
+		}
+	}
+}
+
+func TestEstimateResourcesReport(t *testing.T) {
+	resources := []*types.ResourceState{
+		testResource("i-web", "ec2_instance", "running", map[string]interface{}{"instanceType": "m5.large"}),
+		testResource("i-stopped", "ec2_instance", "stopped", map[string]interface{}{"instanceType": "m5.large"}),
+		testResource("vol-free", "ebs_volume", "available", map[string]interface{}{"size": 50.0, "volumeType": "gp3"}),
+		testResource("eipalloc-free", "elastic_ip", "", nil),
+		testResource("i-gpu", "ec2_instance", "running", map[string]interface{}{"instanceType": "p4d.24xlarge"}),
+		testResource("vpc-1", "vpc", "available", nil),
+	}
+	resources[0].Tags = map[string]string{"Environment": "production"}
+	resources[2].Tags = map[string]string{"Environment": "staging"}
+
+	report := NewEstimator(newTestCatalog()).EstimateResources(resources, ReportOptions{
+		TagKeys:    []string{"Environment"},
+		ManagedIDs: map[string]bool{"i-web": true},
+	})
+
+	// 73 for i-web, 4 for the volume, 3.65 for the address; the stopped instance is free and
+	// the GPU instance unpriced
+	if report.MonthlyTotal != 80.65 {
+		t.Errorf("MonthlyTotal = %.2f, want 80.65", report.MonthlyTotal)
+	}
+	if report.ByType["ec2_instance"] != 73 || report.ByType["ebs_volume"] != 4 || report.ByType["elastic_ip"] != 3.65 {
+		t.Errorf("unexpected ByType %v", report.ByType)
+	}
+	byEnvironment := report.ByTag["Environment"]
+	if byEnvironment["production"] != 73 || byEnvironment["staging"] != 4 || byEnvironment[untaggedValue] != 3.65 {
+		t.Errorf("unexpected ByTag %v", byEnvironment)
+	}
+	if len(report.UnpricedResources) != 1 || report.UnpricedResources[0] != "i-gpu" {
+		t.Errorf("UnpricedResources = %v, want [i-gpu]", report.UnpricedResources)
+	}
+
+	// The VPC is free and left out; the rest is ordered by cost
+	if len(report.Resources) != 5 || report.Resources[0].ResourceID != "i-web" {
+		t.Fatalf("unexpected resources %+v", report.Resources)
+	}
+	if !report.Resources[0].Managed || report.Resources[1].Managed {
+		t.Errorf("only i-web should be managed")
+	}
+
+	if len(report.Findings) != 2 || report.Findings[0].Kind != FindingUnattachedVolume || report.Findings[1].Kind != FindingUnassociatedEIP {
+		t.Fatalf("unexpected findings %+v", report.Findings)
+	}
+	if report.MonthlySavings != 7.65 {
+		t.Errorf("MonthlySavings = %.2f, want 7.65", report.MonthlySavings)
+	}
+}
@@ -2,36 +2,168 @@ package discovery
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"sync"
 	"time"
 
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
 	"github.com/versus-control/ai-infrastructure-agent/internal/logging"
 	"github.com/versus-control/ai-infrastructure-agent/pkg/aws"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/tagging"
 	"github.com/versus-control/ai-infrastructure-agent/pkg/types"
 )
 
 // Scanner handles infrastructure discovery and scanning
 type Scanner struct {
-	awsClient *aws.Client
-	logger    *logging.Logger
+	awsClient  *aws.Client
+	clientPool *aws.ClientPool
+	tagPolicy  *tagging.Policy
+	logger     *logging.Logger
 }
 
 // NewScanner creates a new infrastructure scanner
 func NewScanner(awsClient *aws.Client, logger *logging.Logger) *Scanner {
 	return &Scanner{
 		awsClient: awsClient,
+		tagPolicy: tagging.DefaultPolicy(),
 		logger:    logger,
 	}
 }
 
+// SetClientPool enables multi-region discovery using the given regional client pool
+func (s *Scanner) SetClientPool(pool *aws.ClientPool) {
+	s.clientPool = pool
+}
+
+// SetTagPolicy sets the tag policy used to recognize resources created by the agent
+func (s *Scanner) SetTagPolicy(policy *tagging.Policy) {
+	s.tagPolicy = policy
+}
+
+// IsAgentManaged reports whether a discovered resource was created by the agent
+func IsAgentManaged(resource *types.ResourceState) bool {
+	managed, _ := resource.Properties["managedByAgent"].(bool)
+	return managed
+}
+
+// ConfiguredRegions returns the regions multi-region discovery fans out across
+func (s *Scanner) ConfiguredRegions() []string {
+	if s.clientPool == nil {
+		return []string{s.awsClient.GetRegion()}
+	}
+	return s.clientPool.Regions()
+}
+
 // DiscoverInfrastructure performs a comprehensive scan of existing infrastructure
+// in the default region
 func (s *Scanner) DiscoverInfrastructure(ctx context.Context) ([]*types.ResourceState, error) {
 	s.logger.Info("Starting infrastructure discovery")
 
+	buckets := s.bucketsByRegion(ctx)
+	resources, err := s.discoverRegion(ctx, s.awsClient, false, buckets[s.awsClient.GetRegion()])
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.WithField("resource_count", len(resources)).Info("Infrastructure discovery completed")
+	return resources, nil
+}
+
+// DiscoverAllRegions scans every configured region
+func (s *Scanner) DiscoverAllRegions(ctx context.Context) ([]*types.ResourceState, error) {
+	return s.DiscoverInfrastructureInRegions(ctx, s.ConfiguredRegions())
+}
+
+// DiscoverInfrastructureInRegions scans the given regions concurrently. A region
+// that fails is logged and skipped so one unreachable region does not hide the rest.
+func (s *Scanner) DiscoverInfrastructureInRegions(ctx context.Context, regions []string) ([]*types.ResourceState, error) {
+	return s.discoverInRegions(ctx, regions, false)
+}
+
+// DiscoverBillableInfrastructureInRegions scans the given regions like
+// DiscoverInfrastructureInRegions, adding the NAT gateways, EBS volumes, Elastic IPs, route
+// tables and RDS instances cost reports price, and the CPU utilization of instances. It makes
+// many more API calls, so only cost reports use it.
+func (s *Scanner) DiscoverBillableInfrastructureInRegions(ctx context.Context, regions []string) ([]*types.ResourceState, error) {
+	return s.discoverInRegions(ctx, regions, true)
+}
+
+// discoverInRegions scans the given regions concurrently, the default region when none are
+// given, optionally including the billable resources of cost reports
+func (s *Scanner) discoverInRegions(ctx context.Context, regions []string, billable bool) ([]*types.ResourceState, error) {
+	if len(regions) == 0 {
+		if !billable {
+			return s.DiscoverInfrastructure(ctx)
+		}
+		buckets := s.bucketsByRegion(ctx)
+		return s.discoverRegion(ctx, s.awsClient, true, buckets[s.awsClient.GetRegion()])
+	}
+	if s.clientPool == nil {
+		return nil, fmt.Errorf("multi-region discovery requires a client pool")
+	}
+
+	s.logger.WithField("regions", regions).Info("Starting multi-region infrastructure discovery")
+
+	type regionResult struct {
+		region    string
+		resources []*types.ResourceState
+		err       error
+	}
+
+	// Buckets are listed once for all regions and described in their own region only
+	buckets := s.bucketsByRegion(ctx)
+
+	results := make(chan regionResult, len(regions))
+	var wg sync.WaitGroup
+	for _, region := range regions {
+		wg.Add(1)
+		go func(region string) {
+			defer wg.Done()
+			client, err := s.clientPool.GetClient(region)
+			if err != nil {
+				results <- regionResult{region: region, err: err}
+				return
+			}
+			resources, err := s.discoverRegion(ctx, client, billable, buckets[region])
+			results <- regionResult{region: region, resources: resources, err: err}
+		}(region)
+	}
+	wg.Wait()
+	close(results)
+
+	var resources []*types.ResourceState
+	var failedRegions []string
+	for result := range results {
+		if result.err != nil {
+			s.logger.WithError(result.err).WithField("region", result.region).Error("Failed to discover region")
+			failedRegions = append(failedRegions, result.region)
+			continue
+		}
+		resources = append(resources, result.resources...)
+	}
+
+	if len(failedRegions) == len(regions) {
+		return nil, fmt.Errorf("discovery failed in all regions: %v", failedRegions)
+	}
+
+	s.logger.WithFields(map[string]interface{}{
+		"resource_count": len(resources),
+		"regions":        len(regions),
+		"failed_regions": failedRegions,
+	}).Info("Multi-region infrastructure discovery completed")
+	return resources, nil
+}
+
+// discoverRegion runs every resource discovery against a single regional client. buckets are the
+// S3 buckets located in the region and billable adds the resources only cost reports need.
+func (s *Scanner) discoverRegion(ctx context.Context, client *aws.Client, billable bool, buckets []aws.BucketLocation) ([]*types.ResourceState, error) {
 	var resources []*types.ResourceState
 
 	// Discover VPCs
-	vpcs, err := s.discoverVPCs(ctx)
+	vpcs, err := s.discoverVPCs(ctx, client)
 	if err != nil {
 		s.logger.WithError(err).Error("Failed to discover VPCs")
 		return nil, err
@@ -39,7 +171,7 @@ func (s *Scanner) DiscoverInfrastructure(ctx context.Context) ([]*types.Resource
 	resources = append(resources, vpcs...)
 
 	// Discover EC2 instances
-	instances, err := s.discoverEC2Instances(ctx)
+	instances, err := s.discoverEC2Instances(ctx, client)
 	if err != nil {
 		s.logger.WithError(err).Error("Failed to discover EC2 instances")
 		return nil, err
@@ -47,7 +179,7 @@ func (s *Scanner) DiscoverInfrastructure(ctx context.Context) ([]*types.Resource
 	resources = append(resources, instances...)
 
 	// Discover Security Groups
-	securityGroups, err := s.discoverSecurityGroups(ctx)
+	securityGroups, err := s.discoverSecurityGroups(ctx, client)
 	if err != nil {
 		s.logger.WithError(err).Error("Failed to discover security groups")
 		return nil, err
@@ -55,30 +187,75 @@ func (s *Scanner) DiscoverInfrastructure(ctx context.Context) ([]*types.Resource
 	resources = append(resources, securityGroups...)
 
 	// Discover Load Balancers
-	loadBalancers, err := s.discoverLoadBalancers(ctx)
+	loadBalancers, err := s.discoverLoadBalancers(ctx, client)
 	if err != nil {
 		s.logger.WithError(err).Error("Failed to discover load balancers")
 		return nil, err
 	}
 	resources = append(resources, loadBalancers...)
 
+	// Discover listeners and listener rules of the load balancers found above
+	listeners, err := s.discoverListeners(ctx, client, loadBalancers)
+	if err != nil {
+		s.logger.WithError(err).Warn("Failed to discover listeners, continuing without them")
+	} else {
+		resources = append(resources, listeners...)
+	}
+
 	// Discover Auto Scaling Groups
-	autoScalingGroups, err := s.discoverAutoScalingGroups(ctx)
+	autoScalingGroups, err := s.discoverAutoScalingGroups(ctx, client)
 	if err != nil {
 		s.logger.WithError(err).Error("Failed to discover auto scaling groups")
 		return nil, err
 	}
 	resources = append(resources, autoScalingGroups...)
 
-	s.logger.WithField("resource_count", len(resources)).Info("Infrastructure discovery completed")
+	// Describe the region's S3 buckets
+	resources = append(resources, s.discoverS3Buckets(ctx, client, buckets)...)
+
+	// Discover the NAT gateways, volumes, Elastic IPs, route tables and databases that cost
+	// reports price. Like S3, these are best effort: a resource type that fails is left out
+	// and the others are kept.
+	if billable {
+		costResources, err := s.discoverCostResources(ctx, client)
+		if err != nil {
+			s.logger.WithError(err).Warn("Failed to discover some billable resource types, continuing without them")
+		}
+		resources = append(resources, costResources...)
+		s.attachCPUUtilization(ctx, client, resources)
+	}
+
+	// Stamp every resource with the region it was discovered in and mark the ones carrying
+	// the tag policy's ManagedBy tag as created by the agent
+	region := client.GetRegion()
+	for _, resource := range resources {
+		resource.Region = region
+		s.markAgentManaged(resource)
+	}
+
 	return resources, nil
 }
 
+// markAgentManaged records on a resource whether the agent created it, and for which decision
+func (s *Scanner) markAgentManaged(resource *types.ResourceState) {
+	if s.tagPolicy == nil || !s.tagPolicy.IsManaged(resource.Tags) {
+		return
+	}
+
+	if resource.Properties == nil {
+		resource.Properties = make(map[string]interface{})
+	}
+	resource.Properties["managedByAgent"] = true
+	if decisionID := resource.Tags[tagging.KeyDecisionID]; decisionID != "" {
+		resource.Properties["decisionId"] = decisionID
+	}
+}
+
 // discoverVPCs discovers all VPCs in the region
-func (s *Scanner) discoverVPCs(ctx context.Context) ([]*types.ResourceState, error) {
+func (s *Scanner) discoverVPCs(ctx context.Context, client *aws.Client) ([]*types.ResourceState, error) {
 	s.logger.Debug("Discovering VPCs")
 
-	vpcs, err := s.awsClient.DescribeVPCs(ctx)
+	vpcs, err := client.DescribeVPCs(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to describe VPCs: %w", err)
 	}
@@ -112,10 +289,10 @@ func (s *Scanner) discoverVPCs(ctx context.Context) ([]*types.ResourceState, err
 }
 
 // discoverEC2Instances discovers all EC2 instances in the region
-func (s *Scanner) discoverEC2Instances(ctx context.Context) ([]*types.ResourceState, error) {
+func (s *Scanner) discoverEC2Instances(ctx context.Context, client *aws.Client) ([]*types.ResourceState, error) {
 	s.logger.Debug("Discovering EC2 instances")
 
-	instances, err := s.awsClient.DescribeInstances(ctx)
+	instances, err := client.DescribeInstances(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to describe instances: %w", err)
 	}
@@ -162,18 +339,18 @@ func (s *Scanner) discoverEC2Instances(ctx context.Context) ([]*types.ResourceSt
 }
 
 // discoverSecurityGroups discovers all security groups in the region
-func (s *Scanner) discoverSecurityGroups(ctx context.Context) ([]*types.ResourceState, error) {
+func (s *Scanner) discoverSecurityGroups(ctx context.Context, client *aws.Client) ([]*types.ResourceState, error) {
 	s.logger.Debug("Discovering security groups")
 
 	// Get all VPCs first to discover security groups across all VPCs
-	vpcs, err := s.awsClient.DescribeVPCs(ctx)
+	vpcs, err := client.DescribeVPCs(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to describe VPCs for security group discovery: %w", err)
 	}
 
 	var resources []*types.ResourceState
 	for _, vpc := range vpcs {
-		securityGroups, err := s.awsClient.ListSecurityGroups(ctx, vpc.ID)
+		securityGroups, err := client.ListSecurityGroups(ctx, vpc.ID)
 		if err != nil {
 			s.logger.WithError(err).WithField("vpc_id", vpc.ID).Warn("Failed to list security groups for VPC")
 			continue
@@ -193,40 +370,20 @@ func (s *Scanner) discoverSecurityGroups(ctx context.Context) ([]*types.Resource
 				}
 			}
 
+			// Security groups referenced by rules, recorded as properties rather than
+			// dependencies because groups commonly reference each other
+			referencedGroups := make(map[string]bool)
+
 			// Convert ingress rules
 			ingressRules := make([]map[string]interface{}, len(sg.IpPermissions))
 			for i, rule := range sg.IpPermissions {
-				var ipRanges []string
-				for _, r := range rule.IpRanges {
-					if r.CidrIp != nil {
-						ipRanges = append(ipRanges, *r.CidrIp)
-					}
-				}
-
-				ingressRules[i] = map[string]interface{}{
-					"from_port":   rule.FromPort,
-					"to_port":     rule.ToPort,
-					"ip_protocol": *rule.IpProtocol,
-					"ip_ranges":   ipRanges,
-				}
+				ingressRules[i] = securityGroupPermissionToMap(rule, referencedGroups)
 			}
 
 			// Convert egress rules
 			egressRules := make([]map[string]interface{}, len(sg.IpPermissionsEgress))
 			for i, rule := range sg.IpPermissionsEgress {
-				var ipRanges []string
-				for _, r := range rule.IpRanges {
-					if r.CidrIp != nil {
-						ipRanges = append(ipRanges, *r.CidrIp)
-					}
-				}
-
-				egressRules[i] = map[string]interface{}{
-					"from_port":   rule.FromPort,
-					"to_port":     rule.ToPort,
-					"ip_protocol": *rule.IpProtocol,
-					"ip_ranges":   ipRanges,
-				}
+				egressRules[i] = securityGroupPermissionToMap(rule, referencedGroups)
 			}
 
 			vpcID := ""
@@ -248,11 +405,12 @@ func (s *Scanner) discoverSecurityGroups(ctx context.Context) ([]*types.Resource
 				CurrentState: "active",
 				Tags:         tags,
 				Properties: map[string]interface{}{
-					"group_name":    name,
-					"description":   description,
-					"vpc_id":        vpcID,
-					"ingress_rules": ingressRules,
-					"egress_rules":  egressRules,
+					"group_name":                 name,
+					"description":                description,
+					"vpc_id":                     vpcID,
+					"ingress_rules":              ingressRules,
+					"egress_rules":               egressRules,
+					"referenced_security_groups": sortedKeys(referencedGroups),
 				},
 				Dependencies: []string{vpcID},
 				CreatedAt:    time.Now(),
@@ -267,11 +425,56 @@ func (s *Scanner) discoverSecurityGroups(ctx context.Context) ([]*types.Resource
 	return resources, nil
 }
 
+// securityGroupPermissionToMap converts a security group permission, collecting referenced groups
+func securityGroupPermissionToMap(rule ec2types.IpPermission, referencedGroups map[string]bool) map[string]interface{} {
+	var ipRanges, sourceGroups, prefixLists []string
+	for _, r := range rule.IpRanges {
+		if r.CidrIp != nil {
+			ipRanges = append(ipRanges, *r.CidrIp)
+		}
+	}
+	for _, r := range rule.Ipv6Ranges {
+		if r.CidrIpv6 != nil {
+			ipRanges = append(ipRanges, *r.CidrIpv6)
+		}
+	}
+	for _, pair := range rule.UserIdGroupPairs {
+		if pair.GroupId != nil {
+			sourceGroups = append(sourceGroups, *pair.GroupId)
+			referencedGroups[*pair.GroupId] = true
+		}
+	}
+	for _, prefixList := range rule.PrefixListIds {
+		if prefixList.PrefixListId != nil {
+			prefixLists = append(prefixLists, *prefixList.PrefixListId)
+		}
+	}
+
+	return map[string]interface{}{
+		"from_port":       rule.FromPort,
+		"to_port":         rule.ToPort,
+		"ip_protocol":     *rule.IpProtocol,
+		"ip_ranges":       ipRanges,
+		"security_groups": sourceGroups,
+		"prefix_lists":    prefixLists,
+	}
+}
+
+// sortedKeys returns the keys of a set in a stable order
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // discoverLoadBalancers discovers all application load balancers
-func (s *Scanner) discoverLoadBalancers(ctx context.Context) ([]*types.ResourceState, error) {
+func (s *Scanner) discoverLoadBalancers(ctx context.Context, client *aws.Client) ([]*types.ResourceState, error) {
 	s.logger.Debug("Discovering load balancers")
 
-	loadBalancers, err := s.awsClient.DescribeLoadBalancers(ctx)
+	loadBalancers, err := client.DescribeLoadBalancers(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to describe load balancers: %w", err)
 	}
@@ -323,11 +526,79 @@ func (s *Scanner) discoverLoadBalancers(ctx context.Context) ([]*types.ResourceS
 	return resources, nil
 }
 
+// discoverListeners discovers the listeners and non-default listener rules of the given load balancers
+func (s *Scanner) discoverListeners(ctx context.Context, client *aws.Client, loadBalancers []*types.ResourceState) ([]*types.ResourceState, error) {
+	s.logger.Debug("Discovering listeners")
+
+	var resources []*types.ResourceState
+	for _, lb := range loadBalancers {
+		listeners, err := client.DescribeListeners(ctx, lb.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe listeners for %s: %w", lb.ID, err)
+		}
+
+		for _, listener := range listeners {
+			dependencies := []string{lb.ID}
+			if targetGroupArn, ok := listener.Details["targetGroupArn"].(string); ok && targetGroupArn != "" {
+				dependencies = append(dependencies, targetGroupArn)
+			}
+
+			resources = append(resources, &types.ResourceState{
+				ID:           listener.ID,
+				Name:         fmt.Sprintf("%s:%v", lb.Name, listener.Details["port"]),
+				Type:         "listener",
+				Status:       listener.State,
+				DesiredState: "active",
+				CurrentState: listener.State,
+				Tags:         listener.Tags,
+				Properties:   listener.Details,
+				Dependencies: dependencies,
+				CreatedAt:    time.Now(),
+				UpdatedAt:    time.Now(),
+			})
+
+			rules, err := client.DescribeListenerRules(ctx, listener.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to describe rules for listener %s: %w", listener.ID, err)
+			}
+
+			for _, rule := range rules {
+				// The default rule mirrors the listener's default action
+				if isDefault, _ := rule.Details["isDefault"].(bool); isDefault {
+					continue
+				}
+
+				ruleDependencies := []string{listener.ID}
+				if targetGroupArn, ok := rule.Details["targetGroupArn"].(string); ok && targetGroupArn != "" {
+					ruleDependencies = append(ruleDependencies, targetGroupArn)
+				}
+
+				resources = append(resources, &types.ResourceState{
+					ID:           rule.ID,
+					Name:         fmt.Sprintf("%s:%v priority %v", lb.Name, listener.Details["port"], rule.Details["priority"]),
+					Type:         "listener_rule",
+					Status:       rule.State,
+					DesiredState: "active",
+					CurrentState: rule.State,
+					Tags:         rule.Tags,
+					Properties:   rule.Details,
+					Dependencies: ruleDependencies,
+					CreatedAt:    time.Now(),
+					UpdatedAt:    time.Now(),
+				})
+			}
+		}
+	}
+
+	s.logger.WithField("listener_resource_count", len(resources)).Debug("Listener discovery completed")
+	return resources, nil
+}
+
 // discoverAutoScalingGroups discovers all auto scaling groups
-func (s *Scanner) discoverAutoScalingGroups(ctx context.Context) ([]*types.ResourceState, error) {
+func (s *Scanner) discoverAutoScalingGroups(ctx context.Context, client *aws.Client) ([]*types.ResourceState, error) {
 	s.logger.Debug("Discovering auto scaling groups")
 
-	autoScalingGroups, err := s.awsClient.DescribeAutoScalingGroups(ctx)
+	autoScalingGroups, err := client.DescribeAutoScalingGroups(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to describe auto scaling groups: %w", err)
 	}
@@ -375,3 +646,189 @@ func (s *Scanner) discoverAutoScalingGroups(ctx context.Context) ([]*types.Resou
 	s.logger.WithField("auto_scaling_group_count", len(resources)).Debug("Auto scaling group discovery completed")
 	return resources, nil
 }
+
+// bucketsByRegion lists the account's S3 buckets and groups them by region. Bucket permissions
+// are often scoped separately from EC2 ones, so a failure is logged and discovery continues
+// without buckets.
+func (s *Scanner) bucketsByRegion(ctx context.Context) map[string][]aws.BucketLocation {
+	locations, err := s.awsClient.ListBucketLocations(ctx)
+	if err != nil {
+		s.logger.WithError(err).Warn("Failed to list S3 buckets, continuing without them")
+		return nil
+	}
+
+	byRegion := make(map[string][]aws.BucketLocation)
+	for _, location := range locations {
+		byRegion[location.Region] = append(byRegion[location.Region], location)
+	}
+	return byRegion
+}
+
+// discoverS3Buckets describes the S3 buckets located in the client's region
+func (s *Scanner) discoverS3Buckets(ctx context.Context, client *aws.Client, buckets []aws.BucketLocation) []*types.ResourceState {
+	s.logger.Debug("Discovering S3 buckets")
+
+	var resources []*types.ResourceState
+	for _, bucket := range client.DescribeBuckets(ctx, buckets) {
+		resource := &types.ResourceState{
+			ID:           bucket.ID,
+			Name:         bucket.ID,
+			Type:         "s3_bucket",
+			Status:       "available",
+			DesiredState: "available",
+			CurrentState: "available",
+			Tags:         bucket.Tags,
+			Properties:   bucket.Details,
+			Dependencies: []string{},
+			CreatedAt:    time.Now(),
+			UpdatedAt:    time.Now(),
+		}
+
+		resources = append(resources, resource)
+	}
+
+	s.logger.WithField("s3_bucket_count", len(resources)).Debug("S3 bucket discovery completed")
+	return resources
+}
+
+// discoverCostResources discovers NAT gateways, EBS volumes, Elastic IPs, route tables and RDS
+// instances. Route tables are included so reports can tell which NAT gateways serve subnets.
+// A resource type that cannot be listed is skipped; the resources of the other types are
+// returned along with the errors of the failed ones.
+func (s *Scanner) discoverCostResources(ctx context.Context, client *aws.Client) ([]*types.ResourceState, error) {
+	s.logger.Debug("Discovering billable network, storage and database resources")
+
+	var resources []*types.ResourceState
+	var errs []error
+	failed := func(resourceType string, err error) {
+		errs = append(errs, fmt.Errorf("%s: %w", resourceType, err))
+	}
+
+	natGateways, err := client.DescribeNATGateways(ctx, nil)
+	if err != nil {
+		failed("nat_gateway", err)
+	}
+	for _, natGateway := range natGateways {
+		// Deleted gateways stay visible for about an hour
+		if natGateway.State == "deleted" || natGateway.State == "deleting" {
+			continue
+		}
+		vpcID, _ := natGateway.Details["vpcId"].(string)
+		subnetID, _ := natGateway.Details["subnetId"].(string)
+		resources = append(resources, discoveredResource(natGateway, "nat_gateway", "available", vpcID, subnetID))
+	}
+
+	volumes, err := client.DescribeVolumes(ctx)
+	if err != nil {
+		failed("ebs_volume", err)
+	}
+	for _, volume := range volumes {
+		attached, _ := volume.Details["attachedInstances"].([]string)
+		resources = append(resources, discoveredResource(volume, "ebs_volume", "in-use", attached...))
+	}
+
+	addresses, err := client.DescribeAddresses(ctx)
+	if err != nil {
+		failed("elastic_ip", err)
+	}
+	for _, address := range addresses {
+		instanceID, _ := address.Details["instanceId"].(string)
+		resources = append(resources, discoveredResource(address, "elastic_ip", "associated", instanceID))
+	}
+
+	routeTables, err := client.DescribeRouteTables(ctx)
+	if err != nil {
+		failed("route_table", err)
+	}
+	for _, routeTable := range routeTables {
+		vpcID, _ := routeTable.Details["vpcId"].(string)
+		resources = append(resources, discoveredResource(routeTable, "route_table", "available", vpcID))
+	}
+
+	dbInstances, err := client.ListDBInstances(ctx)
+	if err != nil {
+		failed("rds_instance", err)
+	}
+	for i := range dbInstances {
+		resources = append(resources, discoveredResource(&dbInstances[i], "rds_instance", "available"))
+	}
+
+	s.logger.WithFields(map[string]interface{}{
+		"resource_count": len(resources),
+		"failed_types":   len(errs),
+	}).Debug("Billable resource discovery completed")
+	return resources, errors.Join(errs...)
+}
+
+// utilizationDays is the period of CPU utilization attached to instances for oversizing checks
+const utilizationDays = 14
+
+// attachCPUUtilization adds the CPU utilization of the running EC2 and available RDS instances
+// to their properties as cpuUtilization. Instances without CloudWatch datapoints, or whose
+// metrics cannot be read, are left without it and are not checked for oversizing.
+func (s *Scanner) attachCPUUtilization(ctx context.Context, client *aws.Client, resources []*types.ResourceState) {
+	failures := 0
+	for _, resource := range resources {
+		var utilization *aws.CPUUtilization
+		var err error
+		switch {
+		case resource.Type == "ec2_instance" && resource.Status == "running":
+			utilization, err = client.GetInstanceCPUUtilization(ctx, resource.ID, utilizationDays)
+		case resource.Type == "rds_instance" && resource.Status == "available":
+			utilization, err = client.GetDBInstanceCPUUtilization(ctx, resource.ID, utilizationDays)
+		default:
+			continue
+		}
+		if err != nil {
+			failures++
+			s.logger.WithError(err).WithField("resource_id", resource.ID).Debug("Failed to get CPU utilization")
+			continue
+		}
+		if utilization == nil {
+			continue
+		}
+
+		if resource.Properties == nil {
+			resource.Properties = make(map[string]interface{})
+		}
+		resource.Properties["cpuUtilization"] = map[string]interface{}{
+			"average": utilization.Average,
+			"maximum": utilization.Maximum,
+			"days":    utilization.Days,
+		}
+	}
+
+	if failures > 0 {
+		s.logger.WithField("failed_count", failures).Warn("Failed to get CPU utilization of some instances, they are not checked for oversizing")
+	}
+}
+
+// discoveredResource converts a described AWS resource to a resource state. Empty dependencies
+// are dropped.
+func discoveredResource(resource *types.AWSResource, resourceType, desiredState string, dependencies ...string) *types.ResourceState {
+	name := resource.Tags["Name"]
+	if name == "" {
+		name = resource.ID
+	}
+
+	var deps []string
+	for _, dependency := range dependencies {
+		if dependency != "" {
+			deps = append(deps, dependency)
+		}
+	}
+
+	return &types.ResourceState{
+		ID:           resource.ID,
+		Name:         name,
+		Type:         resourceType,
+		Status:       resource.State,
+		DesiredState: desiredState,
+		CurrentState: resource.State,
+		Tags:         resource.Tags,
+		Properties:   resource.Details,
+		Dependencies: deps,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+	}
+}
@@ -2,6 +2,7 @@ package graph
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/versus-control/ai-infrastructure-agent/pkg/types"
@@ -178,6 +179,22 @@ func (a *Analyzer) GenerateTextualRepresentation() string {
 	builder.WriteString(fmt.Sprintf("Total Resources: %d\n", len(graph.Nodes)))
 	builder.WriteString(fmt.Sprintf("Total Dependencies: %d\n\n", a.getTotalEdges()))
 
+	// Region summary, only shown when resources span more than one region
+	regionGroups := a.manager.GetResourcesByRegion()
+	if len(regionGroups) > 1 {
+		regions := make([]string, 0, len(regionGroups))
+		for region := range regionGroups {
+			regions = append(regions, region)
+		}
+		sort.Strings(regions)
+
+		builder.WriteString("Resources by Region:\n")
+		for _, region := range regions {
+			builder.WriteString(fmt.Sprintf("  %s (%d): %s\n", region, len(regionGroups[region]), strings.Join(regionGroups[region], ", ")))
+		}
+		builder.WriteString("\n")
+	}
+
 	// Group by resource type
 	typeGroups := make(map[string][]string)
 	for nodeID, node := range graph.Nodes {
@@ -238,6 +255,7 @@ func (a *Analyzer) GenerateMermaidDiagram() string {
 	builder.WriteString("    classDef sg fill:#f3e5f5\n")
 	builder.WriteString("    classDef lb fill:#e8f5e8\n")
 	builder.WriteString("    classDef asg fill:#fce4ec\n")
+	builder.WriteString("    classDef s3 fill:#fff8e1\n")
 
 	return builder.String()
 }
@@ -269,10 +287,12 @@ func (a *Analyzer) getNodeStyle(resourceType string) string {
 		return ":::ec2"
 	case "security_group":
 		return ":::sg"
-	case "load_balancer":
+	case "load_balancer", "listener", "listener_rule":
 		return ":::lb"
 	case "auto_scaling_group":
 		return ":::asg"
+	case "s3_bucket":
+		return ":::s3"
 	default:
 		return ""
 	}
@@ -40,6 +40,7 @@ func (m *Manager) BuildGraph(ctx context.Context, resources []*types.ResourceSta
 		node := &types.DependencyNode{
 			ID:           resource.ID,
 			ResourceType: resource.Type,
+			Region:       resource.Region,
 			Status:       resource.Status,
 			Properties:   make(map[string]string),
 		}
@@ -112,6 +113,10 @@ func (m *Manager) detectImplicitDependencies() {
 			m.detectLoadBalancerDependencies(nodeID, node)
 		case "auto_scaling_group":
 			m.detectASGDependencies(nodeID, node)
+		case "listener":
+			m.detectListenerDependencies(nodeID, node)
+		case "listener_rule":
+			m.detectListenerRuleDependencies(nodeID, node)
 		}
 	}
 }
@@ -208,6 +213,32 @@ func (m *Manager) detectASGDependencies(asgID string, node *types.DependencyNode
 	}
 }
 
+// detectListenerDependencies detects dependencies for load balancer listeners
+func (m *Manager) detectListenerDependencies(listenerID string, node *types.DependencyNode) {
+	// Load balancer dependency
+	if lbArn, exists := node.Properties["loadBalancerArn"]; exists && m.graph.Nodes[lbArn] != nil {
+		m.addEdge(listenerID, lbArn)
+	}
+
+	// Default action target group dependency
+	if tgArn, exists := node.Properties["targetGroupArn"]; exists && m.graph.Nodes[tgArn] != nil {
+		m.addEdge(listenerID, tgArn)
+	}
+}
+
+// detectListenerRuleDependencies detects dependencies for listener rules
+func (m *Manager) detectListenerRuleDependencies(ruleID string, node *types.DependencyNode) {
+	// Listener dependency
+	if listenerArn, exists := node.Properties["listenerArn"]; exists && m.graph.Nodes[listenerArn] != nil {
+		m.addEdge(ruleID, listenerArn)
+	}
+
+	// Forward action target group dependency
+	if tgArn, exists := node.Properties["targetGroupArn"]; exists && m.graph.Nodes[tgArn] != nil {
+		m.addEdge(ruleID, tgArn)
+	}
+}
+
 // GetDeploymentOrder returns resources in deployment order (topological sort)
 func (m *Manager) GetDeploymentOrder() ([]string, error) {
 	m.logger.Debug("Calculating deployment order")
@@ -353,6 +384,25 @@ func (m *Manager) GetResourcesByType(resourceType string) []string {
 	return resources
 }
 
+// GetResourcesByRegion returns node IDs grouped by region. Nodes without a
+// region are grouped under "default".
+func (m *Manager) GetResourcesByRegion() map[string][]string {
+	grouped := make(map[string][]string)
+
+	for nodeID, node := range m.graph.Nodes {
+		region := node.Region
+		if region == "" {
+			region = "default"
+		}
+		grouped[region] = append(grouped[region], nodeID)
+	}
+
+	for region := range grouped {
+		sort.Strings(grouped[region])
+	}
+	return grouped
+}
+
 // GetCriticalPath identifies the critical path for resource deployment
 func (m *Manager) GetCriticalPath(targetResource string) ([]string, error) {
 	if _, exists := m.graph.Nodes[targetResource]; !exists {
@@ -0,0 +1,110 @@
+package keystore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/versus-control/ai-infrastructure-agent/internal/logging"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/aws"
+)
+
+// Environment variables configuring where generated private keys are kept
+const (
+	// BackendEnvVar selects the keystore backend: "local" (default) or "secretsmanager"
+	BackendEnvVar = "KEYSTORE_BACKEND"
+
+	// DirEnvVar is the directory of the local keystore, "keystore" next to the state file when
+	// unset, so every process sharing the state also shares the keystore and its key
+	DirEnvVar = "KEYSTORE_DIR"
+
+	// KeyEnvVar holds a base64 encoded 32-byte key for the local keystore. When unset a key is
+	// generated on first use and kept next to the encrypted files.
+	KeyEnvVar = "KEYSTORE_KEY"
+
+	// DownloadTokenEnvVar is the bearer token required by the private key download endpoint.
+	// Downloads are disabled when it is unset.
+	DownloadTokenEnvVar = "KEYSTORE_DOWNLOAD_TOKEN"
+)
+
+// defaultDirName is the local keystore directory created next to the state file
+const defaultDirName = "keystore"
+
+// ErrNotFound is returned when no private key is stored under a name
+var ErrNotFound = errors.New("private key not found in keystore")
+
+// validName matches EC2 key pair names that can be used as keystore entries
+var validName = regexp.MustCompile(`^[A-Za-z0-9._-]{1,255}$`)
+
+// validRegion matches AWS region names, e.g. us-east-1 or us-gov-west-1
+var validRegion = regexp.MustCompile(`^[a-z]{2}(-[a-z]+)+-[0-9]+$`)
+
+// Store keeps private key material out of tool responses. Callers receive a reference from Put
+// and fetch the material again by region and key pair name; key pair names are only unique
+// within a region, so key pairs of the same name in two regions are separate entries.
+type Store interface {
+	// Put stores the private key of a key pair and returns a reference to it
+	Put(ctx context.Context, region, name string, material []byte) (string, error)
+
+	// Get returns the private key stored for a key pair
+	Get(ctx context.Context, region, name string) ([]byte, error)
+
+	// Delete removes the private key stored for a key pair
+	Delete(ctx context.Context, region, name string) error
+
+	// Backend names the storage backend
+	Backend() string
+}
+
+// NewFromEnv creates the keystore selected by KEYSTORE_BACKEND. stateFile is the configured
+// state file, which locates the local keystore unless KEYSTORE_DIR is set.
+func NewFromEnv(stateFile string, awsClient *aws.Client, logger *logging.Logger) (Store, error) {
+	backend := strings.ToLower(strings.TrimSpace(os.Getenv(BackendEnvVar)))
+
+	switch backend {
+	case "", "local":
+		dir := os.Getenv(DirEnvVar)
+		if dir == "" {
+			if stateFile == "" {
+				return nil, fmt.Errorf("the local keystore needs %s or a configured state file", DirEnvVar)
+			}
+			dir = filepath.Join(filepath.Dir(stateFile), defaultDirName)
+		}
+		return NewLocalStore(dir, os.Getenv(KeyEnvVar), logger)
+	case "secretsmanager":
+		if awsClient == nil {
+			return nil, fmt.Errorf("the secretsmanager keystore requires an AWS client")
+		}
+		return NewSecretsManagerStore(awsClient, logger), nil
+	default:
+		return nil, fmt.Errorf("unsupported %s %q, expected local or secretsmanager", BackendEnvVar, backend)
+	}
+}
+
+// ValidateName checks that a key pair name can be used as a keystore entry
+func ValidateName(name string) error {
+	if !validName.MatchString(name) {
+		return fmt.Errorf("key pair name %q can only contain letters, digits, '.', '_' and '-'", name)
+	}
+	return nil
+}
+
+// ValidateRegion checks that a region can be used to locate keystore entries
+func ValidateRegion(region string) error {
+	if !validRegion.MatchString(region) {
+		return fmt.Errorf("region %q is not a valid AWS region", region)
+	}
+	return nil
+}
+
+// validateEntry checks the region and key pair name of a keystore entry
+func validateEntry(region, name string) error {
+	if err := ValidateRegion(region); err != nil {
+		return err
+	}
+	return ValidateName(name)
+}
@@ -0,0 +1,217 @@
+package keystore
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/versus-control/ai-infrastructure-agent/internal/logging"
+)
+
+const (
+	// masterKeyFile holds the generated encryption key when KEYSTORE_KEY is unset
+	masterKeyFile = ".master.key"
+
+	// keyFileSuffix is appended to the key pair name for encrypted key files
+	keyFileSuffix = ".key.enc"
+)
+
+// LocalStore keeps private keys in a directory per region, each encrypted with AES-256-GCM. The
+// region and key pair name are bound to the ciphertext so files cannot be swapped between entries.
+type LocalStore struct {
+	dir    string
+	aead   cipher.AEAD
+	mutex  sync.Mutex
+	logger *logging.Logger
+}
+
+// NewLocalStore opens or creates a local keystore. encodedKey is the base64 encoded 32-byte
+// encryption key; when empty the key is read from, or generated into, the keystore directory.
+func NewLocalStore(dir, encodedKey string, logger *logging.Logger) (*LocalStore, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create keystore directory %s: %w", dir, err)
+	}
+
+	key, err := loadMasterKey(dir, encodedKey)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize keystore cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize keystore cipher: %w", err)
+	}
+
+	return &LocalStore{
+		dir:    dir,
+		aead:   aead,
+		logger: logger,
+	}, nil
+}
+
+// Put encrypts and writes the private key of a key pair
+func (s *LocalStore) Put(ctx context.Context, region, name string, material []byte) (string, error) {
+	if err := validateEntry(region, name); err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	sealed := s.aead.Seal(nonce, nonce, material, entryData(region, name))
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	path := s.path(region, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return "", fmt.Errorf("failed to create keystore directory for %s: %w", region, err)
+	}
+
+	// Write to a temporary file first so a crash never leaves a truncated key behind
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, sealed, 0600); err != nil {
+		return "", fmt.Errorf("failed to write keystore entry %s: %w", name, err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return "", fmt.Errorf("failed to write keystore entry %s: %w", name, err)
+	}
+
+	s.logger.WithFields(map[string]interface{}{
+		"keyName": name,
+		"region":  region,
+	}).Info("Private key stored in local keystore")
+	return "keystore://local/" + region + "/" + name, nil
+}
+
+// Get reads and decrypts the private key of a key pair
+func (s *LocalStore) Get(ctx context.Context, region, name string) ([]byte, error) {
+	if err := validateEntry(region, name); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	sealed, err := os.ReadFile(s.path(region, name))
+	s.mutex.Unlock()
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s in %s", ErrNotFound, name, region)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keystore entry %s: %w", name, err)
+	}
+
+	nonceSize := s.aead.NonceSize()
+	if len(sealed) < nonceSize {
+		return nil, fmt.Errorf("keystore entry %s is corrupt", name)
+	}
+
+	material, err := s.aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], entryData(region, name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt keystore entry %s: %w", name, err)
+	}
+
+	return material, nil
+}
+
+// Delete removes the private key of a key pair
+func (s *LocalStore) Delete(ctx context.Context, region, name string) error {
+	if err := validateEntry(region, name); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err := os.Remove(s.path(region, name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete keystore entry %s: %w", name, err)
+	}
+	return nil
+}
+
+// Backend names the storage backend
+func (s *LocalStore) Backend() string {
+	return "local"
+}
+
+// path returns the file holding the encrypted key of a key pair
+func (s *LocalStore) path(region, name string) string {
+	return filepath.Join(s.dir, region, name+keyFileSuffix)
+}
+
+// entryData is the additional data binding a ciphertext to its region and key pair name
+func entryData(region, name string) []byte {
+	return []byte(region + "/" + name)
+}
+
+// loadMasterKey decodes the configured key or loads the generated key, creating it if needed
+func loadMasterKey(dir, encodedKey string) ([]byte, error) {
+	if encodedKey == "" {
+		keyPath := filepath.Join(dir, masterKeyFile)
+		data, err := os.ReadFile(keyPath)
+		if errors.Is(err, os.ErrNotExist) {
+			data, err = createMasterKey(keyPath)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read keystore key: %w", err)
+		}
+		encodedKey = string(data)
+	}
+
+	key, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil {
+		return nil, fmt.Errorf("keystore key is not valid base64: %w", err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("keystore key must be 32 bytes, got %d", len(key))
+	}
+
+	return key, nil
+}
+
+// createMasterKey generates the key file of a keystore. The web server and the MCP server may
+// open the same keystore at once, so the key is written to an exclusively created temporary
+// file and linked into place, which fails if another process won the race; its key is read
+// back instead, so both encrypt with the same key.
+func createMasterKey(keyPath string) ([]byte, error) {
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, fmt.Errorf("failed to generate keystore key: %w", err)
+	}
+	data := []byte(base64.StdEncoding.EncodeToString(key))
+
+	tmpFile, err := os.CreateTemp(filepath.Dir(keyPath), masterKeyFile+".*.tmp")
+	if err != nil {
+		return nil, fmt.Errorf("failed to write keystore key: %w", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	_, err = tmpFile.Write(data)
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to write keystore key: %w", err)
+	}
+
+	if err := os.Link(tmpFile.Name(), keyPath); err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return os.ReadFile(keyPath)
+		}
+		return nil, fmt.Errorf("failed to write keystore key: %w", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,131 @@
+package keystore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/versus-control/ai-infrastructure-agent/internal/logging"
+)
+
+func TestLoadMasterKeyConcurrentCreation(t *testing.T) {
+	dir := t.TempDir()
+
+	// Processes opening a new keystore at once must all end up with the same key
+	const openers = 16
+	keys := make([][]byte, openers)
+	errs := make([]error, openers)
+	var wg sync.WaitGroup
+	for i := 0; i < openers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			keys[i], errs[i] = loadMasterKey(dir, "")
+		}(i)
+	}
+	wg.Wait()
+
+	for i := range keys {
+		if errs[i] != nil {
+			t.Fatalf("opener %d failed: %v", i, errs[i])
+		}
+		if !bytes.Equal(keys[i], keys[0]) {
+			t.Fatalf("opener %d got a different key", i)
+		}
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("failed to list keystore directory: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Name() != masterKeyFile {
+		t.Fatalf("keystore directory holds %v, want only %s", entries, masterKeyFile)
+	}
+}
+
+func TestLocalStoreSharesGeneratedKey(t *testing.T) {
+	ctx := context.Background()
+	dir := filepath.Join(t.TempDir(), "keystore")
+	logger := logging.NewLogger("test", "error")
+
+	writer, err := NewLocalStore(dir, "", logger)
+	if err != nil {
+		t.Fatalf("failed to open keystore: %v", err)
+	}
+	if _, err := writer.Put(ctx, "us-east-1", "app-keypair", []byte("private key")); err != nil {
+		t.Fatalf("failed to store key: %v", err)
+	}
+
+	reader, err := NewLocalStore(dir, "", logger)
+	if err != nil {
+		t.Fatalf("failed to reopen keystore: %v", err)
+	}
+	material, err := reader.Get(ctx, "us-east-1", "app-keypair")
+	if err != nil {
+		t.Fatalf("failed to read key: %v", err)
+	}
+	if string(material) != "private key" {
+		t.Fatalf("read %q, want the stored key", material)
+	}
+}
+
+func TestLocalStoreKeysEntriesByRegion(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewLocalStore(t.TempDir(), "", logging.NewLogger("test", "error"))
+	if err != nil {
+		t.Fatalf("failed to open keystore: %v", err)
+	}
+
+	// Key pair names are unique per region, so the same name in two regions must not collide
+	if _, err := store.Put(ctx, "us-east-1", "app-keypair", []byte("east key")); err != nil {
+		t.Fatalf("failed to store key: %v", err)
+	}
+	if _, err := store.Put(ctx, "eu-west-1", "app-keypair", []byte("west key")); err != nil {
+		t.Fatalf("failed to store key: %v", err)
+	}
+
+	for region, want := range map[string]string{"us-east-1": "east key", "eu-west-1": "west key"} {
+		material, err := store.Get(ctx, region, "app-keypair")
+		if err != nil {
+			t.Fatalf("failed to read key in %s: %v", region, err)
+		}
+		if string(material) != want {
+			t.Fatalf("read %q in %s, want %q", material, region, want)
+		}
+	}
+
+	if err := store.Delete(ctx, "us-east-1", "app-keypair"); err != nil {
+		t.Fatalf("failed to delete key: %v", err)
+	}
+	if _, err := store.Get(ctx, "us-east-1", "app-keypair"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get() after Delete() error = %v, want ErrNotFound", err)
+	}
+	if _, err := store.Get(ctx, "eu-west-1", "app-keypair"); err != nil {
+		t.Fatalf("deleting the us-east-1 key removed the eu-west-1 key: %v", err)
+	}
+
+	// A file moved to another region must not decrypt there
+	if err := os.Rename(store.path("eu-west-1", "app-keypair"), store.path("us-east-1", "app-keypair")); err != nil {
+		t.Fatalf("failed to move key file: %v", err)
+	}
+	if _, err := store.Get(ctx, "us-east-1", "app-keypair"); err == nil {
+		t.Fatal("Get() decrypted a key moved from another region")
+	}
+}
+
+func TestLocalStoreRejectsInvalidRegion(t *testing.T) {
+	store, err := NewLocalStore(t.TempDir(), "", logging.NewLogger("test", "error"))
+	if err != nil {
+		t.Fatalf("failed to open keystore: %v", err)
+	}
+
+	for _, region := range []string{"", "..", "us-east-1/../x", "US-EAST-1"} {
+		if _, err := store.Put(context.Background(), region, "app-keypair", []byte("key")); err == nil {
+			t.Fatalf("Put() accepted region %q", region)
+		}
+	}
+}
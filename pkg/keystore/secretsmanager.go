@@ -0,0 +1,83 @@
+package keystore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	smtypes "github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+
+	"github.com/versus-control/ai-infrastructure-agent/internal/logging"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/aws"
+)
+
+// secretNamePrefix namespaces the secrets holding key pair private keys, which are named
+// "<prefix><region>/<key pair name>"
+const secretNamePrefix = "ai-infrastructure-agent/key-pairs/"
+
+// SecretsManagerStore keeps private keys as AWS Secrets Manager secrets
+type SecretsManagerStore struct {
+	client *aws.Client
+	logger *logging.Logger
+}
+
+// NewSecretsManagerStore creates a keystore backed by Secrets Manager in the client's region
+func NewSecretsManagerStore(client *aws.Client, logger *logging.Logger) *SecretsManagerStore {
+	return &SecretsManagerStore{
+		client: client,
+		logger: logger,
+	}
+}
+
+// Put stores the private key of a key pair in a new secret and returns the secret ARN
+func (s *SecretsManagerStore) Put(ctx context.Context, region, name string, material []byte) (string, error) {
+	if err := validateEntry(region, name); err != nil {
+		return "", err
+	}
+
+	arn, err := s.client.CreateSecretString(ctx, secretName(region, name),
+		fmt.Sprintf("Private key of EC2 key pair %s in %s", name, region), string(material),
+		map[string]string{"KeyPairName": name, "KeyPairRegion": region})
+	if err != nil {
+		return "", err
+	}
+
+	return arn, nil
+}
+
+// Get reads the private key of a key pair
+func (s *SecretsManagerStore) Get(ctx context.Context, region, name string) ([]byte, error) {
+	if err := validateEntry(region, name); err != nil {
+		return nil, err
+	}
+
+	material, err := s.client.GetSecretString(ctx, secretName(region, name))
+	var notFound *smtypes.ResourceNotFoundException
+	if errors.As(err, &notFound) {
+		return nil, fmt.Errorf("%w: %s in %s", ErrNotFound, name, region)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(material), nil
+}
+
+// Delete removes the secret holding the private key of a key pair
+func (s *SecretsManagerStore) Delete(ctx context.Context, region, name string) error {
+	if err := validateEntry(region, name); err != nil {
+		return err
+	}
+
+	return s.client.DeleteSecret(ctx, secretName(region, name))
+}
+
+// Backend names the storage backend
+func (s *SecretsManagerStore) Backend() string {
+	return "secretsmanager"
+}
+
+// secretName returns the name of the secret holding the private key of a key pair
+func secretName(region, name string) string {
+	return secretNamePrefix + region + "/" + name
+}
@@ -0,0 +1,286 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// TransportEnvVar selects the transport Start serves on, TransportStdio when unset
+const TransportEnvVar = "MCP_TRANSPORT"
+
+// Transports the MCP server can serve on
+const (
+	// TransportStdio reads JSON-RPC messages from stdin, one client per process
+	TransportStdio = "stdio"
+
+	// TransportHTTP serves streamable HTTP and SSE clients on one listen address
+	TransportHTTP = "http"
+)
+
+// HTTPAddrEnvVar sets the listen address of the HTTP transport, DefaultHTTPAddr when unset
+const HTTPAddrEnvVar = "MCP_HTTP_ADDR"
+
+// DefaultHTTPAddr only accepts local clients
+const DefaultHTTPAddr = "127.0.0.1:8090"
+
+// AuthTokenEnvVar is the bearer token HTTP clients must send
+const AuthTokenEnvVar = "MCP_AUTH_TOKEN"
+
+// Endpoints of the HTTP transport
+const (
+	streamableEndpoint = "/mcp"
+	sseEndpoint        = "/sse"
+	messageEndpoint    = "/message"
+	healthEndpoint     = "/health"
+)
+
+// maxHTTPMessageSize is the largest request body inspected for subscription requests
+const maxHTTPMessageSize = 10 * 1024 * 1024
+
+// sessionHeartbeat keeps idle streamable HTTP and SSE connections open through proxies
+const sessionHeartbeat = 30 * time.Second
+
+// httpShutdownTimeout bounds how long in-flight requests may finish after shutdown starts
+const httpShutdownTimeout = 15 * time.Second
+
+// HTTPOptions configure the HTTP transport
+type HTTPOptions struct {
+	// Addr is the listen address, e.g. "127.0.0.1:8090" or ":8090"
+	Addr string
+
+	// AuthToken is the bearer token clients must send. Without one only loopback addresses
+	// may be listened on.
+	AuthToken string
+}
+
+// TransportFromEnv returns the transport configured with MCP_TRANSPORT
+func TransportFromEnv() string {
+	if transport := strings.ToLower(strings.TrimSpace(os.Getenv(TransportEnvVar))); transport != "" {
+		return transport
+	}
+	return TransportStdio
+}
+
+// HTTPOptionsFromEnv returns the HTTP transport options configured with MCP_HTTP_ADDR and
+// MCP_AUTH_TOKEN
+func HTTPOptionsFromEnv() HTTPOptions {
+	options := HTTPOptions{
+		Addr:      strings.TrimSpace(os.Getenv(HTTPAddrEnvVar)),
+		AuthToken: os.Getenv(AuthTokenEnvVar),
+	}
+	if options.Addr == "" {
+		options.Addr = DefaultHTTPAddr
+	}
+	return options
+}
+
+// StartHTTP serves the MCP server over HTTP until ctx is cancelled. Streamable HTTP clients
+// use /mcp and SSE clients /sse with /message; both keep a session per client, so one server
+// can be shared by many clients.
+func (s *Server) StartHTTP(ctx context.Context, options HTTPOptions) error {
+	if options.AuthToken == "" && !isLoopbackAddr(options.Addr) {
+		return fmt.Errorf("the MCP HTTP transport listens on %s, which is not a loopback address; set %s to require a bearer token", options.Addr, AuthTokenEnvVar)
+	}
+
+	streamableServer := server.NewStreamableHTTPServer(s.mcpServer,
+		server.WithEndpointPath(streamableEndpoint),
+		server.WithHeartbeatInterval(sessionHeartbeat),
+		server.WithHTTPContextFunc(markRemoteClient),
+	)
+	sseServer := server.NewSSEServer(s.mcpServer,
+		server.WithSSEEndpoint(sseEndpoint),
+		server.WithMessageEndpoint(messageEndpoint),
+		server.WithKeepAliveInterval(sessionHeartbeat),
+		server.WithSSEContextFunc(markRemoteClient),
+	)
+
+	mux := http.NewServeMux()
+	mux.Handle(streamableEndpoint, s.requireBearerToken(options.AuthToken, s.streamableSubscriptions(streamableServer)))
+	mux.Handle(sseEndpoint, s.requireBearerToken(options.AuthToken, sseServer.SSEHandler()))
+	mux.Handle(messageEndpoint, s.requireBearerToken(options.AuthToken, s.sseSubscriptions(sseServer)))
+	mux.HandleFunc(healthEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":"ok"}`))
+	})
+
+	httpServer := &http.Server{
+		Addr:              options.Addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- httpServer.ListenAndServe()
+	}()
+
+	s.Logger.WithFields(map[string]interface{}{
+		"addr":          options.Addr,
+		"streamable":    streamableEndpoint,
+		"sse":           sseEndpoint,
+		"authenticated": options.AuthToken != "",
+	}).Info("Starting MCP server on HTTP")
+
+	select {
+	case err := <-serveErr:
+		return fmt.Errorf("MCP HTTP server failed: %w", err)
+	case <-ctx.Done():
+	}
+
+	s.Logger.Info("Shutdown signal received, stopping MCP HTTP server")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), httpShutdownTimeout)
+	defer cancel()
+
+	// Close the long-lived SSE streams first so the HTTP server can drain
+	if err := sseServer.Shutdown(shutdownCtx); err != nil {
+		s.Logger.WithError(err).Warn("Failed to close SSE sessions")
+	}
+	if err := streamableServer.Shutdown(shutdownCtx); err != nil {
+		s.Logger.WithError(err).Warn("Failed to close streamable HTTP sessions")
+	}
+	if err := httpServer.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to shut down MCP HTTP server: %w", err)
+	}
+	return ctx.Err()
+}
+
+// remoteClientKey marks the context of requests received over the HTTP transport
+type remoteClientKey struct{}
+
+// markRemoteClient marks a request as coming from an HTTP client rather than the agent
+func markRemoteClient(ctx context.Context, r *http.Request) context.Context {
+	return context.WithValue(ctx, remoteClientKey{}, true)
+}
+
+// isRemoteClient reports whether a request was received over the HTTP transport
+func isRemoteClient(ctx context.Context) bool {
+	remote, _ := ctx.Value(remoteClientKey{}).(bool)
+	return remote
+}
+
+// requireBearerToken rejects requests without the expected bearer token. An empty token
+// disables the check.
+func (s *Server) requireBearerToken(expectedToken string, next http.Handler) http.Handler {
+	if expectedToken == "" {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := bearerToken(r)
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(expectedToken)) != 1 {
+			s.Logger.WithField("remoteAddr", r.RemoteAddr).Warn("Rejected MCP HTTP request with invalid token")
+			w.Header().Set("WWW-Authenticate", `Bearer realm="mcp"`)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// bearerToken returns the token of an "Authorization: Bearer <token>" header. The scheme
+// matches case-insensitively; other schemes and bare tokens are rejected.
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// streamableSubscriptions answers resource subscription requests of streamable HTTP clients in
+// the response body. A session's subscriptions end when the client deletes the session.
+func (s *Server) streamableSubscriptions(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sessionID := r.Header.Get(server.HeaderKeySessionID)
+		if r.Method == http.MethodDelete && sessionID != "" {
+			s.subscriptions.removeSession(sessionID)
+		}
+		if r.Method != http.MethodPost {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		body, err := readRequestBody(r)
+		if err != nil {
+			http.Error(w, "Failed to read request body", http.StatusBadRequest)
+			return
+		}
+		response, handled := s.handleSubscriptionRequest(sessionID, true, body)
+		if !handled {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			s.Logger.WithError(err).Error("Failed to write subscription response")
+		}
+	})
+}
+
+// sseSubscriptions answers resource subscription requests of SSE clients on their event
+// stream, like the SSE server answers every other request
+func (s *Server) sseSubscriptions(sseServer *server.SSEServer) http.Handler {
+	next := sseServer.MessageHandler()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		body, err := readRequestBody(r)
+		if err != nil {
+			http.Error(w, "Failed to read request body", http.StatusBadRequest)
+			return
+		}
+		sessionID := r.URL.Query().Get("sessionId")
+		response, handled := s.handleSubscriptionRequest(sessionID, false, body)
+		if !handled {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if err := sseServer.SendEventToSession(sessionID, response); err != nil {
+			http.Error(w, fmt.Sprintf("Failed to answer on session %s: %v", sessionID, err), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	})
+}
+
+// readRequestBody reads the body of a request and puts it back for the next handler
+func readRequestBody(r *http.Request) ([]byte, error) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxHTTPMessageSize))
+	r.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// isLoopbackAddr reports whether a listen address only accepts local connections
+func isLoopbackAddr(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,64 @@
+package mcp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/versus-control/ai-infrastructure-agent/internal/logging"
+)
+
+func TestRequireBearerToken(t *testing.T) {
+	s := &Server{Logger: logging.NewLogger("test", "error")}
+	handler := s.requireBearerToken("s3cr3t", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	tests := []struct {
+		name          string
+		authorization string
+		wantStatus    int
+	}{
+		{"bearer token", "Bearer s3cr3t", http.StatusNoContent},
+		{"scheme in lower case", "bearer s3cr3t", http.StatusNoContent},
+		{"scheme in upper case with extra spaces", "BEARER   s3cr3t ", http.StatusNoContent},
+		{"no header", "", http.StatusUnauthorized},
+		{"token without scheme", "s3cr3t", http.StatusUnauthorized},
+		{"other scheme", "Basic s3cr3t", http.StatusUnauthorized},
+		{"scheme without token", "Bearer ", http.StatusUnauthorized},
+		{"wrong token", "Bearer guess", http.StatusUnauthorized},
+		{"token prefix", "Bearer s3cr3", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+			if tt.authorization != "" {
+				request.Header.Set("Authorization", tt.authorization)
+			}
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+
+			if recorder.Code != tt.wantStatus {
+				t.Fatalf("status %d, want %d", recorder.Code, tt.wantStatus)
+			}
+			challenge := recorder.Header().Get("WWW-Authenticate")
+			if tt.wantStatus == http.StatusUnauthorized && challenge != `Bearer realm="mcp"` {
+				t.Fatalf("WWW-Authenticate = %q, want the bearer challenge", challenge)
+			}
+		})
+	}
+}
+
+func TestRequireBearerTokenDisabled(t *testing.T) {
+	s := &Server{Logger: logging.NewLogger("test", "error")}
+	handler := s.requireBearerToken("", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/mcp", nil))
+	if recorder.Code != http.StatusNoContent {
+		t.Fatalf("status %d without a configured token, want %d", recorder.Code, http.StatusNoContent)
+	}
+}
@@ -0,0 +1,50 @@
+package mcp
+
+import (
+	"context"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/aws"
+)
+
+// progressNotification is sent to clients while a tool they called is still running
+const progressNotification = "notifications/progress"
+
+// withProgressNotifications returns a context whose resource waits send progress notifications
+// to the client, when the client asked for them with a progress token
+func (s *Server) withProgressNotifications(ctx context.Context, toolName string, request mcp.CallToolRequest) context.Context {
+	if request.Params.Meta == nil || request.Params.Meta.ProgressToken == nil {
+		return ctx
+	}
+	mcpServer := server.ServerFromContext(ctx)
+	if mcpServer == nil {
+		return ctx
+	}
+
+	token := request.Params.Meta.ProgressToken
+	var mutex sync.Mutex
+	lastProgress := -1.0
+
+	return aws.WithWaitProgress(ctx, func(progress, total float64, message string) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		// Progress must increase with every notification for the same token
+		if progress <= lastProgress {
+			return
+		}
+		lastProgress = progress
+
+		err := mcpServer.SendNotificationToClient(ctx, progressNotification, map[string]interface{}{
+			"progressToken": token,
+			"progress":      progress,
+			"total":         total,
+			"message":       message,
+		})
+		if err != nil {
+			s.Logger.WithError(err).WithField("toolName", toolName).Debug("Failed to send progress notification")
+		}
+	})
+}
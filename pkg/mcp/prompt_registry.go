@@ -0,0 +1,43 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/prompts"
+)
+
+// registerPrompts publishes every recipe of the prompt catalog as an MCP prompt
+func (s *Server) registerPrompts() {
+	for _, prompt := range s.PromptCatalog.Prompts {
+		options := []mcp.PromptOption{mcp.WithPromptDescription(prompt.Description)}
+		for _, argument := range prompt.Arguments {
+			argumentOptions := []mcp.ArgumentOption{mcp.ArgumentDescription(argument.Summary())}
+			if argument.Required {
+				argumentOptions = append(argumentOptions, mcp.RequiredArgument())
+			}
+			options = append(options, mcp.WithArgument(argument.Name, argumentOptions...))
+		}
+
+		s.mcpServer.AddPrompt(mcp.NewPrompt(prompt.Name, options...), s.createPromptHandler(prompt))
+	}
+
+	s.Logger.WithField("promptCount", len(s.PromptCatalog.Prompts)).Info("Successfully registered all prompts")
+}
+
+// createPromptHandler renders a recipe with the arguments of a prompts/get request
+func (s *Server) createPromptHandler(prompt *prompts.Prompt) func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	return func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		text, err := prompt.Render(request.Params.Arguments)
+		if err != nil {
+			s.Logger.WithError(err).WithField("prompt", prompt.Name).Warn("Invalid prompt arguments")
+			return nil, fmt.Errorf("invalid arguments for prompt %s: %w", prompt.Name, err)
+		}
+
+		s.Logger.WithField("prompt", prompt.Name).Debug("Rendered prompt")
+		return mcp.NewGetPromptResult(prompt.Description, []mcp.PromptMessage{
+			mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(text)),
+		}), nil
+	}
+}
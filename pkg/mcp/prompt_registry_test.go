@@ -0,0 +1,84 @@
+package mcp
+
+import (
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/versus-control/ai-infrastructure-agent/internal/logging"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/prompts"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/tools"
+)
+
+// toolReference matches the tools a recipe tells the planner to use, e.g. "with create-vpc"
+var toolReference = regexp.MustCompile(`\b(?:with|using) ([a-z0-9]+(?:-[a-z0-9]+)+)`)
+
+// parameterReference matches camelCase words, which recipes only use for tool parameters
+var parameterReference = regexp.MustCompile(`\b[a-z]+[A-Z][A-Za-z0-9]*\b`)
+
+func TestPromptsMatchToolSchemas(t *testing.T) {
+	catalog, err := prompts.LoadFile(filepath.Join("..", "..", prompts.DefaultCatalogFile))
+	if err != nil {
+		t.Fatalf("failed to load the prompt catalog: %v", err)
+	}
+	if len(catalog.Prompts) == 0 {
+		t.Fatal("the prompt catalog is empty")
+	}
+
+	factory := tools.NewToolFactory(nil, logging.NewLogger("test", "error"))
+	for _, prompt := range catalog.Prompts {
+		t.Run(prompt.Name, func(t *testing.T) {
+			// Optional arguments render with their defaults
+			arguments := map[string]string{}
+			for _, argument := range prompt.Arguments {
+				if argument.Required {
+					arguments[argument.Name] = sampleArgument(argument)
+				}
+			}
+			text, err := prompt.Render(arguments)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+
+			matches := toolReference.FindAllStringSubmatch(text, -1)
+			if len(matches) == 0 {
+				t.Fatal("the recipe names no tools")
+			}
+
+			properties := map[string]bool{}
+			for _, match := range matches {
+				toolName := match[1]
+				tool, err := factory.CreateTool(toolName, factory.GetToolActionType(toolName), &tools.ToolDependencies{})
+				if err != nil {
+					t.Errorf("the recipe uses unknown tool %s", toolName)
+					continue
+				}
+				schemaProperties, _ := tool.GetInputSchema()["properties"].(map[string]interface{})
+				for name := range schemaProperties {
+					properties[name] = true
+				}
+			}
+
+			for _, parameter := range parameterReference.FindAllString(text, -1) {
+				if !properties[parameter] {
+					t.Errorf("the recipe sets %s, which none of its tools accept", parameter)
+				}
+			}
+		})
+	}
+}
+
+// sampleArgument returns a valid value for a required argument, lowercase so that it is not
+// taken for a parameter name
+func sampleArgument(argument *prompts.Argument) string {
+	if len(argument.Enum) > 0 {
+		return argument.Enum[0]
+	}
+	switch argument.Type {
+	case prompts.TypeInteger:
+		return "1"
+	case prompts.TypeBoolean:
+		return "true"
+	}
+	return "sample"
+}
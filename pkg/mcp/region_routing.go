@@ -0,0 +1,95 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/interfaces"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/tools"
+)
+
+// regionArgument is the optional argument added to every tool that does not
+// define its own region handling. It selects which regional AWS client runs the call.
+const regionArgument = "region"
+
+// declaresRegion reports whether a tool's input schema already owns the region argument
+func declaresRegion(schema map[string]interface{}) bool {
+	properties, ok := schema["properties"].(map[string]interface{})
+	if !ok {
+		return false
+	}
+	_, exists := properties[regionArgument]
+	return exists
+}
+
+// executeInRegion runs a tool against the regional client named by the region
+// argument. Calls for the default region go through the shared tool manager.
+func (s *Server) executeInRegion(ctx context.Context, toolName string, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	region, _ := arguments[regionArgument].(string)
+	delete(arguments, regionArgument)
+
+	if s.ClientPool == nil || s.ClientPool.IsDefaultRegion(region) {
+		return s.ToolManager.ExecuteTool(ctx, toolName, arguments)
+	}
+
+	tool, err := s.getRegionalTool(toolName, region)
+	if err != nil {
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{
+				mcp.NewTextContent(err.Error()),
+			},
+		}, nil
+	}
+
+	s.Logger.WithFields(map[string]interface{}{
+		"toolName": toolName,
+		"region":   region,
+	}).Info("Executing tool in non-default region")
+	return tool.Execute(ctx, arguments)
+}
+
+// getRegionalTool returns a cached tool instance bound to the given region's client
+func (s *Server) getRegionalTool(toolName, region string) (interfaces.MCPTool, error) {
+	s.regionalMutex.Lock()
+	defer s.regionalMutex.Unlock()
+
+	if regionTools, exists := s.regionalTools[region]; exists {
+		if tool, exists := regionTools[toolName]; exists {
+			return tool, nil
+		}
+	}
+
+	baseTool, exists := s.ToolManager.registry.GetTool(toolName)
+	if !exists {
+		return nil, fmt.Errorf("tool '%s' not found", toolName)
+	}
+
+	regionalClient, err := s.ClientPool.GetClient(region)
+	if err != nil {
+		return nil, err
+	}
+
+	factory := tools.NewToolFactory(regionalClient, s.Logger)
+	tool, err := factory.CreateTool(toolName, baseTool.ActionType(), &tools.ToolDependencies{
+		AWSClient:        regionalClient,
+		ClientPool:       s.ClientPool,
+		StateManager:     s.StateManager,
+		DiscoveryScanner: s.DiscoveryScanner,
+		GraphManager:     s.GraphManager,
+		GraphAnalyzer:    s.GraphAnalyzer,
+		ConflictResolver: s.ConflictResolver,
+		Config:           s.Config,
+		CostCatalog:      s.CostCatalog,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tool '%s' for region %s: %w", toolName, region, err)
+	}
+
+	if s.regionalTools[region] == nil {
+		s.regionalTools[region] = make(map[string]interfaces.MCPTool)
+	}
+	s.regionalTools[region][toolName] = tool
+	return tool, nil
+}
@@ -26,6 +26,8 @@ func CreateResourceDefinitions(awsClient *aws.Client, logger *logging.Logger) []
 			DetailName:        "EC2 Instance Details",
 			DetailDescription: "Detailed information about a specific EC2 instance",
 			Adapter:           ec2Adapter,
+			ResourceTypes:     []string{"ec2_instance"},
+			Tools:             []string{"create-ec2-instance", "start-ec2-instance", "stop-ec2-instance", "terminate-ec2-instance"},
 			ListFormatter:     formatInstancesForAI,
 			DetailFormatter:   formatInstanceForAI,
 		},
@@ -39,6 +41,8 @@ func CreateResourceDefinitions(awsClient *aws.Client, logger *logging.Logger) []
 			DetailName:        "VPC Details",
 			DetailDescription: "Detailed information about a specific VPC",
 			Adapter:           vpcAdapter,
+			ResourceTypes:     []string{"vpc"},
+			Tools:             []string{"create-vpc"},
 			ListFormatter:     formatVPCsForAI,
 			DetailFormatter:   formatVPCForAI,
 		},
@@ -51,6 +55,8 @@ func CreateResourceDefinitions(awsClient *aws.Client, logger *logging.Logger) []
 			DetailName:        "Subnet Details",
 			DetailDescription: "Detailed information about a specific subnet",
 			Adapter:           vpcAdapter,
+			ResourceTypes:     []string{"subnet"},
+			Tools:             []string{"create-subnet", "create-private-subnet", "create-public-subnet", "associate-route-table"},
 			ListFormatter:     formatSubnetsForAI,
 			DetailFormatter:   formatSubnetForAI,
 		},
@@ -64,6 +70,8 @@ func CreateResourceDefinitions(awsClient *aws.Client, logger *logging.Logger) []
 			DetailName:        "Auto Scaling Group Details",
 			DetailDescription: "Detailed information about a specific Auto Scaling Group",
 			Adapter:           asgAdapter,
+			ResourceTypes:     []string{"auto_scaling_group"},
+			Tools:             []string{"create-auto-scaling-group"},
 			ListFormatter:     formatASGsForAI,
 			DetailFormatter:   formatASGForAI,
 		},
@@ -77,6 +85,8 @@ func CreateResourceDefinitions(awsClient *aws.Client, logger *logging.Logger) []
 			DetailName:        "Load Balancer Details",
 			DetailDescription: "Detailed information about a specific Load Balancer",
 			Adapter:           albAdapter,
+			ResourceTypes:     []string{"load_balancer"},
+			Tools:             []string{"create-load-balancer", "create-network-load-balancer", "set-load-balancer-cross-zone", "delete-load-balancer"},
 			ListFormatter:     formatLoadBalancersForAI,
 			DetailFormatter:   formatLoadBalancerForAI,
 		},
@@ -89,6 +99,8 @@ func CreateResourceDefinitions(awsClient *aws.Client, logger *logging.Logger) []
 			DetailName:        "Target Group Details",
 			DetailDescription: "Detailed information about a specific Target Group",
 			Adapter:           albAdapter,
+			ResourceTypes:     []string{"target_group"},
+			Tools:             []string{"create-target-group", "delete-target-group", "register-targets", "deregister-targets"},
 			ListFormatter:     formatTargetGroupsForAI,
 			DetailFormatter:   formatTargetGroupForAI,
 		},
@@ -102,6 +114,8 @@ func CreateResourceDefinitions(awsClient *aws.Client, logger *logging.Logger) []
 			DetailName:        "RDS Instance Details",
 			DetailDescription: "Detailed information about a specific RDS instance",
 			Adapter:           rdsAdapter,
+			ResourceTypes:     []string{"rds_instance", "db_instance"},
+			Tools:             []string{"create-db-instance", "create-db-read-replica", "restore-db-instance-from-snapshot", "start-db-instance", "stop-db-instance", "modify-db-instance", "delete-db-instance"},
 			ListFormatter:     formatRDSInstancesForAI,
 			DetailFormatter:   formatRDSInstanceForAI,
 		},
@@ -114,6 +128,8 @@ func CreateResourceDefinitions(awsClient *aws.Client, logger *logging.Logger) []
 			DetailName:        "RDS Snapshot Details",
 			DetailDescription: "Detailed information about a specific RDS snapshot",
 			Adapter:           rdsAdapter,
+			ResourceTypes:     []string{"db_snapshot"},
+			Tools:             []string{"create-db-snapshot"},
 			ListFormatter:     formatRDSSnapshotsForAI,
 			DetailFormatter:   formatRDSSnapshotForAI,
 		},
@@ -127,6 +143,8 @@ func CreateResourceDefinitions(awsClient *aws.Client, logger *logging.Logger) []
 			DetailName:        "Launch Template Details",
 			DetailDescription: "Detailed information about a specific Launch Template",
 			Adapter:           ec2Adapter,
+			ResourceTypes:     []string{"launch_template"},
+			Tools:             []string{"create-launch-template"},
 			ListFormatter:     formatLaunchTemplatesForAI,
 			DetailFormatter:   formatLaunchTemplateForAI,
 		},
@@ -139,6 +157,8 @@ func CreateResourceDefinitions(awsClient *aws.Client, logger *logging.Logger) []
 			DetailName:        "AMI Details",
 			DetailDescription: "Detailed information about a specific AMI",
 			Adapter:           ec2Adapter,
+			ResourceTypes:     []string{"ami"},
+			Tools:             []string{"create-ami-from-instance"},
 			ListFormatter:     formatAMIsForAI,
 			DetailFormatter:   formatAMIForAI,
 		},
@@ -0,0 +1,258 @@
+package mcp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/types"
+)
+
+// Subscription requests, which mcp-go leaves to the server to handle
+const (
+	subscribeMethod   = "resources/subscribe"
+	unsubscribeMethod = "resources/unsubscribe"
+)
+
+// resourceSubscriptions tracks the resource URIs each client session subscribed to
+type resourceSubscriptions struct {
+	mutex    sync.Mutex
+	sessions map[string]map[string]bool
+
+	// Streamable HTTP sessions outlive their notification stream and end with DELETE
+	persistent map[string]bool
+}
+
+// subscriptionRequest holds the fields of resources/subscribe and resources/unsubscribe
+type subscriptionRequest struct {
+	ID     mcp.RequestId `json:"id"`
+	Method string        `json:"method"`
+	Params struct {
+		URI string `json:"uri"`
+	} `json:"params"`
+}
+
+// newResourceSubscriptions creates an empty subscription set
+func newResourceSubscriptions() *resourceSubscriptions {
+	return &resourceSubscriptions{
+		sessions:   make(map[string]map[string]bool),
+		persistent: make(map[string]bool),
+	}
+}
+
+// subscribe adds a URI to the subscriptions of a session
+func (r *resourceSubscriptions) subscribe(sessionID, uri string, persistent bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.sessions[sessionID] == nil {
+		r.sessions[sessionID] = make(map[string]bool)
+	}
+	r.sessions[sessionID][uri] = true
+	if persistent {
+		r.persistent[sessionID] = true
+	}
+}
+
+// unsubscribe removes a URI from the subscriptions of a session
+func (r *resourceSubscriptions) unsubscribe(sessionID, uri string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	delete(r.sessions[sessionID], uri)
+	if len(r.sessions[sessionID]) == 0 {
+		delete(r.sessions, sessionID)
+		delete(r.persistent, sessionID)
+	}
+}
+
+// removeSession drops every subscription of a session
+func (r *resourceSubscriptions) removeSession(sessionID string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	delete(r.sessions, sessionID)
+	delete(r.persistent, sessionID)
+}
+
+// sessionUnregistered drops the subscriptions of a session whose connection closed, unless the
+// session outlives its connection
+func (r *resourceSubscriptions) sessionUnregistered(sessionID string) {
+	r.mutex.Lock()
+	persistent := r.persistent[sessionID]
+	r.mutex.Unlock()
+
+	if !persistent {
+		r.removeSession(sessionID)
+	}
+}
+
+// subscribers returns the sessions subscribed to a URI
+func (r *resourceSubscriptions) subscribers(uri string) []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var sessionIDs []string
+	for sessionID, uris := range r.sessions {
+		if uris[uri] {
+			sessionIDs = append(sessionIDs, sessionID)
+		}
+	}
+	return sessionIDs
+}
+
+// handleSubscriptionRequest answers resources/subscribe and resources/unsubscribe for a session.
+// It reports false for every other message, which the MCP server handles as usual.
+func (s *Server) handleSubscriptionRequest(sessionID string, persistent bool, message []byte) (mcp.JSONRPCMessage, bool) {
+	var request subscriptionRequest
+	if err := json.Unmarshal(message, &request); err != nil {
+		return nil, false
+	}
+	if request.Method != subscribeMethod && request.Method != unsubscribeMethod {
+		return nil, false
+	}
+
+	uri := request.Params.URI
+	if sessionID == "" {
+		return mcp.NewJSONRPCError(request.ID, mcp.INVALID_REQUEST, "Subscriptions require a session", nil), true
+	}
+	if !s.isKnownResourceURI(uri) {
+		return mcp.NewJSONRPCError(request.ID, mcp.INVALID_PARAMS, fmt.Sprintf("Unknown resource URI: %s", uri), nil), true
+	}
+
+	if request.Method == subscribeMethod {
+		s.subscriptions.subscribe(sessionID, uri, persistent)
+		s.Logger.WithFields(map[string]interface{}{
+			"session_id": sessionID,
+			"uri":        uri,
+		}).Info("Client subscribed to resource")
+	} else {
+		s.subscriptions.unsubscribe(sessionID, uri)
+		s.Logger.WithFields(map[string]interface{}{
+			"session_id": sessionID,
+			"uri":        uri,
+		}).Info("Client unsubscribed from resource")
+	}
+	return mcp.NewJSONRPCResponse(request.ID, mcp.Result{}), true
+}
+
+// isKnownResourceURI reports whether a URI is a list resource or a detail resource of a
+// registered resource definition or of the state
+func (s *Server) isKnownResourceURI(uri string) bool {
+	baseURIs := append([]string{}, stateResourceURIs...)
+	for _, def := range s.resourceDefinitions {
+		baseURIs = append(baseURIs, def.BaseURI)
+	}
+
+	for _, baseURI := range baseURIs {
+		if uri == baseURI || strings.HasPrefix(uri, baseURI+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// notifyResourcesUpdated sends notifications/resources/updated to the sessions subscribed to
+// each URI
+func (s *Server) notifyResourcesUpdated(uris ...string) {
+	for _, uri := range uris {
+		for _, sessionID := range s.subscriptions.subscribers(uri) {
+			err := s.mcpServer.SendNotificationToSpecificClient(sessionID, mcp.MethodNotificationResourceUpdated, map[string]any{
+				"uri": uri,
+			})
+			if errors.Is(err, server.ErrSessionNotFound) || errors.Is(err, server.ErrSessionNotInitialized) {
+				// Streamable HTTP clients only receive notifications while their stream is open
+				s.Logger.WithField("session_id", sessionID).Debug("Subscribed session is not connected, skipping resource update")
+				continue
+			}
+			if err != nil {
+				s.Logger.WithError(err).WithField("session_id", sessionID).Warn("Failed to send resource update notification")
+			}
+		}
+	}
+}
+
+// notifyResourceListChanged tells every client to list the resources again
+func (s *Server) notifyResourceListChanged() {
+	s.mcpServer.SendNotificationToAllClients(mcp.MethodNotificationResourcesListChanged, nil)
+}
+
+// notifyResourceChange notifies subscribers of the list and detail resources of a changed
+// resource. Created and deleted resources also change the resource list.
+func (s *Server) notifyResourceChange(def ResourceDefinition, resourceID string, listChanged bool) {
+	uris := []string{def.BaseURI}
+	if resourceID != "" {
+		uris = append(uris, def.BaseURI+"/"+resourceID)
+	}
+	s.notifyResourcesUpdated(uris...)
+
+	if listChanged {
+		s.notifyResourceListChanged()
+	}
+}
+
+// handleStateChange notifies subscribers of resources whose state changed or drifted, and of
+// the state resources showing them
+func (s *Server) handleStateChange(resource *types.ResourceState, changeType string) {
+	s.notifyResourcesUpdated(stateResourcesURI, stateResourcesURI+"/"+resource.ID, stateGraphURI)
+	for _, def := range s.resourceDefinitions {
+		if containsString(def.ResourceTypes, resource.Type) {
+			s.notifyResourceChange(def, resource.ID, false)
+		}
+	}
+
+	if changeType == "create" || changeType == "delete" {
+		s.notifyResourceListChanged()
+	}
+}
+
+// notifyToolChanges notifies subscribers of the resources a successful tool call changed
+func (s *Server) notifyToolChanges(toolName, actionType string, arguments map[string]interface{}, result *mcp.CallToolResult) {
+	if result == nil || result.IsError {
+		return
+	}
+	response, _ := result.StructuredContent.(map[string]interface{})
+	if success, ok := response["success"].(bool); ok && !success {
+		return
+	}
+
+	for _, def := range s.resourceDefinitions {
+		if !containsString(def.Tools, toolName) {
+			continue
+		}
+		resourceID := resourceIDFromCall(def, response, arguments)
+		s.notifyResourceChange(def, resourceID, actionType == "creation" || actionType == "deletion")
+	}
+}
+
+// resourceIDFromCall finds the ID of the changed resource in a tool response or its arguments,
+// under the name of the detail template's placeholder, e.g. instanceId
+func resourceIDFromCall(def ResourceDefinition, response, arguments map[string]interface{}) string {
+	keys := []string{"resourceId"}
+	if start := strings.LastIndex(def.DetailTemplate, "{"); start >= 0 && strings.HasSuffix(def.DetailTemplate, "}") {
+		keys = append([]string{def.DetailTemplate[start+1 : len(def.DetailTemplate)-1]}, keys...)
+	}
+
+	for _, source := range []map[string]interface{}{response, arguments} {
+		for _, key := range keys {
+			if id, ok := source[key].(string); ok && id != "" {
+				return id
+			}
+		}
+	}
+	return ""
+}
+
+// containsString reports whether values contains value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,183 @@
+package mcp
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/versus-control/ai-infrastructure-agent/internal/logging"
+)
+
+// newSubscriptionServer returns a server with an EC2 instances resource definition
+func newSubscriptionServer() *Server {
+	return &Server{
+		Logger:        logging.NewLogger("test", "error"),
+		subscriptions: newResourceSubscriptions(),
+		resourceDefinitions: []ResourceDefinition{
+			{
+				BaseURI:        "aws://ec2/instances",
+				DetailTemplate: "aws://ec2/instances/{instanceId}",
+				ResourceTypes:  []string{"ec2_instance"},
+				Tools:          []string{"create-ec2-instance"},
+			},
+		},
+	}
+}
+
+func subscriptionMessage(method, uri string) []byte {
+	return []byte(`{"jsonrpc":"2.0","id":7,"method":"` + method + `","params":{"uri":"` + uri + `"}}`)
+}
+
+func TestHandleSubscriptionRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		sessionID   string
+		message     []byte
+		wantHandled bool
+		wantError   string
+		wantURIs    []string
+	}{
+		{
+			name:        "subscribe to a list resource",
+			sessionID:   "session-1",
+			message:     subscriptionMessage(subscribeMethod, "aws://ec2/instances"),
+			wantHandled: true,
+			wantURIs:    []string{"aws://ec2/instances"},
+		},
+		{
+			name:        "subscribe to a detail resource",
+			sessionID:   "session-1",
+			message:     subscriptionMessage(subscribeMethod, "aws://ec2/instances/i-0abc"),
+			wantHandled: true,
+			wantURIs:    []string{"aws://ec2/instances/i-0abc"},
+		},
+		{
+			name:        "subscribe to a state resource",
+			sessionID:   "session-1",
+			message:     subscriptionMessage(subscribeMethod, stateGraphURI),
+			wantHandled: true,
+			wantURIs:    []string{stateGraphURI},
+		},
+		{
+			name:        "unknown resource",
+			sessionID:   "session-1",
+			message:     subscriptionMessage(subscribeMethod, "aws://ec2/instancesx"),
+			wantHandled: true,
+			wantError:   "Unknown resource URI",
+		},
+		{
+			name:        "no session",
+			message:     subscriptionMessage(subscribeMethod, "aws://ec2/instances"),
+			wantHandled: true,
+			wantError:   "require a session",
+		},
+		{
+			name:      "other method",
+			sessionID: "session-1",
+			message:   []byte(`{"jsonrpc":"2.0","id":7,"method":"resources/read","params":{"uri":"aws://ec2/instances"}}`),
+		},
+		{
+			name:      "not json",
+			sessionID: "session-1",
+			message:   []byte(`resources/subscribe`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newSubscriptionServer()
+			response, handled := s.handleSubscriptionRequest(tt.sessionID, false, tt.message)
+			if handled != tt.wantHandled {
+				t.Fatalf("handled = %v, want %v", handled, tt.wantHandled)
+			}
+			if !handled {
+				return
+			}
+
+			responseBytes, err := json.Marshal(response)
+			if err != nil {
+				t.Fatalf("failed to marshal response: %v", err)
+			}
+			if tt.wantError != "" {
+				if !strings.Contains(string(responseBytes), tt.wantError) {
+					t.Fatalf("response %s, want an error containing %q", responseBytes, tt.wantError)
+				}
+				return
+			}
+			if strings.Contains(string(responseBytes), `"error"`) {
+				t.Fatalf("unexpected error response %s", responseBytes)
+			}
+
+			for _, uri := range tt.wantURIs {
+				if subscribers := s.subscriptions.subscribers(uri); len(subscribers) != 1 || subscribers[0] != tt.sessionID {
+					t.Fatalf("subscribers of %s = %v, want [%s]", uri, subscribers, tt.sessionID)
+				}
+			}
+		})
+	}
+}
+
+func TestUnsubscribeAndSessionEnd(t *testing.T) {
+	s := newSubscriptionServer()
+	s.handleSubscriptionRequest("stdio", false, subscriptionMessage(subscribeMethod, "aws://ec2/instances"))
+	s.handleSubscriptionRequest("stdio", false, subscriptionMessage(subscribeMethod, stateGraphURI))
+	s.handleSubscriptionRequest("http-1", true, subscriptionMessage(subscribeMethod, "aws://ec2/instances"))
+
+	s.handleSubscriptionRequest("stdio", false, subscriptionMessage(unsubscribeMethod, "aws://ec2/instances"))
+	subscribers := s.subscriptions.subscribers("aws://ec2/instances")
+	if len(subscribers) != 1 || subscribers[0] != "http-1" {
+		t.Fatalf("subscribers after unsubscribe = %v, want [http-1]", subscribers)
+	}
+
+	// A closed connection ends the subscriptions of its session, unless the session outlives it
+	s.subscriptions.sessionUnregistered("stdio")
+	s.subscriptions.sessionUnregistered("http-1")
+	if subscribers := s.subscriptions.subscribers(stateGraphURI); len(subscribers) != 0 {
+		t.Fatalf("subscribers of an ended session = %v, want none", subscribers)
+	}
+	subscribers = s.subscriptions.subscribers("aws://ec2/instances")
+	sort.Strings(subscribers)
+	if len(subscribers) != 1 || subscribers[0] != "http-1" {
+		t.Fatalf("subscribers of a persistent session = %v, want [http-1]", subscribers)
+	}
+
+	s.subscriptions.removeSession("http-1")
+	if subscribers := s.subscriptions.subscribers("aws://ec2/instances"); len(subscribers) != 0 {
+		t.Fatalf("subscribers after the session was removed = %v, want none", subscribers)
+	}
+}
+
+func TestResourceIDFromCall(t *testing.T) {
+	instances := ResourceDefinition{
+		BaseURI:        "aws://ec2/instances",
+		DetailTemplate: "aws://ec2/instances/{instanceId}",
+	}
+	noTemplate := ResourceDefinition{BaseURI: "aws://s3/buckets"}
+
+	tests := []struct {
+		name      string
+		def       ResourceDefinition
+		response  map[string]interface{}
+		arguments map[string]interface{}
+		want      string
+	}{
+		{"placeholder in the response", instances, map[string]interface{}{"instanceId": "i-0abc"}, nil, "i-0abc"},
+		{"resourceId in the response", instances, map[string]interface{}{"resourceId": "i-0abc"}, nil, "i-0abc"},
+		{"placeholder preferred over resourceId", instances, map[string]interface{}{"instanceId": "i-0abc", "resourceId": "i-0def"}, nil, "i-0abc"},
+		{"placeholder in the arguments", instances, map[string]interface{}{"success": true}, map[string]interface{}{"instanceId": "i-0abc"}, "i-0abc"},
+		{"response preferred over arguments", instances, map[string]interface{}{"resourceId": "i-0abc"}, map[string]interface{}{"instanceId": "i-0def"}, "i-0abc"},
+		{"empty ID", instances, map[string]interface{}{"instanceId": ""}, nil, ""},
+		{"non-string ID", instances, map[string]interface{}{"instanceId": 42}, nil, ""},
+		{"no detail template", noTemplate, map[string]interface{}{"bucketName": "assets", "resourceId": "assets"}, nil, "assets"},
+		{"no ID", instances, nil, nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resourceIDFromCall(tt.def, tt.response, tt.arguments); got != tt.want {
+				t.Fatalf("resourceIDFromCall() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -28,6 +28,10 @@ type ResourceDefinition struct {
 	// Adapter reference
 	Adapter interfaces.AWSResourceAdapter
 
+	// Change notifications for subscribed clients
+	ResourceTypes []string // State resource types of this definition, e.g. "ec2_instance"
+	Tools         []string // Tools whose calls change resources of this definition
+
 	// Custom formatting functions (optional)
 	ListFormatter   func([]*types.AWSResource) map[string]interface{}
 	DetailFormatter func(types.AWSResource) map[string]interface{}
@@ -48,6 +52,7 @@ func (s *Server) registerResources() {
 
 	// Get all resource definitions
 	definitions := CreateResourceDefinitions(s.AWSClient, s.Logger)
+	s.resourceDefinitions = definitions
 
 	// Add all definitions to the registry
 	for _, def := range definitions {
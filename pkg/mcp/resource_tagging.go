@@ -0,0 +1,48 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/versus-control/ai-infrastructure-agent/pkg/aws"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/tagging"
+)
+
+// decisionArgument is the optional argument added to every creation tool. The agent sets it to
+// the decision a step belongs to so the resources it creates carry the DecisionID tag.
+const decisionArgument = "decisionId"
+
+// applyTagPolicy merges the tag policy into the tags of a creation tool call and validates
+// the result. The returned context carries the tags to every AWS create call the tool makes.
+func (s *Server) applyTagPolicy(ctx context.Context, toolName string, arguments map[string]interface{}) (context.Context, error) {
+	decisionID, _ := arguments[decisionArgument].(string)
+	delete(arguments, decisionArgument)
+
+	if s.TagPolicy == nil {
+		return ctx, nil
+	}
+
+	region, _ := arguments[regionArgument].(string)
+	if region == "" && s.AWSClient != nil {
+		region = s.AWSClient.GetRegion()
+	}
+
+	explicit := make(map[string]string)
+	if tagsArg, ok := arguments["tags"].(map[string]interface{}); ok {
+		for key, value := range tagsArg {
+			explicit[key] = fmt.Sprintf("%v", value)
+		}
+	}
+
+	tags := s.TagPolicy.Apply(explicit, tagging.Variables{
+		Workspace:  tagging.WorkspaceFromEnv(),
+		DecisionID: decisionID,
+		Tool:       toolName,
+		Region:     region,
+	})
+	if err := s.TagPolicy.Validate(tags); err != nil {
+		return ctx, err
+	}
+
+	return aws.WithResourceTags(ctx, tags), nil
+}
@@ -1,18 +1,22 @@
 package mcp
 
 import (
-	"bufio"
 	"context"
-	"encoding/json"
-	"os"
+	"fmt"
+	"sync"
 
 	"github.com/versus-control/ai-infrastructure-agent/internal/config"
 	"github.com/versus-control/ai-infrastructure-agent/internal/logging"
 	"github.com/versus-control/ai-infrastructure-agent/pkg/aws"
 	"github.com/versus-control/ai-infrastructure-agent/pkg/conflict"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/cost"
 	"github.com/versus-control/ai-infrastructure-agent/pkg/discovery"
 	"github.com/versus-control/ai-infrastructure-agent/pkg/graph"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/interfaces"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/prompts"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/redact"
 	"github.com/versus-control/ai-infrastructure-agent/pkg/state"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/tagging"
 
 	"github.com/mark3labs/mcp-go/server"
 )
@@ -22,6 +26,7 @@ type Server struct {
 
 	Config           *config.Config
 	AWSClient        *aws.Client
+	ClientPool       *aws.ClientPool
 	Logger           *logging.Logger
 	StateManager     *state.Manager
 	DiscoveryScanner *discovery.Scanner
@@ -29,23 +34,67 @@ type Server struct {
 	GraphAnalyzer    *graph.Analyzer
 	ConflictResolver *conflict.Resolver
 	ToolManager      *ToolManager
+	TagPolicy        *tagging.Policy
+	CostCatalog      *cost.Catalog
+	PromptCatalog    *prompts.Catalog
+
+	// Tool instances bound to non-default regions, created on first use
+	regionalTools map[string]map[string]interfaces.MCPTool
+	regionalMutex sync.Mutex
+
+	// Resource definitions and the client subscriptions to them, for change notifications
+	resourceDefinitions []ResourceDefinition
+	subscriptions       *resourceSubscriptions
 }
 
 func NewServer(cfg *config.Config, awsClient *aws.Client, logger *logging.Logger) *Server {
+	// Mask secrets in everything the server logs
+	redact.Install(logger)
+
 	// Initialize individual components
 	stateManager := state.NewManager(cfg.State.FilePath, cfg.AWS.Region, logger)
+	if err := stateManager.EnableEncryptionFromEnv(awsClient); err != nil {
+		logger.WithError(err).Error("Invalid state encryption configuration, the state file will not be read or written")
+	}
+	clientPool := aws.NewClientPool(awsClient, aws.RegionsFromEnv(cfg.AWS.Region), logger)
+	tagPolicy, err := tagging.LoadConfigured()
+	if err != nil {
+		logger.WithError(err).Error("Invalid tag policy, using the default tag policy")
+		tagPolicy = tagging.DefaultPolicy()
+	}
+	costCatalog, err := cost.LoadConfiguredCatalog()
+	if err != nil {
+		logger.WithError(err).Error("Invalid pricing catalog, cost reports are disabled")
+	}
+	promptCatalog, err := prompts.LoadConfigured()
+	if err != nil {
+		logger.WithError(err).Error("Invalid prompt catalog, no prompts will be published")
+		promptCatalog = &prompts.Catalog{}
+	}
 	discoveryScanner := discovery.NewScanner(awsClient, logger)
+	discoveryScanner.SetClientPool(clientPool)
+	discoveryScanner.SetTagPolicy(tagPolicy)
 	graphManager := graph.NewManager(logger)
 	graphAnalyzer := graph.NewAnalyzer(graphManager)
 	conflictResolver := conflict.NewResolver(logger)
 	toolManager := NewToolManager(logger)
 
+	// Subscriptions end with the session that made them
+	subscriptions := newResourceSubscriptions()
+	hooks := &server.Hooks{}
+	hooks.AddOnUnregisterSession(func(ctx context.Context, session server.ClientSession) {
+		subscriptions.sessionUnregistered(session.SessionID())
+	})
+
 	// Create MCP server
 	mcpServer := server.NewMCPServer(
 		cfg.MCP.ServerName,
 		cfg.MCP.Version,
 		server.WithResourceCapabilities(true, true),
 		server.WithToolCapabilities(true),
+		server.WithPromptCapabilities(false),
+		server.WithHooks(hooks),
+		server.WithToolFilter(hideInternalTools),
 	)
 
 	s := &Server{
@@ -53,6 +102,7 @@ func NewServer(cfg *config.Config, awsClient *aws.Client, logger *logging.Logger
 
 		Config:           cfg,
 		AWSClient:        awsClient,
+		ClientPool:       clientPool,
 		Logger:           logger,
 		StateManager:     stateManager,
 		DiscoveryScanner: discoveryScanner,
@@ -60,14 +110,30 @@ func NewServer(cfg *config.Config, awsClient *aws.Client, logger *logging.Logger
 		GraphAnalyzer:    graphAnalyzer,
 		ConflictResolver: conflictResolver,
 		ToolManager:      toolManager,
+		TagPolicy:        tagPolicy,
+		CostCatalog:      costCatalog,
+		PromptCatalog:    promptCatalog,
+
+		regionalTools: make(map[string]map[string]interfaces.MCPTool),
+		subscriptions: subscriptions,
 	}
 
 	// Register resources using the new registry-based approach
 	s.registerResources()
 
+	// Register the agent's managed view: state resources, dependency graph and executions
+	s.registerStateResources()
+
 	// Register modern adapter-based tools (replaces legacy registerTools)
 	s.registerServerTools()
 
+	// Register the infrastructure recipes of the prompt catalog
+	s.registerPrompts()
+
+	// Notify subscribed clients when managed resources change or drift, and of new executions
+	stateManager.OnChange(s.handleStateChange)
+	stateManager.OnExecution(s.handleExecutionRecorded)
+
 	// Load existing state from file
 	if err := s.StateManager.LoadState(context.Background()); err != nil {
 		logger.WithError(err).Error("Failed to load infrastructure state, continuing with empty state")
@@ -77,43 +143,20 @@ func NewServer(cfg *config.Config, awsClient *aws.Client, logger *logging.Logger
 	return s
 }
 
-// Start begins the stdio message loop for the MCP server
-func (s *Server) Start(ctx context.Context) error {
-	s.Logger.Info("Starting MCP server message loop on stdio...")
-	scanner := bufio.NewScanner(os.Stdin)
-
-	for scanner.Scan() {
-		select {
-		case <-ctx.Done():
-			s.Logger.Info("Shutdown signal received, stopping server")
-			return ctx.Err()
-		default:
-			line := scanner.Bytes()
-			if len(line) == 0 {
-				continue
-			}
-
-			// Handle the JSON-RPC message
-			response := s.mcpServer.HandleMessage(ctx, line)
-
-			// Write response to stdout
-			if response != nil {
-				responseBytes, err := json.Marshal(response)
-				if err != nil {
-					s.Logger.WithError(err).Error("Failed to marshal response")
-					continue
-				}
-
-				os.Stdout.Write(responseBytes)
-				os.Stdout.Write([]byte("\n"))
-			}
-		}
-	}
+// MCPServer returns the underlying MCP server, e.g. to connect an in-process client
+func (s *Server) MCPServer() *server.MCPServer {
+	return s.mcpServer
+}
 
-	if err := scanner.Err(); err != nil {
-		s.Logger.WithError(err).Error("Error reading from stdin")
-		return err
+// Start serves the MCP server on the transport selected by MCP_TRANSPORT until ctx is
+// cancelled: stdio (the default) or http
+func (s *Server) Start(ctx context.Context) error {
+	switch transport := TransportFromEnv(); transport {
+	case TransportStdio:
+		return s.startStdio(ctx)
+	case TransportHTTP:
+		return s.StartHTTP(ctx, HTTPOptionsFromEnv())
+	default:
+		return fmt.Errorf("unknown MCP transport %q, expected %s or %s", transport, TransportStdio, TransportHTTP)
 	}
-
-	return nil
 }
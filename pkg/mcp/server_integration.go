@@ -2,9 +2,11 @@ package mcp
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/versus-control/ai-infrastructure-agent/pkg/interfaces"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/redact"
 	"github.com/versus-control/ai-infrastructure-agent/pkg/tools"
 )
 
@@ -24,12 +26,14 @@ func (s *Server) registerServerTools() {
 			// Create tool instance using factory with basic dependencies
 			tool, err := factory.CreateTool(toolName, actionType, &tools.ToolDependencies{
 				AWSClient:        s.AWSClient,
+				ClientPool:       s.ClientPool,
 				StateManager:     s.StateManager,
 				DiscoveryScanner: s.DiscoveryScanner,
 				GraphManager:     s.GraphManager,
 				GraphAnalyzer:    s.GraphAnalyzer,
 				ConflictResolver: s.ConflictResolver,
 				Config:           s.Config,
+				CostCatalog:      s.CostCatalog,
 			})
 			if err != nil {
 				s.Logger.WithField("toolName", toolName).WithError(err).Debug("Skipping tool (may require additional dependencies)")
@@ -64,13 +68,38 @@ func (s *Server) registerToolDynamic(tool interfaces.MCPTool) {
 	inputSchema := tool.GetInputSchema()
 	if inputSchema != nil {
 		mcpOptions = append(mcpOptions, s.convertSchemaToMCPOptions(inputSchema)...)
+
+		// Arguments flagged sensitive are masked wherever they are logged or persisted
+		if sensitive := redact.Default().RegisterSchema(inputSchema); len(sensitive) > 0 {
+			s.Logger.WithField("toolName", name).WithField("sensitiveArguments", sensitive).Debug("Registered sensitive tool arguments")
+		}
+	}
+
+	// Tools without their own region argument can be routed to another region's client
+	routeByRegion := !declaresRegion(inputSchema)
+	if routeByRegion {
+		mcpOptions = append(mcpOptions, mcp.WithString(regionArgument,
+			mcp.Description("AWS region to run this tool in (defaults to the server's region)")))
+	}
+
+	// Resources created by creation tools are tagged according to the tag policy
+	tagResources := tool.ActionType() == "creation"
+	if tagResources {
+		mcpOptions = append(mcpOptions, mcp.WithString(decisionArgument,
+			mcp.Description("ID of the agent decision this call belongs to, written to the DecisionID tag")))
+	}
+
+	// Publish behavior hints and the shape of structured results
+	mcpOptions = append(mcpOptions, toolAnnotationOptions(tool)...)
+	if outputSchema := tool.GetOutputSchema(); outputSchema != nil {
+		mcpOptions = append(mcpOptions, withOutputSchema(outputSchema))
 	}
 
 	// Create MCP tool with dynamic parameters
 	mcpTool := mcp.NewTool(name, mcpOptions...)
 
 	// Create handler that delegates to tool manager
-	handler := s.createToolHandler(name)
+	handler := s.createToolHandler(name, tool.ActionType(), routeByRegion, tagResources)
 
 	// Register with MCP server
 	s.mcpServer.AddTool(mcpTool, handler)
@@ -78,6 +107,18 @@ func (s *Server) registerToolDynamic(tool interfaces.MCPTool) {
 	s.Logger.WithField("toolName", name).Info("Successfully registered modern tool")
 }
 
+// hideInternalTools leaves the tools reserved for the agent out of tools/list, so neither the
+// planner nor MCP clients are offered them
+func hideInternalTools(ctx context.Context, listed []mcp.Tool) []mcp.Tool {
+	visible := make([]mcp.Tool, 0, len(listed))
+	for _, tool := range listed {
+		if !tools.IsInternalTool(tool.Name) {
+			visible = append(visible, tool)
+		}
+	}
+	return visible
+}
+
 // convertSchemaToMCPOptions converts JSON Schema to MCP tool options
 func (s *Server) convertSchemaToMCPOptions(schema map[string]interface{}) []mcp.ToolOption {
 	var options []mcp.ToolOption
@@ -114,6 +155,9 @@ func (s *Server) convertSchemaToMCPOptions(schema map[string]interface{}) []mcp.
 		if requiredSet[propName] {
 			paramOptions = append(paramOptions, mcp.Required())
 		}
+		if sensitive, _ := propMap["sensitive"].(bool); sensitive {
+			paramOptions = append(paramOptions, sensitiveProperty)
+		}
 
 		// Add parameter based on type
 		switch propType {
@@ -136,9 +180,26 @@ func (s *Server) convertSchemaToMCPOptions(schema map[string]interface{}) []mcp.
 	return options
 }
 
+// sensitiveProperty keeps the "sensitive" flag in the published schema so MCP clients can
+// redact the argument too
+func sensitiveProperty(schema map[string]interface{}) {
+	schema["sensitive"] = true
+}
+
 // createToolHandler creates a handler function that delegates to the tool manager
-func (s *Server) createToolHandler(toolName string) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+func (s *Server) createToolHandler(toolName, actionType string, routeByRegion, tagResources bool) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	internal := tools.IsInternalTool(toolName)
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if internal && isRemoteClient(ctx) {
+			s.Logger.WithField("toolName", toolName).Warn("Rejected call of an internal tool from an HTTP client")
+			return &mcp.CallToolResult{
+				IsError: true,
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("tool %s is reserved for the agent", toolName)),
+				},
+			}, nil
+		}
+
 		arguments, ok := request.Params.Arguments.(map[string]interface{})
 		if !ok {
 			return &mcp.CallToolResult{
@@ -149,7 +210,33 @@ func (s *Server) createToolHandler(toolName string) func(context.Context, mcp.Ca
 			}, nil
 		}
 
+		if tagResources {
+			taggedCtx, err := s.applyTagPolicy(ctx, toolName, arguments)
+			if err != nil {
+				s.Logger.WithField("toolName", toolName).WithError(err).Warn("Rejected tool call that violates the tagging policy")
+				return &mcp.CallToolResult{
+					IsError: true,
+					Content: []mcp.Content{
+						mcp.NewTextContent(err.Error()),
+					},
+				}, nil
+			}
+			ctx = taggedCtx
+		}
+
+		ctx = s.withProgressNotifications(ctx, toolName, request)
+
 		s.Logger.WithField("toolName", toolName).WithField("arguments", arguments).Info("Executing modern tool via tool manager")
-		return s.ToolManager.ExecuteTool(ctx, toolName, arguments)
+		var result *mcp.CallToolResult
+		var err error
+		if routeByRegion {
+			result, err = s.executeInRegion(ctx, toolName, arguments)
+		} else {
+			result, err = s.ToolManager.ExecuteTool(ctx, toolName, arguments)
+		}
+		if err == nil && actionType != "query" {
+			s.notifyToolChanges(toolName, actionType, arguments, result)
+		}
+		return result, err
 	}
 }
@@ -0,0 +1,228 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/graph"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/types"
+)
+
+// URIs of the resources serving the agent's managed view from the state
+const (
+	stateResourcesURI  = "state://resources"
+	stateGraphURI      = "state://graph"
+	stateExecutionsURI = "state://executions"
+)
+
+// stateResourceURIs are the base URIs of the state resources, for subscriptions
+var stateResourceURIs = []string{stateResourcesURI, stateGraphURI, stateExecutionsURI}
+
+// registerStateResources registers the resources backed by the state manager: managed
+// resources, their dependency graph and the execution history
+func (s *Server) registerStateResources() {
+	s.mcpServer.AddResource(
+		mcp.NewResource(
+			stateResourcesURI,
+			"Managed Resources",
+			mcp.WithResourceDescription("List the resources the agent manages, as recorded in its state"),
+			mcp.WithMIMEType("application/json"),
+		),
+		s.stateResourceHandler(func(ctx context.Context, uri string) (interface{}, error) {
+			return s.formatManagedResources(), nil
+		}),
+	)
+
+	s.mcpServer.AddResourceTemplate(
+		mcp.NewResourceTemplate(
+			stateResourcesURI+"/{resourceId}",
+			"Managed Resource Details",
+			mcp.WithTemplateDescription("A managed resource as recorded in the state, with its dependencies and dependents"),
+			mcp.WithTemplateMIMEType("application/json"),
+		),
+		s.stateResourceHandler(func(ctx context.Context, uri string) (interface{}, error) {
+			return s.formatManagedResource(strings.TrimPrefix(uri, stateResourcesURI+"/"))
+		}),
+	)
+
+	s.mcpServer.AddResource(
+		mcp.NewResource(
+			stateGraphURI,
+			"Dependency Graph",
+			mcp.WithResourceDescription("Dependency graph of the managed resources with their deployment order"),
+			mcp.WithMIMEType("application/json"),
+		),
+		s.stateResourceHandler(func(ctx context.Context, uri string) (interface{}, error) {
+			return s.formatDependencyGraph(ctx)
+		}),
+	)
+
+	s.mcpServer.AddResource(
+		mcp.NewResource(
+			stateExecutionsURI,
+			"Plan Executions",
+			mcp.WithResourceDescription("Recent plan executions of the agent, most recent first"),
+			mcp.WithMIMEType("application/json"),
+		),
+		s.stateResourceHandler(func(ctx context.Context, uri string) (interface{}, error) {
+			return s.formatExecutions(), nil
+		}),
+	)
+
+	s.mcpServer.AddResourceTemplate(
+		mcp.NewResourceTemplate(
+			stateExecutionsURI+"/{executionId}",
+			"Plan Execution Details",
+			mcp.WithTemplateDescription("A plan execution with the status, output and errors of each step"),
+			mcp.WithTemplateMIMEType("application/json"),
+		),
+		s.stateResourceHandler(func(ctx context.Context, uri string) (interface{}, error) {
+			executionID := strings.TrimPrefix(uri, stateExecutionsURI+"/")
+			execution, exists := s.StateManager.GetExecution(executionID)
+			if !exists {
+				return nil, fmt.Errorf("execution %s not found", executionID)
+			}
+			return execution, nil
+		}),
+	)
+
+	s.Logger.Info("Successfully registered state resources")
+}
+
+// stateResourceHandler wraps a state read as a resource handler returning JSON
+func (s *Server) stateResourceHandler(read func(ctx context.Context, uri string) (interface{}, error)) func(context.Context, mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		uri := request.Params.URI
+		s.Logger.WithField("uri", uri).Info("Received request for state resource")
+
+		data, err := read(ctx, uri)
+		if err != nil {
+			s.Logger.WithError(err).WithField("uri", uri).Error("Failed to read state resource")
+			return nil, err
+		}
+
+		jsonData, err := json.MarshalIndent(data, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal state resource: %w", err)
+		}
+		return []mcp.ResourceContents{
+			&mcp.TextResourceContents{
+				URI:      uri,
+				MIMEType: "application/json",
+				Text:     string(jsonData),
+			},
+		}, nil
+	}
+}
+
+// formatManagedResources summarizes the managed resources of the state
+func (s *Server) formatManagedResources() map[string]interface{} {
+	state := s.StateManager.GetState()
+
+	ids := make([]string, 0, len(state.Resources))
+	for id := range state.Resources {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	resources := make([]map[string]interface{}, 0, len(ids))
+	typeCount := make(map[string]int)
+	for _, id := range ids {
+		resource := state.Resources[id]
+		resources = append(resources, map[string]interface{}{
+			"id":         resource.ID,
+			"name":       resource.Name,
+			"type":       resource.Type,
+			"region":     resource.Region,
+			"status":     resource.Status,
+			"updated_at": resource.UpdatedAt,
+			"uri":        stateResourcesURI + "/" + resource.ID,
+		})
+		typeCount[resource.Type]++
+	}
+
+	return map[string]interface{}{
+		"total_resources": len(resources),
+		"region":          state.Region,
+		"regions":         state.Regions,
+		"last_updated":    state.LastUpdated,
+		"resources":       resources,
+		"summary_by_type": typeCount,
+	}
+}
+
+// formatManagedResource returns a managed resource with its dependencies and dependents
+func (s *Server) formatManagedResource(resourceID string) (map[string]interface{}, error) {
+	resource, exists := s.StateManager.GetResource(resourceID)
+	if !exists {
+		return nil, fmt.Errorf("resource %s not found in state", resourceID)
+	}
+
+	return map[string]interface{}{
+		"resource":     resource,
+		"dependencies": s.StateManager.GetDependencies(resourceID),
+		"dependents":   s.StateManager.GetDependents(resourceID),
+	}, nil
+}
+
+// formatDependencyGraph builds the dependency graph of the managed resources. A graph of its
+// own is built so reads do not race with the tools using the shared graph manager.
+func (s *Server) formatDependencyGraph(ctx context.Context) (map[string]interface{}, error) {
+	state := s.StateManager.GetState()
+	resources := make([]*types.ResourceState, 0, len(state.Resources))
+	for _, resource := range state.Resources {
+		resources = append(resources, resource)
+	}
+
+	manager := graph.NewManager(s.Logger)
+	if err := manager.BuildGraph(ctx, resources); err != nil {
+		return nil, fmt.Errorf("failed to build dependency graph: %w", err)
+	}
+	dependencyGraph := manager.GetGraph()
+
+	result := map[string]interface{}{
+		"nodes":      dependencyGraph.Nodes,
+		"edges":      dependencyGraph.Edges,
+		"node_count": len(dependencyGraph.Nodes),
+	}
+	if order, err := manager.GetDeploymentOrder(); err != nil {
+		result["deployment_order_error"] = err.Error()
+	} else {
+		result["deployment_order"] = order
+	}
+	return result, nil
+}
+
+// formatExecutions summarizes the recorded plan executions
+func (s *Server) formatExecutions() map[string]interface{} {
+	executions := s.StateManager.ListExecutions()
+
+	summaries := make([]map[string]interface{}, 0, len(executions))
+	for _, execution := range executions {
+		summaries = append(summaries, map[string]interface{}{
+			"id":           execution.ID,
+			"name":         execution.Name,
+			"status":       execution.Status,
+			"started_at":   execution.StartedAt,
+			"completed_at": execution.CompletedAt,
+			"step_count":   len(execution.Steps),
+			"error_count":  len(execution.Errors),
+			"uri":          stateExecutionsURI + "/" + execution.ID,
+		})
+	}
+
+	return map[string]interface{}{
+		"total_executions": len(summaries),
+		"executions":       summaries,
+	}
+}
+
+// handleExecutionRecorded notifies subscribers of the execution history
+func (s *Server) handleExecutionRecorded(execution *types.PlanExecution) {
+	s.notifyResourcesUpdated(stateExecutionsURI, stateExecutionsURI+"/"+execution.ID)
+	s.notifyResourceListChanged()
+}
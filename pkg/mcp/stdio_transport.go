@@ -0,0 +1,383 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// StdioWorkersEnvVar sets how many stdio requests are handled at once, DefaultStdioWorkers
+// when unset
+const StdioWorkersEnvVar = "MCP_STDIO_WORKERS"
+
+// DefaultStdioWorkers is the number of stdio requests handled at once by default
+const DefaultStdioWorkers = 8
+
+// StdioQueueSizeEnvVar sets how many stdio requests may wait for a free worker,
+// DefaultStdioQueueSize when unset
+const StdioQueueSizeEnvVar = "MCP_STDIO_QUEUE_SIZE"
+
+// DefaultStdioQueueSize is the number of stdio requests that may wait for a free worker by default
+const DefaultStdioQueueSize = 64
+
+// maxStdioMessageSize is the largest JSON-RPC line accepted on stdin
+const maxStdioMessageSize = 10 * 1024 * 1024
+
+// stdioDrainTimeout bounds how long in-flight requests may finish after shutdown starts
+const stdioDrainTimeout = 30 * time.Second
+
+// stdioSessionID identifies the single client of the stdio transport
+const stdioSessionID = "stdio"
+
+// cancelledNotification is sent by clients to abort a request they no longer need
+const cancelledNotification = "notifications/cancelled"
+
+// stdioMessage holds the JSON-RPC fields the stdio loop dispatches on
+type stdioMessage struct {
+	ID     json.RawMessage `json:"id,omitempty"`
+	Method string          `json:"method"`
+	Params struct {
+		RequestID json.RawMessage `json:"requestId,omitempty"`
+		Reason    string          `json:"reason,omitempty"`
+	} `json:"params"`
+}
+
+// stdioSession tracks the in-flight requests of the stdio loop
+type stdioSession struct {
+	server  *Server
+	out     io.Writer
+	writeMu sync.Mutex
+
+	inflightMu sync.Mutex
+	inflight   map[string]context.CancelFunc
+
+	// queue holds the requests waiting for a worker, in the order they arrived
+	queue chan *stdioRequest
+	wg    sync.WaitGroup
+}
+
+// stdioRequest is a request queued for the worker pool
+type stdioRequest struct {
+	key     string
+	line    []byte
+	ctx     context.Context // Cancelled by notifications/cancelled or shutdown
+	cancel  context.CancelFunc
+	session context.Context // The context of all requests, cancelled only by shutdown
+}
+
+// stdioClientSession is the MCP session of the stdio client. Notifications the server sends to
+// it, such as progress notifications, are written to stdout between responses.
+type stdioClientSession struct {
+	notifications chan mcp.JSONRPCNotification
+	initialized   atomic.Bool
+}
+
+// SessionID returns the ID of the stdio session
+func (c *stdioClientSession) SessionID() string {
+	return stdioSessionID
+}
+
+// NotificationChannel returns the channel the server queues notifications for the client on
+func (c *stdioClientSession) NotificationChannel() chan<- mcp.JSONRPCNotification {
+	return c.notifications
+}
+
+// Initialize marks the session initialized once the client completed the handshake
+func (c *stdioClientSession) Initialize() {
+	c.initialized.Store(true)
+}
+
+// Initialized reports whether the client completed the handshake
+func (c *stdioClientSession) Initialized() bool {
+	return c.initialized.Load()
+}
+
+// StdioWorkersFromEnv returns the worker pool size configured with MCP_STDIO_WORKERS
+func StdioWorkersFromEnv() int {
+	if value := strings.TrimSpace(os.Getenv(StdioWorkersEnvVar)); value != "" {
+		if workers, err := strconv.Atoi(value); err == nil && workers > 0 {
+			return workers
+		}
+	}
+	return DefaultStdioWorkers
+}
+
+// StdioQueueSizeFromEnv returns the request queue size configured with MCP_STDIO_QUEUE_SIZE
+func StdioQueueSizeFromEnv() int {
+	if value := strings.TrimSpace(os.Getenv(StdioQueueSizeEnvVar)); value != "" {
+		if size, err := strconv.Atoi(value); err == nil && size >= 0 {
+			return size
+		}
+	}
+	return DefaultStdioQueueSize
+}
+
+// startStdio runs the stdio message loop for the MCP server
+func (s *Server) startStdio(ctx context.Context) error {
+	return s.serveStdio(ctx, os.Stdin, os.Stdout, StdioWorkersFromEnv(), StdioQueueSizeFromEnv())
+}
+
+// serveStdio reads JSON-RPC messages line by line and handles up to workers requests at once,
+// so a slow tool call does not stall the others. Up to queueSize more requests wait for a
+// worker in arrival order; requests beyond that are rejected. Notifications are handled in
+// order, and notifications/cancelled aborts the request it names. When the input ends or ctx
+// is cancelled, in-flight requests get stdioDrainTimeout to finish before they are cancelled.
+func (s *Server) serveStdio(ctx context.Context, in io.Reader, out io.Writer, workers, queueSize int) error {
+	s.Logger.WithFields(map[string]interface{}{
+		"workers":    workers,
+		"queue_size": queueSize,
+	}).Info("Starting MCP server message loop on stdio...")
+
+	session := &stdioSession{
+		server:   s,
+		out:      out,
+		inflight: make(map[string]context.CancelFunc),
+		queue:    make(chan *stdioRequest, queueSize),
+	}
+
+	// Requests run on a context of their own so shutdown can drain them before cancelling
+	requestCtx, cancelRequests := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelRequests()
+
+	// Register the client session so tools can send it notifications while they run
+	clientSession := &stdioClientSession{
+		notifications: make(chan mcp.JSONRPCNotification, 100),
+	}
+	if err := s.mcpServer.RegisterSession(requestCtx, clientSession); err != nil {
+		return fmt.Errorf("failed to register stdio session: %w", err)
+	}
+	defer s.mcpServer.UnregisterSession(requestCtx, stdioSessionID)
+	requestCtx = s.mcpServer.WithContext(requestCtx, clientSession)
+
+	for i := 0; i < workers; i++ {
+		session.wg.Add(1)
+		go session.work()
+	}
+
+	stopNotifications := make(chan struct{})
+	notificationsDone := make(chan struct{})
+	go func() {
+		defer close(notificationsDone)
+		for {
+			select {
+			case notification := <-clientSession.notifications:
+				session.write(notification)
+			case <-stopNotifications:
+				return
+			}
+		}
+	}()
+
+	// Read on a separate goroutine so shutdown does not wait for the next line
+	lines := make(chan []byte)
+	readErr := make(chan error, 1)
+	go func() {
+		scanner := bufio.NewScanner(in)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxStdioMessageSize)
+		for scanner.Scan() {
+			line := bytes.TrimSpace(scanner.Bytes())
+			if len(line) == 0 {
+				continue
+			}
+			// The scanner reuses its buffer, and the line outlives this iteration
+			message := make([]byte, len(line))
+			copy(message, line)
+			select {
+			case lines <- message:
+			case <-ctx.Done():
+				return
+			}
+		}
+		readErr <- scanner.Err()
+	}()
+
+	var err error
+loop:
+	for {
+		select {
+		case <-ctx.Done():
+			s.Logger.Info("Shutdown signal received, stopping server")
+			err = ctx.Err()
+			break loop
+		case err = <-readErr:
+			if err != nil {
+				s.Logger.WithError(err).Error("Error reading from stdin")
+			}
+			break loop
+		case line := <-lines:
+			session.dispatch(requestCtx, line)
+		}
+	}
+
+	// Workers finish the queued requests, then stop
+	close(session.queue)
+	session.drain(cancelRequests)
+	close(stopNotifications)
+	<-notificationsDone
+	return err
+}
+
+// dispatch handles one message. Requests are queued for the worker pool, so a request waiting
+// for a free worker does not hold up the read loop and notifications/cancelled still reaches
+// it. A request arriving while the queue is full is rejected rather than blocking the loop.
+func (session *stdioSession) dispatch(ctx context.Context, line []byte) {
+	var message stdioMessage
+	if err := json.Unmarshal(line, &message); err != nil {
+		// Let the server answer with the proper JSON-RPC parse error
+		session.write(session.server.mcpServer.HandleMessage(ctx, line))
+		return
+	}
+
+	if message.Method == cancelledNotification {
+		session.cancel(message.Params.RequestID, message.Params.Reason)
+		return
+	}
+
+	if response, handled := session.server.handleSubscriptionRequest(stdioSessionID, false, line); handled {
+		session.write(response)
+		return
+	}
+
+	// Notifications have no response and may depend on order, e.g. notifications/initialized
+	if len(message.ID) == 0 || string(message.ID) == "null" {
+		session.write(session.server.mcpServer.HandleMessage(ctx, line))
+		return
+	}
+
+	key := requestKey(message.ID)
+	requestCtx, cancel := context.WithCancel(ctx)
+	session.inflightMu.Lock()
+	session.inflight[key] = cancel
+	session.inflightMu.Unlock()
+
+	request := &stdioRequest{key: key, line: line, ctx: requestCtx, cancel: cancel, session: ctx}
+	select {
+	case session.queue <- request:
+	default:
+		session.finish(request)
+		session.server.Logger.WithField("request_id", key).Warn("Rejecting request, all workers are busy and the queue is full")
+
+		var id mcp.RequestId
+		json.Unmarshal(message.ID, &id)
+		session.write(mcp.NewJSONRPCError(id, mcp.INTERNAL_ERROR, "Server is busy, retry the request later", nil))
+	}
+}
+
+// work handles queued requests until the queue is closed
+func (session *stdioSession) work() {
+	defer session.wg.Done()
+	for request := range session.queue {
+		session.handle(request)
+	}
+}
+
+// handle runs a queued request and writes its response
+func (session *stdioSession) handle(request *stdioRequest) {
+	defer session.finish(request)
+
+	if request.ctx.Err() != nil {
+		// Cancelled by the client, or by shutdown, before a worker was free
+		session.server.Logger.WithField("request_id", request.key).Debug("Dropping request cancelled while waiting for a worker")
+		return
+	}
+
+	response := session.server.mcpServer.HandleMessage(request.ctx, request.line)
+
+	// The client gave up on a cancelled request and expects no response
+	if request.ctx.Err() != nil && request.session.Err() == nil {
+		session.server.Logger.WithField("request_id", request.key).Debug("Dropping response of cancelled request")
+		return
+	}
+	session.write(response)
+}
+
+// finish releases a request that was handled, dropped or rejected
+func (session *stdioSession) finish(request *stdioRequest) {
+	request.cancel()
+	session.inflightMu.Lock()
+	delete(session.inflight, request.key)
+	session.inflightMu.Unlock()
+}
+
+// cancel aborts an in-flight request
+func (session *stdioSession) cancel(requestID json.RawMessage, reason string) {
+	key := requestKey(requestID)
+
+	session.inflightMu.Lock()
+	cancel, exists := session.inflight[key]
+	delete(session.inflight, key)
+	session.inflightMu.Unlock()
+
+	if !exists {
+		return
+	}
+	session.server.Logger.WithFields(map[string]interface{}{
+		"request_id": key,
+		"reason":     reason,
+	}).Info("Cancelling in-flight request")
+	cancel()
+}
+
+// drain waits for in-flight requests, cancelling those still running after stdioDrainTimeout
+func (session *stdioSession) drain(cancelRequests context.CancelFunc) {
+	done := make(chan struct{})
+	go func() {
+		session.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return
+	case <-time.After(stdioDrainTimeout):
+	}
+
+	session.inflightMu.Lock()
+	remaining := len(session.inflight)
+	session.inflightMu.Unlock()
+	session.server.Logger.WithField("in_flight", remaining).Warn("Cancelling requests still running after the drain timeout")
+
+	cancelRequests()
+	<-done
+}
+
+// write sends a response as one line. Writes are serialized so concurrent responses do not
+// interleave on stdout.
+func (session *stdioSession) write(response interface{}) {
+	if response == nil {
+		return
+	}
+
+	responseBytes, err := json.Marshal(response)
+	if err != nil {
+		session.server.Logger.WithError(err).Error("Failed to marshal response")
+		return
+	}
+	responseBytes = append(responseBytes, '\n')
+
+	session.writeMu.Lock()
+	defer session.writeMu.Unlock()
+	if _, err := session.out.Write(responseBytes); err != nil {
+		session.server.Logger.WithError(err).Error("Failed to write response")
+	}
+}
+
+// requestKey normalizes a JSON-RPC id so the id of a request and of its cancellation match
+func requestKey(id json.RawMessage) string {
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, id); err != nil {
+		return string(id)
+	}
+	return compact.String()
+}
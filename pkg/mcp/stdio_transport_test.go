@@ -0,0 +1,234 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/versus-control/ai-infrastructure-agent/internal/logging"
+)
+
+// stdioHarness runs serveStdio over pipes with a blocking "wait" tool and an immediate "ping"
+// tool
+type stdioHarness struct {
+	input     *io.PipeWriter
+	responses chan map[string]interface{}
+	started   chan string   // Receives the id argument of each wait call that starts
+	release   chan struct{} // Closing it lets the wait calls return
+	done      chan struct{} // Closed when serveStdio returns
+	err       error         // Returned by serveStdio
+}
+
+// responseRecorder decodes the responses written by the stdio session, one line per write
+type responseRecorder struct {
+	responses chan map[string]interface{}
+}
+
+func (r *responseRecorder) Write(line []byte) (int, error) {
+	var response map[string]interface{}
+	if err := json.Unmarshal(line, &response); err != nil {
+		return 0, err
+	}
+	r.responses <- response
+	return len(line), nil
+}
+
+func newStdioHarness(t *testing.T, workers, queueSize int) *stdioHarness {
+	t.Helper()
+
+	h := &stdioHarness{
+		responses: make(chan map[string]interface{}, 16),
+		started:   make(chan string, 16),
+		release:   make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+
+	mcpServer := server.NewMCPServer("test", "1.0.0", server.WithToolCapabilities(false))
+	mcpServer.AddTool(mcp.NewTool("wait", mcp.WithString("id")), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		h.started <- request.GetString("id", "")
+		select {
+		case <-h.release:
+			return mcp.NewToolResultText("released"), nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	})
+	mcpServer.AddTool(mcp.NewTool("ping"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("pong"), nil
+	})
+
+	s := &Server{mcpServer: mcpServer, Logger: logging.NewLogger("test", "error")}
+
+	var in *io.PipeReader
+	in, h.input = io.Pipe()
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		defer close(h.done)
+		h.err = s.serveStdio(ctx, in, &responseRecorder{responses: h.responses}, workers, queueSize)
+	}()
+
+	t.Cleanup(func() {
+		cancel()
+		h.input.Close()
+		select {
+		case <-h.done:
+		case <-time.After(5 * time.Second):
+			t.Error("stdio loop did not stop")
+		}
+	})
+	return h
+}
+
+func (h *stdioHarness) send(t *testing.T, message string) {
+	t.Helper()
+	if _, err := io.WriteString(h.input, message+"\n"); err != nil {
+		t.Fatalf("failed to send %s: %v", message, err)
+	}
+}
+
+func (h *stdioHarness) callWait(t *testing.T, id int) {
+	h.send(t, fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"method":"tools/call","params":{"name":"wait","arguments":{"id":"%d"}}}`, id, id))
+}
+
+func (h *stdioHarness) expectStarted(t *testing.T, want string) {
+	t.Helper()
+	select {
+	case id := <-h.started:
+		if id != want {
+			t.Fatalf("request %s started, want %s", id, want)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("request %s did not start", want)
+	}
+}
+
+func (h *stdioHarness) expectResponse(t *testing.T) map[string]interface{} {
+	t.Helper()
+	select {
+	case response := <-h.responses:
+		return response
+	case <-time.After(5 * time.Second):
+		t.Fatal("no response received")
+		return nil
+	}
+}
+
+func TestServeStdioHandlesRequestsConcurrently(t *testing.T) {
+	h := newStdioHarness(t, 2, DefaultStdioQueueSize)
+
+	// Both calls block until released, so the second only starts if they run at once
+	h.callWait(t, 1)
+	h.expectStarted(t, "1")
+	h.callWait(t, 2)
+	h.expectStarted(t, "2")
+
+	close(h.release)
+	ids := map[string]bool{}
+	for i := 0; i < 2; i++ {
+		response := h.expectResponse(t)
+		if response["error"] != nil {
+			t.Fatalf("unexpected error response %v", response)
+		}
+		ids[fmt.Sprint(response["id"])] = true
+	}
+	if !ids["1"] || !ids["2"] {
+		t.Fatalf("responses for %v, want requests 1 and 2", ids)
+	}
+}
+
+func TestServeStdioCancelsRequestWaitingForWorker(t *testing.T) {
+	h := newStdioHarness(t, 1, DefaultStdioQueueSize)
+
+	h.callWait(t, 1)
+	h.expectStarted(t, "1")
+
+	// The only worker is busy, so request 2 waits; the loop must still read its cancellation
+	h.callWait(t, 2)
+	h.send(t, `{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":2,"reason":"no longer needed"}}`)
+
+	// Cancelling request 1 frees the worker, which must go to request 3 and not request 2
+	h.send(t, `{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":1}}`)
+	h.send(t, `{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"ping"}}`)
+
+	response := h.expectResponse(t)
+	if fmt.Sprint(response["id"]) != "3" {
+		t.Fatalf("got response %v, want only the response to request 3", response)
+	}
+	select {
+	case id := <-h.started:
+		t.Fatalf("cancelled request %s was handled", id)
+	default:
+	}
+}
+
+func TestServeStdioHandlesQueuedRequestsInOrder(t *testing.T) {
+	h := newStdioHarness(t, 1, DefaultStdioQueueSize)
+
+	h.callWait(t, 1)
+	h.expectStarted(t, "1")
+	for id := 2; id <= 5; id++ {
+		h.callWait(t, id)
+	}
+
+	// The single worker must take the waiting requests in the order they arrived
+	close(h.release)
+	for _, want := range []string{"2", "3", "4", "5"} {
+		h.expectStarted(t, want)
+	}
+	for i := 0; i < 5; i++ {
+		h.expectResponse(t)
+	}
+}
+
+func TestServeStdioRejectsRequestsWhenQueueIsFull(t *testing.T) {
+	h := newStdioHarness(t, 1, 1)
+
+	h.callWait(t, 1)
+	h.expectStarted(t, "1")
+
+	// Request 2 takes the only queue slot, so request 3 is rejected right away
+	h.callWait(t, 2)
+	h.callWait(t, 3)
+	response := h.expectResponse(t)
+	if fmt.Sprint(response["id"]) != "3" || response["error"] == nil {
+		t.Fatalf("got response %v, want an error response to request 3", response)
+	}
+
+	close(h.release)
+	h.expectStarted(t, "2")
+	ids := map[string]bool{}
+	for i := 0; i < 2; i++ {
+		response := h.expectResponse(t)
+		if response["error"] != nil {
+			t.Fatalf("unexpected error response %v", response)
+		}
+		ids[fmt.Sprint(response["id"])] = true
+	}
+	if !ids["1"] || !ids["2"] {
+		t.Fatalf("responses for %v, want requests 1 and 2", ids)
+	}
+}
+
+func TestServeStdioStopsWhenInputEnds(t *testing.T) {
+	h := newStdioHarness(t, 1, DefaultStdioQueueSize)
+
+	h.send(t, `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"ping"}}`)
+	if response := h.expectResponse(t); fmt.Sprint(response["id"]) != "1" {
+		t.Fatalf("unexpected response %v", response)
+	}
+
+	h.input.Close()
+	select {
+	case <-h.done:
+		if h.err != nil {
+			t.Fatalf("serveStdio returned %v at the end of input", h.err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("stdio loop did not stop at the end of input")
+	}
+}
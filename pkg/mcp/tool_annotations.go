@@ -0,0 +1,75 @@
+package mcp
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/interfaces"
+)
+
+// toolHints are the MCP behavior hints of a tool action type
+type toolHints struct {
+	readOnly    bool
+	destructive bool
+	idempotent  bool
+}
+
+// actionTypeHints maps tool action types to the hints clients use to decide which calls need
+// confirmation. Modifications count as destructive because they can replace settings, e.g. a
+// bucket policy or security group rules, and stopping instances interrupts what runs on them.
+var actionTypeHints = map[string]toolHints{
+	"query":        {readOnly: true, idempotent: true},
+	"creation":     {},
+	"modification": {destructive: true, idempotent: true},
+	"deletion":     {destructive: true, idempotent: true},
+	"association":  {idempotent: true},
+	"state":        {},
+}
+
+// closedWorldCategories are the tool categories that work on the agent's own state and plans
+// rather than on AWS, e.g. analyzing or recording state and planning deployments
+var closedWorldCategories = map[string]bool{
+	"state":         true,
+	"planning":      true,
+	"analysis":      true,
+	"visualization": true,
+}
+
+// toolAnnotationOptions returns the MCP annotations of a tool, derived from its action type.
+// Tools act on AWS, and so are open world, unless their category is closed world.
+func toolAnnotationOptions(tool interfaces.MCPTool) []mcp.ToolOption {
+	hints, known := actionTypeHints[tool.ActionType()]
+	if !known {
+		// Without an action type the safe assumption is a tool that may destroy things
+		hints = toolHints{destructive: true}
+	}
+
+	return []mcp.ToolOption{
+		mcp.WithReadOnlyHintAnnotation(hints.readOnly),
+		mcp.WithDestructiveHintAnnotation(hints.destructive),
+		mcp.WithIdempotentHintAnnotation(hints.idempotent),
+		mcp.WithOpenWorldHintAnnotation(!closedWorldCategories[tool.Category()]),
+	}
+}
+
+// withOutputSchema publishes the schema of a tool's structured results
+func withOutputSchema(schema map[string]interface{}) mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		outputSchema := mcp.ToolOutputSchema{Type: "object"}
+		if schemaType, ok := schema["type"].(string); ok {
+			outputSchema.Type = schemaType
+		}
+		if properties, ok := schema["properties"].(map[string]interface{}); ok {
+			outputSchema.Properties = properties
+		}
+		switch required := schema["required"].(type) {
+		case []string:
+			outputSchema.Required = required
+		case []interface{}:
+			for _, field := range required {
+				if name, ok := field.(string); ok {
+					outputSchema.Required = append(outputSchema.Required, name)
+				}
+			}
+		}
+		tool.OutputSchema = outputSchema
+	}
+}
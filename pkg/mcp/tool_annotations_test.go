@@ -0,0 +1,43 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/versus-control/ai-infrastructure-agent/internal/logging"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/tools"
+)
+
+func TestToolAnnotationsOpenWorldHint(t *testing.T) {
+	tests := []struct {
+		tool      string
+		openWorld bool
+	}{
+		{"analyze-infrastructure-state", false},
+		{"export-infrastructure-state", false},
+		{"visualize-dependency-graph", false},
+		{"detect-infrastructure-conflicts", false},
+		{"add-resource-to-state", false},
+		{"record-execution", false},
+		{"plan-infrastructure-deployment", false},
+		{"create-vpc", true},
+		{"list-db-instances", true},
+		{"delete-volume", true},
+	}
+
+	factory := tools.NewToolFactory(nil, logging.NewLogger("test", "error"))
+	for _, tt := range tests {
+		t.Run(tt.tool, func(t *testing.T) {
+			tool, err := factory.CreateTool(tt.tool, factory.GetToolActionType(tt.tool), &tools.ToolDependencies{})
+			if err != nil {
+				t.Fatalf("CreateTool() error = %v", err)
+			}
+
+			mcpTool := mcp.NewTool(tool.Name(), toolAnnotationOptions(tool)...)
+			openWorld := mcpTool.Annotations.OpenWorldHint
+			if openWorld == nil || *openWorld != tt.openWorld {
+				t.Fatalf("open world hint = %v, want %v", openWorld, tt.openWorld)
+			}
+		})
+	}
+}
@@ -0,0 +1,505 @@
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/versus-control/ai-infrastructure-agent/pkg/types"
+)
+
+// PolicyFileEnvVar points at the guardrail policy file, DefaultPolicyFile when unset
+const PolicyFileEnvVar = "POLICY_FILE"
+
+// DefaultPolicyFile holds the guardrail policies shipped with the agent
+const DefaultPolicyFile = "settings/policies.yaml"
+
+// Policy effects. A "confirm" plan needs an extra, explicit confirmation before it executes.
+const (
+	EffectDeny    = "deny"
+	EffectConfirm = "confirm"
+	EffectWarn    = "warn"
+)
+
+// Levels a policy can apply to
+const (
+	LevelStep     = "step"
+	LevelDecision = "decision"
+)
+
+// File is the YAML layout of a policy file
+type File struct {
+	Policies []*Policy `yaml:"policies"`
+}
+
+// Policy is a guardrail rule. It matches when, for any of its targets, every condition holds.
+//
+// Step policies see "tool", "action", "region", "stepId", "name" and "parameters" (the step's
+// tool parameters); decision policies see "action", "resource", "confidence", "stepCount",
+// "tools", "regions" and, when the plan has a cost estimate, "monthlyCost" and "stepCosts".
+type Policy struct {
+	ID          string `yaml:"id"`
+	Description string `yaml:"description"`
+	Effect      string `yaml:"effect"`
+	Message     string `yaml:"message"`
+
+	// Level is "step" (default) or "decision"
+	Level string `yaml:"level"`
+
+	// Tools limits a step policy to these MCP tools; empty means every tool
+	Tools []string `yaml:"tools"`
+
+	// Targets are paths of the objects the conditions are evaluated against. Arrays expand to
+	// their elements, so "parameters.ingressRules" checks each rule. Empty means the whole input.
+	Targets []string `yaml:"targets"`
+
+	Conditions []*Condition `yaml:"conditions"`
+}
+
+// Condition compares the value at Field, relative to the target, using one operator. When the
+// field resolves to several values (through arrays) the condition holds if any value matches.
+type Condition struct {
+	Field string `yaml:"field"`
+
+	Equals    interface{}   `yaml:"equals"`
+	NotEquals interface{}   `yaml:"not_equals"`
+	In        []interface{} `yaml:"in"`
+	NotIn     []interface{} `yaml:"not_in"`
+	Matches   string        `yaml:"matches"`
+	Exists    *bool         `yaml:"exists"`
+	LT        *float64      `yaml:"lt"`
+	LTE       *float64      `yaml:"lte"`
+	GT        *float64      `yaml:"gt"`
+	GTE       *float64      `yaml:"gte"`
+
+	pattern *regexp.Regexp
+}
+
+// Engine evaluates guardrail policies against decisions and plan steps
+type Engine struct {
+	policies []*Policy
+}
+
+// NewEngine validates policies and creates an engine
+func NewEngine(policies []*Policy) (*Engine, error) {
+	for _, p := range policies {
+		if err := p.validate(); err != nil {
+			return nil, err
+		}
+	}
+	return &Engine{policies: policies}, nil
+}
+
+// LoadFile creates an engine from a policy file
+func LoadFile(path string) (*Engine, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file File
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse policy file %s: %w", path, err)
+	}
+
+	engine, err := NewEngine(file.Policies)
+	if err != nil {
+		return nil, fmt.Errorf("invalid policy file %s: %w", path, err)
+	}
+	return engine, nil
+}
+
+// LoadConfigured loads the file named by POLICY_FILE, or the default policy file. Without
+// either the engine has no policies.
+func LoadConfigured() (*Engine, error) {
+	if path := os.Getenv(PolicyFileEnvVar); path != "" {
+		return LoadFile(path)
+	}
+
+	engine, err := LoadFile(DefaultPolicyFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Engine{}, nil
+	}
+	return engine, err
+}
+
+// Policies returns the loaded policies
+func (e *Engine) Policies() []*Policy {
+	return e.policies
+}
+
+// EvaluateDecision checks a decision and each of its steps. Steps without a region run in
+// defaultRegion.
+func (e *Engine) EvaluateDecision(decision *types.AgentDecision, defaultRegion string) []*types.PolicyViolation {
+	var violations []*types.PolicyViolation
+
+	tools := make([]interface{}, 0, len(decision.ExecutionPlan))
+	regions := make([]interface{}, 0, len(decision.ExecutionPlan))
+	for _, step := range decision.ExecutionPlan {
+		if step.MCPTool != "" {
+			tools = append(tools, step.MCPTool)
+		}
+		regions = append(regions, stepRegion(step, defaultRegion))
+	}
+
+	input := map[string]interface{}{
+		"action":     decision.Action,
+		"resource":   decision.Resource,
+		"confidence": decision.Confidence,
+		"stepCount":  len(decision.ExecutionPlan),
+		"tools":      tools,
+		"regions":    regions,
+	}
+	if decision.CostEstimate != nil {
+		stepCosts := make([]interface{}, 0, len(decision.CostEstimate.Steps))
+		for _, stepCost := range decision.CostEstimate.Steps {
+			stepCosts = append(stepCosts, stepCost.MonthlyCost)
+		}
+		input["monthlyCost"] = decision.CostEstimate.MonthlyTotal
+		input["stepCosts"] = stepCosts
+	}
+	for _, p := range e.policies {
+		if p.Level == LevelDecision && p.matches(input) {
+			violations = append(violations, p.violation("", ""))
+		}
+	}
+
+	for _, step := range decision.ExecutionPlan {
+		violations = append(violations, e.EvaluateStep(step, defaultRegion)...)
+	}
+
+	return violations
+}
+
+// EvaluateStep checks a single plan step
+func (e *Engine) EvaluateStep(step *types.ExecutionPlanStep, defaultRegion string) []*types.PolicyViolation {
+	var violations []*types.PolicyViolation
+
+	parameters := step.ToolParameters
+	if parameters == nil {
+		parameters = step.Parameters
+	}
+
+	input := map[string]interface{}{
+		"tool":       step.MCPTool,
+		"action":     step.Action,
+		"region":     stepRegion(step, defaultRegion),
+		"stepId":     step.ID,
+		"name":       step.Name,
+		"parameters": parameters,
+	}
+	for _, p := range e.policies {
+		if p.Level == LevelDecision || !p.appliesToTool(step.MCPTool) {
+			continue
+		}
+		if p.matches(input) {
+			violations = append(violations, p.violation(step.ID, step.MCPTool))
+		}
+	}
+
+	return violations
+}
+
+// Denied reports whether any violation has the deny effect
+func Denied(violations []*types.PolicyViolation) bool {
+	for _, v := range violations {
+		if v.Effect == EffectDeny {
+			return true
+		}
+	}
+	return false
+}
+
+// RequiresConfirmation reports whether any violation needs an extra confirmation
+func RequiresConfirmation(violations []*types.PolicyViolation) bool {
+	for _, v := range violations {
+		if v.Effect == EffectConfirm {
+			return true
+		}
+	}
+	return false
+}
+
+// Summary joins violation messages for errors and log lines
+func Summary(violations []*types.PolicyViolation) string {
+	messages := make([]string, 0, len(violations))
+	for _, v := range violations {
+		messages = append(messages, fmt.Sprintf("%s (%s): %s", v.PolicyID, v.Effect, v.Message))
+	}
+	return strings.Join(messages, "; ")
+}
+
+// validate checks a policy and compiles its patterns
+func (p *Policy) validate() error {
+	if p.ID == "" {
+		return fmt.Errorf("policy without id")
+	}
+
+	p.Effect = strings.ToLower(p.Effect)
+	if p.Effect == "" {
+		p.Effect = EffectDeny
+	}
+	if p.Effect != EffectDeny && p.Effect != EffectConfirm && p.Effect != EffectWarn {
+		return fmt.Errorf("policy %s: effect must be deny, confirm or warn, got %q", p.ID, p.Effect)
+	}
+
+	p.Level = strings.ToLower(p.Level)
+	if p.Level == "" {
+		p.Level = LevelStep
+	}
+	if p.Level != LevelStep && p.Level != LevelDecision {
+		return fmt.Errorf("policy %s: level must be step or decision, got %q", p.ID, p.Level)
+	}
+
+	if len(p.Conditions) == 0 {
+		return fmt.Errorf("policy %s has no conditions", p.ID)
+	}
+	for _, c := range p.Conditions {
+		if err := c.validate(); err != nil {
+			return fmt.Errorf("policy %s: %w", p.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// appliesToTool reports whether a step policy covers a tool
+func (p *Policy) appliesToTool(tool string) bool {
+	if len(p.Tools) == 0 {
+		return true
+	}
+	for _, t := range p.Tools {
+		if t == tool {
+			return true
+		}
+	}
+	return false
+}
+
+// matches reports whether every condition holds for any target
+func (p *Policy) matches(input map[string]interface{}) bool {
+	targets := []interface{}{input}
+	if len(p.Targets) > 0 {
+		targets = nil
+		for _, path := range p.Targets {
+			targets = append(targets, resolve(input, path)...)
+		}
+	}
+
+	for _, target := range targets {
+		object, ok := target.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		matched := true
+		for _, c := range p.Conditions {
+			if !c.holds(resolve(object, c.Field)) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// violation describes a match of the policy
+func (p *Policy) violation(stepID, tool string) *types.PolicyViolation {
+	message := p.Message
+	if message == "" {
+		message = p.Description
+	}
+	if message == "" {
+		message = fmt.Sprintf("policy %s matched", p.ID)
+	}
+
+	return &types.PolicyViolation{
+		PolicyID: p.ID,
+		Effect:   p.Effect,
+		Message:  message,
+		StepID:   stepID,
+		Tool:     tool,
+	}
+}
+
+// validate checks that a condition has a field and exactly one operator
+func (c *Condition) validate() error {
+	if c.Field == "" {
+		return fmt.Errorf("condition without field")
+	}
+
+	operators := 0
+	for _, set := range []bool{
+		c.Equals != nil, c.NotEquals != nil, c.In != nil, c.NotIn != nil, c.Matches != "",
+		c.Exists != nil, c.LT != nil, c.LTE != nil, c.GT != nil, c.GTE != nil,
+	} {
+		if set {
+			operators++
+		}
+	}
+	if operators != 1 {
+		return fmt.Errorf("condition on %s must have exactly one operator, got %d", c.Field, operators)
+	}
+
+	if c.Matches != "" {
+		pattern, err := regexp.Compile(c.Matches)
+		if err != nil {
+			return fmt.Errorf("condition on %s: invalid pattern: %w", c.Field, err)
+		}
+		c.pattern = pattern
+	}
+
+	return nil
+}
+
+// holds evaluates the condition against the resolved field values
+func (c *Condition) holds(values []interface{}) bool {
+	if c.Exists != nil {
+		return (len(values) > 0) == *c.Exists
+	}
+
+	for _, value := range values {
+		if c.holdsFor(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// holdsFor evaluates the condition against a single value
+func (c *Condition) holdsFor(value interface{}) bool {
+	switch {
+	case c.Equals != nil:
+		return equal(value, c.Equals)
+	case c.NotEquals != nil:
+		return !equal(value, c.NotEquals)
+	case c.In != nil:
+		return contains(c.In, value)
+	case c.NotIn != nil:
+		return !contains(c.NotIn, value)
+	case c.pattern != nil:
+		return c.pattern.MatchString(fmt.Sprint(value))
+	}
+
+	number, ok := toNumber(value)
+	if !ok {
+		return false
+	}
+	switch {
+	case c.LT != nil:
+		return number < *c.LT
+	case c.LTE != nil:
+		return number <= *c.LTE
+	case c.GT != nil:
+		return number > *c.GT
+	case c.GTE != nil:
+		return number >= *c.GTE
+	}
+	return false
+}
+
+// resolve returns the values at a dotted path, expanding arrays along the way
+func resolve(value interface{}, path string) []interface{} {
+	current := []interface{}{value}
+	if path == "" || path == "." {
+		return expand(current)
+	}
+
+	for _, segment := range strings.Split(path, ".") {
+		var next []interface{}
+		for _, item := range expand(current) {
+			object, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if child, exists := object[segment]; exists && child != nil {
+				next = append(next, child)
+			}
+		}
+		current = next
+	}
+
+	return expand(current)
+}
+
+// expand replaces arrays by their elements
+func expand(values []interface{}) []interface{} {
+	var expanded []interface{}
+	for _, value := range values {
+		switch v := value.(type) {
+		case []interface{}:
+			expanded = append(expanded, v...)
+		case []string:
+			for _, s := range v {
+				expanded = append(expanded, s)
+			}
+		case []map[string]interface{}:
+			for _, m := range v {
+				expanded = append(expanded, m)
+			}
+		default:
+			expanded = append(expanded, value)
+		}
+	}
+	return expanded
+}
+
+// equal compares values, treating numbers and numeric strings alike
+func equal(a, b interface{}) bool {
+	if x, ok := toNumber(a); ok {
+		if y, ok := toNumber(b); ok {
+			return x == y
+		}
+	}
+	return fmt.Sprint(a) == fmt.Sprint(b)
+}
+
+// contains reports whether a list holds a value
+func contains(list []interface{}, value interface{}) bool {
+	for _, item := range list {
+		if equal(value, item) {
+			return true
+		}
+	}
+	return false
+}
+
+// toNumber converts numbers and numeric strings produced by JSON or YAML decoding
+func toNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case string:
+		number, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return number, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// stepRegion returns the region a step runs in
+func stepRegion(step *types.ExecutionPlanStep, defaultRegion string) string {
+	if step.Region != "" {
+		return step.Region
+	}
+	if region, ok := step.ToolParameters["region"].(string); ok && region != "" {
+		return region
+	}
+	return defaultRegion
+}
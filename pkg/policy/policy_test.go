@@ -0,0 +1,433 @@
+package policy
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/versus-control/ai-infrastructure-agent/pkg/types"
+)
+
+func number(value float64) *float64 {
+	return &value
+}
+
+func boolean(value bool) *bool {
+	return &value
+}
+
+func TestResolve(t *testing.T) {
+	input := map[string]interface{}{
+		"tool": "add-security-group-ingress-rule",
+		"parameters": map[string]interface{}{
+			"port":  22,
+			"empty": nil,
+			"ingressRules": []interface{}{
+				map[string]interface{}{"port": 22, "cidrBlocks": []interface{}{"10.0.0.0/8", "0.0.0.0/0"}},
+				map[string]interface{}{"port": 443, "cidrBlocks": []string{"0.0.0.0/0"}},
+				"not an object",
+			},
+			"tags":  []map[string]interface{}{{"key": "Owner"}, {"key": "Environment"}},
+			"names": []string{"a", "b"},
+		},
+	}
+
+	tests := []struct {
+		path string
+		want []interface{}
+	}{
+		{"tool", []interface{}{"add-security-group-ingress-rule"}},
+		{"parameters.port", []interface{}{22}},
+		{"parameters.ingressRules.port", []interface{}{22, 443}},
+		{"parameters.ingressRules.cidrBlocks", []interface{}{"10.0.0.0/8", "0.0.0.0/0", "0.0.0.0/0"}},
+		{"parameters.tags.key", []interface{}{"Owner", "Environment"}},
+		{"parameters.names", []interface{}{"a", "b"}},
+		{"parameters.missing", nil},
+		{"parameters.empty", nil},
+		{"tool.length", nil},
+		{"parameters.port.value", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := resolve(input, tt.path); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("resolve(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveWholeValue(t *testing.T) {
+	rules := []interface{}{map[string]interface{}{"port": 22}, map[string]interface{}{"port": 80}}
+
+	for _, path := range []string{"", "."} {
+		if got := resolve(rules, path); len(got) != 2 {
+			t.Errorf("resolve(%q) = %v, want both rules", path, got)
+		}
+	}
+}
+
+func TestExpand(t *testing.T) {
+	got := expand([]interface{}{
+		[]interface{}{1, 2},
+		[]string{"a"},
+		[]map[string]interface{}{{"k": "v"}},
+		"scalar",
+		[]interface{}{[]interface{}{"nested"}}, // Only one level is expanded
+	})
+
+	want := []interface{}{1, 2, "a", map[string]interface{}{"k": "v"}, "scalar", []interface{}{"nested"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expand() = %v, want %v", got, want)
+	}
+}
+
+func TestConditionHolds(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition Condition
+		values    []interface{}
+		want      bool
+	}{
+		{"equals string", Condition{Equals: "tcp"}, []interface{}{"tcp"}, true},
+		{"equals number and numeric string", Condition{Equals: 22}, []interface{}{"22"}, true},
+		{"equals float and int", Condition{Equals: 22.0}, []interface{}{int32(22)}, true},
+		{"equals any value", Condition{Equals: "0.0.0.0/0"}, []interface{}{"10.0.0.0/8", "0.0.0.0/0"}, true},
+		{"equals nothing", Condition{Equals: "tcp"}, nil, false},
+		{"not equals", Condition{NotEquals: "tcp"}, []interface{}{"udp"}, true},
+		{"not equals same", Condition{NotEquals: "tcp"}, []interface{}{"tcp"}, false},
+		{"in", Condition{In: []interface{}{"us-east-1", "eu-west-1"}}, []interface{}{"eu-west-1"}, true},
+		{"in numbers", Condition{In: []interface{}{22, 3389}}, []interface{}{3389.0}, true},
+		{"not in", Condition{NotIn: []interface{}{"us-east-1", "eu-west-1"}}, []interface{}{"ap-south-1"}, true},
+		{"not in listed", Condition{NotIn: []interface{}{"us-east-1"}}, []interface{}{"us-east-1"}, false},
+		{"matches", Condition{Matches: `^m5\.(4|8|12)xlarge$`}, []interface{}{"m5.8xlarge"}, true},
+		{"matches anchored", Condition{Matches: `^m5\.(4|8|12)xlarge$`}, []interface{}{"m5.48xlarge"}, false},
+		{"matches wildcard", Condition{Matches: `^prod-.*-db$`}, []interface{}{"prod-orders-db"}, true},
+		{"matches numbers as text", Condition{Matches: `^22$`}, []interface{}{22}, true},
+		{"exists", Condition{Exists: boolean(true)}, []interface{}{false}, true},
+		{"exists without values", Condition{Exists: boolean(true)}, nil, false},
+		{"must not exist", Condition{Exists: boolean(false)}, nil, true},
+		{"lt", Condition{LT: number(0.5)}, []interface{}{0.4}, true},
+		{"lt equal", Condition{LT: number(0.5)}, []interface{}{0.5}, false},
+		{"lte equal", Condition{LTE: number(0.5)}, []interface{}{0.5}, true},
+		{"gt", Condition{GT: number(500)}, []interface{}{500.01}, true},
+		{"gt any value", Condition{GT: number(200)}, []interface{}{12.0, 250.0}, true},
+		{"gt numeric string", Condition{GT: number(100)}, []interface{}{" 120 "}, true},
+		{"gt not a number", Condition{GT: number(100)}, []interface{}{"large"}, false},
+		{"gte equal", Condition{GTE: number(500)}, []interface{}{500}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition := tt.condition
+			condition.Field = "value"
+			if err := condition.validate(); err != nil {
+				t.Fatalf("validate() = %v", err)
+			}
+			if got := condition.holds(tt.values); got != tt.want {
+				t.Fatalf("holds(%v) = %v, want %v", tt.values, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewEngineValidates(t *testing.T) {
+	condition := []*Condition{{Field: "tool", Equals: "create-vpc"}}
+
+	tests := []struct {
+		name    string
+		policy  *Policy
+		wantErr string
+	}{
+		{"missing id", &Policy{Conditions: condition}, "policy without id"},
+		{"unknown effect", &Policy{ID: "p", Effect: "block", Conditions: condition}, "effect must be deny, confirm or warn"},
+		{"unknown level", &Policy{ID: "p", Level: "plan", Conditions: condition}, "level must be step or decision"},
+		{"no conditions", &Policy{ID: "p"}, "has no conditions"},
+		{"condition without field", &Policy{ID: "p", Conditions: []*Condition{{Equals: "x"}}}, "condition without field"},
+		{"no operator", &Policy{ID: "p", Conditions: []*Condition{{Field: "tool"}}}, "exactly one operator, got 0"},
+		{"two operators", &Policy{ID: "p", Conditions: []*Condition{{Field: "port", GT: number(1), LT: number(10)}}}, "exactly one operator, got 2"},
+		{"invalid pattern", &Policy{ID: "p", Conditions: []*Condition{{Field: "tool", Matches: "("}}}, "invalid pattern"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewEngine([]*Policy{tt.policy})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("NewEngine() error = %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewEngineDefaults(t *testing.T) {
+	p := &Policy{ID: "p", Effect: "CONFIRM", Conditions: []*Condition{{Field: "tool", Equals: "create-vpc"}}}
+	if _, err := NewEngine([]*Policy{p}); err != nil {
+		t.Fatal(err)
+	}
+	if p.Effect != EffectConfirm || p.Level != LevelStep {
+		t.Fatalf("effect %q and level %q, want confirm and step", p.Effect, p.Level)
+	}
+
+	p = &Policy{ID: "p", Conditions: []*Condition{{Field: "tool", Equals: "create-vpc"}}}
+	if _, err := NewEngine([]*Policy{p}); err != nil {
+		t.Fatal(err)
+	}
+	if p.Effect != EffectDeny {
+		t.Fatalf("effect %q, want deny by default", p.Effect)
+	}
+}
+
+func TestEvaluateStep(t *testing.T) {
+	engine, err := NewEngine([]*Policy{
+		{
+			ID:      "no-ssh-from-internet",
+			Effect:  EffectDeny,
+			Tools:   []string{"set-security-group-rules"},
+			Targets: []string{"parameters.ingress"},
+			Conditions: []*Condition{
+				{Field: "fromPort", LTE: number(22)},
+				{Field: "toPort", GTE: number(22)},
+				{Field: "cidrBlocks", In: []interface{}{"0.0.0.0/0", "::/0"}},
+			},
+		},
+		{
+			ID:         "approved-regions",
+			Effect:     EffectDeny,
+			Conditions: []*Condition{{Field: "region", NotIn: []interface{}{"us-east-1", "eu-west-1"}}},
+		},
+		{
+			ID:         "untagged",
+			Effect:     EffectWarn,
+			Tools:      []string{"create-ec2-instance"},
+			Conditions: []*Condition{{Field: "parameters.tags.Owner", Exists: boolean(false)}},
+		},
+		{
+			ID:         "budget",
+			Effect:     EffectConfirm,
+			Level:      LevelDecision,
+			Conditions: []*Condition{{Field: "tool", Matches: ".*"}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rules := func(rules ...map[string]interface{}) map[string]interface{} {
+		ingress := make([]interface{}, len(rules))
+		for i, rule := range rules {
+			ingress[i] = rule
+		}
+		return map[string]interface{}{"ingress": ingress}
+	}
+
+	tests := []struct {
+		name string
+		step *types.ExecutionPlanStep
+		want []string
+	}{
+		{
+			name: "SSH from the internet",
+			step: &types.ExecutionPlanStep{ID: "step-sg", MCPTool: "set-security-group-rules", ToolParameters: rules(
+				map[string]interface{}{"fromPort": 443, "toPort": 443, "cidrBlocks": []interface{}{"0.0.0.0/0"}},
+				map[string]interface{}{"fromPort": 0, "toPort": 65535, "cidrBlocks": []interface{}{"10.0.0.0/8", "::/0"}},
+			)},
+			want: []string{"no-ssh-from-internet"},
+		},
+		{
+			name: "conditions must hold for the same rule",
+			step: &types.ExecutionPlanStep{ID: "step-sg", MCPTool: "set-security-group-rules", ToolParameters: rules(
+				map[string]interface{}{"fromPort": 22, "toPort": 22, "cidrBlocks": []interface{}{"10.0.0.0/8"}},
+				map[string]interface{}{"fromPort": 443, "toPort": 443, "cidrBlocks": []interface{}{"0.0.0.0/0"}},
+			)},
+		},
+		{
+			name: "tool filter",
+			step: &types.ExecutionPlanStep{ID: "step-rule", MCPTool: "add-security-group-ingress-rule", ToolParameters: rules(
+				map[string]interface{}{"fromPort": 22, "toPort": 22, "cidrBlocks": []interface{}{"0.0.0.0/0"}},
+			)},
+		},
+		{
+			name: "step region",
+			step: &types.ExecutionPlanStep{ID: "step-vpc", MCPTool: "create-vpc", Region: "ap-south-1"},
+			want: []string{"approved-regions"},
+		},
+		{
+			name: "region parameter",
+			step: &types.ExecutionPlanStep{ID: "step-vpc", MCPTool: "create-vpc", ToolParameters: map[string]interface{}{"region": "ap-south-1"}},
+			want: []string{"approved-regions"},
+		},
+		{
+			name: "legacy parameters",
+			step: &types.ExecutionPlanStep{ID: "step-ec2", MCPTool: "create-ec2-instance", Parameters: map[string]interface{}{
+				"tags": map[string]interface{}{"Owner": "platform"},
+			}},
+		},
+		{
+			name: "missing field",
+			step: &types.ExecutionPlanStep{ID: "step-ec2", MCPTool: "create-ec2-instance", ToolParameters: map[string]interface{}{}},
+			want: []string{"untagged"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, violation := range engine.EvaluateStep(tt.step, "us-east-1") {
+				if violation.StepID != tt.step.ID || violation.Tool != tt.step.MCPTool {
+					t.Errorf("violation %+v does not name the step", violation)
+				}
+				got = append(got, violation.PolicyID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("violations %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvaluateDecision(t *testing.T) {
+	engine, err := NewEngine([]*Policy{
+		{
+			ID:         "monthly-budget",
+			Effect:     EffectConfirm,
+			Level:      LevelDecision,
+			Conditions: []*Condition{{Field: "monthlyCost", GT: number(500)}},
+		},
+		{
+			ID:         "expensive-resource",
+			Effect:     EffectWarn,
+			Level:      LevelDecision,
+			Conditions: []*Condition{{Field: "stepCosts", GT: number(200)}},
+		},
+		{
+			ID:         "no-iam-users",
+			Effect:     EffectDeny,
+			Level:      LevelDecision,
+			Conditions: []*Condition{{Field: "tools", Matches: `^create-iam-user$`}},
+		},
+		{
+			ID:         "large-instances",
+			Effect:     EffectDeny,
+			Tools:      []string{"create-ec2-instance"},
+			Conditions: []*Condition{{Field: "parameters.instanceType", Matches: `\.(1[2-9]|[2-9][0-9])xlarge$`}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decision := &types.AgentDecision{
+		ExecutionPlan: []*types.ExecutionPlanStep{
+			{ID: "step-ec2", MCPTool: "create-ec2-instance", ToolParameters: map[string]interface{}{"instanceType": "m5.24xlarge"}},
+			{ID: "step-user", MCPTool: "create-iam-user"},
+		},
+		CostEstimate: &types.CostEstimate{
+			MonthlyTotal: 3363.84,
+			Steps:        []*types.StepCost{{StepID: "step-ec2", MonthlyCost: 3363.84}},
+		},
+	}
+
+	violations := engine.EvaluateDecision(decision, "us-east-1")
+	var got []string
+	for _, violation := range violations {
+		got = append(got, violation.PolicyID)
+	}
+	want := []string{"monthly-budget", "expensive-resource", "no-iam-users", "large-instances"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("violations %v, want %v", got, want)
+	}
+
+	// A deny outranks the confirmation the budget asks for
+	if !Denied(violations) || !RequiresConfirmation(violations) {
+		t.Fatalf("Denied() = %v, RequiresConfirmation() = %v, want both", Denied(violations), RequiresConfirmation(violations))
+	}
+
+	// Without an estimate the cost policies cannot match
+	decision.CostEstimate = nil
+	for _, violation := range engine.EvaluateDecision(decision, "us-east-1") {
+		if violation.PolicyID == "monthly-budget" || violation.PolicyID == "expensive-resource" {
+			t.Fatalf("cost policy %s matched without an estimate", violation.PolicyID)
+		}
+	}
+}
+
+func TestEffects(t *testing.T) {
+	violation := func(effect string) *types.PolicyViolation {
+		return &types.PolicyViolation{PolicyID: effect + "-policy", Effect: effect, Message: "matched"}
+	}
+
+	tests := []struct {
+		name        string
+		violations  []*types.PolicyViolation
+		wantDenied  bool
+		wantConfirm bool
+	}{
+		{name: "none"},
+		{name: "warnings only", violations: []*types.PolicyViolation{violation(EffectWarn)}},
+		{name: "confirm", violations: []*types.PolicyViolation{violation(EffectWarn), violation(EffectConfirm)}, wantConfirm: true},
+		{name: "deny", violations: []*types.PolicyViolation{violation(EffectDeny)}, wantDenied: true},
+		{name: "deny after confirm", violations: []*types.PolicyViolation{violation(EffectConfirm), violation(EffectDeny)}, wantDenied: true, wantConfirm: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Denied(tt.violations); got != tt.wantDenied {
+				t.Errorf("Denied() = %v, want %v", got, tt.wantDenied)
+			}
+			if got := RequiresConfirmation(tt.violations); got != tt.wantConfirm {
+				t.Errorf("RequiresConfirmation() = %v, want %v", got, tt.wantConfirm)
+			}
+		})
+	}
+}
+
+func TestSummary(t *testing.T) {
+	engine, err := NewEngine([]*Policy{
+		{ID: "with-message", Message: "not allowed", Conditions: []*Condition{{Field: "tool", Equals: "create-vpc"}}},
+		{ID: "with-description", Effect: EffectWarn, Description: "described", Conditions: []*Condition{{Field: "tool", Equals: "create-vpc"}}},
+		{ID: "bare", Effect: EffectWarn, Conditions: []*Condition{{Field: "tool", Equals: "create-vpc"}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	violations := engine.EvaluateStep(&types.ExecutionPlanStep{ID: "step-vpc", MCPTool: "create-vpc"}, "us-east-1")
+	want := "with-message (deny): not allowed; with-description (warn): described; bare (warn): policy bare matched"
+	if got := Summary(violations); got != want {
+		t.Fatalf("Summary() = %q, want %q", got, want)
+	}
+}
+
+func TestPublicDatabasesPolicy(t *testing.T) {
+	engine, err := LoadFile(filepath.Join("..", "..", DefaultPolicyFile))
+	if err != nil {
+		t.Fatalf("failed to load the default policy file: %v", err)
+	}
+
+	tests := []struct {
+		tool       string
+		parameters map[string]interface{}
+		want       bool
+	}{
+		{"create-db-instance", map[string]interface{}{"publiclyAccessible": true}, true},
+		{"create-db-instance", map[string]interface{}{"publiclyAccessible": false}, false},
+		{"create-db-instance", map[string]interface{}{}, false},
+		{"modify-db-instance", map[string]interface{}{"publiclyAccessible": true}, true},
+		{"create-db-read-replica", map[string]interface{}{"publiclyAccessible": true}, true},
+		{"restore-db-instance-from-snapshot", map[string]interface{}{"publiclyAccessible": true}, true},
+	}
+
+	for _, tt := range tests {
+		step := &types.ExecutionPlanStep{ID: "step-db", MCPTool: tt.tool, ToolParameters: tt.parameters}
+		got := false
+		for _, violation := range engine.EvaluateStep(step, "us-east-1") {
+			if violation.PolicyID == "public-databases" {
+				got = true
+			}
+		}
+		if got != tt.want {
+			t.Errorf("%s with %v: public-databases matched = %v, want %v", tt.tool, tt.parameters, got, tt.want)
+		}
+	}
+}
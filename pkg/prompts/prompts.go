@@ -0,0 +1,230 @@
+package prompts
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CatalogFileEnvVar points at the prompt catalog, DefaultCatalogFile when unset
+const CatalogFileEnvVar = "PROMPTS_FILE"
+
+// DefaultCatalogFile holds the prompt recipes shipped with the agent
+const DefaultCatalogFile = "settings/templates/prompts.yaml"
+
+// Argument types. MCP passes every prompt argument as a string; the type says how it is checked.
+const (
+	TypeString  = "string"
+	TypeInteger = "integer"
+	TypeBoolean = "boolean"
+)
+
+// placeholderPattern matches the {{argumentName}} placeholders of a template
+var placeholderPattern = regexp.MustCompile(`\{\{([A-Za-z][A-Za-z0-9]*)\}\}`)
+
+// Catalog is the set of parameterized infrastructure recipes published as MCP prompts
+type Catalog struct {
+	Prompts []*Prompt `yaml:"prompts"`
+}
+
+// Prompt is a recipe whose template is filled with the caller's arguments
+type Prompt struct {
+	Name        string      `yaml:"name"`
+	Description string      `yaml:"description"`
+	Template    string      `yaml:"template"` // Template file, relative to the catalog file
+	Arguments   []*Argument `yaml:"arguments"`
+
+	text string
+}
+
+// Argument is a typed prompt argument
+type Argument struct {
+	Name        string   `yaml:"name"`
+	Description string   `yaml:"description"`
+	Type        string   `yaml:"type"` // string, integer or boolean; string when empty
+	Required    bool     `yaml:"required"`
+	Default     string   `yaml:"default"` // Used when an optional argument is not given
+	Enum        []string `yaml:"enum"`    // Allowed values, any value when empty
+}
+
+// LoadFile reads a prompt catalog and the templates it references
+func LoadFile(path string) (*Catalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var catalog Catalog
+	if err := yaml.Unmarshal(data, &catalog); err != nil {
+		return nil, fmt.Errorf("failed to parse prompt catalog %s: %w", path, err)
+	}
+
+	names := make(map[string]bool)
+	for _, prompt := range catalog.Prompts {
+		if prompt.Name == "" {
+			return nil, fmt.Errorf("invalid prompt catalog %s: a prompt has no name", path)
+		}
+		if names[prompt.Name] {
+			return nil, fmt.Errorf("invalid prompt catalog %s: prompt %s is defined twice", path, prompt.Name)
+		}
+		names[prompt.Name] = true
+
+		templatePath := prompt.Template
+		if !filepath.IsAbs(templatePath) {
+			templatePath = filepath.Join(filepath.Dir(path), templatePath)
+		}
+		text, err := os.ReadFile(templatePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template of prompt %s: %w", prompt.Name, err)
+		}
+		prompt.text = string(text)
+
+		if err := prompt.validate(); err != nil {
+			return nil, fmt.Errorf("invalid prompt %s in %s: %w", prompt.Name, path, err)
+		}
+	}
+
+	return &catalog, nil
+}
+
+// LoadConfigured loads the file named by PROMPTS_FILE, or the default prompt catalog. Without
+// either the catalog is empty.
+func LoadConfigured() (*Catalog, error) {
+	if path := os.Getenv(CatalogFileEnvVar); path != "" {
+		return LoadFile(path)
+	}
+
+	catalog, err := LoadFile(DefaultCatalogFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Catalog{}, nil
+	}
+	return catalog, err
+}
+
+// Get returns the prompt with the given name
+func (c *Catalog) Get(name string) (*Prompt, bool) {
+	for _, prompt := range c.Prompts {
+		if prompt.Name == name {
+			return prompt, true
+		}
+	}
+	return nil, false
+}
+
+// validate checks the arguments of a prompt and that its template only uses declared arguments
+func (p *Prompt) validate() error {
+	declared := make(map[string]bool)
+	for _, argument := range p.Arguments {
+		if argument.Name == "" {
+			return fmt.Errorf("an argument has no name")
+		}
+		if declared[argument.Name] {
+			return fmt.Errorf("argument %s is declared twice", argument.Name)
+		}
+		declared[argument.Name] = true
+
+		if argument.Type == "" {
+			argument.Type = TypeString
+		}
+		switch argument.Type {
+		case TypeString, TypeInteger, TypeBoolean:
+		default:
+			return fmt.Errorf("argument %s has unknown type %s", argument.Name, argument.Type)
+		}
+
+		// Every placeholder is filled, so optional arguments need a default
+		if !argument.Required {
+			if argument.Default == "" {
+				return fmt.Errorf("optional argument %s has no default", argument.Name)
+			}
+			if err := argument.check(argument.Default); err != nil {
+				return fmt.Errorf("default of argument %s: %w", argument.Name, err)
+			}
+		}
+	}
+
+	for _, match := range placeholderPattern.FindAllStringSubmatch(p.text, -1) {
+		if !declared[match[1]] {
+			return fmt.Errorf("template uses undeclared argument %s", match[1])
+		}
+	}
+	return nil
+}
+
+// Render fills the template with the given arguments, applying defaults and checking types
+func (p *Prompt) Render(arguments map[string]string) (string, error) {
+	values := make(map[string]string, len(p.Arguments))
+	for _, argument := range p.Arguments {
+		value := strings.TrimSpace(arguments[argument.Name])
+		if value == "" {
+			if argument.Required {
+				return "", fmt.Errorf("argument %s is required", argument.Name)
+			}
+			value = argument.Default
+		}
+		if err := argument.check(value); err != nil {
+			return "", fmt.Errorf("argument %s: %w", argument.Name, err)
+		}
+		values[argument.Name] = value
+	}
+
+	for name := range arguments {
+		if _, declared := values[name]; !declared {
+			return "", fmt.Errorf("prompt %s has no argument %s", p.Name, name)
+		}
+	}
+
+	return placeholderPattern.ReplaceAllStringFunc(p.text, func(placeholder string) string {
+		return values[placeholderPattern.FindStringSubmatch(placeholder)[1]]
+	}), nil
+}
+
+// Summary describes the argument with its type, allowed values and default, since MCP clients
+// only see argument descriptions
+func (a *Argument) Summary() string {
+	var hints []string
+	if a.Type != "" && a.Type != TypeString {
+		hints = append(hints, a.Type)
+	}
+	if len(a.Enum) > 0 {
+		hints = append(hints, "one of "+strings.Join(a.Enum, ", "))
+	}
+	if !a.Required && a.Default != "" {
+		hints = append(hints, "default "+a.Default)
+	}
+
+	if len(hints) == 0 {
+		return a.Description
+	}
+	return fmt.Sprintf("%s (%s)", a.Description, strings.Join(hints, "; "))
+}
+
+// check reports whether value is valid for the argument's type and allowed values
+func (a *Argument) check(value string) error {
+	switch a.Type {
+	case TypeInteger:
+		if _, err := strconv.Atoi(value); err != nil {
+			return fmt.Errorf("%q is not an integer", value)
+		}
+	case TypeBoolean:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("%q is not true or false", value)
+		}
+	}
+
+	if len(a.Enum) > 0 {
+		for _, allowed := range a.Enum {
+			if value == allowed {
+				return nil
+			}
+		}
+		return fmt.Errorf("%q is not one of %s", value, strings.Join(a.Enum, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package redact
+
+import (
+	"sync"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/versus-control/ai-infrastructure-agent/internal/logging"
+)
+
+// Hook is a logrus hook masking sensitive values in log messages and fields
+type Hook struct {
+	redactor *Redactor
+}
+
+// NewHook creates a log hook backed by a redactor
+func NewHook(redactor *Redactor) *Hook {
+	return &Hook{redactor: redactor}
+}
+
+// Levels applies the hook to every log level
+func (h *Hook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire redacts the entry before it is formatted
+func (h *Hook) Fire(entry *logrus.Entry) error {
+	entry.Message = h.redactor.String(entry.Message)
+	for key, value := range entry.Data {
+		entry.Data[key] = h.redactor.Field(key, value)
+	}
+	return nil
+}
+
+var (
+	installMutex     sync.Mutex
+	installedLoggers = make(map[*logging.Logger]bool)
+	configureOnce    sync.Once
+)
+
+// Install loads the configured redaction patterns into the default redactor and attaches it to
+// the logger. It is safe to call for the same logger more than once.
+func Install(logger *logging.Logger) {
+	configureOnce.Do(func() {
+		if err := Default().LoadConfigured(); err != nil {
+			logger.WithError(err).Warn("Failed to load redaction patterns, using built-in patterns only")
+		}
+	})
+
+	installMutex.Lock()
+	defer installMutex.Unlock()
+
+	if installedLoggers[logger] {
+		return
+	}
+	logger.AddHook(NewHook(Default()))
+	installedLoggers[logger] = true
+}
@@ -0,0 +1,350 @@
+package redact
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Mask replaces every redacted value
+const Mask = "[REDACTED]"
+
+// PatternsFileEnvVar points at a YAML file with additional sensitive fields and patterns.
+// When unset DefaultPatternsFile is used if it exists.
+const PatternsFileEnvVar = "REDACTION_PATTERNS_FILE"
+
+// DefaultPatternsFile is the redaction configuration shipped with the agent
+const DefaultPatternsFile = "settings/redaction-patterns.yaml"
+
+// secretGroup is the capture group that is masked when a pattern defines it; patterns without
+// it have their whole match masked
+const secretGroup = "secret"
+
+// defaultFields are field names whose values are always masked
+var defaultFields = []string{
+	"password",
+	"masterPassword",
+	"masterUserPassword",
+	"newMasterUserPassword",
+	"keyMaterial",
+	"privateKey",
+	"privateKeyMaterial",
+	"secretString",
+	"secretBinary",
+	"secretValue",
+	"secretAccessKey",
+	"sessionToken",
+	"accessToken",
+	"refreshToken",
+	"clientSecret",
+	"apiKey",
+	"authorization",
+	"token",
+	"userData",
+}
+
+// defaultPatterns match secrets embedded in free text such as log messages and prompts
+var defaultPatterns = []string{
+	`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`,
+	`PuTTY-User-Key-File-[0-9]+:[\s\S]*?Private-MAC: [0-9a-f]+`,
+	`(?i)\b(?:password|passwd|pwd|secret|token|api[_-]?key)\s*[=:]\s*["']?(?P<secret>[^\s"'&,;]+)`,
+	`(?i)\bbearer\s+(?P<secret>[A-Za-z0-9\-._~+/]+=*)`,
+	`(?i)aws_secret_access_key\s*[=:]\s*["']?(?P<secret>[A-Za-z0-9/+=]{40})`,
+	`\b(?:AKIA|ASIA)[A-Z0-9]{16}\b`,
+	`\bgh[pousr]_[A-Za-z0-9]{36,}\b`,
+}
+
+// PatternsFile is the YAML layout of the redaction configuration file
+type PatternsFile struct {
+	// Fields are additional field names whose values are masked
+	Fields []string `yaml:"fields"`
+
+	// Patterns are additional regular expressions masked in free text. A named group
+	// "secret" limits the mask to that part of the match.
+	Patterns []string `yaml:"patterns"`
+}
+
+// Redactor masks sensitive values before they leave the process through logs, the state file,
+// LLM prompts or websocket updates. Values are matched by field name, using the defaults, the
+// configuration file and tool input schema properties flagged "sensitive", and by pattern.
+type Redactor struct {
+	mutex      sync.RWMutex
+	fields     map[string]string // normalized name -> name as registered
+	fieldRegex *regexp.Regexp    // matches "field": "value" pairs of sensitive fields in JSON text
+	patterns   []*regexp.Regexp
+}
+
+var (
+	defaultRedactor     *Redactor
+	defaultRedactorOnce sync.Once
+)
+
+// Default returns the process-wide redactor shared by every egress point
+func Default() *Redactor {
+	defaultRedactorOnce.Do(func() {
+		defaultRedactor = New()
+	})
+	return defaultRedactor
+}
+
+// New creates a redactor with the built-in fields and patterns
+func New() *Redactor {
+	r := &Redactor{
+		fields: make(map[string]string),
+	}
+	r.AddFields(defaultFields...)
+	if err := r.AddPatterns(defaultPatterns...); err != nil {
+		panic(fmt.Sprintf("invalid built-in redaction pattern: %v", err))
+	}
+	return r
+}
+
+// AddFields marks field names as sensitive. Names match case-insensitively and ignore '_' and
+// '-', so "master_user_password" matches "masterUserPassword".
+func (r *Redactor) AddFields(names ...string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	added := false
+	for _, name := range names {
+		key := normalize(name)
+		if key == "" {
+			continue
+		}
+		if _, exists := r.fields[key]; !exists {
+			r.fields[key] = name
+			added = true
+		}
+	}
+	if added {
+		r.rebuildFieldRegex()
+	}
+}
+
+// AddPatterns compiles and adds free text patterns
+func (r *Redactor) AddPatterns(expressions ...string) error {
+	compiled := make([]*regexp.Regexp, 0, len(expressions))
+	for _, expression := range expressions {
+		re, err := regexp.Compile(expression)
+		if err != nil {
+			return fmt.Errorf("invalid redaction pattern %q: %w", expression, err)
+		}
+		compiled = append(compiled, re)
+	}
+
+	r.mutex.Lock()
+	r.patterns = append(r.patterns, compiled...)
+	r.mutex.Unlock()
+	return nil
+}
+
+// LoadFile adds the fields and patterns of a redaction configuration file
+func (r *Redactor) LoadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var file PatternsFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("failed to parse redaction patterns file %s: %w", path, err)
+	}
+
+	r.AddFields(file.Fields...)
+	return r.AddPatterns(file.Patterns...)
+}
+
+// LoadConfigured loads the file named by REDACTION_PATTERNS_FILE, or the default patterns file
+// when it exists
+func (r *Redactor) LoadConfigured() error {
+	if path := os.Getenv(PatternsFileEnvVar); path != "" {
+		return r.LoadFile(path)
+	}
+
+	err := r.LoadFile(DefaultPatternsFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// RegisterSchema marks the input schema properties flagged "sensitive": true as sensitive fields,
+// including properties of nested objects and array items. It returns the names it registered.
+func (r *Redactor) RegisterSchema(schema map[string]interface{}) []string {
+	names := SensitiveProperties(schema)
+	r.AddFields(names...)
+	return names
+}
+
+// SensitiveProperties lists the properties of a JSON schema flagged "sensitive": true
+func SensitiveProperties(schema map[string]interface{}) []string {
+	var names []string
+
+	properties, _ := schema["properties"].(map[string]interface{})
+	for name, definition := range properties {
+		propertySchema, ok := definition.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if sensitive, _ := propertySchema["sensitive"].(bool); sensitive {
+			names = append(names, name)
+		}
+		names = append(names, SensitiveProperties(propertySchema)...)
+	}
+	if items, ok := schema["items"].(map[string]interface{}); ok {
+		names = append(names, SensitiveProperties(items)...)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// IsSensitive reports whether values of a field are masked
+func (r *Redactor) IsSensitive(field string) bool {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	_, sensitive := r.fields[normalize(field)]
+	return sensitive
+}
+
+// Field returns the value to emit for a field: the mask for sensitive fields, otherwise the
+// redacted value
+func (r *Redactor) Field(field string, value interface{}) interface{} {
+	if r.IsSensitive(field) {
+		if value == nil || value == "" {
+			return value
+		}
+		return Mask
+	}
+	return r.Value(value)
+}
+
+// Map returns a copy of the map with sensitive values masked. The input is never modified.
+func (r *Redactor) Map(m map[string]interface{}) map[string]interface{} {
+	if m == nil {
+		return nil
+	}
+
+	redacted := make(map[string]interface{}, len(m))
+	for key, value := range m {
+		redacted[key] = r.Field(key, value)
+	}
+	return redacted
+}
+
+// Value returns a copy of a value with sensitive content masked. Maps, slices, strings and
+// errors are redacted; other values are returned unchanged, use Structured for structs.
+func (r *Redactor) Value(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		return r.String(v)
+	case map[string]interface{}:
+		return r.Map(v)
+	case map[string]string:
+		redacted := make(map[string]string, len(v))
+		for key, item := range v {
+			if r.IsSensitive(key) && item != "" {
+				redacted[key] = Mask
+			} else {
+				redacted[key] = r.String(item)
+			}
+		}
+		return redacted
+	case []interface{}:
+		redacted := make([]interface{}, len(v))
+		for i, item := range v {
+			redacted[i] = r.Value(item)
+		}
+		return redacted
+	case []string:
+		redacted := make([]string, len(v))
+		for i, item := range v {
+			redacted[i] = r.String(item)
+		}
+		return redacted
+	case error:
+		message := v.Error()
+		if redacted := r.String(message); redacted != message {
+			return errors.New(redacted)
+		}
+		return v
+	default:
+		return value
+	}
+}
+
+// Structured converts any JSON serializable value into maps and slices and redacts it. Use it
+// for structs whose fields Value cannot see.
+func (r *Redactor) Structured(value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize value for redaction: %w", err)
+	}
+
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return nil, fmt.Errorf("failed to serialize value for redaction: %w", err)
+	}
+
+	return r.Value(generic), nil
+}
+
+// String masks the values of sensitive JSON fields and every pattern match in free text
+func (r *Redactor) String(s string) string {
+	if s == "" {
+		return s
+	}
+
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	if r.fieldRegex != nil {
+		s = maskMatches(r.fieldRegex, s)
+	}
+	for _, re := range r.patterns {
+		s = maskMatches(re, s)
+	}
+	return s
+}
+
+// rebuildFieldRegex compiles the JSON field matcher, callers hold the write lock
+func (r *Redactor) rebuildFieldRegex() {
+	names := make([]string, 0, len(r.fields))
+	for _, name := range r.fields {
+		names = append(names, regexp.QuoteMeta(name))
+	}
+	sort.Strings(names)
+
+	r.fieldRegex = regexp.MustCompile(`(?i)"(?:` + strings.Join(names, "|") + `)"\s*:\s*"(?P<secret>(?:[^"\\]|\\.)+)"`)
+}
+
+// maskMatches replaces the secret group of every match, or the whole match without one
+func maskMatches(re *regexp.Regexp, s string) string {
+	group := re.SubexpIndex(secretGroup)
+	if group < 0 {
+		return re.ReplaceAllString(s, Mask)
+	}
+
+	return re.ReplaceAllStringFunc(s, func(match string) string {
+		indexes := re.FindStringSubmatchIndex(match)
+		if indexes == nil || indexes[2*group] < 0 {
+			return Mask
+		}
+		return match[:indexes[2*group]] + Mask + match[indexes[2*group+1]:]
+	})
+}
+
+// normalize folds a field name for matching
+func normalize(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.ReplaceAll(name, "_", "")
+	return strings.ReplaceAll(name, "-", "")
+}
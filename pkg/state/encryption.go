@@ -0,0 +1,600 @@
+package state
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/versus-control/ai-infrastructure-agent/pkg/aws"
+)
+
+// Environment variables configuring encryption of the state file
+const (
+	// EncryptionEnvVar selects the key provider: "none" (default), "local" or "kms"
+	EncryptionEnvVar = "STATE_ENCRYPTION"
+
+	// EncryptionKeyFileEnvVar is the key file of the local provider, ".state.key" next to the
+	// state file when unset. The file holds one base64 encoded 32-byte key per line, the last
+	// one being active; older keys are kept to read files written before a rotation.
+	EncryptionKeyFileEnvVar = "STATE_ENCRYPTION_KEY_FILE"
+
+	// EncryptionKeyEnvVar holds a base64 encoded 32-byte key for the local provider and takes
+	// precedence over the key file. Rotation is not available with it.
+	EncryptionKeyEnvVar = "STATE_ENCRYPTION_KEY"
+
+	// KMSKeyIDEnvVar is the KMS key ID, ARN or alias used by the kms provider
+	KMSKeyIDEnvVar = "STATE_KMS_KEY_ID"
+)
+
+const (
+	// envelopeVersion is the version of the encrypted state file layout
+	envelopeVersion = 1
+
+	// envelopeAlgorithm encrypts the state with the data key
+	envelopeAlgorithm = "AES-256-GCM"
+
+	// dataKeySize is the size in bytes of data and local master keys
+	dataKeySize = 32
+)
+
+// stateAAD binds ciphertexts to their use as state files
+var stateAAD = []byte("ai-infrastructure-agent/state/v1")
+
+// kmsEncryptionContext is passed to KMS with every data key operation
+var kmsEncryptionContext = map[string]string{"purpose": "ai-infrastructure-agent-state"}
+
+// DataKey is a state encryption key together with its copy wrapped by the provider's master key
+type DataKey struct {
+	Plaintext []byte
+	Wrapped   []byte
+	KeyID     string
+}
+
+// KeyProvider wraps and unwraps the data keys that encrypt the state file
+type KeyProvider interface {
+	// Name identifies the provider in encrypted files
+	Name() string
+
+	// GenerateDataKey creates a new data key wrapped by the active master key
+	GenerateDataKey(ctx context.Context) (*DataKey, error)
+
+	// DecryptDataKey unwraps a data key using the master key it was wrapped with
+	DecryptDataKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error)
+}
+
+// MasterKeyRotator is implemented by providers that manage their own master keys
+type MasterKeyRotator interface {
+	// RotateMasterKey makes a new master key active, keeping older ones for decryption
+	RotateMasterKey() error
+
+	// ActiveKeyID returns the ID of the active master key, which another process may have
+	// rotated
+	ActiveKeyID() (string, error)
+}
+
+// encryptedState is the on-disk layout of an encrypted state file
+type encryptedState struct {
+	Encryption envelopeHeader `json:"encryption"`
+	Ciphertext string         `json:"ciphertext"`
+}
+
+// envelopeHeader describes how the state ciphertext was produced
+type envelopeHeader struct {
+	Version          int    `json:"version"`
+	Algorithm        string `json:"algorithm"`
+	Provider         string `json:"provider"`
+	KeyID            string `json:"keyId"`
+	EncryptedDataKey string `json:"encryptedDataKey"`
+}
+
+// NewKeyProviderFromEnv creates the key provider selected by STATE_ENCRYPTION. It returns nil
+// when encryption is disabled.
+func NewKeyProviderFromEnv(stateFile string, awsClient *aws.Client) (KeyProvider, error) {
+	mode := strings.ToLower(strings.TrimSpace(os.Getenv(EncryptionEnvVar)))
+
+	switch mode {
+	case "", "none":
+		return nil, nil
+	case "local":
+		if encodedKey := os.Getenv(EncryptionKeyEnvVar); encodedKey != "" {
+			return NewStaticKeyProvider(encodedKey)
+		}
+		keyFile := os.Getenv(EncryptionKeyFileEnvVar)
+		if keyFile == "" {
+			keyFile = filepath.Join(filepath.Dir(stateFile), ".state.key")
+		}
+		return NewLocalKeyProvider(keyFile)
+	case "kms":
+		keyID := os.Getenv(KMSKeyIDEnvVar)
+		if keyID == "" {
+			return nil, fmt.Errorf("%s=kms requires %s", EncryptionEnvVar, KMSKeyIDEnvVar)
+		}
+		if awsClient == nil {
+			return nil, fmt.Errorf("the kms state encryption provider requires an AWS client")
+		}
+		return NewKMSKeyProvider(awsClient, keyID), nil
+	default:
+		return nil, fmt.Errorf("unsupported %s %q, expected none, local or kms", EncryptionEnvVar, mode)
+	}
+}
+
+// ========== Local key provider ==========
+
+// keyFileLockTimeout bounds how long a rotation waits for another process rotating the same key
+// file
+const keyFileLockTimeout = 10 * time.Second
+
+// LocalKeyProvider wraps data keys with AES-256-GCM master keys kept in a local key file. The
+// file is read again when another process, e.g. state-crypt, rotated the master key.
+type LocalKeyProvider struct {
+	keyFile string
+	keys    [][]byte // oldest first, the last key is active
+	size    int64    // Size and modification time of the key file when it was read
+	modTime time.Time
+	mutex   sync.RWMutex
+}
+
+// NewLocalKeyProvider opens a key file, generating a first master key when it does not exist
+func NewLocalKeyProvider(keyFile string) (*LocalKeyProvider, error) {
+	p := &LocalKeyProvider{keyFile: keyFile}
+
+	if _, err := os.Stat(keyFile); errors.Is(err, os.ErrNotExist) {
+		if err := os.MkdirAll(filepath.Dir(keyFile), 0700); err != nil {
+			return nil, fmt.Errorf("failed to create state key directory: %w", err)
+		}
+		if err := createKeyFile(keyFile); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := p.load(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// NewStaticKeyProvider creates a local provider with a single master key and no key file
+func NewStaticKeyProvider(encodedKey string) (*LocalKeyProvider, error) {
+	key, err := decodeKey(encodedKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", EncryptionKeyEnvVar, err)
+	}
+	return &LocalKeyProvider{keys: [][]byte{key}}, nil
+}
+
+// Name identifies the provider in encrypted files
+func (p *LocalKeyProvider) Name() string {
+	return "local"
+}
+
+// ActiveKeyID returns the ID of the master key new data keys are wrapped with, reading the key
+// file again if it changed
+func (p *LocalKeyProvider) ActiveKeyID() (string, error) {
+	if err := p.refresh(); err != nil {
+		return "", err
+	}
+
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	return localKeyID(p.keys[len(p.keys)-1]), nil
+}
+
+// GenerateDataKey creates a random data key wrapped by the active master key
+func (p *LocalKeyProvider) GenerateDataKey(ctx context.Context) (*DataKey, error) {
+	if err := p.refresh(); err != nil {
+		return nil, err
+	}
+
+	p.mutex.RLock()
+	masterKey := p.keys[len(p.keys)-1]
+	p.mutex.RUnlock()
+
+	plaintext := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, plaintext); err != nil {
+		return nil, fmt.Errorf("failed to generate data key: %w", err)
+	}
+
+	keyID := localKeyID(masterKey)
+	wrapped, err := seal(masterKey, plaintext, []byte(keyID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap data key: %w", err)
+	}
+
+	return &DataKey{Plaintext: plaintext, Wrapped: wrapped, KeyID: keyID}, nil
+}
+
+// DecryptDataKey unwraps a data key with the master key it was wrapped with. The key file is
+// read again for unknown keys, which another process may have added.
+func (p *LocalKeyProvider) DecryptDataKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	key, ok := p.masterKey(keyID)
+	if !ok && p.keyFile != "" {
+		if err := p.load(); err != nil {
+			return nil, err
+		}
+		key, ok = p.masterKey(keyID)
+	}
+	if !ok {
+		return nil, fmt.Errorf("master key %s is not in the local key file", keyID)
+	}
+
+	plaintext, err := open(key, wrapped, []byte(keyID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+	return plaintext, nil
+}
+
+// RotateMasterKey generates a new active master key and appends it to the key file. The file
+// is locked while it is rewritten, so keys added by a concurrent rotation are never lost.
+func (p *LocalKeyProvider) RotateMasterKey() error {
+	if p.keyFile == "" {
+		return fmt.Errorf("master key rotation requires a key file, unset %s to use one", EncryptionKeyEnvVar)
+	}
+
+	key := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return fmt.Errorf("failed to generate master key: %w", err)
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	unlock, err := lockKeyFile(p.keyFile)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	// Append to the keys on disk rather than the cached ones, which may be out of date
+	keys, _, _, err := readKeyFile(p.keyFile)
+	if err != nil {
+		return err
+	}
+	keys = append(keys, key)
+
+	tmpFile, err := writeTempKeyFile(p.keyFile, keys)
+	if err != nil {
+		return err
+	}
+	if err := os.Rename(tmpFile, p.keyFile); err != nil {
+		os.Remove(tmpFile)
+		return fmt.Errorf("failed to write state key file: %w", err)
+	}
+
+	p.keys = keys
+	if info, err := os.Stat(p.keyFile); err == nil {
+		p.size, p.modTime = info.Size(), info.ModTime()
+	}
+	return nil
+}
+
+// masterKey finds a cached master key by ID
+func (p *LocalKeyProvider) masterKey(keyID string) ([]byte, bool) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	for _, key := range p.keys {
+		if localKeyID(key) == keyID {
+			return key, true
+		}
+	}
+	return nil, false
+}
+
+// refresh reads the key file again when it changed since it was last read
+func (p *LocalKeyProvider) refresh() error {
+	if p.keyFile == "" {
+		return nil
+	}
+
+	info, err := os.Stat(p.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to read state key file: %w", err)
+	}
+
+	p.mutex.RLock()
+	changed := info.Size() != p.size || !info.ModTime().Equal(p.modTime)
+	p.mutex.RUnlock()
+	if !changed {
+		return nil
+	}
+	return p.load()
+}
+
+// load reads the master keys from the key file
+func (p *LocalKeyProvider) load() error {
+	keys, size, modTime, err := readKeyFile(p.keyFile)
+	if err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return fmt.Errorf("state key file %s contains no keys", p.keyFile)
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.keys, p.size, p.modTime = keys, size, modTime
+	return nil
+}
+
+// readKeyFile decodes the keys of a key file, returning no keys when it does not exist
+func readKeyFile(keyFile string) ([][]byte, int64, time.Time, error) {
+	file, err := os.Open(keyFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, 0, time.Time{}, nil
+	}
+	if err != nil {
+		return nil, 0, time.Time{}, fmt.Errorf("failed to read state key file: %w", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, 0, time.Time{}, fmt.Errorf("failed to read state key file: %w", err)
+	}
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, 0, time.Time{}, fmt.Errorf("failed to read state key file: %w", err)
+	}
+
+	var keys [][]byte
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		key, err := decodeKey(line)
+		if err != nil {
+			return nil, 0, time.Time{}, fmt.Errorf("invalid key in state key file %s: %w", keyFile, err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, info.Size(), info.ModTime(), nil
+}
+
+// createKeyFile generates the first master key of a key file. Several processes may start at
+// once, so the key is written to a temporary file and linked into place, which fails if another
+// process won the race; its key file is used instead.
+func createKeyFile(keyFile string) error {
+	key := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return fmt.Errorf("failed to generate master key: %w", err)
+	}
+
+	tmpFile, err := writeTempKeyFile(keyFile, [][]byte{key})
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile)
+
+	if err := os.Link(tmpFile, keyFile); err != nil && !errors.Is(err, os.ErrExist) {
+		return fmt.Errorf("failed to write state key file: %w", err)
+	}
+	return nil
+}
+
+// writeTempKeyFile writes keys to a new temporary file next to the key file and returns its
+// name. Writing there first means a crash never loses the existing keys.
+func writeTempKeyFile(keyFile string, keys [][]byte) (string, error) {
+	var contents bytes.Buffer
+	for _, k := range keys {
+		contents.WriteString(base64.StdEncoding.EncodeToString(k))
+		contents.WriteString("\n")
+	}
+
+	tmpFile, err := os.CreateTemp(filepath.Dir(keyFile), filepath.Base(keyFile)+".*.tmp")
+	if err != nil {
+		return "", fmt.Errorf("failed to write state key file: %w", err)
+	}
+	_, err = tmpFile.Write(contents.Bytes())
+	if syncErr := tmpFile.Sync(); err == nil {
+		err = syncErr
+	}
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmpFile.Name())
+		return "", fmt.Errorf("failed to write state key file: %w", err)
+	}
+	return tmpFile.Name(), nil
+}
+
+// lockKeyFile takes the lock serializing rotations of a key file across processes and returns
+// the function releasing it. A lock left behind by a crashed process must be removed by hand.
+func lockKeyFile(keyFile string) (func(), error) {
+	lockFile := keyFile + ".lock"
+	deadline := time.Now().Add(keyFileLockTimeout)
+	for {
+		file, err := os.OpenFile(lockFile, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err == nil {
+			file.Close()
+			return func() { os.Remove(lockFile) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("failed to lock state key file: %w", err)
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("state key file %s is locked by another rotation; remove %s if none is running", keyFile, lockFile)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// localKeyID derives a stable, non-secret identifier for a master key
+func localKeyID(key []byte) string {
+	sum := sha256.Sum256(key)
+	return "local:" + hex.EncodeToString(sum[:8])
+}
+
+// decodeKey decodes a base64 encoded 32-byte key
+func decodeKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("key is not valid base64: %w", err)
+	}
+	if len(key) != dataKeySize {
+		return nil, fmt.Errorf("key must be %d bytes, got %d", dataKeySize, len(key))
+	}
+	return key, nil
+}
+
+// ========== KMS key provider ==========
+
+// KMSKeyProvider wraps data keys with an AWS KMS key
+type KMSKeyProvider struct {
+	client *aws.Client
+	keyID  string
+}
+
+// NewKMSKeyProvider creates a provider generating data keys under a KMS key
+func NewKMSKeyProvider(client *aws.Client, keyID string) *KMSKeyProvider {
+	return &KMSKeyProvider{
+		client: client,
+		keyID:  keyID,
+	}
+}
+
+// Name identifies the provider in encrypted files
+func (p *KMSKeyProvider) Name() string {
+	return "kms"
+}
+
+// GenerateDataKey creates a data key under the configured KMS key
+func (p *KMSKeyProvider) GenerateDataKey(ctx context.Context) (*DataKey, error) {
+	plaintext, wrapped, keyArn, err := p.client.GenerateDataKey(ctx, p.keyID, kmsEncryptionContext)
+	if err != nil {
+		return nil, err
+	}
+	return &DataKey{Plaintext: plaintext, Wrapped: wrapped, KeyID: keyArn}, nil
+}
+
+// DecryptDataKey decrypts a data key with KMS. Files written under a previous KMS key remain
+// readable as long as the agent may still use that key.
+func (p *KMSKeyProvider) DecryptDataKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	return p.client.DecryptDataKey(ctx, keyID, wrapped, kmsEncryptionContext)
+}
+
+// ========== Envelope ==========
+
+// IsEncrypted reports whether state file contents are an encrypted envelope
+func IsEncrypted(data []byte) bool {
+	var envelope encryptedState
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return false
+	}
+	return envelope.Encryption.Version > 0 && envelope.Ciphertext != ""
+}
+
+// EncryptState seals serialized state with a data key and returns the envelope to write
+func EncryptState(dataKey *DataKey, provider KeyProvider, plaintext []byte) ([]byte, error) {
+	ciphertext, err := seal(dataKey.Plaintext, plaintext, stateAAD)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt state: %w", err)
+	}
+
+	envelope := encryptedState{
+		Encryption: envelopeHeader{
+			Version:          envelopeVersion,
+			Algorithm:        envelopeAlgorithm,
+			Provider:         provider.Name(),
+			KeyID:            dataKey.KeyID,
+			EncryptedDataKey: base64.StdEncoding.EncodeToString(dataKey.Wrapped),
+		},
+		Ciphertext: base64.StdEncoding.EncodeToString(ciphertext),
+	}
+
+	data, err := json.MarshalIndent(envelope, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal encrypted state: %w", err)
+	}
+	return data, nil
+}
+
+// DecryptState opens an encrypted envelope and returns the serialized state with its data key
+func DecryptState(ctx context.Context, provider KeyProvider, data []byte) ([]byte, *DataKey, error) {
+	var envelope encryptedState
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse encrypted state: %w", err)
+	}
+
+	header := envelope.Encryption
+	if header.Version != envelopeVersion || header.Algorithm != envelopeAlgorithm {
+		return nil, nil, fmt.Errorf("unsupported state encryption version %d (%s)", header.Version, header.Algorithm)
+	}
+	if header.Provider != provider.Name() {
+		return nil, nil, fmt.Errorf("state file was encrypted with the %s provider, but %s is configured", header.Provider, provider.Name())
+	}
+
+	wrapped, err := base64.StdEncoding.DecodeString(header.EncryptedDataKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid encrypted data key: %w", err)
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(envelope.Ciphertext)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid state ciphertext: %w", err)
+	}
+
+	dataKey, err := provider.DecryptDataKey(ctx, header.KeyID, wrapped)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	plaintext, err := open(dataKey, ciphertext, stateAAD)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decrypt state: %w", err)
+	}
+
+	return plaintext, &DataKey{Plaintext: dataKey, Wrapped: wrapped, KeyID: header.KeyID}, nil
+}
+
+// seal encrypts with AES-256-GCM, prefixing the random nonce
+func seal(key, plaintext, aad []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return aead.Seal(nonce, nonce, plaintext, aad), nil
+}
+
+// open decrypts the output of seal
+func open(key, sealed, aad []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonceSize := aead.NonceSize()
+	if len(sealed) < nonceSize {
+		return nil, fmt.Errorf("ciphertext is too short")
+	}
+	return aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], aad)
+}
+
+// newGCM creates an AES-GCM cipher for a 32-byte key
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,227 @@
+package state
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/versus-control/ai-infrastructure-agent/internal/logging"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/types"
+)
+
+func newEncryptedManager(t *testing.T, dir string) (*Manager, *LocalKeyProvider) {
+	t.Helper()
+
+	provider, err := NewLocalKeyProvider(filepath.Join(dir, ".state.key"))
+	if err != nil {
+		t.Fatalf("failed to create local key provider: %v", err)
+	}
+
+	manager := NewManager(filepath.Join(dir, "infrastructure-state.json"), "us-west-2", logging.NewLogger("test", "error"))
+	manager.SetEncryption(provider)
+	return manager, provider
+}
+
+func TestEncryptedStateRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	manager, _ := newEncryptedManager(t, dir)
+	if err := manager.LoadState(ctx); err != nil {
+		t.Fatalf("LoadState failed: %v", err)
+	}
+	if err := manager.AddResource(ctx, &types.ResourceState{
+		ID:         "vpc-0123456789abcdef0",
+		Type:       "vpc",
+		Name:       "production-vpc",
+		Status:     "created",
+		Properties: map[string]interface{}{"cidrBlock": "10.0.0.0/16"},
+	}); err != nil {
+		t.Fatalf("AddResource failed: %v", err)
+	}
+
+	data, err := os.ReadFile(manager.stateFile)
+	if err != nil {
+		t.Fatalf("failed to read state file: %v", err)
+	}
+	if !IsEncrypted(data) {
+		t.Fatal("state file was written in clear text")
+	}
+	if strings.Contains(string(data), "production-vpc") {
+		t.Fatal("state file contents are readable without the key")
+	}
+
+	reloaded, _ := newEncryptedManager(t, dir)
+	if err := reloaded.LoadState(ctx); err != nil {
+		t.Fatalf("LoadState of encrypted state failed: %v", err)
+	}
+	if _, exists := reloaded.GetResource("vpc-0123456789abcdef0"); !exists {
+		t.Fatal("resource missing after decrypting the state file")
+	}
+
+	plain := NewManager(manager.stateFile, "us-west-2", logging.NewLogger("test", "error"))
+	if err := plain.LoadState(ctx); err == nil {
+		t.Fatal("expected loading an encrypted state file without a key provider to fail")
+	}
+}
+
+func TestEncryptionKeyRotation(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	manager, provider := newEncryptedManager(t, dir)
+	if err := manager.LoadState(ctx); err != nil {
+		t.Fatalf("LoadState failed: %v", err)
+	}
+	oldKeyID := manager.dataKey.KeyID
+
+	if err := manager.RotateEncryptionKey(ctx); err != nil {
+		t.Fatalf("RotateEncryptionKey failed: %v", err)
+	}
+	if len(provider.keys) != 2 {
+		t.Fatalf("expected 2 master keys after rotation, got %d", len(provider.keys))
+	}
+	if manager.dataKey.KeyID == oldKeyID {
+		t.Fatal("state was not re-encrypted under the new master key")
+	}
+
+	reloaded, _ := newEncryptedManager(t, dir)
+	if err := reloaded.LoadState(ctx); err != nil {
+		t.Fatalf("LoadState after rotation failed: %v", err)
+	}
+}
+
+func TestKeyRotationByAnotherProcess(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	server, _ := newEncryptedManager(t, dir)
+	if err := server.LoadState(ctx); err != nil {
+		t.Fatalf("LoadState failed: %v", err)
+	}
+
+	// state-crypt rotates the key file while the server keeps running
+	cli, _ := newEncryptedManager(t, dir)
+	if err := cli.LoadState(ctx); err != nil {
+		t.Fatalf("LoadState failed: %v", err)
+	}
+	if err := cli.RotateEncryptionKey(ctx); err != nil {
+		t.Fatalf("RotateEncryptionKey failed: %v", err)
+	}
+
+	if err := server.LoadState(ctx); err != nil {
+		t.Fatalf("LoadState after a rotation by another process failed: %v", err)
+	}
+	if err := server.SaveState(ctx); err != nil {
+		t.Fatalf("SaveState failed: %v", err)
+	}
+	if server.dataKey.KeyID != cli.dataKey.KeyID {
+		t.Fatal("state was saved under the master key that was rotated out")
+	}
+
+	// A save with a data key cached before a rotation moves to the new master key as well
+	stale := server.dataKey
+	if err := cli.RotateEncryptionKey(ctx); err != nil {
+		t.Fatalf("RotateEncryptionKey failed: %v", err)
+	}
+	if err := server.SaveState(ctx); err != nil {
+		t.Fatalf("SaveState failed: %v", err)
+	}
+	if server.dataKey == stale || server.dataKey.KeyID != cli.dataKey.KeyID {
+		t.Fatal("cached data key was kept after the master key changed")
+	}
+}
+
+func TestConcurrentKeyRotations(t *testing.T) {
+	ctx := context.Background()
+	keyFile := filepath.Join(t.TempDir(), "keys", ".state.key")
+
+	// Every process creating the key file at once ends up with the same first key
+	const processes = 8
+	providers := make([]*LocalKeyProvider, processes)
+	var wg sync.WaitGroup
+	for i := range providers {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			provider, err := NewLocalKeyProvider(keyFile)
+			if err != nil {
+				t.Errorf("NewLocalKeyProvider failed: %v", err)
+				return
+			}
+			providers[i] = provider
+		}(i)
+	}
+	wg.Wait()
+	if t.Failed() {
+		return
+	}
+
+	// Each provider encrypts under its own rotated key
+	dataKeys := make([]*DataKey, processes)
+	for i, provider := range providers {
+		wg.Add(1)
+		go func(i int, provider *LocalKeyProvider) {
+			defer wg.Done()
+			if err := provider.RotateMasterKey(); err != nil {
+				t.Errorf("RotateMasterKey failed: %v", err)
+				return
+			}
+			dataKey, err := provider.GenerateDataKey(ctx)
+			if err != nil {
+				t.Errorf("GenerateDataKey failed: %v", err)
+				return
+			}
+			dataKeys[i] = dataKey
+		}(i, provider)
+	}
+	wg.Wait()
+	if t.Failed() {
+		return
+	}
+
+	reopened, err := NewLocalKeyProvider(keyFile)
+	if err != nil {
+		t.Fatalf("NewLocalKeyProvider failed: %v", err)
+	}
+	if len(reopened.keys) != processes+1 {
+		t.Fatalf("key file holds %d keys, want %d", len(reopened.keys), processes+1)
+	}
+	for _, dataKey := range dataKeys {
+		if _, err := reopened.DecryptDataKey(ctx, dataKey.KeyID, dataKey.Wrapped); err != nil {
+			t.Fatalf("data key wrapped by a concurrently rotated key is lost: %v", err)
+		}
+	}
+
+	leftovers, _ := filepath.Glob(filepath.Join(filepath.Dir(keyFile), "*.tmp"))
+	if len(leftovers) > 0 {
+		t.Fatalf("temporary key files left behind: %v", leftovers)
+	}
+}
+
+func TestClearTextStateIsEncryptedOnLoad(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	stateFile := filepath.Join(dir, "infrastructure-state.json")
+
+	plain := NewManager(stateFile, "us-west-2", logging.NewLogger("test", "error"))
+	if err := plain.LoadState(ctx); err != nil {
+		t.Fatalf("LoadState failed: %v", err)
+	}
+
+	manager, _ := newEncryptedManager(t, dir)
+	if err := manager.LoadState(ctx); err != nil {
+		t.Fatalf("LoadState of clear text state failed: %v", err)
+	}
+
+	data, err := os.ReadFile(stateFile)
+	if err != nil {
+		t.Fatalf("failed to read state file: %v", err)
+	}
+	if !IsEncrypted(data) {
+		t.Fatal("clear text state file was not encrypted when loaded with encryption enabled")
+	}
+}
@@ -7,9 +7,13 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/versus-control/ai-infrastructure-agent/internal/logging"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/aws"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/redact"
 	"github.com/versus-control/ai-infrastructure-agent/pkg/types"
 )
 
@@ -18,8 +22,28 @@ type Manager struct {
 	stateFile string
 	logger    *logging.Logger
 	state     *types.InfrastructureState
+
+	// Envelope encryption of the state file, disabled when encryption is nil
+	encryption    KeyProvider
+	dataKey       *DataKey
+	encryptionErr error
+
+	// Listeners notified of resource changes and recorded executions, see OnChange and OnExecution
+	listeners          []ChangeListener
+	executionListeners []ExecutionListener
+	listenersMutex     sync.RWMutex
 }
 
+// MaxExecutionHistory is how many plan executions the state keeps, the oldest are dropped
+const MaxExecutionHistory = 50
+
+// ChangeListener is called after a resource of the state changed. changeType is create,
+// update, delete or drift; for delete the resource is the one removed from the state.
+type ChangeListener func(resource *types.ResourceState, changeType string)
+
+// ExecutionListener is called after a plan execution was recorded
+type ExecutionListener func(execution *types.PlanExecution)
+
 // NewManager creates a new state manager
 func NewManager(stateFile string, region string, logger *logging.Logger) *Manager {
 	return &Manager{
@@ -36,10 +60,67 @@ func NewManager(stateFile string, region string, logger *logging.Logger) *Manage
 	}
 }
 
+// EnableEncryptionFromEnv configures state file encryption from STATE_ENCRYPTION. When the
+// configuration is invalid the state is neither loaded nor saved, so it is never written in
+// clear text by mistake.
+func (m *Manager) EnableEncryptionFromEnv(awsClient *aws.Client) error {
+	provider, err := NewKeyProviderFromEnv(m.stateFile, awsClient)
+	if err != nil {
+		m.encryptionErr = err
+		return err
+	}
+	m.SetEncryption(provider)
+	return nil
+}
+
+// SetEncryption sets the key provider encrypting the state file, nil disables encryption
+func (m *Manager) SetEncryption(provider KeyProvider) {
+	m.encryption = provider
+	m.dataKey = nil
+	m.encryptionErr = nil
+
+	if provider != nil {
+		m.logger.WithField("provider", provider.Name()).Info("State file encryption enabled")
+	}
+}
+
+// RotateEncryptionKey re-encrypts the state file with a new data key. Providers managing their
+// own master keys rotate the master key first; for KMS, set a new STATE_KMS_KEY_ID to move the
+// state to another key.
+func (m *Manager) RotateEncryptionKey(ctx context.Context) error {
+	if m.encryptionErr != nil {
+		return fmt.Errorf("state encryption is misconfigured: %w", m.encryptionErr)
+	}
+	if m.encryption == nil {
+		return fmt.Errorf("state encryption is not enabled, set %s", EncryptionEnvVar)
+	}
+
+	if rotator, ok := m.encryption.(MasterKeyRotator); ok {
+		if err := rotator.RotateMasterKey(); err != nil {
+			return fmt.Errorf("failed to rotate master key: %w", err)
+		}
+	}
+
+	m.dataKey = nil
+	if err := m.SaveState(ctx); err != nil {
+		return err
+	}
+
+	m.logger.WithFields(map[string]interface{}{
+		"provider": m.encryption.Name(),
+		"key_id":   m.dataKey.KeyID,
+	}).Info("State encryption key rotated")
+	return nil
+}
+
 // LoadState loads infrastructure state from file
 func (m *Manager) LoadState(ctx context.Context) error {
 	m.logger.WithField("state_file", m.stateFile).Info("Loading infrastructure state from file")
 
+	if m.encryptionErr != nil {
+		return fmt.Errorf("state encryption is misconfigured: %w", m.encryptionErr)
+	}
+
 	// Create state directory if it doesn't exist
 	if err := os.MkdirAll(filepath.Dir(m.stateFile), 0755); err != nil {
 		return fmt.Errorf("failed to create state directory: %w", err)
@@ -59,6 +140,22 @@ func (m *Manager) LoadState(ctx context.Context) error {
 
 	m.logger.WithField("file_size", len(data)).Info("Read state file data")
 
+	// Decrypt encrypted state files; clear text files are encrypted on load when enabled
+	migrate := false
+	if IsEncrypted(data) {
+		if m.encryption == nil {
+			return fmt.Errorf("state file is encrypted, set %s to load it", EncryptionEnvVar)
+		}
+		plaintext, dataKey, err := DecryptState(ctx, m.encryption, data)
+		if err != nil {
+			return err
+		}
+		data = plaintext
+		m.dataKey = dataKey
+	} else if m.encryption != nil {
+		migrate = true
+	}
+
 	// Create a new state object to ensure clean loading
 	newState := &types.InfrastructureState{}
 
@@ -74,6 +171,11 @@ func (m *Manager) LoadState(ctx context.Context) error {
 		"resource_count": len(m.state.Resources),
 		"resources":      getResourceKeys(m.state.Resources),
 	}).Info("Infrastructure state loaded successfully")
+
+	if migrate {
+		m.logger.Info("Encrypting clear text state file")
+		return m.SaveState(ctx)
+	}
 	return nil
 }
 
@@ -90,6 +192,10 @@ func getResourceKeys(resources map[string]*types.ResourceState) []string {
 func (m *Manager) SaveState(ctx context.Context) error {
 	m.logger.WithField("state_file", m.stateFile).Debug("Saving infrastructure state")
 
+	if m.encryptionErr != nil {
+		return fmt.Errorf("state encryption is misconfigured: %w", m.encryptionErr)
+	}
+
 	m.state.LastUpdated = time.Now()
 
 	// Marshal state to JSON
@@ -98,6 +204,28 @@ func (m *Manager) SaveState(ctx context.Context) error {
 		return fmt.Errorf("failed to marshal state: %w", err)
 	}
 
+	if m.encryption != nil {
+		// A master key rotated by another process replaces the cached data key, which is
+		// still wrapped by the previous master key
+		if rotator, ok := m.encryption.(MasterKeyRotator); ok && m.dataKey != nil {
+			activeKeyID, err := rotator.ActiveKeyID()
+			if err != nil {
+				return fmt.Errorf("failed to read the active state master key: %w", err)
+			}
+			if activeKeyID != m.dataKey.KeyID {
+				m.dataKey = nil
+			}
+		}
+		if m.dataKey == nil {
+			if m.dataKey, err = m.encryption.GenerateDataKey(ctx); err != nil {
+				return fmt.Errorf("failed to generate state data key: %w", err)
+			}
+		}
+		if data, err = EncryptState(m.dataKey, m.encryption, data); err != nil {
+			return err
+		}
+	}
+
 	// Write to temporary file first
 	tempFile := m.stateFile + ".tmp"
 	if err := os.WriteFile(tempFile, data, 0644); err != nil {
@@ -124,14 +252,29 @@ func (m *Manager) AddResource(ctx context.Context, resource *types.ResourceState
 		"resource_id":   resource.ID,
 		"resource_type": resource.Type,
 		"resource_name": resource.Name,
+		"region":        resource.Region,
 	}).Info("Adding resource to state")
 
+	// Resources without an explicit region live in the state's default region
+	if resource.Region == "" {
+		resource.Region = m.state.Region
+	}
+	m.trackRegion(resource.Region)
+
+	// Secrets never reach the state file
+	resource.Properties = redact.Default().Map(resource.Properties)
+
 	// Calculate checksum
 	resource.Checksum = m.calculateChecksum(resource)
 	resource.CreatedAt = time.Now()
 	resource.UpdatedAt = time.Now()
 
+	changeType := "create"
+	if _, exists := m.state.Resources[resource.ID]; exists {
+		changeType = "update"
+	}
 	m.state.Resources[resource.ID] = resource
+	m.notifyChange(resource, changeType)
 
 	return m.SaveState(ctx)
 }
@@ -146,20 +289,23 @@ func (m *Manager) UpdateResource(ctx context.Context, resourceID string, updates
 	m.logger.WithField("resource_id", resourceID).Info("Updating resource in state")
 
 	// Apply updates
+	redactor := redact.Default()
 	for key, value := range updates {
-		resource.Properties[key] = value
+		resource.Properties[key] = redactor.Field(key, value)
 	}
 
 	// Update metadata
 	resource.UpdatedAt = time.Now()
 	resource.Checksum = m.calculateChecksum(resource)
+	m.notifyChange(resource, "update")
 
 	return m.SaveState(ctx)
 }
 
 // RemoveResource removes a resource from the state
 func (m *Manager) RemoveResource(ctx context.Context, resourceID string) error {
-	if _, exists := m.state.Resources[resourceID]; !exists {
+	resource, exists := m.state.Resources[resourceID]
+	if !exists {
 		return fmt.Errorf("resource %s not found in state", resourceID)
 	}
 
@@ -177,10 +323,131 @@ func (m *Manager) RemoveResource(ctx context.Context, resourceID string) error {
 			}
 		}
 	}
+	m.notifyChange(resource, "delete")
 
 	return m.SaveState(ctx)
 }
 
+// OnChange registers a listener for resource changes
+func (m *Manager) OnChange(listener ChangeListener) {
+	m.listenersMutex.Lock()
+	defer m.listenersMutex.Unlock()
+	m.listeners = append(m.listeners, listener)
+}
+
+// notifyChange calls the change listeners
+func (m *Manager) notifyChange(resource *types.ResourceState, changeType string) {
+	m.listenersMutex.RLock()
+	listeners := m.listeners
+	m.listenersMutex.RUnlock()
+
+	for _, listener := range listeners {
+		listener(resource, changeType)
+	}
+}
+
+// OnExecution registers a listener for recorded plan executions
+func (m *Manager) OnExecution(listener ExecutionListener) {
+	m.listenersMutex.Lock()
+	defer m.listenersMutex.Unlock()
+	m.executionListeners = append(m.executionListeners, listener)
+}
+
+// RecordExecution adds a plan execution to the execution history, replacing an earlier record
+// of the same execution. Beyond MaxExecutionHistory the oldest executions are dropped.
+func (m *Manager) RecordExecution(ctx context.Context, execution *types.PlanExecution) error {
+	if execution.ID == "" {
+		return fmt.Errorf("execution has no ID")
+	}
+
+	m.logger.WithFields(map[string]interface{}{
+		"execution_id": execution.ID,
+		"status":       execution.Status,
+		"steps":        len(execution.Steps),
+	}).Info("Recording plan execution")
+
+	// The caller keeps using its execution, so a redacted copy is recorded
+	execution = redactedExecution(execution)
+
+	if m.state.Executions == nil {
+		m.state.Executions = make(map[string]*types.PlanExecution)
+	}
+	m.state.Executions[execution.ID] = execution
+
+	if executions := m.ListExecutions(); len(executions) > MaxExecutionHistory {
+		for _, expired := range executions[MaxExecutionHistory:] {
+			delete(m.state.Executions, expired.ID)
+		}
+	}
+
+	m.listenersMutex.RLock()
+	listeners := m.executionListeners
+	m.listenersMutex.RUnlock()
+	for _, listener := range listeners {
+		listener(execution)
+	}
+
+	return m.SaveState(ctx)
+}
+
+// redactedExecution returns a deep copy of an execution with secrets masked. Step outputs hold
+// tool responses and changes hold resource states, either of which may carry secrets.
+func redactedExecution(execution *types.PlanExecution) *types.PlanExecution {
+	redactor := redact.Default()
+
+	copied := *execution
+	if execution.CompletedAt != nil {
+		completedAt := *execution.CompletedAt
+		copied.CompletedAt = &completedAt
+	}
+	if execution.Principal != nil {
+		principal := *execution.Principal
+		copied.Principal = &principal
+	}
+	copied.Errors = append([]string(nil), execution.Errors...)
+
+	copied.Steps = make([]*types.ExecutionStep, len(execution.Steps))
+	for i, step := range execution.Steps {
+		if step == nil {
+			continue
+		}
+		stepCopy := *step
+		stepCopy.Output = redactor.Map(step.Output)
+		copied.Steps[i] = &stepCopy
+	}
+
+	copied.Changes = make([]*types.ChangeDetection, len(execution.Changes))
+	for i, change := range execution.Changes {
+		if change == nil {
+			continue
+		}
+		changeCopy := *change
+		changeCopy.OldState = redactor.Map(change.OldState)
+		changeCopy.NewState = redactor.Map(change.NewState)
+		copied.Changes[i] = &changeCopy
+	}
+
+	return &copied
+}
+
+// GetExecution returns a recorded plan execution
+func (m *Manager) GetExecution(executionID string) (*types.PlanExecution, bool) {
+	execution, exists := m.state.Executions[executionID]
+	return execution, exists
+}
+
+// ListExecutions returns the recorded plan executions, most recent first
+func (m *Manager) ListExecutions() []*types.PlanExecution {
+	executions := make([]*types.PlanExecution, 0, len(m.state.Executions))
+	for _, execution := range m.state.Executions {
+		executions = append(executions, execution)
+	}
+	sort.Slice(executions, func(i, j int) bool {
+		return executions[i].StartedAt.After(executions[j].StartedAt)
+	})
+	return executions
+}
+
 // GetResource returns a resource from the state
 func (m *Manager) GetResource(resourceID string) (*types.ResourceState, bool) {
 	resource, exists := m.state.Resources[resourceID]
@@ -198,6 +465,53 @@ func (m *Manager) ListResources(resourceType string) []*types.ResourceState {
 	return resources
 }
 
+// ListResourcesByRegion returns all resources in a specific region. Resources
+// recorded before regions were tracked are treated as default-region resources.
+func (m *Manager) ListResourcesByRegion(region string) []*types.ResourceState {
+	var resources []*types.ResourceState
+	for _, resource := range m.state.Resources {
+		if m.resourceRegion(resource) == region {
+			resources = append(resources, resource)
+		}
+	}
+	return resources
+}
+
+// GroupResourcesByRegion returns resource IDs grouped by region
+func (m *Manager) GroupResourcesByRegion() map[string][]string {
+	grouped := make(map[string][]string)
+	for id, resource := range m.state.Resources {
+		region := m.resourceRegion(resource)
+		grouped[region] = append(grouped[region], id)
+	}
+	for region := range grouped {
+		sort.Strings(grouped[region])
+	}
+	return grouped
+}
+
+// resourceRegion returns the region of a resource, falling back to the state's default region
+func (m *Manager) resourceRegion(resource *types.ResourceState) string {
+	if resource.Region != "" {
+		return resource.Region
+	}
+	return m.state.Region
+}
+
+// trackRegion records a region in the state's region list
+func (m *Manager) trackRegion(region string) {
+	if region == "" {
+		return
+	}
+	for _, existing := range m.state.Regions {
+		if existing == region {
+			return
+		}
+	}
+	m.state.Regions = append(m.state.Regions, region)
+	sort.Strings(m.state.Regions)
+}
+
 // AddDependency adds a dependency relationship between resources
 func (m *Manager) AddDependency(ctx context.Context, resourceID, dependsOn string) error {
 	m.logger.WithFields(map[string]interface{}{
@@ -275,6 +589,7 @@ func (m *Manager) DetectDrift(ctx context.Context, actualState map[string]interf
 
 	if actualChecksum != resource.Checksum {
 		m.logger.WithField("resource_id", resourceID).Info("Drift detected in resource")
+		m.notifyChange(resource, "drift")
 
 		return &types.ChangeDetection{
 			Resource:   resourceID,
@@ -0,0 +1,51 @@
+package state
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/versus-control/ai-infrastructure-agent/internal/logging"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/redact"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/types"
+)
+
+func TestRecordExecutionKeepsCallerExecution(t *testing.T) {
+	ctx := context.Background()
+	manager := NewManager(filepath.Join(t.TempDir(), "infrastructure-state.json"), "us-west-2", logging.NewLogger("test", "error"))
+	if err := manager.LoadState(ctx); err != nil {
+		t.Fatalf("LoadState failed: %v", err)
+	}
+
+	step := &types.ExecutionStep{ID: "step-db", Status: "completed", Output: map[string]interface{}{
+		"dbInstanceId":       "orders",
+		"masterUserPassword": "hunter22",
+	}}
+	execution := &types.PlanExecution{ID: "execution-1", Status: "running", Steps: []*types.ExecutionStep{step}}
+
+	if err := manager.RecordExecution(ctx, execution); err != nil {
+		t.Fatalf("RecordExecution failed: %v", err)
+	}
+	if step.Output["masterUserPassword"] != "hunter22" {
+		t.Fatal("the caller's step output was redacted")
+	}
+
+	recorded, exists := manager.GetExecution("execution-1")
+	if !exists {
+		t.Fatal("execution was not recorded")
+	}
+	if recorded == execution || recorded.Steps[0] == step {
+		t.Fatal("the caller's execution is shared with the state")
+	}
+	if recorded.Steps[0].Output["masterUserPassword"] != redact.Mask || recorded.Steps[0].Output["dbInstanceId"] != "orders" {
+		t.Fatalf("recorded output %v, want the password masked", recorded.Steps[0].Output)
+	}
+
+	// The agent keeps updating its execution after recording it
+	execution.Status = "completed"
+	step.Status = "failed"
+	step.Output["dbInstanceId"] = "changed"
+	if recorded.Status != "running" || recorded.Steps[0].Status != "completed" || recorded.Steps[0].Output["dbInstanceId"] != "orders" {
+		t.Fatalf("recorded execution changed with the caller's: %+v", recorded)
+	}
+}
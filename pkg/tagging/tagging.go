@@ -0,0 +1,229 @@
+package tagging
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PolicyFileEnvVar points at the tag policy file, DefaultPolicyFile when unset
+const PolicyFileEnvVar = "TAG_POLICY_FILE"
+
+// DefaultPolicyFile holds the tag policy shipped with the agent
+const DefaultPolicyFile = "settings/tag-policy.yaml"
+
+// WorkspaceEnvVar names the workspace written to the Workspace tag, DefaultWorkspace when unset
+const WorkspaceEnvVar = "AGENT_WORKSPACE"
+
+// DefaultWorkspace is the workspace of agents that do not set AGENT_WORKSPACE
+const DefaultWorkspace = "default"
+
+// Tag keys written by the default policy
+const (
+	KeyManagedBy  = "ManagedBy"
+	KeyWorkspace  = "Workspace"
+	KeyDecisionID = "DecisionID"
+)
+
+// AWS limits that apply to the tags of every taggable resource
+const (
+	maxTags        = 50
+	maxKeyLength   = 128
+	maxValueLength = 256
+)
+
+// Tag is a single tag key and value
+type Tag struct {
+	Key   string `yaml:"key"`
+	Value string `yaml:"value"`
+}
+
+// Policy is the tagging standard applied to every resource the agent creates.
+//
+// Default values may use the placeholders {{workspace}}, {{decisionId}}, {{tool}} and {{region}}.
+// A default whose value expands to an empty string is not written.
+type Policy struct {
+	// ManagedBy is always written and marks a resource as created by the agent
+	ManagedBy Tag `yaml:"managedBy"`
+
+	// Required keys must be present, with a non-empty value, once defaults are applied
+	Required []string `yaml:"required"`
+
+	// Defaults are written when the caller did not provide the key
+	Defaults map[string]string `yaml:"defaults"`
+
+	// AllowedValues restricts the values of the listed keys
+	AllowedValues map[string][]string `yaml:"allowedValues"`
+}
+
+// Variables fill the placeholders of default tag values
+type Variables struct {
+	Workspace  string
+	DecisionID string
+	Tool       string
+	Region     string
+}
+
+// DefaultPolicy returns the policy used when no tag policy file is configured
+func DefaultPolicy() *Policy {
+	return &Policy{
+		ManagedBy: Tag{Key: KeyManagedBy, Value: "ai-agent"},
+		Required:  []string{KeyManagedBy, KeyWorkspace},
+		Defaults: map[string]string{
+			KeyWorkspace:  "{{workspace}}",
+			KeyDecisionID: "{{decisionId}}",
+		},
+	}
+}
+
+// LoadFile reads a tag policy file. Settings missing from the file keep their default.
+func LoadFile(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := DefaultPolicy()
+	if err := yaml.Unmarshal(data, policy); err != nil {
+		return nil, fmt.Errorf("failed to parse tag policy file %s: %w", path, err)
+	}
+	if err := policy.validate(); err != nil {
+		return nil, fmt.Errorf("invalid tag policy file %s: %w", path, err)
+	}
+	return policy, nil
+}
+
+// LoadConfigured loads the file named by TAG_POLICY_FILE, or the default tag policy file.
+// Without either the default policy is used.
+func LoadConfigured() (*Policy, error) {
+	if path := os.Getenv(PolicyFileEnvVar); path != "" {
+		return LoadFile(path)
+	}
+
+	policy, err := LoadFile(DefaultPolicyFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return DefaultPolicy(), nil
+	}
+	return policy, err
+}
+
+// WorkspaceFromEnv returns the workspace configured with AGENT_WORKSPACE
+func WorkspaceFromEnv() string {
+	if workspace := strings.TrimSpace(os.Getenv(WorkspaceEnvVar)); workspace != "" {
+		return workspace
+	}
+	return DefaultWorkspace
+}
+
+// Apply returns the tags with the policy defaults and the ManagedBy tag merged in. The caller's
+// tags are kept, except ManagedBy which always carries the policy value.
+func (p *Policy) Apply(tags map[string]string, vars Variables) map[string]string {
+	merged := make(map[string]string, len(tags)+len(p.Defaults)+1)
+	for key, value := range tags {
+		merged[key] = value
+	}
+
+	for key, template := range p.Defaults {
+		if merged[key] != "" {
+			continue
+		}
+		if value := vars.expand(template); value != "" {
+			merged[key] = value
+		}
+	}
+
+	merged[p.ManagedBy.Key] = p.ManagedBy.Value
+	return merged
+}
+
+// Validate checks tags against the required keys, the allowed values and the AWS tag limits
+func (p *Policy) Validate(tags map[string]string) error {
+	var problems []string
+
+	for _, key := range p.Required {
+		if strings.TrimSpace(tags[key]) == "" {
+			problems = append(problems, fmt.Sprintf("missing required tag %s", key))
+		}
+	}
+
+	for key, allowed := range p.AllowedValues {
+		value, exists := tags[key]
+		if !exists {
+			continue
+		}
+		if !containsString(allowed, value) {
+			problems = append(problems, fmt.Sprintf("tag %s=%s is not one of %s", key, value, strings.Join(allowed, ", ")))
+		}
+	}
+
+	if len(tags) > maxTags {
+		problems = append(problems, fmt.Sprintf("%d tags exceed the limit of %d", len(tags), maxTags))
+	}
+	for key, value := range tags {
+		switch {
+		case key == "":
+			problems = append(problems, "tag keys must not be empty")
+		case strings.HasPrefix(strings.ToLower(key), "aws:"):
+			problems = append(problems, fmt.Sprintf("tag key %s uses the reserved aws: prefix", key))
+		case len(key) > maxKeyLength:
+			problems = append(problems, fmt.Sprintf("tag key %s is longer than %d characters", key, maxKeyLength))
+		case len(value) > maxValueLength:
+			problems = append(problems, fmt.Sprintf("value of tag %s is longer than %d characters", key, maxValueLength))
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	sort.Strings(problems)
+	return fmt.Errorf("tags violate the tagging policy: %s", strings.Join(problems, "; "))
+}
+
+// IsManaged reports whether tags mark a resource as created by the agent
+func (p *Policy) IsManaged(tags map[string]string) bool {
+	return p.ManagedBy.Key != "" && tags[p.ManagedBy.Key] == p.ManagedBy.Value
+}
+
+// validate checks that the policy itself is usable
+func (p *Policy) validate() error {
+	if p.ManagedBy.Key == "" || p.ManagedBy.Value == "" {
+		return fmt.Errorf("managedBy requires a key and a value")
+	}
+	for _, key := range p.Required {
+		if strings.TrimSpace(key) == "" {
+			return fmt.Errorf("required tag keys must not be empty")
+		}
+	}
+	for key, allowed := range p.AllowedValues {
+		if len(allowed) == 0 {
+			return fmt.Errorf("allowedValues for %s must list at least one value", key)
+		}
+		if key == p.ManagedBy.Key && !containsString(allowed, p.ManagedBy.Value) {
+			return fmt.Errorf("allowedValues for %s must include the managedBy value %s", key, p.ManagedBy.Value)
+		}
+	}
+	return nil
+}
+
+// expand replaces the placeholders of a default tag value
+func (v Variables) expand(template string) string {
+	return strings.NewReplacer(
+		"{{workspace}}", v.Workspace,
+		"{{decisionId}}", v.DecisionID,
+		"{{tool}}", v.Tool,
+		"{{region}}", v.Region,
+	).Replace(template)
+}
+
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,230 @@
+package tagging
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testPolicy() *Policy {
+	policy := DefaultPolicy()
+	policy.Required = append(policy.Required, "Environment")
+	policy.Defaults["Environment"] = "development"
+	policy.Defaults["Region"] = "{{region}}"
+	policy.Defaults["CreatedBy"] = "{{tool}}"
+	policy.AllowedValues = map[string][]string{
+		"Environment": {"development", "staging", "production"},
+	}
+	return policy
+}
+
+func TestApply(t *testing.T) {
+	vars := Variables{Workspace: "team-a", DecisionID: "decision-1", Tool: "create-ec2-instance", Region: "eu-west-1"}
+
+	tests := []struct {
+		name string
+		tags map[string]string
+		vars Variables
+		want map[string]string
+	}{
+		{
+			name: "defaults fill missing keys",
+			vars: vars,
+			want: map[string]string{
+				KeyManagedBy:  "ai-agent",
+				KeyWorkspace:  "team-a",
+				KeyDecisionID: "decision-1",
+				"Environment": "development",
+				"Region":      "eu-west-1",
+				"CreatedBy":   "create-ec2-instance",
+			},
+		},
+		{
+			name: "explicit tags win over defaults",
+			tags: map[string]string{"Environment": "production", "Owner": "platform"},
+			vars: vars,
+			want: map[string]string{
+				KeyManagedBy:  "ai-agent",
+				KeyWorkspace:  "team-a",
+				KeyDecisionID: "decision-1",
+				"Environment": "production",
+				"Region":      "eu-west-1",
+				"CreatedBy":   "create-ec2-instance",
+				"Owner":       "platform",
+			},
+		},
+		{
+			name: "empty explicit values are replaced by defaults",
+			tags: map[string]string{"Environment": ""},
+			vars: vars,
+			want: map[string]string{
+				KeyManagedBy:  "ai-agent",
+				KeyWorkspace:  "team-a",
+				KeyDecisionID: "decision-1",
+				"Environment": "development",
+				"Region":      "eu-west-1",
+				"CreatedBy":   "create-ec2-instance",
+			},
+		},
+		{
+			name: "ManagedBy cannot be overridden",
+			tags: map[string]string{KeyManagedBy: "terraform"},
+			vars: vars,
+			want: map[string]string{
+				KeyManagedBy:  "ai-agent",
+				KeyWorkspace:  "team-a",
+				KeyDecisionID: "decision-1",
+				"Environment": "development",
+				"Region":      "eu-west-1",
+				"CreatedBy":   "create-ec2-instance",
+			},
+		},
+		{
+			name: "defaults expanding to nothing are left out",
+			vars: Variables{Workspace: "team-a", Tool: "create-vpc"},
+			want: map[string]string{
+				KeyManagedBy:  "ai-agent",
+				KeyWorkspace:  "team-a",
+				"Environment": "development",
+				"CreatedBy":   "create-vpc",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := testPolicy().Apply(tt.tags, tt.vars)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Apply() = %v, want %v", got, tt.want)
+			}
+			for key, value := range tt.want {
+				if got[key] != value {
+					t.Fatalf("Apply() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestApplyKeepsCallerTags(t *testing.T) {
+	tags := map[string]string{"Owner": "platform"}
+	testPolicy().Apply(tags, Variables{Workspace: "team-a"})
+	if len(tags) != 1 {
+		t.Fatalf("Apply modified the caller's tags: %v", tags)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := map[string]string{KeyManagedBy: "ai-agent", KeyWorkspace: "team-a", "Environment": "staging"}
+	with := func(key, value string) map[string]string {
+		tags := map[string]string{}
+		for k, v := range valid {
+			tags[k] = v
+		}
+		tags[key] = value
+		return tags
+	}
+
+	manyTags := with("Owner", "platform")
+	for i := 0; len(manyTags) <= maxTags; i++ {
+		manyTags[strings.Repeat("k", i+1)] = "v"
+	}
+
+	tests := []struct {
+		name    string
+		tags    map[string]string
+		wantErr string
+	}{
+		{name: "valid", tags: valid},
+		{name: "keys without allowed values accept anything", tags: with("Owner", "anyone")},
+		{name: "missing required key", tags: map[string]string{KeyManagedBy: "ai-agent", "Environment": "staging"}, wantErr: "missing required tag Workspace"},
+		{name: "blank required value", tags: with(KeyWorkspace, "  "), wantErr: "missing required tag Workspace"},
+		{name: "value not allowed", tags: with("Environment", "qa"), wantErr: "tag Environment=qa is not one of development, staging, production"},
+		{name: "allowed values are case sensitive", tags: with("Environment", "Production"), wantErr: "is not one of"},
+		{name: "reserved prefix", tags: with("AWS:CloudFormation", "x"), wantErr: "reserved aws: prefix"},
+		{name: "key too long", tags: with(strings.Repeat("k", maxKeyLength+1), "x"), wantErr: "longer than 128 characters"},
+		{name: "value too long", tags: with("Owner", strings.Repeat("v", maxValueLength+1)), wantErr: "value of tag Owner is longer than 256 characters"},
+		{name: "too many tags", tags: manyTags, wantErr: "exceed the limit of 50"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := testPolicy().Validate(tt.tags)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Validate() = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateReportsEveryProblem(t *testing.T) {
+	err := testPolicy().Validate(map[string]string{"Environment": "qa"})
+	if err == nil {
+		t.Fatal("Validate() = nil, want an error")
+	}
+	for _, want := range []string{"missing required tag ManagedBy", "missing required tag Workspace", "tag Environment=qa"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Validate() = %v, missing %q", err, want)
+		}
+	}
+}
+
+func TestAppliedDefaultsSatisfyPolicy(t *testing.T) {
+	policy := testPolicy()
+	tags := policy.Apply(nil, Variables{Workspace: DefaultWorkspace})
+	if err := policy.Validate(tags); err != nil {
+		t.Fatalf("tags from defaults violate the policy: %v", err)
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name: "overrides defaults",
+			content: `
+required: [ManagedBy, Workspace, Owner]
+allowedValues:
+  Environment: [staging, production]
+`,
+		},
+		{name: "missing managedBy value", content: "managedBy:\n  key: ManagedBy\n  value: \"\"\n", wantErr: "managedBy requires a key and a value"},
+		{name: "empty required key", content: "required: [\" \"]\n", wantErr: "required tag keys must not be empty"},
+		{name: "empty allowed values", content: "allowedValues:\n  Environment: []\n", wantErr: "must list at least one value"},
+		{name: "managedBy value not allowed", content: "allowedValues:\n  ManagedBy: [terraform]\n", wantErr: "must include the managedBy value ai-agent"},
+		{name: "invalid yaml", content: "required: {", wantErr: "failed to parse"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "tag-policy.yaml")
+			if err := os.WriteFile(path, []byte(tt.content), 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			policy, err := LoadFile(path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadFile() error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadFile() error = %v", err)
+			}
+			if len(policy.Required) != 3 || policy.ManagedBy.Value != "ai-agent" || policy.Defaults[KeyWorkspace] != "{{workspace}}" {
+				t.Fatalf("unexpected policy %+v", policy)
+			}
+		})
+	}
+}
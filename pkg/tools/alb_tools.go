@@ -62,6 +62,8 @@ func NewCreateLoadBalancerTool(awsClient *aws.Client, actionType string, logger
 		BaseTool: &BaseTool{
 			name:        "create-load-balancer",
 			description: "Create a new application load balancer",
+			category:    "alb",
+			actionType:  actionType,
 			inputSchema: inputSchema,
 			logger:      logger,
 		},
@@ -256,7 +258,7 @@ func NewCreateTargetGroupTool(awsClient *aws.Client, actionType string, logger *
 			},
 			"protocol": map[string]interface{}{
 				"type":        "string",
-				"description": "The protocol (HTTP, HTTPS, TCP)",
+				"description": "The protocol: HTTP or HTTPS for ALBs; TCP, UDP, TCP_UDP or TLS for NLBs (TCP-family target groups default to TCP health checks)",
 				"default":     "HTTP",
 			},
 			"port": map[string]interface{}{
@@ -300,6 +302,8 @@ func NewCreateTargetGroupTool(awsClient *aws.Client, actionType string, logger *
 		BaseTool: &BaseTool{
 			name:        "create-target-group",
 			description: "Create a new target group",
+			category:    "alb",
+			actionType:  actionType,
 			inputSchema: inputSchema,
 			logger:      logger,
 		},
@@ -374,7 +378,7 @@ func NewCreateListenerTool(awsClient *aws.Client, actionType string, logger *log
 			},
 			"protocol": map[string]interface{}{
 				"type":        "string",
-				"description": "The protocol (HTTP, HTTPS)",
+				"description": "The protocol: HTTP or HTTPS for ALBs; TCP, UDP, TCP_UDP or TLS for NLBs",
 				"default":     "HTTP",
 			},
 			"port": map[string]interface{}{
@@ -384,16 +388,52 @@ func NewCreateListenerTool(awsClient *aws.Client, actionType string, logger *log
 			},
 			"targetGroupArn": map[string]interface{}{
 				"type":        "string",
-				"description": "The ARN of the target group",
+				"description": "The ARN of the target group (required for forward actions)",
+			},
+			"defaultActionType": map[string]interface{}{
+				"type":        "string",
+				"description": "Default action: 'forward' to the target group (default), 'redirect' (HTTP to HTTPS on 443 unless redirect fields are set) or 'fixed-response'",
+				"enum":        []string{"forward", "redirect", "fixed-response"},
+				"default":     "forward",
+			},
+			"certificateArn": map[string]interface{}{
+				"type":        "string",
+				"description": "ACM certificate ARN for HTTPS and TLS listeners",
+			},
+			"certificateDomain": map[string]interface{}{
+				"type":        "string",
+				"description": "Domain to look up an issued ACM certificate for when certificateArn is not given (HTTPS and TLS only)",
+			},
+			"sslPolicy": map[string]interface{}{
+				"type":        "string",
+				"description": "Security policy for HTTPS and TLS listeners (defaults to the ELB recommended policy)",
+			},
+			"alpnPolicy": map[string]interface{}{
+				"type":        "string",
+				"description": "ALPN policy for TLS listeners (HTTP1Only, HTTP2Only, HTTP2Optional, HTTP2Preferred, None)",
+			},
+			"redirectProtocol": map[string]interface{}{
+				"type":        "string",
+				"description": "Redirect target protocol (redirect actions only)",
+			},
+			"redirectPort": map[string]interface{}{
+				"type":        "string",
+				"description": "Redirect target port (redirect actions only)",
+			},
+			"redirectStatusCode": map[string]interface{}{
+				"type":        "string",
+				"description": "HTTP_301 (default) or HTTP_302 (redirect actions only)",
 			},
 		},
-		"required": []string{"loadBalancerArn", "targetGroupArn"},
+		"required": []string{"loadBalancerArn"},
 	}
 
 	return &CreateListenerTool{
 		BaseTool: &BaseTool{
 			name:        "create-listener",
-			description: "Create a new listener for a load balancer",
+			description: "Create a new listener for a load balancer. Use protocol HTTPS with certificateDomain or certificateArn for TLS, and defaultActionType 'redirect' on port 80 to send HTTP traffic to HTTPS",
+			category:    "alb",
+			actionType:  actionType,
 			inputSchema: inputSchema,
 			logger:      logger,
 		},
@@ -419,13 +459,18 @@ func (t *CreateListenerTool) Execute(ctx context.Context, arguments map[string]i
 		}
 	}
 
-	targetGroupArn, ok := arguments["targetGroupArn"].(string)
-	if !ok || targetGroupArn == "" {
+	defaultActionType, _ := arguments["defaultActionType"].(string)
+	if defaultActionType == "" {
+		defaultActionType = "forward"
+	}
+
+	targetGroupArn, _ := arguments["targetGroupArn"].(string)
+	if targetGroupArn == "" && defaultActionType == "forward" {
 		return t.CreateErrorResponse("targetGroupArn is required")
 	}
 
 	// Check if the targetGroupArn is actually an ARN format
-	if !strings.HasPrefix(targetGroupArn, "arn:aws:elasticloadbalancing:") {
+	if targetGroupArn != "" && !strings.HasPrefix(targetGroupArn, "arn:aws:elasticloadbalancing:") {
 		t.logger.WithFields(map[string]interface{}{
 			"provided_value": targetGroupArn,
 		}).Warn("targetGroupArn does not appear to be in ARN format - this may cause AWS API errors")
@@ -467,12 +512,15 @@ func (t *CreateListenerTool) Execute(ctx context.Context, arguments map[string]i
 
 	message := "Listener created successfully for load balancer"
 	data := map[string]interface{}{
-		"loadBalancerArn": loadBalancerArn,
-		"targetGroupArn":  targetGroupArn,
-		"protocol":        protocol,
-		"port":            port,
-		"listener":        result,
-		"listenerId":      result.ID,
+		"loadBalancerArn":   loadBalancerArn,
+		"targetGroupArn":    targetGroupArn,
+		"protocol":          protocol,
+		"port":              port,
+		"defaultActionType": defaultActionType,
+		"certificateArn":    result.Details["certificateArn"],
+		"listener":          result,
+		"listenerId":        result.ID,
+		"listenerArn":       result.ID,
 	}
 
 	return t.CreateSuccessResponse(message, data)
@@ -633,6 +681,8 @@ func NewRegisterTargetsTool(awsClient *aws.Client, actionType string, logger *lo
 		BaseTool: &BaseTool{
 			name:        "register-targets",
 			description: "Register targets with a load balancer target group",
+			category:    "alb",
+			actionType:  actionType,
 			inputSchema: inputSchema,
 			logger:      logger,
 		},
@@ -714,6 +764,8 @@ func NewDeregisterTargetsTool(awsClient *aws.Client, actionType string, logger *
 		BaseTool: &BaseTool{
 			name:        "deregister-targets",
 			description: "Deregister targets from a load balancer target group",
+			category:    "alb",
+			actionType:  actionType,
 			inputSchema: inputSchema,
 			logger:      logger,
 		},
@@ -764,3 +816,678 @@ func (t *DeregisterTargetsTool) Execute(ctx context.Context, arguments map[strin
 
 	return t.CreateSuccessResponse(message, data)
 }
+
+// listenerRuleSchemaProperties returns the condition and action properties shared by
+// the listener rule creation and modification tools
+func listenerRuleSchemaProperties() map[string]interface{} {
+	stringArray := func(description string) map[string]interface{} {
+		return map[string]interface{}{
+			"type":        "array",
+			"items":       map[string]interface{}{"type": "string"},
+			"description": description,
+		}
+	}
+
+	return map[string]interface{}{
+		"pathPatterns":     stringArray("Path patterns to match, e.g. /api/*"),
+		"hostHeaders":      stringArray("Host headers to match, e.g. api.example.com"),
+		"httpMethods":      stringArray("HTTP request methods to match, e.g. GET, POST"),
+		"sourceIps":        stringArray("Source IP CIDR blocks to match"),
+		"httpHeaderName":   map[string]interface{}{"type": "string", "description": "Name of an HTTP header to match"},
+		"httpHeaderValues": stringArray("Values of httpHeaderName to match"),
+		"actionType": map[string]interface{}{
+			"type":        "string",
+			"description": "Action when the rule matches",
+			"enum":        []string{"forward", "redirect", "fixed-response"},
+			"default":     "forward",
+		},
+		"targetGroupArn":           map[string]interface{}{"type": "string", "description": "Target group ARN for forward actions"},
+		"redirectProtocol":         map[string]interface{}{"type": "string", "description": "Redirect target protocol (defaults to HTTPS)"},
+		"redirectPort":             map[string]interface{}{"type": "string", "description": "Redirect target port (defaults to 443)"},
+		"redirectHost":             map[string]interface{}{"type": "string", "description": "Redirect target host"},
+		"redirectPath":             map[string]interface{}{"type": "string", "description": "Redirect target path"},
+		"redirectStatusCode":       map[string]interface{}{"type": "string", "description": "HTTP_301 (default) or HTTP_302"},
+		"fixedResponseStatusCode":  map[string]interface{}{"type": "string", "description": "Status code for fixed-response actions"},
+		"fixedResponseContentType": map[string]interface{}{"type": "string", "description": "Content type for fixed-response actions"},
+		"fixedResponseBody":        map[string]interface{}{"type": "string", "description": "Body for fixed-response actions"},
+	}
+}
+
+// DeleteLoadBalancerTool implements MCPTool for deleting load balancers
+type DeleteLoadBalancerTool struct {
+	*BaseTool
+	adapter interfaces.AWSResourceAdapter
+}
+
+// NewDeleteLoadBalancerTool creates a new load balancer deletion tool
+func NewDeleteLoadBalancerTool(awsClient *aws.Client, actionType string, logger *logging.Logger) interfaces.MCPTool {
+	inputSchema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"loadBalancerArn": map[string]interface{}{
+				"type":        "string",
+				"description": "The ARN of the load balancer to delete",
+			},
+		},
+		"required": []string{"loadBalancerArn"},
+	}
+
+	baseTool := NewBaseTool(
+		"delete-load-balancer",
+		"Delete a load balancer and its listeners. Target groups must be deleted separately",
+		"alb",
+		actionType,
+		inputSchema,
+		logger,
+	)
+
+	baseTool.AddExample(
+		"Delete a load balancer",
+		map[string]interface{}{
+			"loadBalancerArn": "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/web-alb/50dc6c495c0c9188",
+		},
+		"Deleted load balancer web-alb",
+	)
+
+	return &DeleteLoadBalancerTool{
+		BaseTool: baseTool,
+		adapter:  adapters.NewALBAdapter(awsClient, logger),
+	}
+}
+
+// Execute deletes a load balancer
+func (t *DeleteLoadBalancerTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	loadBalancerArn, _ := arguments["loadBalancerArn"].(string)
+	if loadBalancerArn == "" {
+		return t.CreateErrorResponse("loadBalancerArn is required")
+	}
+
+	if err := t.adapter.Delete(ctx, loadBalancerArn); err != nil {
+		return t.CreateErrorResponse(fmt.Sprintf("Failed to delete load balancer: %v", err))
+	}
+
+	return t.CreateSuccessResponse(fmt.Sprintf("Successfully deleted load balancer %s", loadBalancerArn), map[string]interface{}{
+		"loadBalancerArn": loadBalancerArn,
+		"status":          "deleted",
+	})
+}
+
+// DeleteALBResourceTool implements MCPTool for deleting target groups, listeners and listener rules
+type DeleteALBResourceTool struct {
+	*BaseTool
+	adapter   interfaces.SpecializedOperations
+	operation string
+	arnKey    string
+	label     string
+}
+
+// NewDeleteTargetGroupTool creates a new target group deletion tool
+func NewDeleteTargetGroupTool(awsClient *aws.Client, actionType string, logger *logging.Logger) interfaces.MCPTool {
+	return newDeleteALBResourceTool(awsClient, actionType, logger,
+		"delete-target-group", "delete-target-group", "targetGroupArn", "target group",
+		"Delete a target group. It must not be referenced by any listener or rule")
+}
+
+// NewDeleteListenerTool creates a new listener deletion tool
+func NewDeleteListenerTool(awsClient *aws.Client, actionType string, logger *logging.Logger) interfaces.MCPTool {
+	return newDeleteALBResourceTool(awsClient, actionType, logger,
+		"delete-listener", "delete-listener", "listenerArn", "listener",
+		"Delete a load balancer listener and its rules")
+}
+
+// NewDeleteListenerRuleTool creates a new listener rule deletion tool
+func NewDeleteListenerRuleTool(awsClient *aws.Client, actionType string, logger *logging.Logger) interfaces.MCPTool {
+	return newDeleteALBResourceTool(awsClient, actionType, logger,
+		"delete-listener-rule", "delete-listener-rule", "ruleArn", "listener rule",
+		"Delete a non-default listener rule")
+}
+
+func newDeleteALBResourceTool(awsClient *aws.Client, actionType string, logger *logging.Logger, name, operation, arnKey, label, description string) interfaces.MCPTool {
+	inputSchema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			arnKey: map[string]interface{}{
+				"type":        "string",
+				"description": fmt.Sprintf("The ARN of the %s to delete", label),
+			},
+		},
+		"required": []string{arnKey},
+	}
+
+	baseTool := NewBaseTool(name, description, "alb", actionType, inputSchema, logger)
+
+	return &DeleteALBResourceTool{
+		BaseTool:  baseTool,
+		adapter:   adapters.NewALBSpecializedAdapter(awsClient, logger),
+		operation: operation,
+		arnKey:    arnKey,
+		label:     label,
+	}
+}
+
+// Execute deletes the target group, listener or listener rule
+func (t *DeleteALBResourceTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	arn, _ := arguments[t.arnKey].(string)
+	if arn == "" {
+		return t.CreateErrorResponse(fmt.Sprintf("%s is required", t.arnKey))
+	}
+
+	if _, err := t.adapter.ExecuteSpecialOperation(ctx, t.operation, arn); err != nil {
+		return t.CreateErrorResponse(fmt.Sprintf("Failed to delete %s: %v", t.label, err))
+	}
+
+	return t.CreateSuccessResponse(fmt.Sprintf("Successfully deleted %s %s", t.label, arn), map[string]interface{}{
+		t.arnKey: arn,
+		"status": "deleted",
+	})
+}
+
+// ListListenersTool implements MCPTool for listing the listeners of a load balancer
+type ListListenersTool struct {
+	*BaseTool
+	adapter interfaces.SpecializedOperations
+}
+
+// NewListListenersTool creates a new listener listing tool
+func NewListListenersTool(awsClient *aws.Client, actionType string, logger *logging.Logger) interfaces.MCPTool {
+	inputSchema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"loadBalancerArn": map[string]interface{}{
+				"type":        "string",
+				"description": "The ARN of the load balancer",
+			},
+		},
+		"required": []string{"loadBalancerArn"},
+	}
+
+	baseTool := NewBaseTool(
+		"list-listeners",
+		"List the listeners of a load balancer",
+		"alb",
+		actionType,
+		inputSchema,
+		logger,
+	)
+
+	return &ListListenersTool{
+		BaseTool: baseTool,
+		adapter:  adapters.NewALBSpecializedAdapter(awsClient, logger),
+	}
+}
+
+// Execute lists the listeners of a load balancer
+func (t *ListListenersTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	loadBalancerArn, _ := arguments["loadBalancerArn"].(string)
+	if loadBalancerArn == "" {
+		return t.CreateErrorResponse("loadBalancerArn is required")
+	}
+
+	result, err := t.adapter.ExecuteSpecialOperation(ctx, "list-listeners", loadBalancerArn)
+	if err != nil {
+		return t.CreateErrorResponse(fmt.Sprintf("Failed to list listeners: %v", err))
+	}
+
+	count, _ := result.Details["count"].(int)
+	return t.CreateSuccessResponse(fmt.Sprintf("Found %d listeners", count), map[string]interface{}{
+		"loadBalancerArn": loadBalancerArn,
+		"listeners":       result.Details["listeners"],
+		"count":           count,
+	})
+}
+
+// CreateListenerRuleTool implements MCPTool for creating listener rules
+type CreateListenerRuleTool struct {
+	*BaseTool
+	adapter interfaces.SpecializedOperations
+}
+
+// NewCreateListenerRuleTool creates a new listener rule creation tool
+func NewCreateListenerRuleTool(awsClient *aws.Client, actionType string, logger *logging.Logger) interfaces.MCPTool {
+	properties := listenerRuleSchemaProperties()
+	properties["listenerArn"] = map[string]interface{}{
+		"type":        "string",
+		"description": "The ARN of the listener to add the rule to",
+	}
+	properties["priority"] = map[string]interface{}{
+		"type":        "integer",
+		"description": "Rule priority (1-50000, lower values are evaluated first, unique per listener)",
+	}
+	properties["tags"] = map[string]interface{}{
+		"type":        "object",
+		"description": "Tags to apply to the rule",
+	}
+
+	inputSchema := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+		"required":   []string{"listenerArn", "priority"},
+	}
+
+	baseTool := NewBaseTool(
+		"create-listener-rule",
+		"Create a listener rule that routes matching requests by path, host, method, source IP or header to a target group, a redirect or a fixed response",
+		"alb",
+		actionType,
+		inputSchema,
+		logger,
+	)
+
+	baseTool.AddExample(
+		"Route API traffic to a dedicated target group",
+		map[string]interface{}{
+			"listenerArn":    "arn:aws:elasticloadbalancing:us-west-2:123456789012:listener/app/web-alb/50dc6c495c0c9188/f2f7dc8efc522ab2",
+			"priority":       10,
+			"pathPatterns":   []string{"/api/*"},
+			"actionType":     "forward",
+			"targetGroupArn": "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/api-tg/73e2d6bc24d8a067",
+		},
+		"Created listener rule with priority 10",
+	)
+
+	return &CreateListenerRuleTool{
+		BaseTool: baseTool,
+		adapter:  adapters.NewALBSpecializedAdapter(awsClient, logger),
+	}
+}
+
+// Execute creates a listener rule
+func (t *CreateListenerRuleTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	listenerArn, _ := arguments["listenerArn"].(string)
+	if listenerArn == "" {
+		return t.CreateErrorResponse("listenerArn is required")
+	}
+
+	if actionType, _ := arguments["actionType"].(string); actionType == "" {
+		arguments["actionType"] = "forward"
+	}
+
+	rule, err := t.adapter.ExecuteSpecialOperation(ctx, "create-listener-rule", arguments)
+	if err != nil {
+		return t.CreateErrorResponse(fmt.Sprintf("Failed to create listener rule: %v", err))
+	}
+
+	return t.CreateSuccessResponse(fmt.Sprintf("Successfully created listener rule with priority %v", rule.Details["priority"]), map[string]interface{}{
+		"ruleArn":     rule.ID,
+		"ruleId":      rule.ID,
+		"listenerArn": listenerArn,
+		"priority":    rule.Details["priority"],
+		"rule":        rule,
+	})
+}
+
+// ModifyListenerRuleTool implements MCPTool for changing listener rule conditions, actions and priority
+type ModifyListenerRuleTool struct {
+	*BaseTool
+	adapter interfaces.SpecializedOperations
+}
+
+// NewModifyListenerRuleTool creates a new listener rule modification tool
+func NewModifyListenerRuleTool(awsClient *aws.Client, actionType string, logger *logging.Logger) interfaces.MCPTool {
+	properties := listenerRuleSchemaProperties()
+	properties["ruleArn"] = map[string]interface{}{
+		"type":        "string",
+		"description": "The ARN of the rule to modify",
+	}
+	properties["priority"] = map[string]interface{}{
+		"type":        "integer",
+		"description": "New rule priority (1-50000)",
+	}
+	delete(properties["actionType"].(map[string]interface{}), "default")
+
+	inputSchema := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+		"required":   []string{"ruleArn"},
+	}
+
+	baseTool := NewBaseTool(
+		"modify-listener-rule",
+		"Modify a listener rule. Supplied conditions or action replace the existing ones; omitted fields are left unchanged",
+		"alb",
+		actionType,
+		inputSchema,
+		logger,
+	)
+
+	return &ModifyListenerRuleTool{
+		BaseTool: baseTool,
+		adapter:  adapters.NewALBSpecializedAdapter(awsClient, logger),
+	}
+}
+
+// Execute modifies a listener rule
+func (t *ModifyListenerRuleTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	ruleArn, _ := arguments["ruleArn"].(string)
+	if ruleArn == "" {
+		return t.CreateErrorResponse("ruleArn is required")
+	}
+
+	rule, err := t.adapter.ExecuteSpecialOperation(ctx, "modify-listener-rule", arguments)
+	if err != nil {
+		return t.CreateErrorResponse(fmt.Sprintf("Failed to modify listener rule: %v", err))
+	}
+
+	return t.CreateSuccessResponse(fmt.Sprintf("Successfully modified listener rule %s", ruleArn), map[string]interface{}{
+		"ruleArn":  rule.ID,
+		"ruleId":   rule.ID,
+		"priority": rule.Details["priority"],
+		"rule":     rule,
+	})
+}
+
+// ListListenerRulesTool implements MCPTool for listing the rules of a listener
+type ListListenerRulesTool struct {
+	*BaseTool
+	adapter interfaces.SpecializedOperations
+}
+
+// NewListListenerRulesTool creates a new listener rule listing tool
+func NewListListenerRulesTool(awsClient *aws.Client, actionType string, logger *logging.Logger) interfaces.MCPTool {
+	inputSchema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"listenerArn": map[string]interface{}{
+				"type":        "string",
+				"description": "The ARN of the listener",
+			},
+		},
+		"required": []string{"listenerArn"},
+	}
+
+	baseTool := NewBaseTool(
+		"list-listener-rules",
+		"List the rules of a listener in priority order, including the default rule",
+		"alb",
+		actionType,
+		inputSchema,
+		logger,
+	)
+
+	return &ListListenerRulesTool{
+		BaseTool: baseTool,
+		adapter:  adapters.NewALBSpecializedAdapter(awsClient, logger),
+	}
+}
+
+// Execute lists the rules of a listener
+func (t *ListListenerRulesTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	listenerArn, _ := arguments["listenerArn"].(string)
+	if listenerArn == "" {
+		return t.CreateErrorResponse("listenerArn is required")
+	}
+
+	result, err := t.adapter.ExecuteSpecialOperation(ctx, "list-listener-rules", listenerArn)
+	if err != nil {
+		return t.CreateErrorResponse(fmt.Sprintf("Failed to list listener rules: %v", err))
+	}
+
+	count, _ := result.Details["count"].(int)
+	return t.CreateSuccessResponse(fmt.Sprintf("Found %d listener rules", count), map[string]interface{}{
+		"listenerArn": listenerArn,
+		"rules":       result.Details["rules"],
+		"count":       count,
+	})
+}
+
+// FindACMCertificateTool implements MCPTool for looking up ACM certificates by domain
+type FindACMCertificateTool struct {
+	*BaseTool
+	adapter interfaces.SpecializedOperations
+}
+
+// NewFindACMCertificateTool creates a new ACM certificate lookup tool
+func NewFindACMCertificateTool(awsClient *aws.Client, actionType string, logger *logging.Logger) interfaces.MCPTool {
+	inputSchema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"domainName": map[string]interface{}{
+				"type":        "string",
+				"description": "Domain the certificate must cover, e.g. app.example.com",
+			},
+		},
+		"required": []string{"domainName"},
+	}
+
+	baseTool := NewBaseTool(
+		"find-acm-certificate",
+		"Find an issued ACM certificate covering a domain, preferring exact matches over wildcards",
+		"alb",
+		actionType,
+		inputSchema,
+		logger,
+	)
+
+	baseTool.AddExample(
+		"Find the certificate for an HTTPS listener",
+		map[string]interface{}{
+			"domainName": "app.example.com",
+		},
+		"Found certificate arn:aws:acm:us-west-2:123456789012:certificate/12345678-1234-1234-1234-123456789012",
+	)
+
+	return &FindACMCertificateTool{
+		BaseTool: baseTool,
+		adapter:  adapters.NewALBSpecializedAdapter(awsClient, logger),
+	}
+}
+
+// Execute looks up an ACM certificate
+func (t *FindACMCertificateTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	domainName, _ := arguments["domainName"].(string)
+	if domainName == "" {
+		return t.CreateErrorResponse("domainName is required")
+	}
+
+	certificate, err := t.adapter.ExecuteSpecialOperation(ctx, "find-certificate", domainName)
+	if err != nil {
+		return t.CreateErrorResponse(fmt.Sprintf("Failed to find certificate: %v", err))
+	}
+
+	return t.CreateSuccessResponse(fmt.Sprintf("Found certificate %s for %s", certificate.ID, domainName), map[string]interface{}{
+		"certificateArn": certificate.ID,
+		"domainName":     domainName,
+	})
+}
+
+// CreateNetworkLoadBalancerTool implements MCPTool for creating Network Load Balancers
+type CreateNetworkLoadBalancerTool struct {
+	*BaseTool
+	adapter interfaces.SpecializedOperations
+}
+
+// NewCreateNetworkLoadBalancerTool creates a new Network Load Balancer creation tool
+func NewCreateNetworkLoadBalancerTool(awsClient *aws.Client, actionType string, logger *logging.Logger) interfaces.MCPTool {
+	inputSchema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"name": map[string]interface{}{
+				"type":        "string",
+				"description": "The name for the load balancer",
+			},
+			"scheme": map[string]interface{}{
+				"type":        "string",
+				"description": "The scheme (internet-facing or internal)",
+				"default":     "internet-facing",
+			},
+			"subnetIds": map[string]interface{}{
+				"type":        "array",
+				"items":       map[string]interface{}{"type": "string"},
+				"description": "Subnet IDs, one per Availability Zone (at least one)",
+				"minItems":    1,
+			},
+			"elasticIpAllocationIds": map[string]interface{}{
+				"type":        "array",
+				"items":       map[string]interface{}{"type": "string"},
+				"description": "Existing Elastic IP allocation IDs, one per subnet in the same order as subnetIds (internet-facing only)",
+			},
+			"allocateElasticIps": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Allocate a new Elastic IP for each subnet without one, giving the load balancer static public addresses. The allocated addresses are released when the load balancer is deleted",
+				"default":     false,
+			},
+			"privateIpv4Addresses": map[string]interface{}{
+				"type":        "array",
+				"items":       map[string]interface{}{"type": "string"},
+				"description": "Static private IPv4 addresses, one per subnet in the same order as subnetIds (internal only)",
+			},
+			"crossZoneEnabled": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Enable cross-zone load balancing (disabled by default for NLBs)",
+			},
+			"securityGroupIds": map[string]interface{}{
+				"type":        "array",
+				"items":       map[string]interface{}{"type": "string"},
+				"description": "Optional security group IDs; they can only be set when the load balancer is created",
+			},
+			"ipAddressType": map[string]interface{}{
+				"type":        "string",
+				"description": "ipv4 or dualstack",
+			},
+			"tags": map[string]interface{}{
+				"type":        "object",
+				"description": "Tags to apply to the load balancer",
+			},
+		},
+		"required": []string{"name", "subnetIds"},
+	}
+
+	baseTool := NewBaseTool(
+		"create-network-load-balancer",
+		"Create a Network Load Balancer for TCP, UDP or TLS traffic, optionally with a static Elastic IP per subnet and cross-zone load balancing",
+		"alb",
+		actionType,
+		inputSchema,
+		logger,
+	)
+
+	baseTool.AddExample(
+		"Create an internet-facing NLB with static IPs",
+		map[string]interface{}{
+			"name":               "tcp-nlb",
+			"subnetIds":          []string{"subnet-12345678", "subnet-87654321"},
+			"allocateElasticIps": true,
+			"crossZoneEnabled":   true,
+		},
+		"Network load balancer tcp-nlb created with 2 Elastic IPs",
+	)
+
+	return &CreateNetworkLoadBalancerTool{
+		BaseTool: baseTool,
+		adapter:  adapters.NewALBSpecializedAdapter(awsClient, logger),
+	}
+}
+
+// Execute creates a Network Load Balancer
+func (t *CreateNetworkLoadBalancerTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	name, _ := arguments["name"].(string)
+	if name == "" {
+		return t.CreateErrorResponse("name is required")
+	}
+
+	// Subnet IDs may arrive as a JSON string from dependency resolution
+	if subnetIdsStr, ok := arguments["subnetIds"].(string); ok && subnetIdsStr != "" {
+		var parsedSubnetIds []string
+		if err := json.Unmarshal([]byte(subnetIdsStr), &parsedSubnetIds); err != nil {
+			parsedSubnetIds = []string{subnetIdsStr}
+		}
+		arguments["subnetIds"] = parsedSubnetIds
+	}
+
+	arguments["type"] = "network"
+	result, err := t.adapter.ExecuteSpecialOperation(ctx, "create-load-balancer", arguments)
+	if err != nil {
+		if result != nil {
+			// The load balancer exists, so its ARN and addresses go back for the caller to record them
+			return t.CreateErrorResponseWithData(fmt.Sprintf("Failed to create network load balancer: %v", err), map[string]interface{}{
+				"resourceId":             result.ID,
+				"loadBalancerArn":        result.ID,
+				"loadBalancer":           result,
+				"elasticIpAllocationIds": result.Details["elasticIpAllocationIds"],
+			})
+		}
+		return t.CreateErrorResponse(fmt.Sprintf("Failed to create network load balancer: %v", err))
+	}
+
+	allocationIds, _ := result.Details["elasticIpAllocationIds"].([]string)
+	message := fmt.Sprintf("Network load balancer %s created successfully", name)
+	if len(allocationIds) > 0 {
+		message = fmt.Sprintf("%s with %d Elastic IPs", message, len(allocationIds))
+	}
+
+	return t.CreateSuccessResponse(message, map[string]interface{}{
+		"name":                   name,
+		"type":                   "network",
+		"loadBalancer":           result,
+		"loadBalancerId":         result.ID,
+		"loadBalancerArn":        result.ID,
+		"arn":                    result.ID,
+		"dnsName":                result.Details["dnsName"],
+		"elasticIpAllocationIds": allocationIds,
+	})
+}
+
+// SetLoadBalancerCrossZoneTool implements MCPTool for toggling cross-zone load balancing
+type SetLoadBalancerCrossZoneTool struct {
+	*BaseTool
+	adapter interfaces.SpecializedOperations
+}
+
+// NewSetLoadBalancerCrossZoneTool creates a new cross-zone load balancing tool
+func NewSetLoadBalancerCrossZoneTool(awsClient *aws.Client, actionType string, logger *logging.Logger) interfaces.MCPTool {
+	inputSchema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"loadBalancerArn": map[string]interface{}{
+				"type":        "string",
+				"description": "The ARN of the load balancer",
+			},
+			"enabled": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Whether cross-zone load balancing is enabled",
+			},
+		},
+		"required": []string{"loadBalancerArn", "enabled"},
+	}
+
+	baseTool := NewBaseTool(
+		"set-load-balancer-cross-zone",
+		"Enable or disable cross-zone load balancing on a load balancer. Cross-zone traffic on NLBs incurs inter-AZ data charges",
+		"alb",
+		actionType,
+		inputSchema,
+		logger,
+	)
+
+	return &SetLoadBalancerCrossZoneTool{
+		BaseTool: baseTool,
+		adapter:  adapters.NewALBSpecializedAdapter(awsClient, logger),
+	}
+}
+
+// Execute updates the cross-zone load balancing attribute
+func (t *SetLoadBalancerCrossZoneTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	loadBalancerArn, _ := arguments["loadBalancerArn"].(string)
+	if loadBalancerArn == "" {
+		return t.CreateErrorResponse("loadBalancerArn is required")
+	}
+
+	enabled, ok := arguments["enabled"].(bool)
+	if !ok {
+		return t.CreateErrorResponse("enabled is required and must be a boolean")
+	}
+
+	if _, err := t.adapter.ExecuteSpecialOperation(ctx, "set-cross-zone", arguments); err != nil {
+		return t.CreateErrorResponse(fmt.Sprintf("Failed to update cross-zone load balancing: %v", err))
+	}
+
+	state := "disabled"
+	if enabled {
+		state = "enabled"
+	}
+
+	return t.CreateSuccessResponse(fmt.Sprintf("Cross-zone load balancing %s for %s", state, loadBalancerArn), map[string]interface{}{
+		"loadBalancerArn":  loadBalancerArn,
+		"crossZoneEnabled": enabled,
+	})
+}
@@ -2,6 +2,7 @@ package tools
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -50,6 +51,15 @@ func NewCreateLaunchTemplateTool(awsClient *aws.Client, actionType string, logge
 				},
 				"description": "List of security group IDs",
 			},
+			"iamInstanceProfile": map[string]interface{}{
+				"type":        "string",
+				"description": "Instance profile name or ARN for launched instances (e.g. {{step-create-profile.resourceId}})",
+			},
+			"userData": map[string]interface{}{
+				"type":        "string",
+				"description": "User data script run at instance launch, plain text or base64 encoded",
+				"sensitive":   true,
+			},
 			"networkInterfaces": map[string]interface{}{
 				"type": "array",
 				"items": map[string]interface{}{
@@ -151,6 +161,15 @@ func (t *CreateLaunchTemplateTool) Execute(ctx context.Context, arguments map[st
 
 	keyName, _ := arguments["keyName"].(string)
 	versionDescription, _ := arguments["versionDescription"].(string)
+	iamInstanceProfile, _ := arguments["iamInstanceProfile"].(string)
+
+	// Launch templates take base64 encoded user data
+	userData, _ := arguments["userData"].(string)
+	if userData != "" {
+		if _, err := base64.StdEncoding.DecodeString(userData); err != nil {
+			userData = base64.StdEncoding.EncodeToString([]byte(userData))
+		}
+	}
 
 	var securityGroupIds []string
 	if sgIds, ok := arguments["securityGroupIds"].([]interface{}); ok {
@@ -188,6 +207,8 @@ func (t *CreateLaunchTemplateTool) Execute(ctx context.Context, arguments map[st
 		InstanceType:       instanceType,
 		KeyName:            keyName,
 		SecurityGroupIDs:   securityGroupIds,
+		IamInstanceProfile: iamInstanceProfile,
+		UserData:           userData,
 		Tags:               map[string]string{},
 		VersionDescription: versionDescription,
 		NetworkInterfaces:  networkInterfaces,
@@ -209,6 +230,7 @@ func (t *CreateLaunchTemplateTool) Execute(ctx context.Context, arguments map[st
 		"instanceType":       instanceType,
 		"keyName":            keyName,
 		"securityGroupIds":   securityGroupIds,
+		"iamInstanceProfile": iamInstanceProfile,
 		"networkInterfaces":  networkInterfaces,
 		"tagSpecifications":  tagSpecs,
 		"resource":           template,
@@ -0,0 +1,141 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/versus-control/ai-infrastructure-agent/internal/logging"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/cost"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/discovery"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/interfaces"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/types"
+)
+
+// CostReportTool estimates the monthly spend of managed and discovered infrastructure and
+// flags idle resources
+type CostReportTool struct {
+	*BaseTool
+	deps *ToolDependencies
+}
+
+// NewCostReportTool creates a cost and rightsizing report tool
+func NewCostReportTool(deps *ToolDependencies, actionType string, logger *logging.Logger) interfaces.MCPTool {
+	inputSchema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"scan_live": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Whether to include live discovery. Volumes, Elastic IPs, route tables and CPU utilization, which idle and oversized resource checks need, are only found by a live scan",
+				"default":     true,
+			},
+			"tag_keys": map[string]interface{}{
+				"type":        "array",
+				"description": "Tag keys to group spend by. Defaults to Workspace and Environment",
+				"items": map[string]interface{}{
+					"type": "string",
+				},
+			},
+			"regions": regionsSchemaProperty,
+		},
+	}
+
+	baseTool := NewBaseTool(
+		"generate-cost-report",
+		"Estimate the monthly spend of existing infrastructure by resource type and tag, and flag idle resources such as unattached volumes, Elastic IPs of stopped instances and NAT gateways no private subnet uses, and EC2 and RDS instances oversized for their CPU utilization",
+		"state",
+		actionType,
+		inputSchema,
+		logger,
+	)
+
+	baseTool.AddExample(
+		"Report spend grouped by environment",
+		map[string]interface{}{
+			"scan_live": true,
+			"tag_keys":  []string{"Environment"},
+		},
+		"Cost report with monthly spend per resource type and Environment tag, and cleanup findings",
+	)
+
+	return &CostReportTool{
+		BaseTool: baseTool,
+		deps:     deps,
+	}
+}
+
+// Execute builds the cost report
+func (t *CostReportTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	if t.deps == nil || t.deps.CostCatalog == nil {
+		return t.CreateErrorResponse(fmt.Sprintf("Cost reports require a pricing catalog (%s or %s)", cost.DefaultCatalogFile, cost.CatalogFileEnvVar))
+	}
+
+	scanLive := getBoolValue(arguments, "scan_live", true)
+	resources := make(map[string]*types.ResourceState)
+	managedIDs := make(map[string]bool)
+	defaultRegion := ""
+	if t.deps.AWSClient != nil {
+		defaultRegion = t.deps.AWSClient.GetRegion()
+	}
+
+	if t.deps.StateManager != nil {
+		if err := t.deps.StateManager.LoadState(ctx); err != nil {
+			t.GetLogger().WithError(err).Warn("Failed to load state from file, continuing with current state")
+		}
+		state := t.deps.StateManager.GetState()
+		if defaultRegion == "" {
+			defaultRegion = state.Region
+		}
+		for id, resource := range state.Resources {
+			resources[id] = resource
+			managedIDs[id] = true
+		}
+	}
+
+	if scanLive && t.deps.DiscoveryScanner != nil {
+		regions := regionsArgument(arguments)
+		if len(regions) == 0 {
+			regions = t.deps.DiscoveryScanner.ConfiguredRegions()
+		}
+		discoveredResources, err := t.deps.DiscoveryScanner.DiscoverBillableInfrastructureInRegions(ctx, regions)
+		if err != nil {
+			return t.CreateErrorResponse(fmt.Sprintf("Failed to discover infrastructure: %s", err.Error()))
+		}
+
+		// Discovered resources describe the current AWS state, so they replace state entries
+		for _, resource := range discoveredResources {
+			resources[resource.ID] = resource
+			if discovery.IsAgentManaged(resource) {
+				managedIDs[resource.ID] = true
+			}
+		}
+	}
+
+	ordered := make([]*types.ResourceState, 0, len(resources))
+	for _, resource := range resources {
+		ordered = append(ordered, resource)
+	}
+	sort.Slice(ordered, func(i, j int) bool { return ordered[i].ID < ordered[j].ID })
+
+	report := cost.NewEstimator(t.deps.CostCatalog).EstimateResources(ordered, cost.ReportOptions{
+		DefaultRegion: defaultRegion,
+		TagKeys:       getStringSlice(arguments, "tag_keys"),
+		ManagedIDs:    managedIDs,
+	})
+
+	t.GetLogger().WithFields(map[string]interface{}{
+		"scan_live":       scanLive,
+		"resource_count":  len(report.Resources),
+		"monthly_total":   report.MonthlyTotal,
+		"finding_count":   len(report.Findings),
+		"monthly_savings": report.MonthlySavings,
+	}).Info("Generated cost report")
+
+	message := fmt.Sprintf("Estimated monthly spend of %.2f %s across %d billable resources, with %d cleanup findings saving %.2f %s",
+		report.MonthlyTotal, report.Currency, len(report.Resources), len(report.Findings), report.MonthlySavings, report.Currency)
+	return t.CreateSuccessResponse(message, map[string]interface{}{
+		"scan_live": scanLive,
+		"report":    report,
+	})
+}
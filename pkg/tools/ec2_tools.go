@@ -48,6 +48,10 @@ func NewCreateEC2InstanceTool(awsClient *aws.Client, actionType string, logger *
 				"type":        "string",
 				"description": "A name tag for the instance",
 			},
+			"iamInstanceProfile": map[string]interface{}{
+				"type":        "string",
+				"description": "Instance profile name or ARN granting the instance an IAM role (e.g. {{step-create-profile.resourceId}})",
+			},
 		},
 		"required": []interface{}{"imageId", "instanceType"},
 	}
@@ -90,15 +94,17 @@ func (t *CreateEC2InstanceTool) Execute(ctx context.Context, arguments map[strin
 	securityGroupID, _ := arguments["securityGroupId"].(string)
 	subnetID, _ := arguments["subnetId"].(string)
 	name, _ := arguments["name"].(string)
+	iamInstanceProfile, _ := arguments["iamInstanceProfile"].(string)
 
 	// Create parameters struct
 	params := aws.CreateInstanceParams{
-		ImageID:         imageID,
-		InstanceType:    instanceType,
-		KeyName:         keyName,
-		SecurityGroupID: securityGroupID,
-		SubnetID:        subnetID,
-		Name:            name,
+		ImageID:            imageID,
+		InstanceType:       instanceType,
+		KeyName:            keyName,
+		SecurityGroupID:    securityGroupID,
+		SubnetID:           subnetID,
+		Name:               name,
+		IamInstanceProfile: iamInstanceProfile,
 	}
 
 	// Validate parameters
@@ -427,6 +433,7 @@ func (t *TerminateEC2InstanceTool) Execute(ctx context.Context, arguments map[st
 type CreateAMIFromInstanceTool struct {
 	*BaseTool
 	specializedAdapter interfaces.SpecializedOperations
+	awsClient          *aws.Client
 }
 
 // NewCreateAMIFromInstanceTool creates a new AMI creation tool
@@ -446,6 +453,7 @@ func NewCreateAMIFromInstanceTool(awsClient *aws.Client, actionType string, logg
 				"type":        "string",
 				"description": "The description for the AMI",
 			},
+			"wait": waitSchemaProperty("AMI"),
 		},
 		"required": []string{"instanceId", "name"},
 	}
@@ -474,6 +482,7 @@ func NewCreateAMIFromInstanceTool(awsClient *aws.Client, actionType string, logg
 	return &CreateAMIFromInstanceTool{
 		BaseTool:           baseTool,
 		specializedAdapter: specializedAdapter,
+		awsClient:          awsClient,
 	}
 }
 
@@ -503,7 +512,6 @@ func (t *CreateAMIFromInstanceTool) Execute(ctx context.Context, arguments map[s
 		return t.CreateErrorResponse(fmt.Sprintf("Failed to create AMI: %s", err.Error()))
 	}
 
-	message := fmt.Sprintf("Successfully created AMI %s from instance %s", resource.ID, instanceID)
 	data := map[string]interface{}{
 		"amiId":       resource.ID,
 		"instanceId":  instanceID,
@@ -513,6 +521,17 @@ func (t *CreateAMIFromInstanceTool) Execute(ctx context.Context, arguments map[s
 		"details":     resource.Details,
 	}
 
+	if getBoolValue(arguments, "wait", false) {
+		if err := t.awsClient.WaitForAMI(ctx, resource.ID); err != nil {
+			// The AMI exists, so its ID goes back for the caller to record it
+			data["resourceId"] = resource.ID
+			return t.CreateErrorResponseWithData(fmt.Sprintf("AMI %s was created but did not become available: %s", resource.ID, err.Error()), data)
+		}
+		resource.State = "available"
+		data["state"] = resource.State
+	}
+
+	message := fmt.Sprintf("Successfully created AMI %s from instance %s", resource.ID, instanceID)
 	return t.CreateSuccessResponse(message, data)
 }
 
@@ -590,3 +609,109 @@ func (t *ListAMIsTool) Execute(ctx context.Context, arguments map[string]interfa
 
 	return t.CreateSuccessResponse(message, data)
 }
+
+// DeleteVolumeTool implements MCPTool for deleting unattached EBS volumes
+type DeleteVolumeTool struct {
+	*BaseTool
+	awsClient *aws.Client
+}
+
+// NewDeleteVolumeTool creates a new EBS volume deletion tool
+func NewDeleteVolumeTool(awsClient *aws.Client, actionType string, logger *logging.Logger) interfaces.MCPTool {
+	inputSchema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"volumeId": map[string]interface{}{
+				"type":        "string",
+				"description": "The ID of the unattached EBS volume to delete",
+			},
+		},
+		"required": []interface{}{"volumeId"},
+	}
+
+	baseTool := NewBaseTool(
+		"delete-volume",
+		"Delete an EBS volume that is not attached to any instance (permanent deletion)",
+		"ec2",
+		actionType,
+		inputSchema,
+		logger,
+	)
+
+	return &DeleteVolumeTool{
+		BaseTool:  baseTool,
+		awsClient: awsClient,
+	}
+}
+
+// Execute deletes an EBS volume
+func (t *DeleteVolumeTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	volumeID, ok := arguments["volumeId"].(string)
+	if !ok || volumeID == "" {
+		return t.CreateErrorResponse("volumeId is required")
+	}
+
+	if err := t.awsClient.DeleteVolume(ctx, volumeID); err != nil {
+		return t.CreateErrorResponse(fmt.Sprintf("Failed to delete volume: %s", err.Error()))
+	}
+
+	data := map[string]interface{}{
+		"volumeId": volumeID,
+		"deleted":  true,
+	}
+
+	return t.CreateSuccessResponse(fmt.Sprintf("Successfully deleted volume %s", volumeID), data)
+}
+
+// ReleaseElasticIPTool implements MCPTool for releasing Elastic IP addresses
+type ReleaseElasticIPTool struct {
+	*BaseTool
+	awsClient *aws.Client
+}
+
+// NewReleaseElasticIPTool creates a new Elastic IP release tool
+func NewReleaseElasticIPTool(awsClient *aws.Client, actionType string, logger *logging.Logger) interfaces.MCPTool {
+	inputSchema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"allocationId": map[string]interface{}{
+				"type":        "string",
+				"description": "The allocation ID of the Elastic IP address to release",
+			},
+		},
+		"required": []interface{}{"allocationId"},
+	}
+
+	baseTool := NewBaseTool(
+		"release-elastic-ip",
+		"Release an Elastic IP address, disassociating it from its instance first",
+		"ec2",
+		actionType,
+		inputSchema,
+		logger,
+	)
+
+	return &ReleaseElasticIPTool{
+		BaseTool:  baseTool,
+		awsClient: awsClient,
+	}
+}
+
+// Execute releases an Elastic IP address
+func (t *ReleaseElasticIPTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	allocationID, ok := arguments["allocationId"].(string)
+	if !ok || allocationID == "" {
+		return t.CreateErrorResponse("allocationId is required")
+	}
+
+	if err := t.awsClient.ReleaseAddress(ctx, allocationID); err != nil {
+		return t.CreateErrorResponse(fmt.Sprintf("Failed to release Elastic IP: %s", err.Error()))
+	}
+
+	data := map[string]interface{}{
+		"allocationId": allocationID,
+		"released":     true,
+	}
+
+	return t.CreateSuccessResponse(fmt.Sprintf("Successfully released Elastic IP %s", allocationID), data)
+}
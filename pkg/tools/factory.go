@@ -7,6 +7,7 @@ import (
 	"github.com/versus-control/ai-infrastructure-agent/internal/logging"
 	"github.com/versus-control/ai-infrastructure-agent/pkg/aws"
 	"github.com/versus-control/ai-infrastructure-agent/pkg/conflict"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/cost"
 	"github.com/versus-control/ai-infrastructure-agent/pkg/discovery"
 	"github.com/versus-control/ai-infrastructure-agent/pkg/graph"
 	"github.com/versus-control/ai-infrastructure-agent/pkg/interfaces"
@@ -21,12 +22,14 @@ type ToolFactoryImpl struct {
 // ToolDependencies contains all dependencies needed to create tools
 type ToolDependencies struct {
 	AWSClient        *aws.Client
+	ClientPool       *aws.ClientPool
 	StateManager     interfaces.StateManager
 	DiscoveryScanner *discovery.Scanner
 	GraphManager     *graph.Manager
 	GraphAnalyzer    *graph.Analyzer
 	ConflictResolver *conflict.Resolver
 	Config           *config.Config
+	CostCatalog      *cost.Catalog
 }
 
 // NewToolFactory creates a new tool factory
@@ -63,6 +66,10 @@ func (f *ToolFactoryImpl) CreateTool(toolType string, actionType string, depende
 		return NewStopEC2InstanceTool(deps.AWSClient, actionType, f.logger), nil
 	case "terminate-ec2-instance":
 		return NewTerminateEC2InstanceTool(deps.AWSClient, actionType, f.logger), nil
+	case "delete-volume":
+		return NewDeleteVolumeTool(deps.AWSClient, actionType, f.logger), nil
+	case "release-elastic-ip":
+		return NewReleaseElasticIPTool(deps.AWSClient, actionType, f.logger), nil
 	case "create-ami-from-instance":
 		return NewCreateAMIFromInstanceTool(deps.AWSClient, actionType, f.logger), nil
 	case "list-amis":
@@ -70,7 +77,11 @@ func (f *ToolFactoryImpl) CreateTool(toolType string, actionType string, depende
 
 	// Key Pair Tools
 	case "create-key-pair":
-		return NewCreateKeyPairTool(deps.AWSClient, actionType, f.logger), nil
+		stateFile := ""
+		if deps.Config != nil {
+			stateFile = deps.Config.State.FilePath
+		}
+		return NewCreateKeyPairTool(deps.AWSClient, stateFile, actionType, f.logger), nil
 	case "list-key-pairs":
 		return NewListKeyPairsTool(deps.AWSClient, actionType, f.logger), nil
 	case "get-key-pair":
@@ -103,6 +114,8 @@ func (f *ToolFactoryImpl) CreateTool(toolType string, actionType string, depende
 		return NewCreateNATGatewayTool(deps.AWSClient, actionType, f.logger), nil
 	case "describe-nat-gateways":
 		return NewDescribeNATGatewaysTool(deps.AWSClient, actionType, f.logger), nil
+	case "delete-nat-gateway":
+		return NewDeleteNATGatewayTool(deps.AWSClient, actionType, f.logger), nil
 	case "create-public-route-table":
 		return NewCreatePublicRouteTableTool(deps.AWSClient, actionType, f.logger), nil
 	case "create-private-route-table":
@@ -121,6 +134,14 @@ func (f *ToolFactoryImpl) CreateTool(toolType string, actionType string, depende
 		return NewAddSecurityGroupIngressRuleTool(deps.AWSClient, actionType, f.logger), nil
 	case "add-security-group-egress-rule":
 		return NewAddSecurityGroupEgressRuleTool(deps.AWSClient, actionType, f.logger), nil
+	case "revoke-security-group-ingress-rule":
+		return NewRevokeSecurityGroupIngressRuleTool(deps.AWSClient, actionType, f.logger), nil
+	case "revoke-security-group-egress-rule":
+		return NewRevokeSecurityGroupEgressRuleTool(deps.AWSClient, actionType, f.logger), nil
+	case "set-security-group-rules":
+		return NewSetSecurityGroupRulesTool(deps.AWSClient, actionType, f.logger), nil
+	case "list-security-group-rules":
+		return NewListSecurityGroupRulesTool(deps.AWSClient, actionType, f.logger), nil
 	case "delete-security-group":
 		return NewDeleteSecurityGroupTool(deps.AWSClient, actionType, f.logger), nil
 
@@ -137,6 +158,10 @@ func (f *ToolFactoryImpl) CreateTool(toolType string, actionType string, depende
 	// Load Balancer Tools
 	case "create-load-balancer":
 		return NewCreateLoadBalancerTool(deps.AWSClient, actionType, f.logger), nil
+	case "create-network-load-balancer":
+		return NewCreateNetworkLoadBalancerTool(deps.AWSClient, actionType, f.logger), nil
+	case "set-load-balancer-cross-zone":
+		return NewSetLoadBalancerCrossZoneTool(deps.AWSClient, actionType, f.logger), nil
 	case "create-target-group":
 		return NewCreateTargetGroupTool(deps.AWSClient, actionType, f.logger), nil
 	case "create-listener":
@@ -149,6 +174,24 @@ func (f *ToolFactoryImpl) CreateTool(toolType string, actionType string, depende
 		return NewRegisterTargetsTool(deps.AWSClient, actionType, f.logger), nil
 	case "deregister-targets":
 		return NewDeregisterTargetsTool(deps.AWSClient, actionType, f.logger), nil
+	case "list-listeners":
+		return NewListListenersTool(deps.AWSClient, actionType, f.logger), nil
+	case "create-listener-rule":
+		return NewCreateListenerRuleTool(deps.AWSClient, actionType, f.logger), nil
+	case "modify-listener-rule":
+		return NewModifyListenerRuleTool(deps.AWSClient, actionType, f.logger), nil
+	case "list-listener-rules":
+		return NewListListenerRulesTool(deps.AWSClient, actionType, f.logger), nil
+	case "find-acm-certificate":
+		return NewFindACMCertificateTool(deps.AWSClient, actionType, f.logger), nil
+	case "delete-load-balancer":
+		return NewDeleteLoadBalancerTool(deps.AWSClient, actionType, f.logger), nil
+	case "delete-target-group":
+		return NewDeleteTargetGroupTool(deps.AWSClient, actionType, f.logger), nil
+	case "delete-listener":
+		return NewDeleteListenerTool(deps.AWSClient, actionType, f.logger), nil
+	case "delete-listener-rule":
+		return NewDeleteListenerRuleTool(deps.AWSClient, actionType, f.logger), nil
 
 	// AMI Tools
 	case "get-latest-amazon-linux-ami":
@@ -179,12 +222,68 @@ func (f *ToolFactoryImpl) CreateTool(toolType string, actionType string, depende
 		return NewListDBInstancesTool(deps.AWSClient, actionType, f.logger), nil
 	case "list-db-snapshots":
 		return NewListDBSnapshotsTool(deps.AWSClient, actionType, f.logger), nil
+	case "modify-db-instance":
+		return NewModifyDBInstanceTool(deps.AWSClient, actionType, f.logger), nil
+	case "create-db-read-replica":
+		return NewCreateDBReadReplicaTool(deps.AWSClient, actionType, f.logger), nil
+	case "restore-db-instance-from-snapshot":
+		return NewRestoreDBInstanceFromSnapshotTool(deps.AWSClient, actionType, f.logger), nil
+	case "describe-db-instances":
+		return NewDescribeDBInstancesTool(deps.AWSClient, actionType, f.logger), nil
+	case "create-db-parameter-group":
+		return NewCreateDBParameterGroupTool(deps.AWSClient, actionType, f.logger), nil
+	case "modify-db-parameter-group":
+		return NewModifyDBParameterGroupTool(deps.AWSClient, actionType, f.logger), nil
+	case "list-db-parameter-groups":
+		return NewListDBParameterGroupsTool(deps.AWSClient, actionType, f.logger), nil
+	case "delete-db-parameter-group":
+		return NewDeleteDBParameterGroupTool(deps.AWSClient, actionType, f.logger), nil
+
+	// S3 Tools
+	case "create-s3-bucket":
+		return NewCreateS3BucketTool(deps.AWSClient, actionType, f.logger), nil
+	case "list-s3-buckets":
+		return NewListS3BucketsTool(deps.AWSClient, actionType, f.logger), nil
+	case "get-s3-bucket":
+		return NewGetS3BucketTool(deps.AWSClient, actionType, f.logger), nil
+	case "put-s3-bucket-versioning":
+		return NewPutS3BucketVersioningTool(deps.AWSClient, actionType, f.logger), nil
+	case "put-s3-bucket-encryption":
+		return NewPutS3BucketEncryptionTool(deps.AWSClient, actionType, f.logger), nil
+	case "put-s3-public-access-block":
+		return NewPutS3PublicAccessBlockTool(deps.AWSClient, actionType, f.logger), nil
+	case "put-s3-bucket-lifecycle":
+		return NewPutS3BucketLifecycleTool(deps.AWSClient, actionType, f.logger), nil
+	case "put-s3-bucket-policy":
+		return NewPutS3BucketPolicyTool(deps.AWSClient, actionType, f.logger), nil
+	case "delete-s3-bucket":
+		return NewDeleteS3BucketTool(deps.AWSClient, actionType, f.logger), nil
+
+	// IAM Tools
+	case "create-iam-role":
+		return NewCreateIAMRoleTool(deps.AWSClient, actionType, f.logger), nil
+	case "attach-role-policy":
+		return NewAttachRolePolicyTool(deps.AWSClient, actionType, f.logger), nil
+	case "put-role-inline-policy":
+		return NewPutRoleInlinePolicyTool(deps.AWSClient, actionType, f.logger), nil
+	case "create-instance-profile":
+		return NewCreateInstanceProfileTool(deps.AWSClient, actionType, f.logger), nil
+	case "list-iam-roles":
+		return NewListIAMRolesTool(deps.AWSClient, actionType, f.logger), nil
+	case "list-iam-policy-templates":
+		return NewListIAMPolicyTemplatesTool(actionType, f.logger), nil
+	case "delete-iam-role":
+		return NewDeleteIAMRoleTool(deps.AWSClient, actionType, f.logger), nil
+	case "delete-instance-profile":
+		return NewDeleteInstanceProfileTool(deps.AWSClient, actionType, f.logger), nil
 
 	// State Management Tools
 	case "analyze-infrastructure-state":
 		return NewAnalyzeStateTool(deps, deps.AWSClient, actionType, f.logger), nil
 	case "export-infrastructure-state":
 		return NewExportStateTool(deps, deps.AWSClient, actionType, f.logger), nil
+	case "generate-cost-report":
+		return NewCostReportTool(deps, actionType, f.logger), nil
 
 	// State-Aware Tools
 	case "visualize-dependency-graph":
@@ -195,6 +294,8 @@ func (f *ToolFactoryImpl) CreateTool(toolType string, actionType string, depende
 		return NewSaveStateTool(deps, actionType, f.logger), nil
 	case "add-resource-to-state":
 		return NewAddResourceToStateTool(deps, actionType, f.logger), nil
+	case "record-execution":
+		return NewRecordExecutionTool(deps, actionType, f.logger), nil
 	case "plan-infrastructure-deployment":
 		return NewPlanDeploymentTool(deps, actionType, f.logger), nil
 
@@ -223,11 +324,19 @@ func (f *ToolFactoryImpl) GetSupportedToolTypes() map[string][]string {
 			"create-launch-template",
 			"create-auto-scaling-group",
 			"create-load-balancer",
+			"create-network-load-balancer",
 			"create-target-group",
 			"create-listener",
+			"create-listener-rule",
 			"create-db-subnet-group",
 			"create-db-instance",
 			"create-db-snapshot",
+			"create-db-read-replica",
+			"restore-db-instance-from-snapshot",
+			"create-db-parameter-group",
+			"create-s3-bucket",
+			"create-iam-role",
+			"create-instance-profile",
 		},
 		"query": {
 			"list-ec2-instances",
@@ -241,44 +350,81 @@ func (f *ToolFactoryImpl) GetSupportedToolTypes() map[string][]string {
 			"select-subnets-for-alb",
 			"describe-nat-gateways",
 			"list-security-groups",
+			"list-security-group-rules",
 			"list-auto-scaling-groups",
 			"list-launch-templates",
 			"list-load-balancers",
 			"list-target-groups",
+			"list-listeners",
+			"list-listener-rules",
+			"find-acm-certificate",
 			"get-latest-amazon-linux-ami",
 			"get-latest-ubuntu-ami",
 			"get-latest-windows-ami",
 			"get-availability-zones",
 			"list-db-instances",
 			"list-db-snapshots",
+			"describe-db-instances",
+			"list-db-parameter-groups",
+			"list-s3-buckets",
+			"get-s3-bucket",
+			"list-iam-roles",
+			"list-iam-policy-templates",
 		},
 		"modification": {
 			"start-ec2-instance",
 			"stop-ec2-instance",
 			"start-db-instance",
 			"stop-db-instance",
+			"modify-db-instance",
+			"modify-db-parameter-group",
+			"modify-listener-rule",
+			"set-security-group-rules",
+			"set-load-balancer-cross-zone",
+			"put-s3-bucket-versioning",
+			"put-s3-bucket-encryption",
+			"put-s3-public-access-block",
+			"put-s3-bucket-lifecycle",
+			"put-s3-bucket-policy",
 		},
 		"deletion": {
 			"terminate-ec2-instance",
+			"delete-volume",
+			"release-elastic-ip",
+			"delete-nat-gateway",
 			"delete-security-group",
 			"delete-db-instance",
+			"delete-db-parameter-group",
+			"delete-load-balancer",
+			"delete-target-group",
+			"delete-listener",
+			"delete-listener-rule",
+			"delete-s3-bucket",
+			"delete-iam-role",
+			"delete-instance-profile",
 		},
 		"association": {
 			"associate-route-table",
 			"add-route",
 			"add-security-group-ingress-rule",
 			"add-security-group-egress-rule",
+			"revoke-security-group-ingress-rule",
+			"revoke-security-group-egress-rule",
 			"register-targets",
 			"deregister-targets",
+			"attach-role-policy",
+			"put-role-inline-policy",
 		},
 		"state": {
 			"analyze-infrastructure-state",
 			"export-infrastructure-state",
+			"generate-cost-report",
 			"visualize-dependency-graph",
 			"detect-infrastructure-conflicts",
 			"plan-infrastructure-deployment",
 			"add-resource-to-state",
 			"save-state",
+			"record-execution",
 		},
 	}
 }
@@ -296,6 +442,17 @@ func (f *ToolFactoryImpl) GetToolActionType(toolName string) string {
 	return "unknown"
 }
 
+// internalTools are called by the agent itself to maintain the state. They are registered like
+// any other tool but are not listed to the planner or to MCP clients.
+var internalTools = map[string]bool{
+	"record-execution": true,
+}
+
+// IsInternalTool reports whether a tool is reserved for the agent
+func IsInternalTool(toolName string) bool {
+	return internalTools[toolName]
+}
+
 // ToolRegistrationHelper helps register all standard tools
 type ToolRegistrationHelper struct {
 	factory  interfaces.ToolFactory
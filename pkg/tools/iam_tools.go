@@ -0,0 +1,634 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/versus-control/ai-infrastructure-agent/internal/logging"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/adapters"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/aws"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/interfaces"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/types"
+)
+
+// policyTemplatesSchema is the shared input schema for least-privilege policy templates
+var policyTemplatesSchema = map[string]interface{}{
+	"type":        "array",
+	"description": "Least-privilege policy templates to grant. Each item has 'name' (ssm-managed-instance, cloudwatch-agent, s3-read-only, s3-read-write, ssm-parameter-read, secrets-read, cloudwatch-logs-write) and 'resources' (bucket names, parameter paths, secret ARNs or log group names the template is scoped to)",
+	"items": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"name": map[string]interface{}{
+				"type": "string",
+			},
+			"resources": map[string]interface{}{
+				"type":  "array",
+				"items": map[string]interface{}{"type": "string"},
+			},
+		},
+	},
+}
+
+// getStringSlice extracts a string array argument
+func getStringSlice(arguments map[string]interface{}, key string) []string {
+	var values []string
+	if rawValues, ok := arguments[key].([]interface{}); ok {
+		for _, rawValue := range rawValues {
+			if value, ok := rawValue.(string); ok && value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+// renderPolicyTemplates renders the policyTemplates argument into managed policy ARNs
+// and inline policy documents keyed by template name
+func renderPolicyTemplates(arguments map[string]interface{}) ([]string, map[string]string, error) {
+	var managedPolicyARNs []string
+	inlinePolicies := make(map[string]string)
+
+	rawTemplates, _ := arguments["policyTemplates"].([]interface{})
+	for _, rawTemplate := range rawTemplates {
+		templateMap, ok := rawTemplate.(map[string]interface{})
+		if !ok {
+			return nil, nil, fmt.Errorf("each policy template must be an object with name and resources")
+		}
+
+		name, _ := templateMap["name"].(string)
+		managedPolicyARN, document, err := aws.RenderIAMPolicyTemplate(name, getStringSlice(templateMap, "resources"))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if managedPolicyARN != "" {
+			managedPolicyARNs = append(managedPolicyARNs, managedPolicyARN)
+		} else {
+			inlinePolicies[name] = document
+		}
+	}
+
+	return managedPolicyARNs, inlinePolicies, nil
+}
+
+// CreateIAMRoleTool implements MCPTool for creating IAM roles
+type CreateIAMRoleTool struct {
+	*BaseTool
+	adapter interfaces.AWSResourceAdapter
+}
+
+// NewCreateIAMRoleTool creates a new IAM role creation tool
+func NewCreateIAMRoleTool(awsClient *aws.Client, actionType string, logger *logging.Logger) interfaces.MCPTool {
+	inputSchema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"roleName": map[string]interface{}{
+				"type":        "string",
+				"description": "The name of the IAM role",
+			},
+			"description": map[string]interface{}{
+				"type":        "string",
+				"description": "A description of what the role is for",
+			},
+			"trustedService": map[string]interface{}{
+				"type":        "string",
+				"description": "Service principal allowed to assume the role",
+				"default":     "ec2.amazonaws.com",
+			},
+			"policyTemplates": policyTemplatesSchema,
+			"managedPolicyArns": map[string]interface{}{
+				"type":        "array",
+				"description": "Additional managed policy ARNs to attach. Prefer policyTemplates; broad policies such as AdministratorAccess are rejected",
+				"items":       map[string]interface{}{"type": "string"},
+			},
+			"tags": map[string]interface{}{
+				"type":        "object",
+				"description": "Tags to apply to the role",
+			},
+		},
+		"required": []interface{}{"roleName"},
+	}
+
+	baseTool := NewBaseTool(
+		"create-iam-role",
+		"Create an IAM role that an AWS service (EC2 by default) can assume, granting least-privilege permissions from policy templates",
+		"iam",
+		actionType,
+		inputSchema,
+		logger,
+	)
+
+	baseTool.AddExample(
+		"Create a role for web servers that reads assets from S3 and is managed by SSM",
+		map[string]interface{}{
+			"roleName": "web-server-role",
+			"policyTemplates": []interface{}{
+				map[string]interface{}{"name": "ssm-managed-instance"},
+				map[string]interface{}{"name": "s3-read-only", "resources": []interface{}{"my-app-assets-bucket"}},
+			},
+		},
+		"Successfully created IAM role web-server-role",
+	)
+
+	return &CreateIAMRoleTool{
+		BaseTool: baseTool,
+		adapter:  adapters.NewIAMAdapter(awsClient, logger),
+	}
+}
+
+// Execute creates an IAM role
+func (t *CreateIAMRoleTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	roleName, _ := arguments["roleName"].(string)
+	description, _ := arguments["description"].(string)
+	trustedService, _ := arguments["trustedService"].(string)
+
+	managedPolicyARNs, inlinePolicies, err := renderPolicyTemplates(arguments)
+	if err != nil {
+		return t.CreateErrorResponse(err.Error())
+	}
+	managedPolicyARNs = append(managedPolicyARNs, getStringSlice(arguments, "managedPolicyArns")...)
+
+	tags := make(map[string]string)
+	if tagsArg, ok := arguments["tags"].(map[string]interface{}); ok {
+		for k, v := range tagsArg {
+			tags[k] = fmt.Sprintf("%v", v)
+		}
+	}
+
+	params := aws.CreateRoleParams{
+		RoleName:          roleName,
+		Description:       description,
+		TrustedService:    trustedService,
+		ManagedPolicyARNs: managedPolicyARNs,
+		InlinePolicies:    inlinePolicies,
+		Tags:              tags,
+	}
+
+	if err := t.adapter.ValidateParams("create", params); err != nil {
+		return t.CreateErrorResponse(fmt.Sprintf("Parameter validation failed: %s", err.Error()))
+	}
+
+	role, err := t.adapter.Create(ctx, params)
+	if err != nil {
+		return t.CreateErrorResponse(fmt.Sprintf("Failed to create IAM role: %s", err.Error()))
+	}
+
+	message := fmt.Sprintf("Successfully created IAM role %s", role.ID)
+	data := map[string]interface{}{
+		"roleName":         role.ID,
+		"roleArn":          role.Details["roleArn"],
+		"attachedPolicies": role.Details["attachedPolicies"],
+		"inlinePolicies":   role.Details["inlinePolicies"],
+		"resource":         role,
+	}
+
+	return t.CreateSuccessResponse(message, data)
+}
+
+// AttachRolePolicyTool implements MCPTool for attaching policies to IAM roles
+type AttachRolePolicyTool struct {
+	*BaseTool
+	adapter interfaces.SpecializedOperations
+}
+
+// NewAttachRolePolicyTool creates a new tool for granting permissions to an existing role
+func NewAttachRolePolicyTool(awsClient *aws.Client, actionType string, logger *logging.Logger) interfaces.MCPTool {
+	inputSchema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"roleName": map[string]interface{}{
+				"type":        "string",
+				"description": "The name of the IAM role",
+			},
+			"policyTemplates": policyTemplatesSchema,
+			"policyArn": map[string]interface{}{
+				"type":        "string",
+				"description": "A managed policy ARN to attach instead of a template",
+			},
+		},
+		"required": []interface{}{"roleName"},
+	}
+
+	baseTool := NewBaseTool(
+		"attach-role-policy",
+		"Grant an existing IAM role additional permissions from policy templates or a managed policy ARN",
+		"iam",
+		actionType,
+		inputSchema,
+		logger,
+	)
+
+	baseTool.AddExample(
+		"Allow a role to read application secrets",
+		map[string]interface{}{
+			"roleName": "web-server-role",
+			"policyTemplates": []interface{}{
+				map[string]interface{}{"name": "secrets-read", "resources": []interface{}{"arn:aws:secretsmanager:us-east-1:123456789012:secret:app-db-AbCdEf"}},
+			},
+		},
+		"Updated IAM role web-server-role",
+	)
+
+	return &AttachRolePolicyTool{
+		BaseTool: baseTool,
+		adapter:  adapters.NewIAMSpecializedAdapter(awsClient, logger),
+	}
+}
+
+// Execute attaches policies to an IAM role
+func (t *AttachRolePolicyTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	roleName, _ := arguments["roleName"].(string)
+	if roleName == "" {
+		return t.CreateErrorResponse("roleName is required")
+	}
+
+	managedPolicyARNs, inlinePolicies, err := renderPolicyTemplates(arguments)
+	if err != nil {
+		return t.CreateErrorResponse(err.Error())
+	}
+	if policyARN, _ := arguments["policyArn"].(string); policyARN != "" {
+		managedPolicyARNs = append(managedPolicyARNs, policyARN)
+	}
+
+	if len(managedPolicyARNs) == 0 && len(inlinePolicies) == 0 {
+		return t.CreateErrorResponse("at least one policy template or policyArn is required")
+	}
+
+	var role *types.AWSResource
+	for _, policyARN := range managedPolicyARNs {
+		result, err := t.adapter.ExecuteSpecialOperation(ctx, "attach-policy", aws.AttachRolePolicyParams{
+			RoleName:  roleName,
+			PolicyARN: policyARN,
+		})
+		if err != nil {
+			return t.CreateErrorResponse(fmt.Sprintf("Failed to attach policy: %s", err.Error()))
+		}
+		role = result
+	}
+
+	for policyName, document := range inlinePolicies {
+		result, err := t.adapter.ExecuteSpecialOperation(ctx, "put-inline-policy", aws.PutRolePolicyParams{
+			RoleName:       roleName,
+			PolicyName:     policyName,
+			PolicyDocument: document,
+		})
+		if err != nil {
+			return t.CreateErrorResponse(fmt.Sprintf("Failed to add inline policy: %s", err.Error()))
+		}
+		role = result
+	}
+
+	data := map[string]interface{}{
+		"roleName":         roleName,
+		"attachedPolicies": managedPolicyARNs,
+		"inlinePolicies":   len(inlinePolicies),
+		"resource":         role,
+	}
+
+	return t.CreateSuccessResponse(fmt.Sprintf("Updated IAM role %s", roleName), data)
+}
+
+// PutRoleInlinePolicyTool implements MCPTool for adding a custom inline policy to a role
+type PutRoleInlinePolicyTool struct {
+	*BaseTool
+	adapter interfaces.SpecializedOperations
+}
+
+// NewPutRoleInlinePolicyTool creates a new inline policy tool
+func NewPutRoleInlinePolicyTool(awsClient *aws.Client, actionType string, logger *logging.Logger) interfaces.MCPTool {
+	inputSchema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"roleName": map[string]interface{}{
+				"type":        "string",
+				"description": "The name of the IAM role",
+			},
+			"policyName": map[string]interface{}{
+				"type":        "string",
+				"description": "The name of the inline policy",
+			},
+			"policyDocument": map[string]interface{}{
+				"type":        "string",
+				"description": "IAM policy JSON document. Scope actions and resources as narrowly as possible; statements allowing every action or resource are rejected",
+			},
+		},
+		"required": []interface{}{"roleName", "policyName", "policyDocument"},
+	}
+
+	baseTool := NewBaseTool(
+		"put-role-inline-policy",
+		"Add or replace a custom inline policy on an IAM role when no policy template fits",
+		"iam",
+		actionType,
+		inputSchema,
+		logger,
+	)
+
+	return &PutRoleInlinePolicyTool{
+		BaseTool: baseTool,
+		adapter:  adapters.NewIAMSpecializedAdapter(awsClient, logger),
+	}
+}
+
+// Execute adds an inline policy to an IAM role
+func (t *PutRoleInlinePolicyTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	roleName, _ := arguments["roleName"].(string)
+	policyName, _ := arguments["policyName"].(string)
+
+	document, err := parsePolicyDocument(arguments, "policyDocument")
+	if err != nil {
+		return t.CreateErrorResponse(err.Error())
+	}
+
+	role, err := t.adapter.ExecuteSpecialOperation(ctx, "put-inline-policy", aws.PutRolePolicyParams{
+		RoleName:       roleName,
+		PolicyName:     policyName,
+		PolicyDocument: document,
+	})
+	if err != nil {
+		return t.CreateErrorResponse(fmt.Sprintf("Failed to add inline policy: %s", err.Error()))
+	}
+
+	data := map[string]interface{}{
+		"roleName":   roleName,
+		"policyName": policyName,
+		"resource":   role,
+	}
+
+	return t.CreateSuccessResponse(fmt.Sprintf("Added inline policy %s to IAM role %s", policyName, roleName), data)
+}
+
+// CreateInstanceProfileTool implements MCPTool for creating instance profiles
+type CreateInstanceProfileTool struct {
+	*BaseTool
+	adapter interfaces.SpecializedOperations
+}
+
+// NewCreateInstanceProfileTool creates a new instance profile creation tool
+func NewCreateInstanceProfileTool(awsClient *aws.Client, actionType string, logger *logging.Logger) interfaces.MCPTool {
+	inputSchema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"instanceProfileName": map[string]interface{}{
+				"type":        "string",
+				"description": "The name of the instance profile",
+			},
+			"roleName": map[string]interface{}{
+				"type":        "string",
+				"description": "The IAM role to place in the instance profile",
+			},
+		},
+		"required": []interface{}{"instanceProfileName", "roleName"},
+	}
+
+	baseTool := NewBaseTool(
+		"create-instance-profile",
+		"Create an instance profile for an IAM role so EC2 instances and launch templates can use it (pass the result as iamInstanceProfile)",
+		"iam",
+		actionType,
+		inputSchema,
+		logger,
+	)
+
+	baseTool.AddExample(
+		"Wrap a role in an instance profile",
+		map[string]interface{}{
+			"instanceProfileName": "web-server-profile",
+			"roleName":            "{{step-create-role.resourceId}}",
+		},
+		"Successfully created instance profile web-server-profile",
+	)
+
+	return &CreateInstanceProfileTool{
+		BaseTool: baseTool,
+		adapter:  adapters.NewIAMSpecializedAdapter(awsClient, logger),
+	}
+}
+
+// Execute creates an instance profile
+func (t *CreateInstanceProfileTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	profileName, _ := arguments["instanceProfileName"].(string)
+	roleName, _ := arguments["roleName"].(string)
+
+	profile, err := t.adapter.ExecuteSpecialOperation(ctx, "create-instance-profile", aws.CreateInstanceProfileParams{
+		InstanceProfileName: profileName,
+		RoleName:            roleName,
+	})
+	if err != nil {
+		return t.CreateErrorResponse(fmt.Sprintf("Failed to create instance profile: %s", err.Error()))
+	}
+
+	message := fmt.Sprintf("Successfully created instance profile %s", profile.ID)
+	data := map[string]interface{}{
+		"instanceProfileName": profile.ID,
+		"instanceProfileArn":  profile.Details["instanceProfileArn"],
+		"roleName":            roleName,
+		"resource":            profile,
+	}
+
+	return t.CreateSuccessResponse(message, data)
+}
+
+// ListIAMRolesTool implements MCPTool for listing IAM roles
+type ListIAMRolesTool struct {
+	*BaseTool
+	adapter interfaces.AWSResourceAdapter
+}
+
+// NewListIAMRolesTool creates a new IAM role listing tool
+func NewListIAMRolesTool(awsClient *aws.Client, actionType string, logger *logging.Logger) interfaces.MCPTool {
+	inputSchema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"tags": map[string]interface{}{
+				"type":        "object",
+				"description": "Filter roles by tags (optional)",
+			},
+		},
+	}
+
+	baseTool := NewBaseTool(
+		"list-iam-roles",
+		"List IAM roles (service-linked roles are excluded)",
+		"iam",
+		actionType,
+		inputSchema,
+		logger,
+	)
+
+	return &ListIAMRolesTool{
+		BaseTool: baseTool,
+		adapter:  adapters.NewIAMAdapter(awsClient, logger),
+	}
+}
+
+// Execute lists IAM roles
+func (t *ListIAMRolesTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	tags := make(map[string]string)
+	if tagsArg, ok := arguments["tags"].(map[string]interface{}); ok {
+		for k, v := range tagsArg {
+			tags[k] = fmt.Sprintf("%v", v)
+		}
+	}
+
+	var roles []*types.AWSResource
+	var err error
+	if len(tags) > 0 {
+		roles, err = t.adapter.ListByTags(ctx, tags)
+	} else {
+		roles, err = t.adapter.List(ctx)
+	}
+	if err != nil {
+		return t.CreateErrorResponse(fmt.Sprintf("Failed to list IAM roles: %s", err.Error()))
+	}
+
+	data := map[string]interface{}{
+		"roles": roles,
+		"count": len(roles),
+	}
+
+	return t.CreateSuccessResponse(fmt.Sprintf("Found %d IAM roles", len(roles)), data)
+}
+
+// ListIAMPolicyTemplatesTool implements MCPTool for listing the built-in policy templates
+type ListIAMPolicyTemplatesTool struct {
+	*BaseTool
+}
+
+// NewListIAMPolicyTemplatesTool creates a new policy template listing tool
+func NewListIAMPolicyTemplatesTool(actionType string, logger *logging.Logger) interfaces.MCPTool {
+	inputSchema := map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{},
+	}
+
+	baseTool := NewBaseTool(
+		"list-iam-policy-templates",
+		"List the least-privilege IAM policy templates accepted by create-iam-role and attach-role-policy",
+		"iam",
+		actionType,
+		inputSchema,
+		logger,
+	)
+
+	return &ListIAMPolicyTemplatesTool{BaseTool: baseTool}
+}
+
+// Execute lists policy templates
+func (t *ListIAMPolicyTemplatesTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	templates := aws.ListIAMPolicyTemplates()
+
+	data := map[string]interface{}{
+		"templates": templates,
+		"count":     len(templates),
+	}
+
+	return t.CreateSuccessResponse(fmt.Sprintf("Found %d IAM policy templates", len(templates)), data)
+}
+
+// DeleteIAMRoleTool implements MCPTool for deleting IAM roles
+type DeleteIAMRoleTool struct {
+	*BaseTool
+	adapter interfaces.AWSResourceAdapter
+}
+
+// NewDeleteIAMRoleTool creates a new IAM role deletion tool
+func NewDeleteIAMRoleTool(awsClient *aws.Client, actionType string, logger *logging.Logger) interfaces.MCPTool {
+	inputSchema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"roleName": map[string]interface{}{
+				"type":        "string",
+				"description": "The name of the IAM role to delete",
+			},
+		},
+		"required": []interface{}{"roleName"},
+	}
+
+	baseTool := NewBaseTool(
+		"delete-iam-role",
+		"Delete an IAM role, detaching its policies and removing it from instance profiles first",
+		"iam",
+		actionType,
+		inputSchema,
+		logger,
+	)
+
+	return &DeleteIAMRoleTool{
+		BaseTool: baseTool,
+		adapter:  adapters.NewIAMAdapter(awsClient, logger),
+	}
+}
+
+// Execute deletes an IAM role
+func (t *DeleteIAMRoleTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	roleName, _ := arguments["roleName"].(string)
+	if roleName == "" {
+		return t.CreateErrorResponse("roleName is required")
+	}
+
+	if err := t.adapter.Delete(ctx, roleName); err != nil {
+		return t.CreateErrorResponse(err.Error())
+	}
+
+	data := map[string]interface{}{
+		"roleName": roleName,
+		"deleted":  true,
+	}
+
+	return t.CreateSuccessResponse(fmt.Sprintf("Deleted IAM role %s", roleName), data)
+}
+
+// DeleteInstanceProfileTool implements MCPTool for deleting instance profiles
+type DeleteInstanceProfileTool struct {
+	*BaseTool
+	adapter interfaces.SpecializedOperations
+}
+
+// NewDeleteInstanceProfileTool creates a new instance profile deletion tool
+func NewDeleteInstanceProfileTool(awsClient *aws.Client, actionType string, logger *logging.Logger) interfaces.MCPTool {
+	inputSchema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"instanceProfileName": map[string]interface{}{
+				"type":        "string",
+				"description": "The name of the instance profile to delete",
+			},
+		},
+		"required": []interface{}{"instanceProfileName"},
+	}
+
+	baseTool := NewBaseTool(
+		"delete-instance-profile",
+		"Delete an instance profile after removing its role",
+		"iam",
+		actionType,
+		inputSchema,
+		logger,
+	)
+
+	return &DeleteInstanceProfileTool{
+		BaseTool: baseTool,
+		adapter:  adapters.NewIAMSpecializedAdapter(awsClient, logger),
+	}
+}
+
+// Execute deletes an instance profile
+func (t *DeleteInstanceProfileTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	profileName, _ := arguments["instanceProfileName"].(string)
+	if profileName == "" {
+		return t.CreateErrorResponse("instanceProfileName is required")
+	}
+
+	if _, err := t.adapter.ExecuteSpecialOperation(ctx, "delete-instance-profile", profileName); err != nil {
+		return t.CreateErrorResponse(fmt.Sprintf("Failed to delete instance profile: %s", err.Error()))
+	}
+
+	data := map[string]interface{}{
+		"instanceProfileName": profileName,
+		"deleted":             true,
+	}
+
+	return t.CreateSuccessResponse(fmt.Sprintf("Deleted instance profile %s", profileName), data)
+}
@@ -10,17 +10,20 @@ import (
 	"github.com/versus-control/ai-infrastructure-agent/pkg/adapters"
 	"github.com/versus-control/ai-infrastructure-agent/pkg/aws"
 	"github.com/versus-control/ai-infrastructure-agent/pkg/interfaces"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/keystore"
 	"github.com/versus-control/ai-infrastructure-agent/pkg/types"
 )
 
 // CreateKeyPairTool implements MCPTool for creating EC2 key pairs
 type CreateKeyPairTool struct {
 	*BaseTool
-	adapter interfaces.AWSResourceAdapter
+	adapter     interfaces.AWSResourceAdapter
+	keystore    keystore.Store
+	keystoreErr error
 }
 
 // NewCreateKeyPairTool creates a new key pair creation tool
-func NewCreateKeyPairTool(awsClient *aws.Client, actionType string, logger *logging.Logger) interfaces.MCPTool {
+func NewCreateKeyPairTool(awsClient *aws.Client, stateFile string, actionType string, logger *logging.Logger) interfaces.MCPTool {
 	inputSchema := map[string]interface{}{
 		"type": "object",
 		"properties": map[string]interface{}{
@@ -50,7 +53,7 @@ func NewCreateKeyPairTool(awsClient *aws.Client, actionType string, logger *logg
 
 	baseTool := NewBaseTool(
 		"create-key-pair",
-		"Create a new EC2 key pair for SSH access. Returns the private key material which should be saved immediately as it cannot be retrieved later.",
+		"Create a new EC2 key pair for SSH access. The private key is stored in the encrypted keystore and only a reference is returned; download it from the web API.",
 		"ec2",
 		actionType,
 		inputSchema,
@@ -63,7 +66,7 @@ func NewCreateKeyPairTool(awsClient *aws.Client, actionType string, logger *logg
 		map[string]interface{}{
 			"keyName": "my-app-keypair",
 		},
-		"Successfully created key pair 'my-app-keypair' (RSA). Private key material returned - save it securely.",
+		"Successfully created key pair 'my-app-keypair' (RSA). Private key stored in keystore.",
 	)
 
 	baseTool.AddExample(
@@ -77,14 +80,23 @@ func NewCreateKeyPairTool(awsClient *aws.Client, actionType string, logger *logg
 				"Purpose":     "SSH access",
 			},
 		},
-		"Successfully created key pair 'production-keypair' (ED25519). Private key material returned.",
+		"Successfully created key pair 'production-keypair' (ED25519). Private key stored in keystore.",
 	)
 
 	adapter := adapters.NewKeyPairAdapter(awsClient, logger)
 
+	// Key pairs are refused at execution time if the keystore cannot be opened, so that
+	// private keys are never returned in responses as a fallback
+	store, err := keystore.NewFromEnv(stateFile, awsClient, logger)
+	if err != nil {
+		logger.WithError(err).Warn("Keystore unavailable, key pair creation is disabled")
+	}
+
 	return &CreateKeyPairTool{
-		BaseTool: baseTool,
-		adapter:  adapter,
+		BaseTool:    baseTool,
+		adapter:     adapter,
+		keystore:    store,
+		keystoreErr: err,
 	}
 }
 
@@ -125,6 +137,12 @@ func (t *CreateKeyPairTool) Execute(ctx context.Context, arguments map[string]in
 	if err := t.adapter.ValidateParams("create", params); err != nil {
 		return t.CreateErrorResponse(fmt.Sprintf("Parameter validation failed: %s", err.Error()))
 	}
+	if t.keystore == nil {
+		return t.CreateErrorResponse(fmt.Sprintf("Key pair creation is disabled because the keystore is unavailable: %v", t.keystoreErr))
+	}
+	if err := keystore.ValidateName(keyName); err != nil {
+		return t.CreateErrorResponse(fmt.Sprintf("Parameter validation failed: %s", err.Error()))
+	}
 
 	// Create the key pair using the adapter
 	resource, err := t.adapter.Create(ctx, params)
@@ -132,28 +150,34 @@ func (t *CreateKeyPairTool) Execute(ctx context.Context, arguments map[string]in
 		return t.CreateErrorResponse(fmt.Sprintf("Failed to create key pair: %s", err.Error()))
 	}
 
-	// Extract private key material from details
-	keyMaterial := ""
-	if details, ok := resource.Details["keyMaterial"].(string); ok {
-		keyMaterial = details
+	// Move the private key material into the keystore; it must not appear in the response
+	keyMaterial, _ := resource.Details["keyMaterial"].(string)
+	delete(resource.Details, "keyMaterial")
+
+	privateKeyRef, err := t.keystore.Put(ctx, resource.Region, keyName, []byte(keyMaterial))
+	if err != nil {
+		// A key pair whose private key was lost is unusable, so remove it again
+		if deleteErr := t.adapter.Delete(ctx, keyName); deleteErr != nil {
+			t.logger.WithError(deleteErr).WithField("keyName", keyName).Error("Failed to delete key pair after keystore failure")
+		}
+		return t.CreateErrorResponse(fmt.Sprintf("Failed to store private key, key pair was not kept: %s", err.Error()))
 	}
 
-	// Return success response with warning about saving the private key
-	message := fmt.Sprintf("Successfully created key pair '%s' (ID: %s, Type: %s, Format: %s)\n\n"+
-		"⚠️  IMPORTANT: Save the private key material below immediately. "+
-		"It cannot be retrieved after this response.\n\n"+
-		"Private Key Material:\n%s",
-		keyName, resource.ID, keyType, keyFormat, keyMaterial)
+	message := fmt.Sprintf("Successfully created key pair '%s' (ID: %s, Type: %s, Format: %s). "+
+		"The private key is stored in the %s keystore and can be downloaded from /api/keypairs/%s/private-key?region=%s",
+		keyName, resource.ID, keyType, keyFormat, t.keystore.Backend(), keyName, resource.Region)
 
 	data := map[string]interface{}{
-		"keyPairId":      resource.ID,
-		"keyName":        keyName,
-		"keyType":        keyType,
-		"keyFormat":      keyFormat,
-		"keyFingerprint": resource.Details["keyFingerprint"],
-		"keyMaterial":    keyMaterial,
-		"tags":           tagSpecs,
-		"state":          resource.State,
+		"keyPairId":       resource.ID,
+		"keyName":         keyName,
+		"region":          resource.Region,
+		"keyType":         keyType,
+		"keyFormat":       keyFormat,
+		"keyFingerprint":  resource.Details["keyFingerprint"],
+		"privateKeyRef":   privateKeyRef,
+		"keystoreBackend": t.keystore.Backend(),
+		"tags":            tagSpecs,
+		"state":           resource.State,
 	}
 
 	return t.CreateSuccessResponse(message, data)
@@ -406,7 +406,8 @@ func (t *CreateInternetGatewayTool) Execute(ctx context.Context, arguments map[s
 // CreateNATGatewayTool implements MCPTool for creating NAT gateways
 type CreateNATGatewayTool struct {
 	*BaseTool
-	adapter interfaces.SpecializedOperations
+	adapter   interfaces.SpecializedOperations
+	awsClient *aws.Client
 }
 
 // NewCreateNATGatewayTool creates a new NAT gateway creation tool
@@ -422,6 +423,7 @@ func NewCreateNATGatewayTool(awsClient *aws.Client, actionType string, logger *l
 				"type":        "string",
 				"description": "A name tag for the NAT gateway",
 			},
+			"wait": waitSchemaProperty("NAT gateway"),
 		},
 		"required": []string{"subnetId"},
 	}
@@ -430,10 +432,13 @@ func NewCreateNATGatewayTool(awsClient *aws.Client, actionType string, logger *l
 		BaseTool: &BaseTool{
 			name:        "create-nat-gateway",
 			description: "Create a new NAT gateway",
+			category:    "networking",
+			actionType:  actionType,
 			inputSchema: inputSchema,
 			logger:      logger,
 		},
-		adapter: adapters.NewVPCSpecializedAdapter(awsClient, logger),
+		adapter:   adapters.NewVPCSpecializedAdapter(awsClient, logger),
+		awsClient: awsClient,
 	}
 }
 
@@ -451,7 +456,6 @@ func (t *CreateNATGatewayTool) Execute(ctx context.Context, arguments map[string
 		return t.CreateErrorResponse(fmt.Sprintf("Failed to create NAT gateway: %s", err.Error()))
 	}
 
-	message := fmt.Sprintf("Successfully created NAT gateway %s in subnet %s", natGateway.ID, subnetID)
 	data := map[string]interface{}{
 		"natGatewayId": natGateway.ID,
 		"subnetId":     subnetID,
@@ -460,6 +464,16 @@ func (t *CreateNATGatewayTool) Execute(ctx context.Context, arguments map[string
 		"resource":     natGateway,
 	}
 
+	if getBoolValue(arguments, "wait", false) {
+		if err := t.awsClient.WaitForNATGateway(ctx, natGateway.ID); err != nil {
+			// The NAT gateway exists, so its ID goes back for the caller to record it
+			data["resourceId"] = natGateway.ID
+			return t.CreateErrorResponseWithData(fmt.Sprintf("NAT gateway %s was created but did not become available: %s", natGateway.ID, err.Error()), data)
+		}
+		natGateway.State = "available"
+	}
+
+	message := fmt.Sprintf("Successfully created NAT gateway %s in subnet %s", natGateway.ID, subnetID)
 	return t.CreateSuccessResponse(message, data)
 }
 
@@ -490,6 +504,8 @@ func NewCreatePublicRouteTableTool(awsClient *aws.Client, actionType string, log
 		BaseTool: &BaseTool{
 			name:        "create-public-route-table",
 			description: "Create a new public route table",
+			category:    "networking",
+			actionType:  actionType,
 			inputSchema: inputSchema,
 			logger:      logger,
 		},
@@ -551,6 +567,8 @@ func NewCreatePrivateRouteTableTool(awsClient *aws.Client, actionType string, lo
 		BaseTool: &BaseTool{
 			name:        "create-private-route-table",
 			description: "Create a new private route table",
+			category:    "networking",
+			actionType:  actionType,
 			inputSchema: inputSchema,
 			logger:      logger,
 		},
@@ -619,6 +637,8 @@ func NewAssociateRouteTableTool(awsClient *aws.Client, actionType string, logger
 		BaseTool: &BaseTool{
 			name:        "associate-route-table",
 			description: "Associate a route table with a subnet",
+			category:    "networking",
+			actionType:  actionType,
 			inputSchema: inputSchema,
 			logger:      logger,
 		},
@@ -692,6 +712,8 @@ func NewAddRouteTool(awsClient *aws.Client, actionType string, logger *logging.L
 		BaseTool: &BaseTool{
 			name:        "add-route",
 			description: "Add a route to a route table",
+			category:    "networking",
+			actionType:  actionType,
 			inputSchema: inputSchema,
 			logger:      logger,
 		},
@@ -1013,3 +1035,56 @@ func (t *DescribeNATGatewaysTool) Execute(ctx context.Context, arguments map[str
 
 	return t.CreateSuccessResponse(message, data)
 }
+
+// DeleteNATGatewayTool implements MCPTool for deleting NAT gateways
+type DeleteNATGatewayTool struct {
+	*BaseTool
+	awsClient *aws.Client
+}
+
+// NewDeleteNATGatewayTool creates a new NAT gateway deletion tool
+func NewDeleteNATGatewayTool(awsClient *aws.Client, actionType string, logger *logging.Logger) interfaces.MCPTool {
+	inputSchema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"natGatewayId": map[string]interface{}{
+				"type":        "string",
+				"description": "The ID of the NAT gateway to delete",
+			},
+		},
+		"required": []interface{}{"natGatewayId"},
+	}
+
+	baseTool := NewBaseTool(
+		"delete-nat-gateway",
+		"Delete a NAT gateway. Its Elastic IP stays allocated and can be released with release-elastic-ip",
+		"networking",
+		actionType,
+		inputSchema,
+		logger,
+	)
+
+	return &DeleteNATGatewayTool{
+		BaseTool:  baseTool,
+		awsClient: awsClient,
+	}
+}
+
+// Execute deletes a NAT gateway
+func (t *DeleteNATGatewayTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	natGatewayID, ok := arguments["natGatewayId"].(string)
+	if !ok || natGatewayID == "" {
+		return t.CreateErrorResponse("natGatewayId is required")
+	}
+
+	if err := t.awsClient.DeleteNATGateway(ctx, natGatewayID); err != nil {
+		return t.CreateErrorResponse(fmt.Sprintf("Failed to delete NAT gateway: %s", err.Error()))
+	}
+
+	data := map[string]interface{}{
+		"natGatewayId": natGatewayID,
+		"status":       "deleting",
+	}
+
+	return t.CreateSuccessResponse(fmt.Sprintf("Successfully initiated deletion of NAT gateway %s", natGatewayID), data)
+}
@@ -45,6 +45,8 @@ func NewCreateDBSubnetGroupTool(awsClient *aws.Client, actionType string, logger
 		BaseTool: &BaseTool{
 			name:        "create-db-subnet-group",
 			description: "Create a new DB subnet group",
+			category:    "rds",
+			actionType:  actionType,
 			inputSchema: inputSchema,
 			logger:      logger,
 		},
@@ -83,7 +85,8 @@ func (t *CreateDBSubnetGroupTool) Execute(ctx context.Context, arguments map[str
 // CreateDBInstanceTool implements MCPTool for creating DB instances
 type CreateDBInstanceTool struct {
 	*BaseTool
-	adapter interfaces.SpecializedOperations
+	adapter   interfaces.SpecializedOperations
+	awsClient *aws.Client
 }
 
 // NewCreateDBInstanceTool creates a new DB instance creation tool
@@ -109,9 +112,28 @@ func NewCreateDBInstanceTool(awsClient *aws.Client, actionType string, logger *l
 				"type":        "string",
 				"description": "The master username",
 			},
+			"passwordMode": map[string]interface{}{
+				"type":        "string",
+				"description": "How the master password is provided: rds-managed (RDS generates it and stores it in Secrets Manager), generate (a generated password is stored in a new secret) or secret (read from masterUserSecretId)",
+				"enum":        []string{"rds-managed", "generate", "secret"},
+				"default":     "rds-managed",
+			},
+			"masterUserSecretId": map[string]interface{}{
+				"type":        "string",
+				"description": "Name or ARN of an existing Secrets Manager secret holding the password (plain text or JSON with a password key)",
+			},
 			"masterUserPassword": map[string]interface{}{
 				"type":        "string",
-				"description": "The master user password",
+				"description": "Literal master password for direct callers only; plans must use passwordMode instead",
+				"sensitive":   true,
+			},
+			"masterUserSecretName": map[string]interface{}{
+				"type":        "string",
+				"description": "Name of the secret created in generate mode (defaults to rds/<dbInstanceIdentifier>/master)",
+			},
+			"masterUserSecretKmsKeyId": map[string]interface{}{
+				"type":        "string",
+				"description": "KMS key used to encrypt the master user secret",
 			},
 			"allocatedStorage": map[string]interface{}{
 				"type":        "integer",
@@ -129,18 +151,37 @@ func NewCreateDBInstanceTool(awsClient *aws.Client, actionType string, logger *l
 				},
 				"description": "List of VPC security group IDs",
 			},
+			"multiAz": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Create the instance as a Multi-AZ deployment",
+				"default":     false,
+			},
+			"storageEncrypted": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Encrypt the DB instance storage",
+				"default":     false,
+			},
+			"publiclyAccessible": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Whether the instance is publicly accessible",
+				"default":     false,
+			},
+			"wait": waitSchemaProperty("DB instance"),
 		},
-		"required": []string{"dbInstanceIdentifier", "masterUsername", "masterUserPassword"},
+		"required": []string{"dbInstanceIdentifier", "masterUsername"},
 	}
 
 	return &CreateDBInstanceTool{
 		BaseTool: &BaseTool{
 			name:        "create-db-instance",
-			description: "Create a new RDS DB instance",
+			description: "Create a new RDS DB instance. By default the master password is kept in AWS Secrets Manager and never passed as an argument",
+			category:    "rds",
+			actionType:  actionType,
 			inputSchema: inputSchema,
 			logger:      logger,
 		},
-		adapter: adapters.NewRDSSpecializedAdapter(awsClient, logger),
+		adapter:   adapters.NewRDSSpecializedAdapter(awsClient, logger),
+		awsClient: awsClient,
 	}
 }
 
@@ -155,11 +196,6 @@ func (t *CreateDBInstanceTool) Execute(ctx context.Context, arguments map[string
 		return t.CreateErrorResponse("masterUsername is required")
 	}
 
-	masterUserPassword, ok := arguments["masterUserPassword"].(string)
-	if !ok || masterUserPassword == "" {
-		return t.CreateErrorResponse("masterUserPassword is required")
-	}
-
 	dbInstanceClass, _ := arguments["dbInstanceClass"].(string)
 	if dbInstanceClass == "" {
 		dbInstanceClass = "db.t3.micro"
@@ -176,16 +212,27 @@ func (t *CreateDBInstanceTool) Execute(ctx context.Context, arguments map[string
 		return t.CreateErrorResponse(fmt.Sprintf("Failed to create DB instance: %v", err))
 	}
 
-	message := fmt.Sprintf("DB instance %s created successfully", dbInstanceIdentifier)
 	data := map[string]interface{}{
 		"dbInstanceIdentifier": dbInstanceIdentifier,
 		"dbInstanceClass":      dbInstanceClass,
 		"engine":               engine,
 		"masterUsername":       masterUsername,
+		"passwordMode":         result.Details["passwordMode"],
+		"masterUserSecretArn":  result.Details["masterUserSecretArn"],
 		"result":               result,
 		"dbInstanceId":         result.ID,
 	}
 
+	if getBoolValue(arguments, "wait", false) {
+		if err := t.awsClient.WaitForDBInstance(ctx, dbInstanceIdentifier); err != nil {
+			// The DB instance exists, so its ID goes back for the caller to record it
+			data["resourceId"] = result.ID
+			return t.CreateErrorResponseWithData(fmt.Sprintf("DB instance %s was created but did not become available: %v", dbInstanceIdentifier, err), data)
+		}
+		result.State = "available"
+	}
+
+	message := fmt.Sprintf("DB instance %s created successfully", dbInstanceIdentifier)
 	return t.CreateSuccessResponse(message, data)
 }
 
@@ -212,6 +259,8 @@ func NewStartDBInstanceTool(awsClient *aws.Client, actionType string, logger *lo
 		BaseTool: &BaseTool{
 			name:        "start-db-instance",
 			description: "Start a stopped DB instance",
+			category:    "rds",
+			actionType:  actionType,
 			inputSchema: inputSchema,
 			logger:      logger,
 		},
@@ -264,6 +313,8 @@ func NewStopDBInstanceTool(awsClient *aws.Client, actionType string, logger *log
 		BaseTool: &BaseTool{
 			name:        "stop-db-instance",
 			description: "Stop a running DB instance",
+			category:    "rds",
+			actionType:  actionType,
 			inputSchema: inputSchema,
 			logger:      logger,
 		},
@@ -321,6 +372,8 @@ func NewDeleteDBInstanceTool(awsClient *aws.Client, actionType string, logger *l
 		BaseTool: &BaseTool{
 			name:        "delete-db-instance",
 			description: "Delete a DB instance",
+			category:    "rds",
+			actionType:  actionType,
 			inputSchema: inputSchema,
 			logger:      logger,
 		},
@@ -384,6 +437,8 @@ func NewCreateDBSnapshotTool(awsClient *aws.Client, actionType string, logger *l
 		BaseTool: &BaseTool{
 			name:        "create-db-snapshot",
 			description: "Create a snapshot of a DB instance",
+			category:    "rds",
+			actionType:  actionType,
 			inputSchema: inputSchema,
 			logger:      logger,
 		},
@@ -510,6 +565,8 @@ func NewListDBSnapshotsTool(awsClient *aws.Client, actionType string, logger *lo
 		BaseTool: &BaseTool{
 			name:        "list-db-snapshots",
 			description: "List all DB snapshots",
+			category:    "rds",
+			actionType:  actionType,
 			inputSchema: inputSchema,
 			logger:      logger,
 		},
@@ -562,3 +619,660 @@ func (t *ListDBSnapshotsTool) Execute(ctx context.Context, arguments map[string]
 
 	return t.CreateSuccessResponse(message, data)
 }
+
+// ModifyDBInstanceTool implements MCPTool for modifying DB instances
+type ModifyDBInstanceTool struct {
+	*BaseTool
+	adapter interfaces.SpecializedOperations
+}
+
+// NewModifyDBInstanceTool creates a new DB instance modification tool
+func NewModifyDBInstanceTool(awsClient *aws.Client, actionType string, logger *logging.Logger) interfaces.MCPTool {
+	inputSchema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"dbInstanceIdentifier": map[string]interface{}{
+				"type":        "string",
+				"description": "The DB instance identifier",
+			},
+			"dbInstanceClass": map[string]interface{}{
+				"type":        "string",
+				"description": "The new DB instance class (e.g., db.t3.medium)",
+			},
+			"allocatedStorage": map[string]interface{}{
+				"type":        "integer",
+				"description": "The new allocated storage in GB; storage can only grow",
+			},
+			"maxAllocatedStorage": map[string]interface{}{
+				"type":        "integer",
+				"description": "Upper limit in GB for storage autoscaling",
+			},
+			"storageType": map[string]interface{}{
+				"type":        "string",
+				"description": "The storage type (gp2, gp3, io1, io2)",
+			},
+			"iops": map[string]interface{}{
+				"type":        "integer",
+				"description": "Provisioned IOPS for io1, io2 or gp3 storage",
+			},
+			"multiAz": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Enable or disable Multi-AZ deployment",
+			},
+			"backupRetentionPeriod": map[string]interface{}{
+				"type":        "integer",
+				"description": "Days to retain automated backups (0 disables backups)",
+			},
+			"dbParameterGroupName": map[string]interface{}{
+				"type":        "string",
+				"description": "The DB parameter group to associate with the instance",
+			},
+			"vpcSecurityGroupIds": map[string]interface{}{
+				"type": "array",
+				"items": map[string]interface{}{
+					"type": "string",
+				},
+				"description": "Replacement list of VPC security group IDs",
+			},
+			"applyImmediately": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Apply now instead of during the next maintenance window",
+				"default":     false,
+			},
+		},
+		"required": []string{"dbInstanceIdentifier"},
+	}
+
+	baseTool := NewBaseTool(
+		"modify-db-instance",
+		"Modify an RDS DB instance: instance class, storage, Multi-AZ, backup retention, parameter group or security groups. The instance enters the modifying state while changes are applied",
+		"rds",
+		actionType,
+		inputSchema,
+		logger,
+	)
+
+	baseTool.AddExample(
+		"Scale up and enable Multi-AZ",
+		map[string]interface{}{
+			"dbInstanceIdentifier": "production-db",
+			"dbInstanceClass":      "db.r6g.large",
+			"multiAz":              true,
+			"applyImmediately":     true,
+		},
+		"DB instance production-db modification started",
+	)
+
+	return &ModifyDBInstanceTool{
+		BaseTool: baseTool,
+		adapter:  adapters.NewRDSSpecializedAdapter(awsClient, logger),
+	}
+}
+
+// Execute modifies the DB instance
+func (t *ModifyDBInstanceTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	dbInstanceIdentifier, _ := arguments["dbInstanceIdentifier"].(string)
+	if dbInstanceIdentifier == "" {
+		return t.CreateErrorResponse("dbInstanceIdentifier is required")
+	}
+
+	result, err := t.adapter.ExecuteSpecialOperation(ctx, "modify-db-instance", arguments)
+	if err != nil {
+		return t.CreateErrorResponse(fmt.Sprintf("Failed to modify DB instance: %v", err))
+	}
+
+	message := fmt.Sprintf("DB instance %s modification started", dbInstanceIdentifier)
+	data := map[string]interface{}{
+		"dbInstanceIdentifier": dbInstanceIdentifier,
+		"dbInstanceId":         result.ID,
+		"status":               result.State,
+		"pendingModifications": result.Details["pending_modifications"],
+		"result":               result,
+	}
+
+	return t.CreateSuccessResponse(message, data)
+}
+
+// CreateDBReadReplicaTool implements MCPTool for creating read replicas
+type CreateDBReadReplicaTool struct {
+	*BaseTool
+	adapter interfaces.SpecializedOperations
+}
+
+// NewCreateDBReadReplicaTool creates a new read replica creation tool
+func NewCreateDBReadReplicaTool(awsClient *aws.Client, actionType string, logger *logging.Logger) interfaces.MCPTool {
+	inputSchema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"dbInstanceIdentifier": map[string]interface{}{
+				"type":        "string",
+				"description": "The identifier for the new read replica",
+			},
+			"sourceDbInstanceIdentifier": map[string]interface{}{
+				"type":        "string",
+				"description": "The identifier (or ARN for cross-region) of the source DB instance",
+			},
+			"dbInstanceClass": map[string]interface{}{
+				"type":        "string",
+				"description": "The replica instance class; defaults to the source instance class",
+			},
+			"availabilityZone": map[string]interface{}{
+				"type":        "string",
+				"description": "The Availability Zone for the replica",
+			},
+			"dbSubnetGroupName": map[string]interface{}{
+				"type":        "string",
+				"description": "The DB subnet group name (cross-region replicas only)",
+			},
+			"vpcSecurityGroupIds": map[string]interface{}{
+				"type": "array",
+				"items": map[string]interface{}{
+					"type": "string",
+				},
+				"description": "List of VPC security group IDs",
+			},
+			"dbParameterGroupName": map[string]interface{}{
+				"type":        "string",
+				"description": "The DB parameter group for the replica",
+			},
+			"multiAz": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Create the replica as a Multi-AZ deployment",
+				"default":     false,
+			},
+			"publiclyAccessible": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Whether the replica is publicly accessible",
+				"default":     false,
+			},
+		},
+		"required": []string{"dbInstanceIdentifier", "sourceDbInstanceIdentifier"},
+	}
+
+	baseTool := NewBaseTool(
+		"create-db-read-replica",
+		"Create a read replica of an RDS DB instance. The source must have automated backups enabled",
+		"rds",
+		actionType,
+		inputSchema,
+		logger,
+	)
+
+	baseTool.AddExample(
+		"Create a read replica",
+		map[string]interface{}{
+			"dbInstanceIdentifier":       "production-db-replica-1",
+			"sourceDbInstanceIdentifier": "production-db",
+		},
+		"Read replica production-db-replica-1 of production-db is being created",
+	)
+
+	return &CreateDBReadReplicaTool{
+		BaseTool: baseTool,
+		adapter:  adapters.NewRDSSpecializedAdapter(awsClient, logger),
+	}
+}
+
+// Execute creates the read replica
+func (t *CreateDBReadReplicaTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	dbInstanceIdentifier, _ := arguments["dbInstanceIdentifier"].(string)
+	sourceIdentifier, _ := arguments["sourceDbInstanceIdentifier"].(string)
+	if dbInstanceIdentifier == "" || sourceIdentifier == "" {
+		return t.CreateErrorResponse("dbInstanceIdentifier and sourceDbInstanceIdentifier are required")
+	}
+
+	result, err := t.adapter.ExecuteSpecialOperation(ctx, "create-read-replica", arguments)
+	if err != nil {
+		return t.CreateErrorResponse(fmt.Sprintf("Failed to create read replica: %v", err))
+	}
+
+	message := fmt.Sprintf("Read replica %s of %s is being created", dbInstanceIdentifier, sourceIdentifier)
+	data := map[string]interface{}{
+		"dbInstanceIdentifier":       dbInstanceIdentifier,
+		"sourceDbInstanceIdentifier": sourceIdentifier,
+		"dbInstanceId":               result.ID,
+		"status":                     result.State,
+		"result":                     result,
+	}
+
+	return t.CreateSuccessResponse(message, data)
+}
+
+// RestoreDBInstanceFromSnapshotTool implements MCPTool for restoring DB instances from snapshots
+type RestoreDBInstanceFromSnapshotTool struct {
+	*BaseTool
+	adapter interfaces.SpecializedOperations
+}
+
+// NewRestoreDBInstanceFromSnapshotTool creates a new snapshot restore tool
+func NewRestoreDBInstanceFromSnapshotTool(awsClient *aws.Client, actionType string, logger *logging.Logger) interfaces.MCPTool {
+	inputSchema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"dbInstanceIdentifier": map[string]interface{}{
+				"type":        "string",
+				"description": "The identifier for the restored DB instance",
+			},
+			"dbSnapshotIdentifier": map[string]interface{}{
+				"type":        "string",
+				"description": "The identifier of the DB snapshot to restore from",
+			},
+			"dbInstanceClass": map[string]interface{}{
+				"type":        "string",
+				"description": "The instance class for the restored instance",
+			},
+			"dbSubnetGroupName": map[string]interface{}{
+				"type":        "string",
+				"description": "The DB subnet group name",
+			},
+			"vpcSecurityGroupIds": map[string]interface{}{
+				"type": "array",
+				"items": map[string]interface{}{
+					"type": "string",
+				},
+				"description": "List of VPC security group IDs",
+			},
+			"dbParameterGroupName": map[string]interface{}{
+				"type":        "string",
+				"description": "The DB parameter group for the restored instance",
+			},
+			"multiAz": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Restore as a Multi-AZ deployment",
+				"default":     false,
+			},
+			"publiclyAccessible": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Whether the restored instance is publicly accessible",
+				"default":     false,
+			},
+		},
+		"required": []string{"dbInstanceIdentifier", "dbSnapshotIdentifier"},
+	}
+
+	baseTool := NewBaseTool(
+		"restore-db-instance-from-snapshot",
+		"Create a new RDS DB instance from a DB snapshot. The restored instance uses the master credentials stored in the snapshot",
+		"rds",
+		actionType,
+		inputSchema,
+		logger,
+	)
+
+	baseTool.AddExample(
+		"Restore a snapshot into a new instance",
+		map[string]interface{}{
+			"dbInstanceIdentifier": "staging-db",
+			"dbSnapshotIdentifier": "production-db-2024-01-01",
+			"dbSubnetGroupName":    "staging-db-subnets",
+		},
+		"DB instance staging-db is being restored from snapshot production-db-2024-01-01",
+	)
+
+	return &RestoreDBInstanceFromSnapshotTool{
+		BaseTool: baseTool,
+		adapter:  adapters.NewRDSSpecializedAdapter(awsClient, logger),
+	}
+}
+
+// Execute restores the DB instance
+func (t *RestoreDBInstanceFromSnapshotTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	dbInstanceIdentifier, _ := arguments["dbInstanceIdentifier"].(string)
+	dbSnapshotIdentifier, _ := arguments["dbSnapshotIdentifier"].(string)
+	if dbInstanceIdentifier == "" || dbSnapshotIdentifier == "" {
+		return t.CreateErrorResponse("dbInstanceIdentifier and dbSnapshotIdentifier are required")
+	}
+
+	result, err := t.adapter.ExecuteSpecialOperation(ctx, "restore-from-snapshot", arguments)
+	if err != nil {
+		return t.CreateErrorResponse(fmt.Sprintf("Failed to restore DB instance: %v", err))
+	}
+
+	message := fmt.Sprintf("DB instance %s is being restored from snapshot %s", dbInstanceIdentifier, dbSnapshotIdentifier)
+	data := map[string]interface{}{
+		"dbInstanceIdentifier": dbInstanceIdentifier,
+		"dbSnapshotIdentifier": dbSnapshotIdentifier,
+		"dbInstanceId":         result.ID,
+		"status":               result.State,
+		"result":               result,
+	}
+
+	return t.CreateSuccessResponse(message, data)
+}
+
+// DescribeDBInstancesTool implements MCPTool for describing DB instance status
+type DescribeDBInstancesTool struct {
+	*BaseTool
+	adapter interfaces.AWSResourceAdapter
+}
+
+// NewDescribeDBInstancesTool creates a new DB instance describe tool
+func NewDescribeDBInstancesTool(awsClient *aws.Client, actionType string, logger *logging.Logger) interfaces.MCPTool {
+	inputSchema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"dbInstanceIdentifier": map[string]interface{}{
+				"type":        "string",
+				"description": "The DB instance identifier",
+			},
+		},
+		"required": []string{"dbInstanceIdentifier"},
+	}
+
+	baseTool := NewBaseTool(
+		"describe-db-instances",
+		"Describe the status, endpoint and pending modifications of an RDS DB instance",
+		"rds",
+		actionType,
+		inputSchema,
+		logger,
+	)
+
+	return &DescribeDBInstancesTool{
+		BaseTool: baseTool,
+		adapter:  adapters.NewRDSAdapter(awsClient, logger),
+	}
+}
+
+// Execute describes the DB instance
+func (t *DescribeDBInstancesTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	dbInstanceIdentifier, _ := arguments["dbInstanceIdentifier"].(string)
+	if dbInstanceIdentifier == "" {
+		return t.CreateErrorResponse("dbInstanceIdentifier is required")
+	}
+
+	dbInstance, err := t.adapter.Get(ctx, dbInstanceIdentifier)
+	if err != nil {
+		return t.CreateErrorResponse(fmt.Sprintf("Failed to describe DB instance: %v", err))
+	}
+
+	instance := map[string]interface{}{
+		"dbInstanceIdentifier": dbInstance.ID,
+		"dbInstanceStatus":     dbInstance.State,
+		"endpoint":             dbInstance.Details["endpoint"],
+		"dbInstanceClass":      dbInstance.Details["instance_class"],
+		"multiAz":              dbInstance.Details["multi_az"],
+		"pendingModifications": dbInstance.Details["pending_modifications"],
+		"details":              dbInstance.Details,
+	}
+
+	message := fmt.Sprintf("DB instance %s is %s", dbInstance.ID, dbInstance.State)
+	data := map[string]interface{}{
+		"dbInstances": []map[string]interface{}{instance},
+	}
+
+	return t.CreateSuccessResponse(message, data)
+}
+
+// CreateDBParameterGroupTool implements MCPTool for creating DB parameter groups
+type CreateDBParameterGroupTool struct {
+	*BaseTool
+	adapter interfaces.SpecializedOperations
+}
+
+// NewCreateDBParameterGroupTool creates a new DB parameter group creation tool
+func NewCreateDBParameterGroupTool(awsClient *aws.Client, actionType string, logger *logging.Logger) interfaces.MCPTool {
+	inputSchema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"dbParameterGroupName": map[string]interface{}{
+				"type":        "string",
+				"description": "The name of the DB parameter group",
+			},
+			"family": map[string]interface{}{
+				"type":        "string",
+				"description": "The engine family (e.g., mysql8.0, postgres16)",
+			},
+			"description": map[string]interface{}{
+				"type":        "string",
+				"description": "The description of the DB parameter group",
+			},
+		},
+		"required": []string{"dbParameterGroupName", "family"},
+	}
+
+	baseTool := NewBaseTool(
+		"create-db-parameter-group",
+		"Create a DB parameter group for an engine family",
+		"rds",
+		actionType,
+		inputSchema,
+		logger,
+	)
+
+	baseTool.AddExample(
+		"Create a MySQL 8.0 parameter group",
+		map[string]interface{}{
+			"dbParameterGroupName": "production-mysql",
+			"family":               "mysql8.0",
+		},
+		"DB parameter group production-mysql created",
+	)
+
+	return &CreateDBParameterGroupTool{
+		BaseTool: baseTool,
+		adapter:  adapters.NewRDSSpecializedAdapter(awsClient, logger),
+	}
+}
+
+// Execute creates the DB parameter group
+func (t *CreateDBParameterGroupTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	groupName, _ := arguments["dbParameterGroupName"].(string)
+	family, _ := arguments["family"].(string)
+	if groupName == "" || family == "" {
+		return t.CreateErrorResponse("dbParameterGroupName and family are required")
+	}
+
+	result, err := t.adapter.ExecuteSpecialOperation(ctx, "create-parameter-group", arguments)
+	if err != nil {
+		return t.CreateErrorResponse(fmt.Sprintf("Failed to create DB parameter group: %v", err))
+	}
+
+	return t.CreateSuccessResponse(fmt.Sprintf("DB parameter group %s created", groupName), map[string]interface{}{
+		"dbParameterGroupName": groupName,
+		"dbParameterGroupArn":  result.Details["dbParameterGroupArn"],
+		"family":               family,
+		"result":               result,
+	})
+}
+
+// ModifyDBParameterGroupTool implements MCPTool for setting DB parameters
+type ModifyDBParameterGroupTool struct {
+	*BaseTool
+	adapter interfaces.SpecializedOperations
+}
+
+// NewModifyDBParameterGroupTool creates a new DB parameter group modification tool
+func NewModifyDBParameterGroupTool(awsClient *aws.Client, actionType string, logger *logging.Logger) interfaces.MCPTool {
+	inputSchema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"dbParameterGroupName": map[string]interface{}{
+				"type":        "string",
+				"description": "The name of the DB parameter group",
+			},
+			"parameters": map[string]interface{}{
+				"type":        "array",
+				"description": "Parameters to set",
+				"items": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"name": map[string]interface{}{
+							"type":        "string",
+							"description": "The parameter name",
+						},
+						"value": map[string]interface{}{
+							"type":        "string",
+							"description": "The parameter value",
+						},
+						"applyMethod": map[string]interface{}{
+							"type":        "string",
+							"description": "immediate for dynamic parameters, pending-reboot for static parameters",
+							"enum":        []string{"immediate", "pending-reboot"},
+							"default":     "immediate",
+						},
+					},
+					"required": []string{"name", "value"},
+				},
+			},
+		},
+		"required": []string{"dbParameterGroupName", "parameters"},
+	}
+
+	baseTool := NewBaseTool(
+		"modify-db-parameter-group",
+		"Set engine parameters on a DB parameter group. Static parameters need applyMethod pending-reboot and take effect after the instance reboots",
+		"rds",
+		actionType,
+		inputSchema,
+		logger,
+	)
+
+	baseTool.AddExample(
+		"Raise max_connections",
+		map[string]interface{}{
+			"dbParameterGroupName": "production-mysql",
+			"parameters": []map[string]interface{}{
+				{"name": "max_connections", "value": "500"},
+			},
+		},
+		"Updated 1 parameter(s) in DB parameter group production-mysql",
+	)
+
+	return &ModifyDBParameterGroupTool{
+		BaseTool: baseTool,
+		adapter:  adapters.NewRDSSpecializedAdapter(awsClient, logger),
+	}
+}
+
+// Execute sets the parameters
+func (t *ModifyDBParameterGroupTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	groupName, _ := arguments["dbParameterGroupName"].(string)
+	if groupName == "" {
+		return t.CreateErrorResponse("dbParameterGroupName is required")
+	}
+
+	parameters, _ := arguments["parameters"].([]interface{})
+	if len(parameters) == 0 {
+		return t.CreateErrorResponse("parameters must contain at least one parameter")
+	}
+
+	result, err := t.adapter.ExecuteSpecialOperation(ctx, "modify-parameter-group", arguments)
+	if err != nil {
+		return t.CreateErrorResponse(fmt.Sprintf("Failed to modify DB parameter group: %v", err))
+	}
+
+	return t.CreateSuccessResponse(fmt.Sprintf("Updated %d parameter(s) in DB parameter group %s", len(parameters), groupName), map[string]interface{}{
+		"dbParameterGroupName": groupName,
+		"parameters":           result.Details["parameters"],
+	})
+}
+
+// ListDBParameterGroupsTool implements MCPTool for listing DB parameter groups
+type ListDBParameterGroupsTool struct {
+	*BaseTool
+	adapter interfaces.SpecializedOperations
+}
+
+// NewListDBParameterGroupsTool creates a new DB parameter group listing tool
+func NewListDBParameterGroupsTool(awsClient *aws.Client, actionType string, logger *logging.Logger) interfaces.MCPTool {
+	inputSchema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"dbParameterGroupName": map[string]interface{}{
+				"type":        "string",
+				"description": "Describe a single group including its modified parameters",
+			},
+		},
+	}
+
+	baseTool := NewBaseTool(
+		"list-db-parameter-groups",
+		"List DB parameter groups, or describe one group with its modified parameters",
+		"rds",
+		actionType,
+		inputSchema,
+		logger,
+	)
+
+	return &ListDBParameterGroupsTool{
+		BaseTool: baseTool,
+		adapter:  adapters.NewRDSSpecializedAdapter(awsClient, logger),
+	}
+}
+
+// Execute lists the DB parameter groups
+func (t *ListDBParameterGroupsTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	if groupName, _ := arguments["dbParameterGroupName"].(string); groupName != "" {
+		group, err := t.adapter.ExecuteSpecialOperation(ctx, "describe-parameter-group", groupName)
+		if err != nil {
+			return t.CreateErrorResponse(fmt.Sprintf("Failed to describe DB parameter group: %v", err))
+		}
+
+		return t.CreateSuccessResponse(fmt.Sprintf("Retrieved DB parameter group %s", groupName), map[string]interface{}{
+			"dbParameterGroups": []interface{}{group},
+			"count":             1,
+		})
+	}
+
+	result, err := t.adapter.ExecuteSpecialOperation(ctx, "list-parameter-groups", nil)
+	if err != nil {
+		return t.CreateErrorResponse(fmt.Sprintf("Failed to list DB parameter groups: %v", err))
+	}
+
+	return t.CreateSuccessResponse(fmt.Sprintf("Successfully retrieved %d DB parameter groups", result.Details["count"]), map[string]interface{}{
+		"dbParameterGroups": result.Details["dbParameterGroups"],
+		"count":             result.Details["count"],
+	})
+}
+
+// DeleteDBParameterGroupTool implements MCPTool for deleting DB parameter groups
+type DeleteDBParameterGroupTool struct {
+	*BaseTool
+	adapter interfaces.SpecializedOperations
+}
+
+// NewDeleteDBParameterGroupTool creates a new DB parameter group deletion tool
+func NewDeleteDBParameterGroupTool(awsClient *aws.Client, actionType string, logger *logging.Logger) interfaces.MCPTool {
+	inputSchema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"dbParameterGroupName": map[string]interface{}{
+				"type":        "string",
+				"description": "The name of the DB parameter group",
+			},
+		},
+		"required": []string{"dbParameterGroupName"},
+	}
+
+	baseTool := NewBaseTool(
+		"delete-db-parameter-group",
+		"Delete a DB parameter group. The group must not be associated with any DB instance",
+		"rds",
+		actionType,
+		inputSchema,
+		logger,
+	)
+
+	return &DeleteDBParameterGroupTool{
+		BaseTool: baseTool,
+		adapter:  adapters.NewRDSSpecializedAdapter(awsClient, logger),
+	}
+}
+
+// Execute deletes the DB parameter group
+func (t *DeleteDBParameterGroupTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	groupName, _ := arguments["dbParameterGroupName"].(string)
+	if groupName == "" {
+		return t.CreateErrorResponse("dbParameterGroupName is required")
+	}
+
+	if _, err := t.adapter.ExecuteSpecialOperation(ctx, "delete-parameter-group", groupName); err != nil {
+		return t.CreateErrorResponse(fmt.Sprintf("Failed to delete DB parameter group: %v", err))
+	}
+
+	return t.CreateSuccessResponse(fmt.Sprintf("DB parameter group %s deleted", groupName), map[string]interface{}{
+		"dbParameterGroupName": groupName,
+		"status":               "deleted",
+	})
+}
@@ -220,7 +220,8 @@ func (b *BaseTool) GetInputSchema() map[string]interface{} {
 	return b.inputSchema
 }
 
-// GetOutputSchema returns a default output schema
+// GetOutputSchema returns the schema of the structured results of CreateSuccessResponse and
+// CreateErrorResponse. Operation-specific fields sit next to success and message.
 func (b *BaseTool) GetOutputSchema() map[string]interface{} {
 	return map[string]interface{}{
 		"type": "object",
@@ -233,12 +234,13 @@ func (b *BaseTool) GetOutputSchema() map[string]interface{} {
 				"type":        "string",
 				"description": "Human-readable message about the operation",
 			},
-			"data": map[string]interface{}{
-				"type":        "object",
-				"description": "Operation-specific data",
+			"error": map[string]interface{}{
+				"type":        "string",
+				"description": "Why the operation failed, set when success is false",
 			},
 		},
-		"required": []string{"success", "message"},
+		"required":             []string{"success"},
+		"additionalProperties": true,
 	}
 }
 
@@ -345,19 +347,30 @@ func (b *BaseTool) CreateSuccessResponse(message string, data map[string]interfa
 		mcp.NewTextContent(string(jsonBytes)),
 	}
 
+	// The text content stays for clients that do not read structured content
 	return &mcp.CallToolResult{
-		Content: content,
-		IsError: false,
+		Content:           content,
+		StructuredContent: response,
+		IsError:           false,
 	}, nil
 }
 
 // CreateErrorResponse creates a standardized error response
 func (b *BaseTool) CreateErrorResponse(message string) (*mcp.CallToolResult, error) {
+	return b.CreateErrorResponseWithData(message, nil)
+}
+
+// CreateErrorResponseWithData creates a standardized error response carrying data, such as the
+// ID of a resource that was created before the tool failed
+func (b *BaseTool) CreateErrorResponseWithData(message string, data map[string]interface{}) (*mcp.CallToolResult, error) {
 	// Create structured error response that the agent expects
 	response := map[string]interface{}{
 		"success": false,
 		"error":   message,
 	}
+	for key, value := range data {
+		response[key] = value
+	}
 
 	// Marshal to JSON string for the text content
 	jsonBytes, err := json.Marshal(response)
@@ -378,7 +391,18 @@ func (b *BaseTool) CreateErrorResponse(message string) (*mcp.CallToolResult, err
 	}
 
 	return &mcp.CallToolResult{
-		Content: content,
-		IsError: true,
+		Content:           content,
+		StructuredContent: response,
+		IsError:           true,
 	}, nil
 }
+
+// waitSchemaProperty is the input schema of the wait argument of tools that create resources
+// which take minutes to become available
+func waitSchemaProperty(resource string) map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "boolean",
+		"description": fmt.Sprintf("Whether to return only once the %s is available. Clients that send a progress token receive progress notifications while waiting", resource),
+		"default":     false,
+	}
+}
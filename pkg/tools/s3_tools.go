@@ -0,0 +1,571 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/versus-control/ai-infrastructure-agent/internal/logging"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/adapters"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/aws"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/interfaces"
+)
+
+// lifecycleRulesSchema is the shared input schema for S3 lifecycle rules
+var lifecycleRulesSchema = map[string]interface{}{
+	"type":        "array",
+	"description": "Lifecycle rules. Each rule may set id, prefix, expirationDays, transitionDays with transitionStorageClass (STANDARD_IA, INTELLIGENT_TIERING, GLACIER, DEEP_ARCHIVE), noncurrentVersionExpirationDays and abortIncompleteUploadDays",
+	"items": map[string]interface{}{
+		"type": "object",
+	},
+}
+
+// parseLifecycleRules converts lifecycle rule arguments into aws.S3LifecycleRuleParams
+func parseLifecycleRules(arguments map[string]interface{}) []aws.S3LifecycleRuleParams {
+	var rules []aws.S3LifecycleRuleParams
+
+	rawRules, _ := arguments["lifecycleRules"].([]interface{})
+	for _, rawRule := range rawRules {
+		ruleMap, ok := rawRule.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		rule := aws.S3LifecycleRuleParams{}
+		rule.ID, _ = ruleMap["id"].(string)
+		rule.Prefix, _ = ruleMap["prefix"].(string)
+		rule.TransitionStorageClass, _ = ruleMap["transitionStorageClass"].(string)
+		if val, ok := ruleMap["expirationDays"].(float64); ok {
+			rule.ExpirationDays = int32(val)
+		}
+		if val, ok := ruleMap["transitionDays"].(float64); ok {
+			rule.TransitionDays = int32(val)
+		}
+		if val, ok := ruleMap["noncurrentVersionExpirationDays"].(float64); ok {
+			rule.NoncurrentVersionExpirationDays = int32(val)
+		}
+		if val, ok := ruleMap["abortIncompleteUploadDays"].(float64); ok {
+			rule.AbortIncompleteUploadDays = int32(val)
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules
+}
+
+// parsePolicyDocument accepts a policy document argument as either a JSON string or an object
+func parsePolicyDocument(arguments map[string]interface{}, key string) (string, error) {
+	switch policy := arguments[key].(type) {
+	case nil:
+		return "", nil
+	case string:
+		if policy != "" && !json.Valid([]byte(policy)) {
+			return "", fmt.Errorf("%s must be a valid JSON document", key)
+		}
+		return policy, nil
+	case map[string]interface{}:
+		policyBytes, err := json.Marshal(policy)
+		if err != nil {
+			return "", fmt.Errorf("failed to encode %s: %w", key, err)
+		}
+		return string(policyBytes), nil
+	default:
+		return "", fmt.Errorf("%s must be a JSON string or object", key)
+	}
+}
+
+// CreateS3BucketTool implements MCPTool for creating S3 buckets
+type CreateS3BucketTool struct {
+	*BaseTool
+	adapter interfaces.AWSResourceAdapter
+}
+
+// NewCreateS3BucketTool creates a new S3 bucket creation tool
+func NewCreateS3BucketTool(awsClient *aws.Client, actionType string, logger *logging.Logger) interfaces.MCPTool {
+	inputSchema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"bucketName": map[string]interface{}{
+				"type":        "string",
+				"description": "Globally unique bucket name (3-63 lowercase characters)",
+			},
+			"versioning": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Enable object versioning",
+				"default":     true,
+			},
+			"encryption": map[string]interface{}{
+				"type":        "string",
+				"description": "Default server-side encryption: 'AES256' (default) or 'aws:kms'",
+				"enum":        []string{"AES256", "aws:kms"},
+				"default":     "AES256",
+			},
+			"kmsKeyId": map[string]interface{}{
+				"type":        "string",
+				"description": "KMS key ID or ARN, only used with aws:kms encryption (defaults to the AWS managed key)",
+			},
+			"blockPublicAccess": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Block all public access to the bucket. False turns the bucket's public access block off; an account-level block still applies",
+				"default":     true,
+			},
+			"lifecycleRules": lifecycleRulesSchema,
+			"policy": map[string]interface{}{
+				"type":        "string",
+				"description": "Bucket policy JSON document",
+			},
+			"tags": map[string]interface{}{
+				"type":        "object",
+				"description": "Tags to apply to the bucket",
+			},
+		},
+		"required": []interface{}{"bucketName"},
+	}
+
+	baseTool := NewBaseTool(
+		"create-s3-bucket",
+		"Create an S3 bucket with versioning, default encryption, public access block, lifecycle rules and an optional bucket policy. Buckets are private and encrypted by default.",
+		"s3",
+		actionType,
+		inputSchema,
+		logger,
+	)
+
+	baseTool.AddExample(
+		"Create a private, versioned and encrypted bucket",
+		map[string]interface{}{
+			"bucketName": "my-app-assets-bucket",
+		},
+		"Successfully created S3 bucket 'my-app-assets-bucket'",
+	)
+
+	baseTool.AddExample(
+		"Create a log bucket that moves objects to Glacier and expires them",
+		map[string]interface{}{
+			"bucketName": "my-app-logs-bucket",
+			"encryption": "aws:kms",
+			"lifecycleRules": []interface{}{
+				map[string]interface{}{
+					"id":                     "archive-logs",
+					"prefix":                 "logs/",
+					"transitionDays":         30,
+					"transitionStorageClass": "GLACIER",
+					"expirationDays":         365,
+				},
+			},
+		},
+		"Successfully created S3 bucket 'my-app-logs-bucket' with 1 lifecycle rule",
+	)
+
+	return &CreateS3BucketTool{
+		BaseTool: baseTool,
+		adapter:  adapters.NewS3Adapter(awsClient, logger),
+	}
+}
+
+// Execute creates an S3 bucket
+func (t *CreateS3BucketTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	bucketName, _ := arguments["bucketName"].(string)
+
+	encryption, _ := arguments["encryption"].(string)
+	if encryption == "" {
+		encryption = "AES256"
+	}
+	kmsKeyID, _ := arguments["kmsKeyId"].(string)
+
+	policy, err := parsePolicyDocument(arguments, "policy")
+	if err != nil {
+		return t.CreateErrorResponse(err.Error())
+	}
+
+	tags := make(map[string]string)
+	if tagsArg, ok := arguments["tags"].(map[string]interface{}); ok {
+		for k, v := range tagsArg {
+			tags[k] = fmt.Sprintf("%v", v)
+		}
+	}
+
+	params := aws.CreateBucketParams{
+		BucketName:        bucketName,
+		Versioning:        getBoolValue(arguments, "versioning", true),
+		Encryption:        encryption,
+		KMSKeyID:          kmsKeyID,
+		BlockPublicAccess: getBoolValue(arguments, "blockPublicAccess", true),
+		LifecycleRules:    parseLifecycleRules(arguments),
+		Policy:            policy,
+		Tags:              tags,
+	}
+
+	if err := t.adapter.ValidateParams("create", params); err != nil {
+		return t.CreateErrorResponse(fmt.Sprintf("Parameter validation failed: %s", err.Error()))
+	}
+
+	bucket, err := t.adapter.Create(ctx, params)
+	if err != nil {
+		if bucket != nil {
+			// The bucket exists, so its name goes back for the caller to record it
+			return t.CreateErrorResponseWithData(fmt.Sprintf("Failed to create S3 bucket: %s", err.Error()), map[string]interface{}{
+				"bucketName": bucket.ID,
+				"resourceId": bucket.ID,
+				"resource":   bucket,
+			})
+		}
+		return t.CreateErrorResponse(fmt.Sprintf("Failed to create S3 bucket: %s", err.Error()))
+	}
+
+	message := fmt.Sprintf("Successfully created S3 bucket '%s'", bucketName)
+	data := map[string]interface{}{
+		"bucketName":        bucket.ID,
+		"region":            bucket.Region,
+		"versioning":        bucket.Details["versioning"],
+		"encryption":        bucket.Details["encryption"],
+		"publicAccessBlock": bucket.Details["publicAccessBlock"],
+		"lifecycleRules":    len(params.LifecycleRules),
+		"hasPolicy":         policy != "",
+		"resource":          bucket,
+	}
+
+	return t.CreateSuccessResponse(message, data)
+}
+
+// ListS3BucketsTool implements MCPTool for listing S3 buckets
+type ListS3BucketsTool struct {
+	*BaseTool
+	adapter interfaces.AWSResourceAdapter
+}
+
+// NewListS3BucketsTool creates a new S3 bucket listing tool
+func NewListS3BucketsTool(awsClient *aws.Client, actionType string, logger *logging.Logger) interfaces.MCPTool {
+	inputSchema := map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{},
+	}
+
+	baseTool := NewBaseTool(
+		"list-s3-buckets",
+		"List S3 buckets in the current region with their versioning, encryption and public access settings",
+		"s3",
+		actionType,
+		inputSchema,
+		logger,
+	)
+
+	return &ListS3BucketsTool{
+		BaseTool: baseTool,
+		adapter:  adapters.NewS3Adapter(awsClient, logger),
+	}
+}
+
+// Execute lists S3 buckets
+func (t *ListS3BucketsTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	buckets, err := t.adapter.List(ctx)
+	if err != nil {
+		return t.CreateErrorResponse(fmt.Sprintf("Failed to list S3 buckets: %s", err.Error()))
+	}
+
+	message := fmt.Sprintf("Found %d S3 buckets", len(buckets))
+	data := map[string]interface{}{
+		"buckets": buckets,
+		"count":   len(buckets),
+	}
+
+	return t.CreateSuccessResponse(message, data)
+}
+
+// GetS3BucketTool implements MCPTool for describing a single S3 bucket
+type GetS3BucketTool struct {
+	*BaseTool
+	adapter interfaces.AWSResourceAdapter
+}
+
+// NewGetS3BucketTool creates a new S3 bucket describe tool
+func NewGetS3BucketTool(awsClient *aws.Client, actionType string, logger *logging.Logger) interfaces.MCPTool {
+	inputSchema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"bucketName": map[string]interface{}{
+				"type":        "string",
+				"description": "The bucket name",
+			},
+		},
+		"required": []interface{}{"bucketName"},
+	}
+
+	baseTool := NewBaseTool(
+		"get-s3-bucket",
+		"Get an S3 bucket's versioning, encryption, public access block, lifecycle, policy and tag settings",
+		"s3",
+		actionType,
+		inputSchema,
+		logger,
+	)
+
+	return &GetS3BucketTool{
+		BaseTool: baseTool,
+		adapter:  adapters.NewS3Adapter(awsClient, logger),
+	}
+}
+
+// Execute describes an S3 bucket
+func (t *GetS3BucketTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	bucketName, _ := arguments["bucketName"].(string)
+	if bucketName == "" {
+		return t.CreateErrorResponse("bucketName is required")
+	}
+
+	bucket, err := t.adapter.Get(ctx, bucketName)
+	if err != nil {
+		return t.CreateErrorResponse(err.Error())
+	}
+
+	data := map[string]interface{}{
+		"bucketName": bucket.ID,
+		"region":     bucket.Region,
+		"tags":       bucket.Tags,
+		"details":    bucket.Details,
+	}
+
+	return t.CreateSuccessResponse(fmt.Sprintf("Retrieved S3 bucket '%s'", bucketName), data)
+}
+
+// UpdateS3BucketTool implements the put-s3-* tools, each changing one bucket setting
+type UpdateS3BucketTool struct {
+	*BaseTool
+	adapter     interfaces.AWSResourceAdapter
+	buildParams func(arguments map[string]interface{}) (aws.UpdateBucketParams, error)
+}
+
+// newUpdateS3BucketTool creates a bucket setting tool with the shared bucketName argument
+func newUpdateS3BucketTool(name, description string, properties map[string]interface{}, required []interface{},
+	buildParams func(map[string]interface{}) (aws.UpdateBucketParams, error),
+	awsClient *aws.Client, actionType string, logger *logging.Logger) *UpdateS3BucketTool {
+
+	properties["bucketName"] = map[string]interface{}{
+		"type":        "string",
+		"description": "The bucket name",
+	}
+	inputSchema := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+		"required":   append([]interface{}{"bucketName"}, required...),
+	}
+
+	return &UpdateS3BucketTool{
+		BaseTool:    NewBaseTool(name, description, "s3", actionType, inputSchema, logger),
+		adapter:     adapters.NewS3Adapter(awsClient, logger),
+		buildParams: buildParams,
+	}
+}
+
+// Execute applies the bucket setting change
+func (t *UpdateS3BucketTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	bucketName, _ := arguments["bucketName"].(string)
+	if bucketName == "" {
+		return t.CreateErrorResponse("bucketName is required")
+	}
+
+	params, err := t.buildParams(arguments)
+	if err != nil {
+		return t.CreateErrorResponse(err.Error())
+	}
+
+	bucket, err := t.adapter.Update(ctx, bucketName, params)
+	if err != nil {
+		return t.CreateErrorResponse(fmt.Sprintf("Failed to update S3 bucket: %s", err.Error()))
+	}
+
+	data := map[string]interface{}{
+		"bucketName": bucket.ID,
+		"details":    bucket.Details,
+	}
+
+	return t.CreateSuccessResponse(fmt.Sprintf("Updated S3 bucket '%s'", bucketName), data)
+}
+
+// NewPutS3BucketVersioningTool creates a tool that enables or suspends bucket versioning
+func NewPutS3BucketVersioningTool(awsClient *aws.Client, actionType string, logger *logging.Logger) interfaces.MCPTool {
+	tool := newUpdateS3BucketTool(
+		"put-s3-bucket-versioning",
+		"Enable or suspend versioning on an S3 bucket",
+		map[string]interface{}{
+			"enabled": map[string]interface{}{
+				"type":        "boolean",
+				"description": "true to enable versioning, false to suspend it",
+			},
+		},
+		[]interface{}{"enabled"},
+		func(arguments map[string]interface{}) (aws.UpdateBucketParams, error) {
+			enabled := getBoolValue(arguments, "enabled", true)
+			return aws.UpdateBucketParams{Versioning: &enabled}, nil
+		},
+		awsClient, actionType, logger,
+	)
+	tool.AddExample("Enable versioning", map[string]interface{}{"bucketName": "my-bucket", "enabled": true}, "Updated S3 bucket 'my-bucket'")
+	return tool
+}
+
+// NewPutS3BucketEncryptionTool creates a tool that sets default bucket encryption
+func NewPutS3BucketEncryptionTool(awsClient *aws.Client, actionType string, logger *logging.Logger) interfaces.MCPTool {
+	tool := newUpdateS3BucketTool(
+		"put-s3-bucket-encryption",
+		"Set default server-side encryption on an S3 bucket",
+		map[string]interface{}{
+			"encryption": map[string]interface{}{
+				"type":        "string",
+				"description": "'AES256' or 'aws:kms'",
+				"enum":        []string{"AES256", "aws:kms"},
+			},
+			"kmsKeyId": map[string]interface{}{
+				"type":        "string",
+				"description": "KMS key ID or ARN, only used with aws:kms encryption",
+			},
+		},
+		[]interface{}{"encryption"},
+		func(arguments map[string]interface{}) (aws.UpdateBucketParams, error) {
+			encryption, _ := arguments["encryption"].(string)
+			kmsKeyID, _ := arguments["kmsKeyId"].(string)
+			if encryption == "" {
+				return aws.UpdateBucketParams{}, fmt.Errorf("encryption is required")
+			}
+			return aws.UpdateBucketParams{Encryption: encryption, KMSKeyID: kmsKeyID}, nil
+		},
+		awsClient, actionType, logger,
+	)
+	tool.AddExample("Use KMS encryption", map[string]interface{}{"bucketName": "my-bucket", "encryption": "aws:kms"}, "Updated S3 bucket 'my-bucket'")
+	return tool
+}
+
+// NewPutS3PublicAccessBlockTool creates a tool that blocks or allows public bucket access
+func NewPutS3PublicAccessBlockTool(awsClient *aws.Client, actionType string, logger *logging.Logger) interfaces.MCPTool {
+	tool := newUpdateS3BucketTool(
+		"put-s3-public-access-block",
+		"Turn the S3 public access block on or off for a bucket (all four settings together)",
+		map[string]interface{}{
+			"blockPublicAccess": map[string]interface{}{
+				"type":        "boolean",
+				"description": "true to block all public access, false to remove the block",
+			},
+		},
+		[]interface{}{"blockPublicAccess"},
+		func(arguments map[string]interface{}) (aws.UpdateBucketParams, error) {
+			block := getBoolValue(arguments, "blockPublicAccess", true)
+			return aws.UpdateBucketParams{BlockPublicAccess: &block}, nil
+		},
+		awsClient, actionType, logger,
+	)
+	tool.AddExample("Block all public access", map[string]interface{}{"bucketName": "my-bucket", "blockPublicAccess": true}, "Updated S3 bucket 'my-bucket'")
+	return tool
+}
+
+// NewPutS3BucketLifecycleTool creates a tool that replaces bucket lifecycle rules
+func NewPutS3BucketLifecycleTool(awsClient *aws.Client, actionType string, logger *logging.Logger) interfaces.MCPTool {
+	tool := newUpdateS3BucketTool(
+		"put-s3-bucket-lifecycle",
+		"Replace the lifecycle rules of an S3 bucket",
+		map[string]interface{}{
+			"lifecycleRules": lifecycleRulesSchema,
+		},
+		[]interface{}{"lifecycleRules"},
+		func(arguments map[string]interface{}) (aws.UpdateBucketParams, error) {
+			rules := parseLifecycleRules(arguments)
+			if len(rules) == 0 {
+				return aws.UpdateBucketParams{}, fmt.Errorf("at least one lifecycle rule is required")
+			}
+			return aws.UpdateBucketParams{LifecycleRules: rules}, nil
+		},
+		awsClient, actionType, logger,
+	)
+	tool.AddExample(
+		"Expire old object versions",
+		map[string]interface{}{
+			"bucketName": "my-bucket",
+			"lifecycleRules": []interface{}{
+				map[string]interface{}{"id": "expire-noncurrent", "noncurrentVersionExpirationDays": 30},
+			},
+		},
+		"Updated S3 bucket 'my-bucket'",
+	)
+	return tool
+}
+
+// NewPutS3BucketPolicyTool creates a tool that attaches a bucket policy
+func NewPutS3BucketPolicyTool(awsClient *aws.Client, actionType string, logger *logging.Logger) interfaces.MCPTool {
+	tool := newUpdateS3BucketTool(
+		"put-s3-bucket-policy",
+		"Attach a bucket policy JSON document to an S3 bucket",
+		map[string]interface{}{
+			"policy": map[string]interface{}{
+				"type":        "string",
+				"description": "Bucket policy JSON document",
+			},
+		},
+		[]interface{}{"policy"},
+		func(arguments map[string]interface{}) (aws.UpdateBucketParams, error) {
+			policy, err := parsePolicyDocument(arguments, "policy")
+			if err != nil {
+				return aws.UpdateBucketParams{}, err
+			}
+			if policy == "" {
+				return aws.UpdateBucketParams{}, fmt.Errorf("policy is required")
+			}
+			return aws.UpdateBucketParams{Policy: policy}, nil
+		},
+		awsClient, actionType, logger,
+	)
+	return tool
+}
+
+// DeleteS3BucketTool implements MCPTool for deleting S3 buckets
+type DeleteS3BucketTool struct {
+	*BaseTool
+	adapter interfaces.AWSResourceAdapter
+}
+
+// NewDeleteS3BucketTool creates a new S3 bucket deletion tool
+func NewDeleteS3BucketTool(awsClient *aws.Client, actionType string, logger *logging.Logger) interfaces.MCPTool {
+	inputSchema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"bucketName": map[string]interface{}{
+				"type":        "string",
+				"description": "The bucket name (the bucket must be empty)",
+			},
+		},
+		"required": []interface{}{"bucketName"},
+	}
+
+	baseTool := NewBaseTool(
+		"delete-s3-bucket",
+		"Delete an empty S3 bucket",
+		"s3",
+		actionType,
+		inputSchema,
+		logger,
+	)
+
+	return &DeleteS3BucketTool{
+		BaseTool: baseTool,
+		adapter:  adapters.NewS3Adapter(awsClient, logger),
+	}
+}
+
+// Execute deletes an S3 bucket
+func (t *DeleteS3BucketTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	bucketName, _ := arguments["bucketName"].(string)
+	if bucketName == "" {
+		return t.CreateErrorResponse("bucketName is required")
+	}
+
+	if err := t.adapter.Delete(ctx, bucketName); err != nil {
+		return t.CreateErrorResponse(fmt.Sprintf("Failed to delete S3 bucket: %s", err.Error()))
+	}
+
+	data := map[string]interface{}{
+		"bucketName": bucketName,
+		"deleted":    true,
+	}
+
+	return t.CreateSuccessResponse(fmt.Sprintf("Deleted S3 bucket '%s'", bucketName), data)
+}
@@ -192,8 +192,20 @@ func NewAddSecurityGroupIngressRuleTool(awsClient *aws.Client, actionType string
 				"type":        "string",
 				"description": "The CIDR block to allow",
 			},
+			"sourceSecurityGroupId": map[string]interface{}{
+				"type":        "string",
+				"description": "Security group to allow instead of a CIDR block (SG-to-SG reference)",
+			},
+			"prefixListId": map[string]interface{}{
+				"type":        "string",
+				"description": "Managed prefix list to allow instead of a CIDR block",
+			},
+			"description": map[string]interface{}{
+				"type":        "string",
+				"description": "Description of the rule",
+			},
 		},
-		"required": []string{"groupId", "protocol", "fromPort", "toPort", "cidrBlock"},
+		"required": []string{"groupId", "protocol", "fromPort", "toPort"},
 	}
 
 	baseTool := NewBaseTool(
@@ -247,18 +259,22 @@ func (t *AddSecurityGroupIngressRuleTool) Execute(ctx context.Context, arguments
 		return t.CreateErrorResponse("toPort is required")
 	}
 
-	cidrBlock, ok := arguments["cidrBlock"].(string)
-	if !ok || cidrBlock == "" {
-		return t.CreateErrorResponse("cidrBlock is required")
+	peer := securityGroupRulePeer(arguments)
+	if peer == "" {
+		return t.CreateErrorResponse("one of cidrBlock, sourceSecurityGroupId or prefixListId is required")
 	}
 
 	// Prepare parameters for the adapter
 	params := map[string]interface{}{
-		"groupId":   groupID,
-		"protocol":  protocol,
-		"fromPort":  int(fromPort),
-		"toPort":    int(toPort),
-		"cidrBlock": cidrBlock,
+		"groupId":  groupID,
+		"protocol": protocol,
+		"fromPort": int(fromPort),
+		"toPort":   int(toPort),
+	}
+	for _, key := range []string{"cidrBlock", "sourceSecurityGroupId", "prefixListId", "description"} {
+		if value, ok := arguments[key].(string); ok && value != "" {
+			params[key] = value
+		}
 	}
 
 	// Add ingress rule using the Security Group specialized adapter
@@ -269,13 +285,13 @@ func (t *AddSecurityGroupIngressRuleTool) Execute(ctx context.Context, arguments
 	}
 
 	message := fmt.Sprintf("Added ingress rule to security group %s: %s %d-%d from %s",
-		groupID, protocol, int(fromPort), int(toPort), cidrBlock)
+		groupID, protocol, int(fromPort), int(toPort), peer)
 	data := map[string]interface{}{
 		"groupId":   groupID,
 		"protocol":  protocol,
 		"fromPort":  int(fromPort),
 		"toPort":    int(toPort),
-		"cidrBlock": cidrBlock,
+		"source":    peer,
 		"direction": "ingress",
 		"resource":  result,
 	}
@@ -314,8 +330,20 @@ func NewAddSecurityGroupEgressRuleTool(awsClient *aws.Client, actionType string,
 				"type":        "string",
 				"description": "The CIDR block to allow",
 			},
+			"sourceSecurityGroupId": map[string]interface{}{
+				"type":        "string",
+				"description": "Security group to allow instead of a CIDR block (SG-to-SG reference)",
+			},
+			"prefixListId": map[string]interface{}{
+				"type":        "string",
+				"description": "Managed prefix list to allow instead of a CIDR block",
+			},
+			"description": map[string]interface{}{
+				"type":        "string",
+				"description": "Description of the rule",
+			},
 		},
-		"required": []string{"groupId", "protocol", "fromPort", "toPort", "cidrBlock"},
+		"required": []string{"groupId", "protocol", "fromPort", "toPort"},
 	}
 
 	baseTool := NewBaseTool(
@@ -369,18 +397,22 @@ func (t *AddSecurityGroupEgressRuleTool) Execute(ctx context.Context, arguments
 		return t.CreateErrorResponse("toPort is required")
 	}
 
-	cidrBlock, ok := arguments["cidrBlock"].(string)
-	if !ok || cidrBlock == "" {
-		return t.CreateErrorResponse("cidrBlock is required")
+	peer := securityGroupRulePeer(arguments)
+	if peer == "" {
+		return t.CreateErrorResponse("one of cidrBlock, sourceSecurityGroupId or prefixListId is required")
 	}
 
 	// Prepare parameters for the adapter
 	params := map[string]interface{}{
-		"groupId":   groupID,
-		"protocol":  protocol,
-		"fromPort":  int(fromPort),
-		"toPort":    int(toPort),
-		"cidrBlock": cidrBlock,
+		"groupId":  groupID,
+		"protocol": protocol,
+		"fromPort": int(fromPort),
+		"toPort":   int(toPort),
+	}
+	for _, key := range []string{"cidrBlock", "sourceSecurityGroupId", "prefixListId", "description"} {
+		if value, ok := arguments[key].(string); ok && value != "" {
+			params[key] = value
+		}
 	}
 
 	// Add egress rule using the Security Group specialized adapter
@@ -391,15 +423,15 @@ func (t *AddSecurityGroupEgressRuleTool) Execute(ctx context.Context, arguments
 	}
 
 	message := fmt.Sprintf("Added egress rule to security group %s: %s %d-%d to %s",
-		groupID, protocol, int(fromPort), int(toPort), cidrBlock)
+		groupID, protocol, int(fromPort), int(toPort), peer)
 	data := map[string]interface{}{
-		"groupId":   groupID,
-		"protocol":  protocol,
-		"fromPort":  int(fromPort),
-		"toPort":    int(toPort),
-		"cidrBlock": cidrBlock,
-		"direction": "egress",
-		"resource":  result,
+		"groupId":     groupID,
+		"protocol":    protocol,
+		"fromPort":    int(fromPort),
+		"toPort":      int(toPort),
+		"destination": peer,
+		"direction":   "egress",
+		"resource":    result,
 	}
 
 	return t.CreateSuccessResponse(message, data)
@@ -472,3 +504,295 @@ func (t *DeleteSecurityGroupTool) Execute(ctx context.Context, arguments map[str
 
 	return t.CreateSuccessResponse(message, data)
 }
+
+// securityGroupRulePeer returns the CIDR block, security group or prefix list a rule refers to
+func securityGroupRulePeer(arguments map[string]interface{}) string {
+	for _, key := range []string{"cidrBlock", "sourceSecurityGroupId", "prefixListId"} {
+		if value, ok := arguments[key].(string); ok && value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// securityGroupRuleSchema returns the schema of a single rule, shared by the revoke and set-rules tools
+func securityGroupRuleSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"protocol": map[string]interface{}{
+			"type":        "string",
+			"description": "The protocol (tcp, udp, icmp, icmpv6, or -1 for all)",
+		},
+		"fromPort": map[string]interface{}{
+			"type":        "integer",
+			"description": "The start port number, or for ICMP the type (defaults to -1, all types)",
+		},
+		"toPort": map[string]interface{}{
+			"type":        "integer",
+			"description": "The end port number (defaults to fromPort), or for ICMP the code (defaults to -1, all codes)",
+		},
+		"cidrBlock": map[string]interface{}{
+			"type":        "string",
+			"description": "IPv4 or IPv6 CIDR block",
+		},
+		"sourceSecurityGroupId": map[string]interface{}{
+			"type":        "string",
+			"description": "Referenced security group (source for ingress, destination for egress)",
+		},
+		"prefixListId": map[string]interface{}{
+			"type":        "string",
+			"description": "Managed prefix list ID",
+		},
+		"description": map[string]interface{}{
+			"type":        "string",
+			"description": "Description of the rule",
+		},
+	}
+}
+
+// RevokeSecurityGroupRuleTool implements revoking ingress or egress rules from security groups
+type RevokeSecurityGroupRuleTool struct {
+	*BaseTool
+	adapter   interfaces.SpecializedOperations
+	direction string
+}
+
+// NewRevokeSecurityGroupIngressRuleTool creates a new ingress rule revocation tool
+func NewRevokeSecurityGroupIngressRuleTool(awsClient *aws.Client, actionType string, logger *logging.Logger) interfaces.MCPTool {
+	return newRevokeSecurityGroupRuleTool(awsClient, actionType, logger, "ingress")
+}
+
+// NewRevokeSecurityGroupEgressRuleTool creates a new egress rule revocation tool
+func NewRevokeSecurityGroupEgressRuleTool(awsClient *aws.Client, actionType string, logger *logging.Logger) interfaces.MCPTool {
+	return newRevokeSecurityGroupRuleTool(awsClient, actionType, logger, "egress")
+}
+
+func newRevokeSecurityGroupRuleTool(awsClient *aws.Client, actionType string, logger *logging.Logger, direction string) interfaces.MCPTool {
+	properties := securityGroupRuleSchema()
+	properties["groupId"] = map[string]interface{}{
+		"type":        "string",
+		"description": "The security group ID",
+	}
+
+	inputSchema := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+		"required":   []string{"groupId", "protocol"},
+	}
+
+	peer := "source"
+	if direction == "egress" {
+		peer = "destination"
+	}
+
+	baseTool := NewBaseTool(
+		fmt.Sprintf("revoke-security-group-%s-rule", direction),
+		fmt.Sprintf("Revoke an %s rule from a security group. Protocol, ports and %s must match the existing rule", direction, peer),
+		"security",
+		actionType,
+		inputSchema,
+		logger,
+	)
+
+	baseTool.AddExample(
+		fmt.Sprintf("Revoke an open SSH %s rule", direction),
+		map[string]interface{}{
+			"groupId":   "sg-123456789",
+			"protocol":  "tcp",
+			"fromPort":  22,
+			"toPort":    22,
+			"cidrBlock": "0.0.0.0/0",
+		},
+		fmt.Sprintf("Revoked %s rule from security group sg-123456789: tcp 22-22 0.0.0.0/0", direction),
+	)
+
+	return &RevokeSecurityGroupRuleTool{
+		BaseTool:  baseTool,
+		adapter:   adapters.NewSecurityGroupSpecializedAdapter(awsClient, logger),
+		direction: direction,
+	}
+}
+
+// Execute revokes the rule
+func (t *RevokeSecurityGroupRuleTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	groupID, _ := arguments["groupId"].(string)
+	if groupID == "" {
+		return t.CreateErrorResponse("groupId is required")
+	}
+
+	peer := securityGroupRulePeer(arguments)
+	if peer == "" {
+		return t.CreateErrorResponse("one of cidrBlock, sourceSecurityGroupId or prefixListId is required")
+	}
+
+	result, err := t.adapter.ExecuteSpecialOperation(ctx, fmt.Sprintf("revoke-%s-rule", t.direction), arguments)
+	if err != nil {
+		t.logger.Error("Failed to revoke rule", "direction", t.direction, "error", err)
+		return t.CreateErrorResponse(fmt.Sprintf("Failed to revoke %s rule: %v", t.direction, err))
+	}
+
+	message := fmt.Sprintf("Revoked %s rule from security group %s: %s %v-%v %s",
+		t.direction, groupID, result.Details["protocol"], result.Details["fromPort"], result.Details["toPort"], peer)
+	data := map[string]interface{}{
+		"groupId":   groupID,
+		"direction": t.direction,
+		"peer":      peer,
+		"status":    "revoked",
+		"resource":  result,
+	}
+
+	return t.CreateSuccessResponse(message, data)
+}
+
+// SetSecurityGroupRulesTool implements idempotent reconciliation of security group rules
+type SetSecurityGroupRulesTool struct {
+	*BaseTool
+	adapter interfaces.SpecializedOperations
+}
+
+// NewSetSecurityGroupRulesTool creates a new security group rule reconciliation tool
+func NewSetSecurityGroupRulesTool(awsClient *aws.Client, actionType string, logger *logging.Logger) interfaces.MCPTool {
+	ruleArray := func(description string) map[string]interface{} {
+		return map[string]interface{}{
+			"type":        "array",
+			"description": description,
+			"items": map[string]interface{}{
+				"type":       "object",
+				"properties": securityGroupRuleSchema(),
+				"required":   []string{"protocol"},
+			},
+		}
+	}
+
+	inputSchema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"groupId": map[string]interface{}{
+				"type":        "string",
+				"description": "The security group ID",
+			},
+			"ingressRules": ruleArray("The complete desired ingress rule list; live ingress rules not listed are revoked"),
+			"egressRules":  ruleArray("The complete desired egress rule list. Omit to leave egress untouched; an empty list removes all egress"),
+			"dryRun": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Report the changes without applying them",
+				"default":     false,
+			},
+		},
+		"required": []string{"groupId", "ingressRules"},
+	}
+
+	baseTool := NewBaseTool(
+		"set-security-group-rules",
+		"Make a security group's rules match a desired list: adds missing rules, revokes unlisted ones and leaves matching rules alone. Safe to repeat",
+		"security",
+		actionType,
+		inputSchema,
+		logger,
+	)
+
+	baseTool.AddExample(
+		"Allow HTTPS from the load balancer only",
+		map[string]interface{}{
+			"groupId": "sg-123456789",
+			"ingressRules": []map[string]interface{}{
+				{"protocol": "tcp", "fromPort": 443, "toPort": 443, "sourceSecurityGroupId": "sg-alb12345"},
+			},
+		},
+		"Security group sg-123456789 reconciled: 1 added, 2 removed, 0 unchanged",
+	)
+
+	return &SetSecurityGroupRulesTool{
+		BaseTool: baseTool,
+		adapter:  adapters.NewSecurityGroupSpecializedAdapter(awsClient, logger),
+	}
+}
+
+// Execute reconciles the security group rules
+func (t *SetSecurityGroupRulesTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	groupID, _ := arguments["groupId"].(string)
+	if groupID == "" {
+		return t.CreateErrorResponse("groupId is required")
+	}
+	if _, ok := arguments["ingressRules"].([]interface{}); !ok {
+		return t.CreateErrorResponse("ingressRules is required and must be an array")
+	}
+
+	result, err := t.adapter.ExecuteSpecialOperation(ctx, "set-rules", arguments)
+	if err != nil {
+		return t.CreateErrorResponse(fmt.Sprintf("Failed to set security group rules: %v", err))
+	}
+
+	added, _ := result.Details["added"].([]string)
+	removed, _ := result.Details["removed"].([]string)
+	unchanged, _ := result.Details["unchanged"].([]string)
+	dryRun, _ := result.Details["dryRun"].(bool)
+
+	verb := "reconciled"
+	if dryRun {
+		verb = "checked (dry run)"
+	}
+	message := fmt.Sprintf("Security group %s %s: %d added, %d removed, %d unchanged",
+		groupID, verb, len(added), len(removed), len(unchanged))
+
+	return t.CreateSuccessResponse(message, map[string]interface{}{
+		"groupId":   groupID,
+		"added":     added,
+		"removed":   removed,
+		"unchanged": unchanged,
+		"dryRun":    dryRun,
+	})
+}
+
+// ListSecurityGroupRulesTool implements listing the live rules of a security group
+type ListSecurityGroupRulesTool struct {
+	*BaseTool
+	adapter interfaces.SpecializedOperations
+}
+
+// NewListSecurityGroupRulesTool creates a new security group rule listing tool
+func NewListSecurityGroupRulesTool(awsClient *aws.Client, actionType string, logger *logging.Logger) interfaces.MCPTool {
+	inputSchema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"groupId": map[string]interface{}{
+				"type":        "string",
+				"description": "The security group ID",
+			},
+		},
+		"required": []string{"groupId"},
+	}
+
+	baseTool := NewBaseTool(
+		"list-security-group-rules",
+		"List the ingress and egress rules of a security group",
+		"security",
+		actionType,
+		inputSchema,
+		logger,
+	)
+
+	return &ListSecurityGroupRulesTool{
+		BaseTool: baseTool,
+		adapter:  adapters.NewSecurityGroupSpecializedAdapter(awsClient, logger),
+	}
+}
+
+// Execute lists the security group rules
+func (t *ListSecurityGroupRulesTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	groupID, _ := arguments["groupId"].(string)
+	if groupID == "" {
+		return t.CreateErrorResponse("groupId is required")
+	}
+
+	result, err := t.adapter.ExecuteSpecialOperation(ctx, "list-rules", groupID)
+	if err != nil {
+		return t.CreateErrorResponse(fmt.Sprintf("Failed to list security group rules: %v", err))
+	}
+
+	count, _ := result.Details["count"].(int)
+	return t.CreateSuccessResponse(fmt.Sprintf("Security group %s has %d rules", groupID, count), map[string]interface{}{
+		"groupId": groupID,
+		"rules":   result.Details["rules"],
+		"count":   count,
+	})
+}
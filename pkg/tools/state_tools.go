@@ -11,6 +11,7 @@ import (
 	"github.com/versus-control/ai-infrastructure-agent/internal/logging"
 	"github.com/versus-control/ai-infrastructure-agent/pkg/adapters"
 	"github.com/versus-control/ai-infrastructure-agent/pkg/aws"
+	"github.com/versus-control/ai-infrastructure-agent/pkg/discovery"
 	"github.com/versus-control/ai-infrastructure-agent/pkg/interfaces"
 	"github.com/versus-control/ai-infrastructure-agent/pkg/types"
 )
@@ -32,9 +33,76 @@ func createAllAvailableAdapters(awsClient *aws.Client, logger *logging.Logger) m
 		"alb":            adapters.NewALBAdapter(awsClient, logger),
 		"asg":            adapters.NewASGAdapter(awsClient, logger),
 		"security-group": adapters.NewSecurityGroupAdapter(awsClient, logger),
+		"s3":             adapters.NewS3Adapter(awsClient, logger),
+		"iam":            adapters.NewIAMAdapter(awsClient, logger),
 	}
 }
 
+// The state tools return their own result formats, often free text, instead of the
+// success and message shape of CreateSuccessResponse, so they publish no output schema
+
+// GetOutputSchema returns no schema, the analysis is returned as JSON text
+func (t *AnalyzeStateTool) GetOutputSchema() map[string]interface{} { return nil }
+
+// GetOutputSchema returns no schema, the export format is chosen by the caller
+func (t *ExportStateTool) GetOutputSchema() map[string]interface{} { return nil }
+
+// GetOutputSchema returns no schema, the graph is returned as text or Mermaid
+func (t *VisualizeDependencyGraphTool) GetOutputSchema() map[string]interface{} { return nil }
+
+// GetOutputSchema returns no schema, conflicts are returned as JSON text
+func (t *DetectConflictsTool) GetOutputSchema() map[string]interface{} { return nil }
+
+// GetOutputSchema returns no schema, the result is returned as JSON text
+func (t *SaveStateTool) GetOutputSchema() map[string]interface{} { return nil }
+
+// GetOutputSchema returns no schema, the result is returned as JSON text
+func (t *AddResourceToStateTool) GetOutputSchema() map[string]interface{} { return nil }
+
+// GetOutputSchema returns no schema, the deployment order is returned as JSON text
+func (t *PlanDeploymentTool) GetOutputSchema() map[string]interface{} { return nil }
+
+// regionsSchemaProperty is the shared input schema for tools that can scan several regions
+var regionsSchemaProperty = map[string]interface{}{
+	"type":        "array",
+	"description": "Optional list of AWS regions to scan. Defaults to every configured region (AWS_REGIONS), or only the default region when none are configured",
+	"items": map[string]interface{}{
+		"type": "string",
+	},
+}
+
+// discoverResourcesInRegions runs live discovery across the requested regions,
+// falling back to every configured region when none are given
+func discoverResourcesInRegions(ctx context.Context, scanner *discovery.Scanner, arguments map[string]interface{}) ([]*types.ResourceState, error) {
+	regions := regionsArgument(arguments)
+	if len(regions) == 0 {
+		return scanner.DiscoverAllRegions(ctx)
+	}
+	return scanner.DiscoverInfrastructureInRegions(ctx, regions)
+}
+
+// regionsArgument returns the regions named by the regions argument of a tool
+func regionsArgument(arguments map[string]interface{}) []string {
+	var regions []string
+	if regionArgs, ok := arguments["regions"].([]interface{}); ok {
+		for _, item := range regionArgs {
+			if region, ok := item.(string); ok && region != "" {
+				regions = append(regions, region)
+			}
+		}
+	}
+	return regions
+}
+
+// groupResourceIDsByRegion groups resource IDs by the region they live in
+func groupResourceIDsByRegion(resources []*types.ResourceState) map[string][]string {
+	grouped := make(map[string][]string)
+	for _, resource := range resources {
+		grouped[resource.Region] = append(grouped[resource.Region], resource.ID)
+	}
+	return grouped
+}
+
 // AnalyzeStateTool implements unified infrastructure state analysis with dynamic resource discovery
 type AnalyzeStateTool struct {
 	*BaseTool
@@ -60,6 +128,7 @@ func NewAnalyzeStateTool(deps *ToolDependencies, awsClient *aws.Client, actionTy
 					"type": "string",
 				},
 			},
+			"regions": regionsSchemaProperty,
 		},
 	}
 
@@ -144,13 +213,30 @@ func (t *AnalyzeStateTool) Execute(ctx context.Context, arguments map[string]int
 	if scanLive {
 		// Use advanced discovery if available
 		if t.deps != nil && t.deps.DiscoveryScanner != nil {
-			discoveredResources, err := t.deps.DiscoveryScanner.DiscoverInfrastructure(ctx)
+			discoveredResources, err := discoverResourcesInRegions(ctx, t.deps.DiscoveryScanner, arguments)
 			if err != nil {
 				t.GetLogger().WithError(err).Warn("Failed to discover infrastructure using advanced scanner, falling back to adapter-based discovery")
 				result["discovery_error"] = err.Error()
 			} else {
 				result["discovered_resources"] = discoveredResources
 				result["discovered_resource_count"] = len(discoveredResources)
+				result["resources_by_region"] = groupResourceIDsByRegion(discoveredResources)
+
+				// Resources tagged as created by the agent, and those of them the state does not track
+				var agentManaged, untracked []string
+				for _, resource := range discoveredResources {
+					if !discovery.IsAgentManaged(resource) {
+						continue
+					}
+					agentManaged = append(agentManaged, resource.ID)
+					if t.deps.StateManager != nil {
+						if _, exists := t.deps.StateManager.GetResource(resource.ID); !exists {
+							untracked = append(untracked, resource.ID)
+						}
+					}
+				}
+				result["agent_managed_resource_count"] = len(agentManaged)
+				result["untracked_agent_resources"] = untracked
 
 				// Detect drift if state manager is available
 				if t.deps.StateManager != nil {
@@ -259,6 +345,7 @@ func NewExportStateTool(deps *ToolDependencies, awsClient *aws.Client, actionTyp
 					"type": "string",
 				},
 			},
+			"regions": regionsSchemaProperty,
 		},
 	}
 
@@ -363,7 +450,7 @@ func (t *ExportStateTool) Execute(ctx context.Context, arguments map[string]inte
 	if includeDiscovered {
 		// Use advanced discovery if available
 		if t.deps != nil && t.deps.DiscoveryScanner != nil {
-			discoveredResources, err := t.deps.DiscoveryScanner.DiscoverInfrastructure(ctx)
+			discoveredResources, err := discoverResourcesInRegions(ctx, t.deps.DiscoveryScanner, arguments)
 			if err != nil {
 				t.GetLogger().WithError(err).Warn("Failed to discover infrastructure using advanced scanner, falling back to adapter-based discovery")
 				exportData["discovery_error"] = err.Error()
@@ -381,6 +468,7 @@ func (t *ExportStateTool) Execute(ctx context.Context, arguments map[string]inte
 
 				exportData["discovered_resources"] = discoveredResources
 				exportData["discovered_resource_count"] = len(discoveredResources)
+				exportData["resources_by_region"] = groupResourceIDsByRegion(discoveredResources)
 			}
 		} else if t.adapters != nil {
 			// Fall back to adapter-based discovery
@@ -915,6 +1003,10 @@ func NewAddResourceToStateTool(deps *ToolDependencies, actionType string, logger
 				"type":        "string",
 				"description": "Resource type",
 			},
+			"region": map[string]interface{}{
+				"type":        "string",
+				"description": "AWS region the resource lives in (defaults to the state's default region)",
+			},
 			"status": map[string]interface{}{
 				"type":        "string",
 				"description": "Resource status",
@@ -1025,6 +1117,11 @@ func (t *AddResourceToStateTool) Execute(ctx context.Context, args map[string]in
 		status = val
 	}
 
+	region := ""
+	if val, ok := args["region"].(string); ok {
+		region = val
+	}
+
 	var properties map[string]interface{}
 	if val, ok := args["properties"].(map[string]interface{}); ok {
 		properties = val
@@ -1045,6 +1142,7 @@ func (t *AddResourceToStateTool) Execute(ctx context.Context, args map[string]in
 		"resource_id":   resourceID,
 		"resource_name": resourceName,
 		"resource_type": resourceType,
+		"region":        region,
 		"status":        status,
 	}).Info("Adding resource to managed state")
 
@@ -1054,6 +1152,7 @@ func (t *AddResourceToStateTool) Execute(ctx context.Context, args map[string]in
 		Name:         resourceName,
 		Description:  description,
 		Type:         resourceType,
+		Region:       region,
 		Status:       status,
 		Properties:   properties,
 		Dependencies: dependencies,
@@ -1081,6 +1180,97 @@ func (t *AddResourceToStateTool) Execute(ctx context.Context, args map[string]in
 	}, nil
 }
 
+// RecordExecutionTool records a plan execution in the execution history of the state. It is an
+// internal tool the agent calls on its MCP server subprocess; HTTP clients cannot call it.
+type RecordExecutionTool struct {
+	*BaseTool
+	deps *ToolDependencies
+}
+
+// NewRecordExecutionTool creates a new execution recording tool
+func NewRecordExecutionTool(deps *ToolDependencies, actionType string, logger *logging.Logger) interfaces.MCPTool {
+	inputSchema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"execution": map[string]interface{}{
+				"type":        "object",
+				"description": "The plan execution: id, name, status, startedAt, completedAt, steps, changes and errors",
+			},
+		},
+		"required": []string{"execution"},
+	}
+
+	baseTool := NewBaseTool(
+		"record-execution",
+		"Record a plan execution in the execution history of the infrastructure state",
+		"state",
+		actionType,
+		inputSchema,
+		logger,
+	)
+
+	baseTool.AddExample(
+		"Record a completed execution",
+		map[string]interface{}{
+			"execution": map[string]interface{}{
+				"id":        "exec-1234567890",
+				"name":      "Create web tier",
+				"status":    "completed",
+				"startedAt": "2024-01-01T12:00:00Z",
+				"steps":     []interface{}{},
+			},
+		},
+		"Execution exec-1234567890 recorded",
+	)
+
+	return &RecordExecutionTool{
+		BaseTool: baseTool,
+		deps:     deps,
+	}
+}
+
+// ValidateArguments validates the tool arguments
+func (t *RecordExecutionTool) ValidateArguments(args map[string]interface{}) error {
+	execution, ok := args["execution"].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("execution must be an object")
+	}
+	if id, ok := execution["id"].(string); !ok || id == "" {
+		return fmt.Errorf("execution.id must be a non-empty string")
+	}
+	return nil
+}
+
+// Execute records the execution
+func (t *RecordExecutionTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	if err := t.ValidateArguments(args); err != nil {
+		return t.CreateErrorResponse(err.Error())
+	}
+
+	// Load state
+	if err := t.deps.StateManager.LoadState(ctx); err != nil {
+		t.GetLogger().WithError(err).Warn("Failed to load state from file, continuing with current state")
+	}
+
+	data, err := json.Marshal(args["execution"])
+	if err != nil {
+		return t.CreateErrorResponse(fmt.Sprintf("invalid execution: %v", err))
+	}
+	var execution types.PlanExecution
+	if err := json.Unmarshal(data, &execution); err != nil {
+		return t.CreateErrorResponse(fmt.Sprintf("invalid execution: %v", err))
+	}
+
+	if err := t.deps.StateManager.RecordExecution(ctx, &execution); err != nil {
+		return t.CreateErrorResponse(fmt.Sprintf("failed to record execution: %v", err))
+	}
+
+	return t.CreateSuccessResponse(fmt.Sprintf("Execution %s recorded", execution.ID), map[string]interface{}{
+		"executionId": execution.ID,
+		"status":      execution.Status,
+	})
+}
+
 // PlanDeploymentTool generates deployment plan with dependency ordering
 type PlanDeploymentTool struct {
 	*BaseTool
@@ -36,9 +36,11 @@ type AWSResource struct {
 type InfrastructureState struct {
 	Version      string                    `json:"version"`
 	LastUpdated  time.Time                 `json:"lastUpdated"`
-	Region       string                    `json:"region"`
+	Region       string                    `json:"region"`            // Default region for resources without their own
+	Regions      []string                  `json:"regions,omitempty"` // All regions that hold managed resources
 	Resources    map[string]*ResourceState `json:"resources"`
 	Dependencies map[string][]string       `json:"dependencies"`
+	Executions   map[string]*PlanExecution `json:"executions,omitempty"` // Recent plan executions by ID
 	Metadata     map[string]interface{}    `json:"metadata,omitempty"`
 }
 
@@ -48,6 +50,7 @@ type ResourceState struct {
 	Name         string                 `json:"name"`
 	Description  string                 `json:"description"`
 	Type         string                 `json:"type"`
+	Region       string                 `json:"region,omitempty"`
 	Status       string                 `json:"status"`
 	DesiredState string                 `json:"desiredState"`
 	CurrentState string                 `json:"currentState"`
@@ -79,6 +82,7 @@ type DependencyGraph struct {
 type DependencyNode struct {
 	ID           string            `json:"id"`
 	ResourceType string            `json:"resourceType"`
+	Region       string            `json:"region,omitempty"`
 	Status       string            `json:"status"`
 	Properties   map[string]string `json:"properties"`
 }
@@ -129,6 +133,7 @@ type ExecutionPlanStep struct {
 	Description       string                 `json:"description"`
 	Action            string                 `json:"action"`
 	ResourceID        string                 `json:"resourceId"`
+	Region            string                 `json:"region,omitempty"`         // Target region, empty means the default region
 	MCPTool           string                 `json:"mcpTool,omitempty"`        // Direct MCP tool name
 	ToolParameters    map[string]interface{} `json:"toolParameters,omitempty"` // Direct MCP tool parameters
 	Parameters        map[string]interface{} `json:"parameters"`               // Legacy/fallback parameters
@@ -150,6 +155,100 @@ type AgentDecision struct {
 	ExecutedAt    *time.Time             `json:"executedAt,omitempty"`
 	Result        string                 `json:"result,omitempty"`
 	Error         string                 `json:"error,omitempty"`
+
+	// Guardrail policies matched by the decision or its steps
+	PolicyViolations []*PolicyViolation `json:"policyViolations,omitempty"`
+
+	// Estimated monthly cost of the resources the plan creates
+	CostEstimate *CostEstimate `json:"costEstimate,omitempty"`
+
+	// Authenticated caller that requested the decision
+	Principal *Principal `json:"principal,omitempty"`
+}
+
+// Principal identifies the authenticated caller of the web API
+type Principal struct {
+	Subject string `json:"subject"`
+	Name    string `json:"name,omitempty"`
+	Method  string `json:"method"` // api-key, jwt or anonymous
+	Issuer  string `json:"issuer,omitempty"`
+}
+
+// PolicyViolation is a guardrail policy matched by a decision or one of its plan steps
+type PolicyViolation struct {
+	PolicyID string `json:"policyId"`
+	Effect   string `json:"effect"` // deny, confirm or warn
+	Message  string `json:"message"`
+	StepID   string `json:"stepId,omitempty"`
+	Tool     string `json:"tool,omitempty"`
+}
+
+// CostEstimate is the estimated monthly cost of the resources an execution plan creates
+type CostEstimate struct {
+	Currency       string      `json:"currency"`
+	MonthlyTotal   float64     `json:"monthlyTotal"`
+	Steps          []*StepCost `json:"steps"`
+	UnpricedSteps  []string    `json:"unpricedSteps,omitempty"` // Billable steps the catalog has no price for
+	CatalogVersion string      `json:"catalogVersion,omitempty"`
+	Assumptions    []string    `json:"assumptions,omitempty"`
+}
+
+// StepCost is the estimated monthly cost of one plan step
+type StepCost struct {
+	StepID      string          `json:"stepId"`
+	Tool        string          `json:"tool"`
+	Region      string          `json:"region"`
+	MonthlyCost float64         `json:"monthlyCost"`
+	LineItems   []*CostLineItem `json:"lineItems,omitempty"`
+	Note        string          `json:"note,omitempty"`
+}
+
+// CostLineItem is one priced component of a step, e.g. instance hours or storage
+type CostLineItem struct {
+	Description string  `json:"description"`
+	Quantity    float64 `json:"quantity"`
+	Unit        string  `json:"unit"` // hours, GB-month or GB
+	UnitPrice   float64 `json:"unitPrice"`
+	MonthlyCost float64 `json:"monthlyCost"`
+}
+
+// CostReport is the estimated monthly spend of existing infrastructure, with the idle
+// resources that could be cleaned up
+type CostReport struct {
+	GeneratedAt       time.Time                     `json:"generatedAt"`
+	Currency          string                        `json:"currency"`
+	MonthlyTotal      float64                       `json:"monthlyTotal"`
+	ByType            map[string]float64            `json:"byType"`
+	ByTag             map[string]map[string]float64 `json:"byTag,omitempty"` // Tag key -> tag value -> monthly cost
+	Resources         []*ResourceCost               `json:"resources"`
+	UnpricedResources []string                      `json:"unpricedResources,omitempty"`
+	Findings          []*RightsizingFinding         `json:"findings"`
+	MonthlySavings    float64                       `json:"monthlySavings"` // Total of the findings' savings
+	CatalogVersion    string                        `json:"catalogVersion,omitempty"`
+	Assumptions       []string                      `json:"assumptions,omitempty"`
+}
+
+// ResourceCost is the estimated monthly cost of one existing resource
+type ResourceCost struct {
+	ResourceID   string          `json:"resourceId"`
+	ResourceType string          `json:"resourceType"`
+	Name         string          `json:"name,omitempty"`
+	Region       string          `json:"region"`
+	Managed      bool            `json:"managed"` // Tracked in state or tagged as created by the agent
+	MonthlyCost  float64         `json:"monthlyCost"`
+	LineItems    []*CostLineItem `json:"lineItems,omitempty"`
+	Note         string          `json:"note,omitempty"`
+}
+
+// RightsizingFinding is an idle or oversized resource and what removing it would save
+type RightsizingFinding struct {
+	Kind           string  `json:"kind"` // stopped-instance-with-eip, unattached-volume, unassociated-eip, idle-nat-gateway, oversized-instance, oversized-db-instance
+	ResourceID     string  `json:"resourceId"`
+	ResourceType   string  `json:"resourceType"`
+	Region         string  `json:"region"`
+	Description    string  `json:"description"`
+	Recommendation string  `json:"recommendation"`
+	MonthlySavings float64 `json:"monthlySavings"`
 }
 
 // PlanExecution represents the execution of an infrastructure plan
@@ -162,6 +261,7 @@ type PlanExecution struct {
 	Steps       []*ExecutionStep   `json:"steps"`
 	Changes     []*ChangeDetection `json:"changes"`
 	Errors      []string           `json:"errors,omitempty"`
+	Principal   *Principal         `json:"principal,omitempty"` // Authenticated caller that started the execution
 }
 
 // ExecutionStep represents a single step in plan execution